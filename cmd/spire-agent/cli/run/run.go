@@ -12,11 +12,13 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/agent"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -32,6 +34,11 @@ const (
 	defaultLogLevel = "INFO"
 )
 
+// defaultReattestNodeAttestors are the node attestors whose attestation data
+// is known to change while the agent runs, e.g. k8s_psat, whose projected
+// service account token is rotated by Kubernetes.
+var defaultReattestNodeAttestors = []string{"k8s_psat"}
+
 // RunConfig represents the available configurables for file
 // and CLI options
 type runConfig struct {
@@ -40,16 +47,23 @@ type runConfig struct {
 }
 
 type agentRunConfig struct {
-	DataDir         string `hcl:"data_dir"`
-	EnableSDS       bool   `hcl:"enable_sds"`
-	LogFile         string `hcl:"log_file"`
-	LogLevel        string `hcl:"log_level"`
-	ServerAddress   string `hcl:"server_address"`
-	ServerPort      int    `hcl:"server_port"`
-	SocketPath      string `hcl:"socket_path"`
-	TrustBundlePath string `hcl:"trust_bundle_path"`
-	TrustDomain     string `hcl:"trust_domain"`
-	JoinToken       string `hcl:"join_token"`
+	DataDir         string   `hcl:"data_dir"`
+	EnableSDS       bool     `hcl:"enable_sds"`
+	LogFile         string   `hcl:"log_file"`
+	LogLevel        string   `hcl:"log_level"`
+	ServerAddress   string   `hcl:"server_address"`
+	ServerPort      int      `hcl:"server_port"`
+	SocketPath      string   `hcl:"socket_path"`
+	TrustBundlePath string   `hcl:"trust_bundle_path"`
+	TrustDomain     string   `hcl:"trust_domain"`
+	JoinToken       string   `hcl:"join_token"`
+	NodeAttestors   []string `hcl:"node_attestors"`
+	WorkloadKeyType string   `hcl:"workload_key_type"`
+	LazySVIDs       bool     `hcl:"lazy_svids"`
+	SVIDIdleTimeout string   `hcl:"svid_idle_timeout"`
+
+	ReattestOnDataChange  bool     `hcl:"reattest_on_data_change"`
+	ReattestNodeAttestors []string `hcl:"reattest_node_attestors"`
 
 	ConfigPath string
 
@@ -220,6 +234,38 @@ func mergeConfig(orig *agentConfig, cmd *runConfig) error {
 		orig.JoinToken = cmd.AgentConfig.JoinToken
 	}
 
+	if len(cmd.AgentConfig.NodeAttestors) > 0 {
+		orig.NodeAttestors = cmd.AgentConfig.NodeAttestors
+	}
+
+	if cmd.AgentConfig.WorkloadKeyType != "" {
+		keyType, err := cryptoutil.ParseKeyType(cmd.AgentConfig.WorkloadKeyType)
+		if err != nil {
+			return fmt.Errorf("unable to parse workload key type %q: %v", cmd.AgentConfig.WorkloadKeyType, err)
+		}
+		orig.WorkloadKeyType = keyType
+	}
+
+	if cmd.AgentConfig.LazySVIDs {
+		orig.LazySVIDs = cmd.AgentConfig.LazySVIDs
+	}
+
+	if cmd.AgentConfig.SVIDIdleTimeout != "" {
+		timeout, err := time.ParseDuration(cmd.AgentConfig.SVIDIdleTimeout)
+		if err != nil {
+			return fmt.Errorf("unable to parse SVID idle timeout %q: %v", cmd.AgentConfig.SVIDIdleTimeout, err)
+		}
+		orig.SVIDIdleTimeout = timeout
+	}
+
+	if cmd.AgentConfig.ReattestOnDataChange {
+		orig.ReattestOnDataChange = cmd.AgentConfig.ReattestOnDataChange
+	}
+
+	if cmd.AgentConfig.ReattestNodeAttestors != nil {
+		orig.ReattestNodeAttestors = cmd.AgentConfig.ReattestNodeAttestors
+	}
+
 	if cmd.AgentConfig.SocketPath != "" {
 		orig.BindAddress.Name = cmd.AgentConfig.SocketPath
 	}
@@ -307,6 +353,8 @@ func newDefaultConfig() *agentConfig {
 			BindAddress: bindAddr,
 			DataDir:     defaultDataDir,
 			Log:         logger,
+
+			ReattestNodeAttestors: defaultReattestNodeAttestors,
 		},
 		umask: -1,
 	}
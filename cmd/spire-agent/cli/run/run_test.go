@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -104,3 +106,92 @@ func TestMergeConfigGood(t *testing.T) {
 	assert.Equal(t, orig.DataDir, ".")
 	assert.Equal(t, orig.umask, 077)
 }
+
+func TestMergeConfigWorkloadKeyType(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentRunConfig{
+			WorkloadKeyType: "rsa-2048",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, common.KeyType_RSA_2048, orig.WorkloadKeyType)
+
+	c.AgentConfig.WorkloadKeyType = "ec-p521"
+	err = mergeConfig(newDefaultConfig(), c)
+	require.EqualError(t, err, `unable to parse workload key type "ec-p521": unsupported key type "ec-p521"`)
+}
+
+func TestMergeConfigNodeAttestors(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentRunConfig{
+			NodeAttestors: []string{"x509pop", "aws_iid"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x509pop", "aws_iid"}, orig.NodeAttestors)
+}
+
+func TestMergeConfigLazySVIDs(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentRunConfig{
+			LazySVIDs:       true,
+			SVIDIdleTimeout: "10m",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.LazySVIDs)
+	assert.Equal(t, 10*time.Minute, orig.SVIDIdleTimeout)
+
+	c.AgentConfig.SVIDIdleTimeout = "forever"
+	err = mergeConfig(newDefaultConfig(), c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unable to parse SVID idle timeout "forever"`)
+}
+
+func TestMergeConfigReattestOnDataChange(t *testing.T) {
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, &runConfig{})
+	require.NoError(t, err)
+	assert.False(t, orig.ReattestOnDataChange)
+
+	err = mergeConfig(orig, &runConfig{
+		AgentConfig: agentRunConfig{
+			ReattestOnDataChange: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, orig.ReattestOnDataChange)
+}
+
+func TestMergeConfigReattestNodeAttestors(t *testing.T) {
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, &runConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"k8s_psat"}, orig.ReattestNodeAttestors)
+
+	err = mergeConfig(orig, &runConfig{
+		AgentConfig: agentRunConfig{
+			ReattestNodeAttestors: []string{"x509pop"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x509pop"}, orig.ReattestNodeAttestors)
+
+	// an empty list disables the checks
+	err = mergeConfig(orig, &runConfig{
+		AgentConfig: agentRunConfig{
+			ReattestNodeAttestors: []string{},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, orig.ReattestNodeAttestors)
+}
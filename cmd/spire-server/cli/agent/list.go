@@ -99,6 +99,10 @@ func (c ListCLI) printAttestedNodes() {
 		fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
 		fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
 		fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+		if node.LastSeenAt != 0 {
+			fmt.Printf("Last seen IP      : %s\n", node.LastSeenIp)
+			fmt.Printf("Last seen time    : %s\n", time.Unix(node.LastSeenAt, 0))
+		}
 		fmt.Println()
 	}
 }
@@ -39,6 +39,11 @@ func (s *ListTestSuite) TestRun() {
 	resp := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			&common.AttestedNode{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
+			&common.AttestedNode{
+				SpiffeId:   "spiffe://example.org/spire/agent/join_token/token_b",
+				LastSeenIp: "10.1.2.3",
+				LastSeenAt: 1547486000,
+			},
 		},
 	}
 	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
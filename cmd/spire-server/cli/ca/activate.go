@@ -0,0 +1,42 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	mitchellh_cli "github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+)
+
+// NewActivateCommand creates a new "activate" subcommand for "ca" command.
+func NewActivateCommand() mitchellh_cli.Command {
+	return newActivateCommand(cli.DefaultEnv, util.NewRegistrationClient)
+}
+
+func newActivateCommand(env *cli.Env, clientMaker clientMaker) mitchellh_cli.Command {
+	return adaptCommand(env, clientMaker, new(activateCommand))
+}
+
+type activateCommand struct{}
+
+func (c *activateCommand) name() string {
+	return "ca activate"
+}
+
+func (c *activateCommand) synopsis() string {
+	return "Activates the prepared CA keypair set"
+}
+
+func (c *activateCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *activateCommand) run(ctx context.Context, env *cli.Env, client registration.RegistrationClient) error {
+	keypairSet, err := client.ActivateCAKeypairSet(ctx, &common.Empty{})
+	if err != nil {
+		return err
+	}
+	return printKeypairSet(env, keypairSet)
+}
@@ -0,0 +1,135 @@
+package ca
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mitchellh_cli "github.com/mitchellh/cli"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCACommands(t *testing.T) {
+	suite.Run(t, new(CASuite))
+}
+
+type CASuite struct {
+	suite.Suite
+
+	mockCtrl   *gomock.Controller
+	mockClient *mock_registration.MockRegistrationClient
+	stdout     *bytes.Buffer
+	stderr     *bytes.Buffer
+
+	listCmd     mitchellh_cli.Command
+	prepareCmd  mitchellh_cli.Command
+	activateCmd mitchellh_cli.Command
+	revokeCmd   mitchellh_cli.Command
+}
+
+func (s *CASuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.stdout = new(bytes.Buffer)
+	s.stderr = new(bytes.Buffer)
+
+	env := &cli.Env{
+		Stdin:  new(bytes.Buffer),
+		Stdout: s.stdout,
+		Stderr: s.stderr,
+	}
+	clientMaker := func(string) (registration.RegistrationClient, error) {
+		return s.mockClient, nil
+	}
+
+	s.listCmd = newListCommand(env, clientMaker)
+	s.prepareCmd = newPrepareCommand(env, clientMaker)
+	s.activateCmd = newActivateCommand(env, clientMaker)
+	s.revokeCmd = newRevokeCommand(env, clientMaker)
+}
+
+func (s *CASuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *CASuite) TestList() {
+	ca := s.loadCA()
+	s.mockClient.EXPECT().ListCAKeypairSets(gomock.Any(), &common.Empty{}).Return(&registration.ListCAKeypairSetsResponse{
+		KeypairSets: []*registration.CAKeypairSet{
+			{
+				Slot:          "A",
+				Active:        true,
+				X509Ca:        ca.Raw,
+				X509CaKeyId:   "X509A",
+				JwtSigningKey: &common.PublicKey{Kid: "KIDA", NotAfter: 1},
+			},
+			{
+				Slot:        "B",
+				X509Ca:      ca.Raw,
+				X509CaKeyId: "X509B",
+			},
+		},
+	}, nil)
+
+	s.Require().Equal(0, s.listCmd.Run(nil))
+	s.Require().Equal(`Slot             : A (active)
+X509 CA Key ID   : X509A
+X509 CA Expires  : `+ca.NotAfter.UTC().Format("2006-01-02T15:04:05Z07:00")+`
+JWT Key ID       : KIDA
+JWT Key Expires  : 1970-01-01T00:00:01Z
+
+Slot             : B (prepared)
+X509 CA Key ID   : X509B
+X509 CA Expires  : `+ca.NotAfter.UTC().Format("2006-01-02T15:04:05Z07:00")+`
+`, s.stdout.String())
+}
+
+func (s *CASuite) TestPrepare() {
+	ca := s.loadCA()
+	s.mockClient.EXPECT().PrepareCAKeypairSet(gomock.Any(), &common.Empty{}).Return(&registration.CAKeypairSet{
+		Slot:        "B",
+		X509Ca:      ca.Raw,
+		X509CaKeyId: "X509B",
+	}, nil)
+
+	s.Require().Equal(0, s.prepareCmd.Run(nil))
+	s.Require().Contains(s.stdout.String(), "Slot             : B (prepared)\n")
+}
+
+func (s *CASuite) TestActivateFailure() {
+	s.mockClient.EXPECT().ActivateCAKeypairSet(gomock.Any(), &common.Empty{}).Return(nil, errors.New("no prepared keypair set"))
+
+	s.Require().Equal(1, s.activateCmd.Run(nil))
+	s.Require().Equal("no prepared keypair set\n", s.stderr.String())
+}
+
+func (s *CASuite) TestRevoke() {
+	s.mockClient.EXPECT().RevokeCAKey(gomock.Any(), &registration.RevokeCAKeyRequest{
+		JwtKeyId: "KIDA",
+	}).Return(&common.Empty{}, nil)
+
+	s.Require().Equal(0, s.revokeCmd.Run([]string{"-jwtKeyID", "KIDA"}))
+	s.Require().Equal("CA key revoked.\n", s.stdout.String())
+}
+
+func (s *CASuite) TestRevokeRequiresExactlyOneKeyID() {
+	s.Require().Equal(1, s.revokeCmd.Run(nil))
+	s.Require().Equal("either -x509CAKeyID or -jwtKeyID is required\n", s.stderr.String())
+
+	s.stderr.Reset()
+	s.Require().Equal(1, s.revokeCmd.Run([]string{"-jwtKeyID", "KIDA", "-x509CAKeyID", "X509A"}))
+	s.Require().Equal("only one of -x509CAKeyID or -jwtKeyID can be set\n", s.stderr.String())
+}
+
+func (s *CASuite) loadCA() *x509.Certificate {
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	return ca
+}
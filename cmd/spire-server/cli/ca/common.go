@@ -0,0 +1,110 @@
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"flag"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+)
+
+type clientMaker func(registrationUDSPath string) (registration.RegistrationClient, error)
+
+// command is a common interface for commands in this package. the adapter
+// can adapter this interface to the Command interface from github.com/mitchellh/cli.
+type command interface {
+	name() string
+	synopsis() string
+	appendFlags(*flag.FlagSet)
+	run(context.Context, *cli.Env, registration.RegistrationClient) error
+}
+
+type adapter struct {
+	env         *cli.Env
+	clientMaker clientMaker
+	cmd         command
+
+	registrationUDSPath string
+	flags               *flag.FlagSet
+}
+
+// adaptCommand converts a command into one conforming to the Command interface from github.com/mitchellh/cli
+func adaptCommand(env *cli.Env, clientMaker clientMaker, cmd command) *adapter {
+	a := &adapter{
+		clientMaker: clientMaker,
+		cmd:         cmd,
+		env:         env,
+	}
+
+	fs := flag.NewFlagSet(cmd.name(), flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	fs.StringVar(&a.registrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	a.cmd.appendFlags(fs)
+	a.flags = fs
+
+	return a
+}
+
+func (a *adapter) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.flags.Parse(args); err != nil {
+		a.env.ErrPrintln(err)
+		return 1
+	}
+
+	client, err := a.clientMaker(a.registrationUDSPath)
+	if err != nil {
+		a.env.ErrPrintln(err)
+		return 1
+	}
+
+	if err := a.cmd.run(ctx, a.env, client); err != nil {
+		a.env.ErrPrintln(err)
+		return 1
+	}
+
+	return 0
+}
+
+func (a *adapter) Help() string {
+	return a.flags.Parse([]string{"-h"}).Error()
+}
+
+func (a *adapter) Synopsis() string {
+	return a.cmd.synopsis()
+}
+
+func printKeypairSet(env *cli.Env, keypairSet *registration.CAKeypairSet) error {
+	status := "prepared"
+	if keypairSet.Active {
+		status = "active"
+	}
+	if err := env.Printf("Slot             : %s (%s)\n", keypairSet.Slot, status); err != nil {
+		return err
+	}
+
+	cert, err := x509.ParseCertificate(keypairSet.X509Ca)
+	if err != nil {
+		return err
+	}
+	if err := env.Printf("X509 CA Key ID   : %s\n", keypairSet.X509CaKeyId); err != nil {
+		return err
+	}
+	if err := env.Printf("X509 CA Expires  : %s\n", cert.NotAfter.UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if keypairSet.JwtSigningKey != nil {
+		if err := env.Printf("JWT Key ID       : %s\n", keypairSet.JwtSigningKey.Kid); err != nil {
+			return err
+		}
+		if err := env.Printf("JWT Key Expires  : %s\n", time.Unix(keypairSet.JwtSigningKey.NotAfter, 0).UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	mitchellh_cli "github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+)
+
+// NewListCommand creates a new "list" subcommand for "ca" command.
+func NewListCommand() mitchellh_cli.Command {
+	return newListCommand(cli.DefaultEnv, util.NewRegistrationClient)
+}
+
+func newListCommand(env *cli.Env, clientMaker clientMaker) mitchellh_cli.Command {
+	return adaptCommand(env, clientMaker, new(listCommand))
+}
+
+type listCommand struct{}
+
+func (c *listCommand) name() string {
+	return "ca list"
+}
+
+func (c *listCommand) synopsis() string {
+	return "Lists the active and prepared CA keypair sets"
+}
+
+func (c *listCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *listCommand) run(ctx context.Context, env *cli.Env, client registration.RegistrationClient) error {
+	resp, err := client.ListCAKeypairSets(ctx, &common.Empty{})
+	if err != nil {
+		return err
+	}
+
+	for i, keypairSet := range resp.KeypairSets {
+		if i > 0 {
+			if err := env.Println(); err != nil {
+				return err
+			}
+		}
+		if err := printKeypairSet(env, keypairSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	mitchellh_cli "github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+)
+
+// NewPrepareCommand creates a new "prepare" subcommand for "ca" command.
+func NewPrepareCommand() mitchellh_cli.Command {
+	return newPrepareCommand(cli.DefaultEnv, util.NewRegistrationClient)
+}
+
+func newPrepareCommand(env *cli.Env, clientMaker clientMaker) mitchellh_cli.Command {
+	return adaptCommand(env, clientMaker, new(prepareCommand))
+}
+
+type prepareCommand struct{}
+
+func (c *prepareCommand) name() string {
+	return "ca prepare"
+}
+
+func (c *prepareCommand) synopsis() string {
+	return "Prepares a new CA keypair set and publishes it in the bundle"
+}
+
+func (c *prepareCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *prepareCommand) run(ctx context.Context, env *cli.Env, client registration.RegistrationClient) error {
+	keypairSet, err := client.PrepareCAKeypairSet(ctx, &common.Empty{})
+	if err != nil {
+		return err
+	}
+	return printKeypairSet(env, keypairSet)
+}
@@ -0,0 +1,60 @@
+package ca
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	mitchellh_cli "github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/proto/api/registration"
+)
+
+// NewRevokeCommand creates a new "revoke" subcommand for "ca" command.
+func NewRevokeCommand() mitchellh_cli.Command {
+	return newRevokeCommand(cli.DefaultEnv, util.NewRegistrationClient)
+}
+
+func newRevokeCommand(env *cli.Env, clientMaker clientMaker) mitchellh_cli.Command {
+	return adaptCommand(env, clientMaker, new(revokeCommand))
+}
+
+type revokeCommand struct {
+	// Subject key ID of the X.509 CA to revoke
+	x509CAKeyID string
+
+	// Key ID of the JWT signing key to revoke
+	jwtKeyID string
+}
+
+func (c *revokeCommand) name() string {
+	return "ca revoke"
+}
+
+func (c *revokeCommand) synopsis() string {
+	return "Revokes an inactive CA key, removing it from the bundle"
+}
+
+func (c *revokeCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.x509CAKeyID, "x509CAKeyID", "", "Key ID of the X.509 CA to revoke")
+	fs.StringVar(&c.jwtKeyID, "jwtKeyID", "", "Key ID of the JWT signing key to revoke")
+}
+
+func (c *revokeCommand) run(ctx context.Context, env *cli.Env, client registration.RegistrationClient) error {
+	if c.x509CAKeyID == "" && c.jwtKeyID == "" {
+		return errors.New("either -x509CAKeyID or -jwtKeyID is required")
+	}
+	if c.x509CAKeyID != "" && c.jwtKeyID != "" {
+		return errors.New("only one of -x509CAKeyID or -jwtKeyID can be set")
+	}
+
+	if _, err := client.RevokeCAKey(ctx, &registration.RevokeCAKeyRequest{
+		X509CaKeyId: c.x509CAKeyID,
+		JwtKeyId:    c.jwtKeyID,
+	}); err != nil {
+		return err
+	}
+
+	return env.Println("CA key revoked.")
+}
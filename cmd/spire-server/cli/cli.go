@@ -6,6 +6,7 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/ca"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
@@ -35,6 +36,18 @@ func Run(args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"ca list": func() (cli.Command, error) {
+			return ca.NewListCommand(), nil
+		},
+		"ca prepare": func() (cli.Command, error) {
+			return ca.NewPrepareCommand(), nil
+		},
+		"ca activate": func() (cli.Command, error) {
+			return ca.NewActivateCommand(), nil
+		},
+		"ca revoke": func() (cli.Command, error) {
+			return ca.NewRevokeCommand(), nil
+		},
 		"experimental bundle show": func() (cli.Command, error) {
 			return bundle.NewExperimentalShowCommand(), nil
 		},
@@ -62,6 +75,12 @@ func Run(args []string) int {
 		"token generate": func() (cli.Command, error) {
 			return &token.GenerateCLI{}, nil
 		},
+		"token list": func() (cli.Command, error) {
+			return &token.ListCLI{}, nil
+		},
+		"token revoke": func() (cli.Command, error) {
+			return &token.RevokeCLI{}, nil
+		},
 		"healthcheck": func() (cli.Command, error) {
 			return healthcheck.NewHealthCheckCommand(), nil
 		},
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/cli"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -37,17 +38,20 @@ type runConfig struct {
 }
 
 type serverRunConfig struct {
-	BindAddress         string           `hcl:"bind_address"`
-	BindPort            int              `hcl:"bind_port"`
-	CASubject           *caSubjectConfig `hcl:"ca_subject"`
-	CATTL               string           `hcl:"ca_ttl"`
-	DataDir             string           `hcl:"data_dir"`
-	LogFile             string           `hcl:"log_file"`
-	LogLevel            string           `hcl:"log_level"`
-	RegistrationUDSPath string           `hcl:"registration_uds_path"`
-	SVIDTTL             string           `hcl:"svid_ttl"`
-	TrustDomain         string           `hcl:"trust_domain"`
-	UpstreamBundle      bool             `hcl:"upstream_bundle"`
+	BindAddress         string              `hcl:"bind_address"`
+	BindPort            int                 `hcl:"bind_port"`
+	CAKeyType           string              `hcl:"ca_key_type"`
+	CASubject           *caSubjectConfig    `hcl:"ca_subject"`
+	CATTL               string              `hcl:"ca_ttl"`
+	DataDir             string              `hcl:"data_dir"`
+	JWTKeyType          string              `hcl:"jwt_key_type"`
+	LogFile             string              `hcl:"log_file"`
+	LogLevel            string              `hcl:"log_level"`
+	NodeAttestorCIDRs   map[string][]string `hcl:"node_attestor_cidrs"`
+	RegistrationUDSPath string              `hcl:"registration_uds_path"`
+	SVIDTTL             string              `hcl:"svid_ttl"`
+	TrustDomain         string              `hcl:"trust_domain"`
+	UpstreamBundle      bool                `hcl:"upstream_bundle"`
 
 	ConfigPath string
 
@@ -283,6 +287,38 @@ func mergeConfig(orig *serverConfig, cmd *runConfig) error {
 		orig.CATTL = ttl
 	}
 
+	if cmd.Server.CAKeyType != "" {
+		keyType, err := cryptoutil.ParseKeyType(cmd.Server.CAKeyType)
+		if err != nil {
+			return fmt.Errorf("unable to parse CA key type %q: %v", cmd.Server.CAKeyType, err)
+		}
+		orig.CAKeyType = keyType
+	}
+
+	if cmd.Server.JWTKeyType != "" {
+		keyType, err := cryptoutil.ParseKeyType(cmd.Server.JWTKeyType)
+		if err != nil {
+			return fmt.Errorf("unable to parse JWT key type %q: %v", cmd.Server.JWTKeyType, err)
+		}
+		orig.JWTKeyType = keyType
+	}
+
+	if len(cmd.Server.NodeAttestorCIDRs) > 0 {
+		orig.NodeAttestorCIDRs = make(map[string][]*net.IPNet)
+		for attestor, cidrs := range cmd.Server.NodeAttestorCIDRs {
+			if len(cidrs) == 0 {
+				return fmt.Errorf("no CIDRs configured for node attestor %q", attestor)
+			}
+			for _, cidr := range cidrs {
+				_, ipNet, err := net.ParseCIDR(cidr)
+				if err != nil {
+					return fmt.Errorf("unable to parse CIDR %q for node attestor %q: %v", cidr, attestor, err)
+				}
+				orig.NodeAttestorCIDRs[attestor] = append(orig.NodeAttestorCIDRs[attestor], ipNet)
+			}
+		}
+	}
+
 	if subject := cmd.Server.CASubject; subject != nil {
 		orig.CASubject = pkix.Name{
 			Organization: subject.Organization,
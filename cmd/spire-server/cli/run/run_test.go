@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -99,3 +100,47 @@ func TestMergeConfigGood(t *testing.T) {
 	assert.Equal(t, orig.GlobalConfig().TrustDomain, "example.org")
 	assert.Equal(t, orig.umask, 0077)
 }
+
+func TestMergeConfigKeyTypes(t *testing.T) {
+	c := &runConfig{
+		Server: serverRunConfig{
+			CAKeyType:  "rsa-2048",
+			JWTKeyType: "ec-p384",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, common.KeyType_RSA_2048, orig.CAKeyType)
+	assert.Equal(t, common.KeyType_EC_P384, orig.JWTKeyType)
+
+	c.Server.CAKeyType = "dsa-1024"
+	err = mergeConfig(newDefaultConfig(), c)
+	require.EqualError(t, err, `unable to parse CA key type "dsa-1024": unsupported key type "dsa-1024"`)
+}
+
+func TestMergeConfigNodeAttestorCIDRs(t *testing.T) {
+	c := &runConfig{
+		Server: serverRunConfig{
+			NodeAttestorCIDRs: map[string][]string{
+				"x509pop": {"10.0.0.0/8", "2001:db8::/32"},
+			},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	require.Len(t, orig.NodeAttestorCIDRs["x509pop"], 2)
+	assert.Equal(t, "10.0.0.0/8", orig.NodeAttestorCIDRs["x509pop"][0].String())
+	assert.Equal(t, "2001:db8::/32", orig.NodeAttestorCIDRs["x509pop"][1].String())
+
+	c.Server.NodeAttestorCIDRs["x509pop"] = []string{"10.0.0.0"}
+	err = mergeConfig(newDefaultConfig(), c)
+	require.EqualError(t, err, `unable to parse CIDR "10.0.0.0" for node attestor "x509pop": invalid CIDR address: 10.0.0.0`)
+
+	c.Server.NodeAttestorCIDRs["x509pop"] = []string{}
+	err = mergeConfig(newDefaultConfig(), c)
+	require.EqualError(t, err, `no CIDRs configured for node attestor "x509pop"`)
+}
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"net/url"
@@ -23,10 +24,40 @@ type GenerateConfig struct {
 	// Optional SPIFFE ID to create with the token
 	SpiffeID string
 
+	// Optional agent SPIFFE ID to bind the token to
+	AgentSpiffeID string
+
+	// Selectors assigned to agents attesting with the token
+	Selectors StringsFlag
+
+	// Number of times the token can be used
+	MaxUses int
+
 	// Token TTL in seconds
 	TTL int
 }
 
+// Validate performs a basic validation on config fields
+func (c *GenerateConfig) Validate() error {
+	if c.MaxUses < 0 {
+		return errors.New("max uses cannot be negative")
+	}
+
+	// agents attesting with an unbound token that can be used more than
+	// once get distinct SPIFFE IDs, so there is no single parent ID for
+	// the vanity record
+	if c.SpiffeID != "" && c.AgentSpiffeID == "" && c.MaxUses > 1 {
+		return errors.New("an agent SPIFFE ID is required to assign a SPIFFE ID to a token that can be used more than once")
+	}
+
+	// the first agent to attest with a bound token takes the agent ID
+	if c.AgentSpiffeID != "" && c.MaxUses > 1 {
+		return errors.New("a token bound to an agent SPIFFE ID can only be used once")
+	}
+
+	return nil
+}
+
 func (GenerateCLI) Synopsis() string {
 	return "Generates a join token"
 }
@@ -45,21 +76,34 @@ func (g GenerateCLI) Run(args []string) int {
 		return 1
 	}
 
+	if err := config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	req, err := config.joinToken()
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
 	c, err := util.NewRegistrationClient(config.RegistrationUDSPath)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	token, err := g.createToken(ctx, c, config.TTL)
+	token, err := g.createToken(ctx, c, req)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
-	fmt.Printf("Token: %s\n", token)
+	fmt.Printf("Token: %s\n", token.Token)
 
 	if config.SpiffeID == "" {
-		fmt.Printf("Warning: Missing SPIFFE ID.\n")
+		if token.SpiffeId == "" {
+			fmt.Printf("Warning: Missing SPIFFE ID.\n")
+		}
 		return 0
 	}
 
@@ -72,37 +116,35 @@ func (g GenerateCLI) Run(args []string) int {
 	return 0
 }
 
-// createToken calls the registration API and creates a new token
-// with the given TTL. It returns the raw token and an error, if any
-func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl int) (string, error) {
-	req := &registration.JoinToken{Ttl: int32(ttl)}
-	resp, err := c.CreateJoinToken(ctx, req)
-	if err != nil {
-		return "", err
-	}
-
-	return resp.Token, nil
+// createToken calls the registration API and creates a new token.
+// It returns the created token and an error, if any
+func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, req *registration.JoinToken) (*registration.JoinToken, error) {
+	return c.CreateJoinToken(ctx, req)
 }
 
 // createVanityRecord inserts a registration entry with parent ID set to the SPIFFE ID
 // belonging to a token. The purpose is to allow folks to easily create vanity names
 // backed by token IDs.
-func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.RegistrationClient, token, spiffeID string) error {
+func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.RegistrationClient, token *registration.JoinToken, spiffeID string) error {
 	id, err := idutil.ParseSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload())
 	if err != nil {
 		return err
 	}
 
-	parentID := &url.URL{
-		Scheme: id.Scheme,
-		Host:   id.Host,
-		Path:   path.Join("spire", "agent", "join_token", token),
+	parentID := token.SpiffeId
+	if parentID == "" {
+		u := &url.URL{
+			Scheme: id.Scheme,
+			Host:   id.Host,
+			Path:   path.Join("spire", "agent", "join_token", token.Token),
+		}
+		parentID = u.String()
 	}
 	req := &common.RegistrationEntry{
-		ParentId: parentID.String(),
+		ParentId: parentID,
 		SpiffeId: id.String(),
 		Selectors: []*common.Selector{
-			{Type: "spiffe_id", Value: parentID.String()},
+			{Type: "spiffe_id", Value: parentID},
 		},
 	}
 
@@ -120,6 +162,9 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 
 	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
 	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
+	flags.StringVar(&c.AgentSpiffeID, "agentSpiffeID", "", "Agent SPIFFE ID to bind the token to (optional)")
+	flags.Var(&c.Selectors, "selector", "A colon-delimited type:value selector assigned to agents attesting with the token. Can be used more than once")
+	flags.IntVar(&c.MaxUses, "maxUses", 1, "Number of times the token can be used")
 	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 
 	err := flags.Parse(args)
@@ -129,3 +174,20 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 
 	return c, nil
 }
+
+// joinToken returns the join token to create for the config
+func (c *GenerateConfig) joinToken() (*registration.JoinToken, error) {
+	token := &registration.JoinToken{
+		Ttl:      int32(c.TTL),
+		SpiffeId: c.AgentSpiffeID,
+		MaxUses:  int32(c.MaxUses),
+	}
+	for _, s := range c.Selectors {
+		selector, err := parseSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		token.Selectors = append(token.Selectors, selector)
+	}
+	return token, nil
+}
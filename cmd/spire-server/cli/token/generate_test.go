@@ -25,9 +25,61 @@ func TestCreateToken(t *testing.T) {
 	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
 
 	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
-	token, err := GenerateCLI{}.createToken(ctx, c, 60)
+	token, err := GenerateCLI{}.createToken(ctx, c, req)
 	require.NoError(t, err)
-	assert.Equal(t, "foobar", token)
+	assert.Equal(t, "foobar", token.Token)
+}
+
+func TestGenerateConfig(t *testing.T) {
+	// defaults
+	config, err := GenerateCLI{}.newConfig([]string{})
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	token, err := config.joinToken()
+	require.NoError(t, err)
+	assert.Equal(t, &registration.JoinToken{Ttl: 600, MaxUses: 1}, token)
+
+	// bound to an agent ID and selectors
+	config, err = GenerateCLI{}.newConfig([]string{
+		"-ttl", "60",
+		"-agentSpiffeID", "spiffe://example.org/spire/agent/node1",
+		"-selector", "a:1",
+		"-selector", "b:2:3",
+	})
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	token, err = config.joinToken()
+	require.NoError(t, err)
+	assert.Equal(t, &registration.JoinToken{
+		Ttl:      60,
+		SpiffeId: "spiffe://example.org/spire/agent/node1",
+		Selectors: []*common.Selector{
+			{Type: "a", Value: "1"},
+			{Type: "b", Value: "2:3"},
+		},
+		MaxUses: 1,
+	}, token)
+
+	// malformed selector
+	config, err = GenerateCLI{}.newConfig([]string{"-selector", "a"})
+	require.NoError(t, err)
+	_, err = config.joinToken()
+	assert.EqualError(t, err, `selector "a" must be formatted as type:value`)
+
+	// negative max uses
+	config, err = GenerateCLI{}.newConfig([]string{"-maxUses", "-1"})
+	require.NoError(t, err)
+	assert.EqualError(t, config.Validate(), "max uses cannot be negative")
+
+	// vanity record for an unbound token that can be used more than once
+	config, err = GenerateCLI{}.newConfig([]string{"-maxUses", "2", "-spiffeID", "spiffe://example.org/foo"})
+	require.NoError(t, err)
+	assert.EqualError(t, config.Validate(), "an agent SPIFFE ID is required to assign a SPIFFE ID to a token that can be used more than once")
+
+	// bound token that can be used more than once
+	config, err = GenerateCLI{}.newConfig([]string{"-maxUses", "2", "-agentSpiffeID", "spiffe://example.org/spire/agent/node1"})
+	require.NoError(t, err)
+	assert.EqualError(t, config.Validate(), "a token bound to an agent SPIFFE ID can only be used once")
 }
 
 func TestCreateVanityRecord(t *testing.T) {
@@ -35,7 +87,7 @@ func TestCreateVanityRecord(t *testing.T) {
 	defer ctrl.Finish()
 
 	c := mock_registration.NewMockRegistrationClient(ctrl)
-	token := "foobar"
+	token := &registration.JoinToken{Token: "foobar"}
 	spiffeID := "spiffe://example.org/VanityID"
 	tokenID := "spiffe://example.org/spire/agent/join_token/foobar"
 
@@ -51,6 +103,21 @@ func TestCreateVanityRecord(t *testing.T) {
 	err := GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.NoError(t, err)
 
+	// Tokens bound to an agent ID use it as the parent ID
+	boundToken := &registration.JoinToken{
+		Token:    "foobar",
+		SpiffeId: "spiffe://example.org/spire/agent/node1",
+	}
+	c.EXPECT().CreateEntry(gomock.Any(), &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/spire/agent/node1",
+		SpiffeId: spiffeID,
+		Selectors: []*common.Selector{
+			{Type: "spiffe_id", Value: "spiffe://example.org/spire/agent/node1"},
+		},
+	})
+	err = GenerateCLI{}.createVanityRecord(ctx, c, boundToken, spiffeID)
+	assert.NoError(t, err)
+
 	// Test a bad spiffe id
 	spiffeID = "badID/foo/bar"
 	c.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).MaxTimes(0)
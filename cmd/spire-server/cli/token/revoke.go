@@ -0,0 +1,91 @@
+package token
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+// RevokeConfig holds configuration for RevokeCLI
+type RevokeConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// Token being revoked
+	Token string
+}
+
+// Validate will perform a basic validation on config fields
+func (c *RevokeConfig) Validate() error {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	if c.Token == "" {
+		return errors.New("a token is required")
+	}
+
+	return nil
+}
+
+// RevokeCLI command for join token revocation
+type RevokeCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (RevokeCLI) Synopsis() string {
+	return "Revokes an outstanding join token"
+}
+
+func (c RevokeCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+// Run will revoke a join token
+func (c RevokeCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+
+	_, err = c.registrationClient.RevokeJoinToken(ctx, &registration.RevokeJoinTokenRequest{Token: config.Token})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error revoking join token: %v \n", err)
+		return 1
+	}
+
+	fmt.Println("Join token revoked successfully")
+	return 0
+}
+
+func (RevokeCLI) parseConfig(args []string) (*RevokeConfig, error) {
+	f := flag.NewFlagSet("token revoke", flag.ContinueOnError)
+	c := &RevokeConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.Token, "token", "", "The join token to revoke")
+
+	return c, f.Parse(args)
+}
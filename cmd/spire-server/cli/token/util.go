@@ -0,0 +1,56 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+)
+
+func parseSelector(str string) (*common.Selector, error) {
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector \"%s\" must be formatted as type:value", str)
+	}
+
+	return &common.Selector{
+		Type:  parts[0],
+		Value: parts[1],
+	}, nil
+}
+
+func printJoinToken(t *registration.JoinToken) {
+	fmt.Printf("Token           : %s\n", t.Token)
+	fmt.Printf("Expiration time : %s\n", time.Unix(t.ExpiresAt, 0))
+
+	if t.SpiffeId != "" {
+		fmt.Printf("SPIFFE ID       : %s\n", t.SpiffeId)
+	}
+
+	maxUses := t.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	fmt.Printf("Uses            : %d/%d\n", t.Uses, maxUses)
+
+	for _, s := range t.Selectors {
+		fmt.Printf("Selector        : %s:%s\n", s.Type, s.Value)
+	}
+
+	fmt.Println()
+}
+
+// StringsFlag defines a custom type for string lists. Doing
+// this allows us to support repeatable string flags.
+type StringsFlag []string
+
+func (s *StringsFlag) String() string {
+	return fmt.Sprint(*s)
+}
+
+func (s *StringsFlag) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
@@ -1,34 +1,77 @@
 module github.com/spiffe/spire
 
+go 1.27.1
+
 require (
 	github.com/Azure/azure-sdk-for-go v19.1.0+incompatible
-	github.com/Azure/go-ansiterm v0.0.0-20170929234023-d6e3b3328b78 // indirect
 	github.com/Azure/go-autorest v10.15.2+incompatible
+	github.com/andres-erbsen/clock v0.0.0-20160526145045-9e14626cd129
+	github.com/armon/go-metrics v0.0.0-20180713145231-3c58d8115a78
+	github.com/aws/aws-sdk-go v1.15.24
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/docker/docker v0.7.3-0.20190123164140-de86ba27fbea
+	github.com/envoyproxy/go-control-plane v0.6.6
+	github.com/gofrs/uuid v3.2.0+incompatible
+	github.com/gogo/googleapis v1.1.0
+	github.com/gogo/protobuf v1.2.0
+	github.com/golang/mock v1.1.1
+	github.com/golang/protobuf v1.3.2
+	github.com/google/go-tpm v0.1.2-0.20190725015402-ae6dd98980d4
+	github.com/google/go-tpm-tools v0.0.0-20190906225433-1614c142f845
+	github.com/grpc-ecosystem/grpc-gateway v1.4.1
+	github.com/hashicorp/go-hclog v0.0.0-20180828044259-75ecd6e6d645
+	github.com/hashicorp/go-plugin v0.0.0-20180111182130-e37881a3f1a0
+	github.com/hashicorp/hcl v1.0.0
+	github.com/imkira/go-observer v1.0.3
+	github.com/jinzhu/gorm v0.0.0-20180818231433-32455088f24d
+	github.com/kubernetes/client-go v10.0.0+incompatible
+	github.com/mitchellh/cli v1.0.0
+	github.com/shirou/gopsutil v0.0.0-20180801053943-8048a2e9c577
+	github.com/sirupsen/logrus v1.0.6
+	github.com/spiffe/go-spiffe v0.0.0-20170907221946-2bb3101d62b4
+	github.com/stretchr/testify v1.2.2
+	github.com/zeebo/errs v1.0.0
+	go.uber.org/atomic v1.3.2
+	golang.org/x/crypto v0.0.0-20181203042331-505ab145d0a9
+	golang.org/x/net v0.0.0-20190108225652-1e06a53dbb7e
+	golang.org/x/oauth2 v0.0.0-20190226205417-e64efc72b421
+	golang.org/x/sys v0.0.0-20181205085412-a5c9d58dba9a
+	golang.org/x/time v0.0.0-20180412165947-fbb02b2291d2
+	google.golang.org/grpc v1.14.0
+	gopkg.in/square/go-jose.v2 v2.1.8
+	gopkg.in/tomb.v2 v2.0.0-20161208151619-d5d1b5820637
+	gotest.tools v2.2.0+incompatible
+	k8s.io/apimachinery v0.0.0-20190221093215-450d01ad5771
+	k8s.io/client-go v10.0.0+incompatible
+)
+
+require (
+	cloud.google.com/go v0.34.0 // indirect
+	github.com/Azure/go-ansiterm v0.0.0-20170929234023-d6e3b3328b78 // indirect
+	github.com/BurntSushi/toml v0.3.1 // indirect
 	github.com/Microsoft/go-winio v0.4.11 // indirect
 	github.com/Nvveen/Gotty v0.0.0-20120604004816-cd527374f1e5 // indirect
 	github.com/StackExchange/wmi v0.0.0-20180116203802-5d049714c4a6 // indirect
-	github.com/andres-erbsen/clock v0.0.0-20160526145045-9e14626cd129
-	github.com/armon/go-metrics v0.0.0-20180713145231-3c58d8115a78
+	github.com/armon/consul-api v0.0.0-20180202201655-eb2c6b5be1b6 // indirect
 	github.com/armon/go-radix v1.0.0 // indirect
-	github.com/aws/aws-sdk-go v1.15.24
+	github.com/bgentry/speakeasy v0.1.0 // indirect
+	github.com/coreos/etcd v3.3.10+incompatible // indirect
+	github.com/coreos/go-etcd v2.0.0+incompatible // indirect
+	github.com/coreos/go-semver v0.2.0 // indirect
+	github.com/cpuguy83/go-md2man v1.0.10 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/denisenkom/go-mssqldb v0.0.0-20181014144952-4e0d7dc8888f // indirect
-	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/dimchansky/utfbom v1.0.0 // indirect
 	github.com/docker/distribution v2.7.1+incompatible // indirect
-	github.com/docker/docker v0.7.3-0.20190123164140-de86ba27fbea
 	github.com/docker/go-connections v0.4.0 // indirect
 	github.com/docker/go-units v0.3.3 // indirect
-	github.com/envoyproxy/go-control-plane v0.6.6
 	github.com/erikstmartin/go-testdb v0.0.0-20160219214506-8d10e4a1bae5 // indirect
+	github.com/fatih/color v1.7.0 // indirect
+	github.com/fsnotify/fsnotify v1.4.7 // indirect
 	github.com/go-ini/ini v1.38.2 // indirect
 	github.com/go-ole/go-ole v1.2.1 // indirect
 	github.com/go-sql-driver/mysql v1.4.1 // indirect
-	github.com/gofrs/uuid v3.2.0+incompatible
-	github.com/gogo/googleapis v1.1.0
-	github.com/gogo/protobuf v1.2.0
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b // indirect
-	github.com/golang/mock v1.1.1
-	github.com/golang/protobuf v1.2.0
 	github.com/google/btree v0.0.0-20180813153112-4030bb1f1f0c // indirect
 	github.com/google/go-cmp v0.2.0 // indirect
 	github.com/google/gofuzz v0.0.0-20170612174753-24818f796faf // indirect
@@ -37,25 +80,29 @@ require (
 	github.com/gorilla/context v1.1.1 // indirect
 	github.com/gorilla/mux v1.6.2 // indirect
 	github.com/gregjones/httpcache v0.0.0-20190212212710-3befbb6ad0cc // indirect
-	github.com/grpc-ecosystem/grpc-gateway v1.4.1
-	github.com/hashicorp/go-hclog v0.0.0-20180828044259-75ecd6e6d645
+	github.com/hashicorp/errwrap v1.0.0 // indirect
 	github.com/hashicorp/go-immutable-radix v1.0.0 // indirect
-	github.com/hashicorp/go-plugin v0.0.0-20180111182130-e37881a3f1a0
-	github.com/hashicorp/hcl v1.0.0
+	github.com/hashicorp/go-multierror v1.0.0 // indirect
+	github.com/hashicorp/go-uuid v1.0.0 // indirect
+	github.com/hashicorp/golang-lru v0.5.0 // indirect
 	github.com/hashicorp/yamux v0.0.0-20180826203732-cc6d2ea263b2 // indirect
+	github.com/hpcloud/tail v1.0.0 // indirect
 	github.com/imdario/mergo v0.3.7 // indirect
-	github.com/imkira/go-observer v1.0.3
-	github.com/jinzhu/gorm v0.0.0-20180818231433-32455088f24d
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/jinzhu/inflection v0.0.0-20180308033659-04140366298a // indirect
 	github.com/jinzhu/now v0.0.0-20181116074157-8ec929ed50c3 // indirect
+	github.com/jmespath/go-jmespath v0.0.0-20160202185014-0b12d6b521d8 // indirect
 	github.com/json-iterator/go v1.1.5 // indirect
 	github.com/jtolds/gls v4.2.1+incompatible // indirect
-	github.com/kubernetes/client-go v10.0.0+incompatible
 	github.com/lib/pq v1.0.0 // indirect
 	github.com/lyft/protoc-gen-validate v0.0.12 // indirect
+	github.com/magiconair/properties v1.8.0 // indirect
+	github.com/mattn/go-colorable v0.0.9 // indirect
+	github.com/mattn/go-isatty v0.0.3 // indirect
 	github.com/mattn/go-sqlite3 v1.9.0 // indirect
-	github.com/mitchellh/cli v1.0.0
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
 	github.com/mitchellh/go-testing-interface v1.0.0 // indirect
+	github.com/mitchellh/mapstructure v1.1.2 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
 	github.com/modern-go/reflect2 v1.0.1 // indirect
 	github.com/onsi/ginkgo v1.7.0 // indirect
@@ -63,36 +110,36 @@ require (
 	github.com/opencontainers/go-digest v1.0.0-rc1 // indirect
 	github.com/opencontainers/image-spec v1.0.1 // indirect
 	github.com/pascaldekloe/goe v0.0.0-20180627143212-57f6aae5913c // indirect
+	github.com/pelletier/go-toml v1.2.0 // indirect
 	github.com/peterbourgon/diskv v2.0.1+incompatible // indirect
 	github.com/pkg/errors v0.8.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/posener/complete v1.1.2 // indirect
-	github.com/shirou/gopsutil v0.0.0-20180801053943-8048a2e9c577
+	github.com/russross/blackfriday v1.5.2 // indirect
 	github.com/shirou/w32 v0.0.0-20160930032740-bb4de0191aa4 // indirect
-	github.com/sirupsen/logrus v1.0.6
 	github.com/smartystreets/assertions v0.0.0-20180927180507-b2de0cb4f26d // indirect
 	github.com/smartystreets/goconvey v0.0.0-20181108003508-044398e4856c // indirect
+	github.com/spf13/afero v1.1.2 // indirect
+	github.com/spf13/cast v1.3.0 // indirect
+	github.com/spf13/cobra v0.0.5 // indirect
+	github.com/spf13/jwalterweatherman v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.3 // indirect
-	github.com/spiffe/go-spiffe v0.0.0-20170907221946-2bb3101d62b4
-	github.com/stretchr/testify v1.2.2
-	github.com/zeebo/errs v1.0.0
-	go.uber.org/atomic v1.3.2
-	golang.org/x/crypto v0.0.0-20180820150726-614d502a4dac // indirect
-	golang.org/x/net v0.0.0-20190108225652-1e06a53dbb7e
-	golang.org/x/oauth2 v0.0.0-20190226205417-e64efc72b421 // indirect
-	golang.org/x/sys v0.0.0-20180909124046-d0be0721c37e
-	golang.org/x/time v0.0.0-20180412165947-fbb02b2291d2
+	github.com/spf13/viper v1.3.2 // indirect
+	github.com/ugorji/go/codec v0.0.0-20181204163529-d75b2dcb6bc8 // indirect
+	github.com/xordataexchange/crypt v0.0.3-0.20170626215501-b2862e3d0a77 // indirect
+	golang.org/x/sync v0.0.0-20181221193216-37e7f081c4d4 // indirect
+	golang.org/x/text v0.3.0 // indirect
+	google.golang.org/appengine v1.4.0 // indirect
 	google.golang.org/genproto v0.0.0-20180817151627-c66870c02cf8 // indirect
-	google.golang.org/grpc v1.14.0
 	gopkg.in/airbrake/gobrake.v2 v2.0.9 // indirect
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 // indirect
+	gopkg.in/fsnotify.v1 v1.4.7 // indirect
 	gopkg.in/gemnasium/logrus-airbrake-hook.v2 v2.1.2 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/ini.v1 v1.40.0 // indirect
-	gopkg.in/square/go-jose.v2 v2.1.8
-	gopkg.in/tomb.v2 v2.0.0-20161208151619-d5d1b5820637
-	gotest.tools v2.2.0+incompatible
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
 	k8s.io/api v0.0.0-20190111032252-67edc246be36 // indirect
-	k8s.io/apimachinery v0.0.0-20190221093215-450d01ad5771
-	k8s.io/client-go v10.0.0+incompatible
 	k8s.io/klog v0.2.0 // indirect
 	sigs.k8s.io/yaml v1.1.0 // indirect
 )
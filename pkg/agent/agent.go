@@ -59,12 +59,13 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	as, err := a.attest(ctx, cat, metrics)
+	nodeAttestor := a.newAttestor(cat, metrics)
+	as, err := nodeAttestor.Attest(ctx)
 	if err != nil {
 		return err
 	}
 
-	manager, err := a.newManager(ctx, cat, metrics, as)
+	manager, err := a.newManager(ctx, cat, metrics, as, nodeAttestor)
 	if err != nil {
 		return err
 	}
@@ -135,22 +136,25 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	}
 }
 
-func (a *Agent) attest(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics) (*attestor.AttestationResult, error) {
+func (a *Agent) newAttestor(cat catalog.Catalog, metrics telemetry.Metrics) attestor.Attestor {
 	config := attestor.Config{
 		Catalog:         cat,
 		Metrics:         metrics,
 		JoinToken:       a.c.JoinToken,
+		NodeAttestors:   a.c.NodeAttestors,
 		TrustDomain:     a.c.TrustDomain,
 		TrustBundle:     a.c.TrustBundle,
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
 		Log:             a.c.Log.WithField("subsystem_name", "attestor"),
 		ServerAddress:   a.c.ServerAddress,
+
+		ReattestNodeAttestors: a.c.ReattestNodeAttestors,
 	}
-	return attestor.New(&config).Attest(ctx)
+	return attestor.New(&config)
 }
 
-func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, as *attestor.AttestationResult) (manager.Manager, error) {
+func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, as *attestor.AttestationResult, nodeAttestor attestor.Attestor) (manager.Manager, error) {
 	config := &manager.Config{
 		SVID:            as.SVID,
 		SVIDKey:         as.Key,
@@ -162,6 +166,13 @@ func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, metrics tel
 		Metrics:         metrics,
 		BundleCachePath: a.bundleCachePath(),
 		SVIDCachePath:   a.agentSVIDPath(),
+		CachePath:       a.cachePath(),
+		WorkloadKeyType: a.c.WorkloadKeyType,
+		LazySVIDs:       a.c.LazySVIDs,
+		SVIDIdleTimeout: a.c.SVIDIdleTimeout,
+	}
+	if a.c.ReattestOnDataChange {
+		config.Reattestor = nodeAttestor
 	}
 
 	mgr, err := manager.New(config)
@@ -196,3 +207,7 @@ func (a *Agent) bundleCachePath() string {
 func (a *Agent) agentSVIDPath() string {
 	return path.Join(a.c.DataDir, "agent_svid.der")
 }
+
+func (a *Agent) cachePath() string {
+	return path.Join(a.c.DataDir, "cache.json")
+}
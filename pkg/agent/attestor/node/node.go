@@ -1,15 +1,20 @@
 package attestor
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/url"
 	"path"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	spiffe_tls "github.com/spiffe/go-spiffe/tls"
@@ -17,6 +22,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/plugin/jointoken"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/agent/keymanager"
@@ -35,12 +41,21 @@ type AttestationResult struct {
 
 type Attestor interface {
 	Attest(ctx context.Context) (*AttestationResult, error)
+
+	// AttestationDataChanged returns true if the attestation data produced by
+	// the node attestors differs from the data the agent last attested with.
+	AttestationDataChanged(ctx context.Context) (bool, error)
+
+	// Reattest performs node attestation again to obtain a new agent SVID
+	// for the given key, authenticating the server with the given root CAs.
+	Reattest(ctx context.Context, key *ecdsa.PrivateKey, rootCAs []*x509.Certificate) ([]*x509.Certificate, error)
 }
 
 type Config struct {
 	Catalog         catalog.Catalog
 	Metrics         telemetry.Metrics
 	JoinToken       string
+	NodeAttestors   []string
 	TrustDomain     url.URL
 	TrustBundle     []*x509.Certificate
 	BundleCachePath string
@@ -48,10 +63,19 @@ type Config struct {
 	Log             logrus.FieldLogger
 	ServerAddress   string
 	NodeClient      node.NodeClient
+
+	// Names of the node attestors whose attestation data changes while the
+	// agent runs, e.g. because the credential they hold is rotated. Only
+	// these are checked for changes in the attestation data.
+	ReattestNodeAttestors []string
 }
 
 type attestor struct {
 	c *Config
+
+	// digest of the attestation data the agent last attested with
+	mu         sync.Mutex
+	dataDigest []byte
 }
 
 func New(config *Config) Attestor {
@@ -79,6 +103,67 @@ func (a *attestor) Attest(ctx context.Context) (res *AttestationResult, err erro
 	return &AttestationResult{Bundle: bundle, SVID: svid, Key: key}, nil
 }
 
+// AttestationDataChanged fetches the initial attestation data from the node
+// attestors whose data changes over time and compares it with the data the
+// agent last attested with. When the agent did not attest since it started
+// (i.e. the SVID was loaded from disk), the current data becomes the baseline
+// and false is returned.
+func (a *attestor) AttestationDataChanged(ctx context.Context) (bool, error) {
+	// make sure all of the streams are cancelled if something goes awry
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	primary, additional, err := a.nodeAttestors()
+	if err != nil {
+		return false, err
+	}
+
+	var attestationData []*common.AttestationData
+	for _, attestor := range append([]*catalog.ManagedNodeAttestor{primary}, additional...) {
+		if !a.hasChangingData(attestor) {
+			continue
+		}
+		fetchStream, err := attestor.FetchAttestationData(ctx)
+		if err != nil {
+			return false, fmt.Errorf("opening stream for fetching %q attestation: %v", attestor.Config().PluginName, err)
+		}
+		data, err := a.fetchAttestationData(fetchStream, nil)
+		if err != nil {
+			return false, err
+		}
+		a.closeFetchStream(fetchStream)
+		attestationData = append(attestationData, data.AttestationData)
+	}
+	if len(attestationData) == 0 {
+		return false, nil
+	}
+	digest := attestationDataDigest(attestationData)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.dataDigest == nil {
+		a.dataDigest = digest
+		return false, nil
+	}
+	return !bytes.Equal(a.dataDigest, digest), nil
+}
+
+// Reattest obtains a new agent SVID for the given key by performing node
+// attestation again.
+func (a *attestor) Reattest(ctx context.Context, key *ecdsa.PrivateKey, rootCAs []*x509.Certificate) (svid []*x509.Certificate, err error) {
+	defer telemetry.CountCall(a.c.Metrics, "node", "reattest")(&err)
+
+	if len(rootCAs) == 0 {
+		return nil, errors.New("no root CAs to authenticate the server")
+	}
+	bundle := bundleutil.BundleFromRootCAs(a.c.TrustDomain.String(), rootCAs)
+	svid, _, err = a.newSVID(ctx, key, bundle)
+	if err != nil {
+		return nil, err
+	}
+	return svid, nil
+}
+
 func (a *attestor) loadSVID(ctx context.Context) ([]*x509.Certificate, *ecdsa.PrivateKey, error) {
 	mgrs := a.c.Catalog.KeyManagers()
 	if len(mgrs) > 1 {
@@ -148,15 +233,24 @@ func (a *attestor) fetchAttestationData(
 			Data: []byte(a.c.JoinToken),
 		}
 
-		id := &url.URL{
-			Scheme: "spiffe",
-			Host:   a.c.TrustDomain.Host,
-			Path:   path.Join("spire", "agent", "join_token", a.c.JoinToken),
+		spiffeID := jointoken.SpiffeID(a.c.TrustDomain.Host, a.c.JoinToken)
+
+		// the server challenges with the SPIFFE ID assigned by the token
+		// when it differs from the one derived from the token
+		if challenge != nil {
+			tokenChallenge := new(jointoken.Challenge)
+			if err := json.Unmarshal(challenge, tokenChallenge); err != nil {
+				return nil, fmt.Errorf("unable to unmarshal join token challenge: %v", err)
+			}
+			if tokenChallenge.SpiffeID == "" {
+				return nil, errors.New("join token challenge is missing the assigned SPIFFE ID")
+			}
+			spiffeID = tokenChallenge.SpiffeID
 		}
 
 		return &nodeattestor.FetchAttestationDataResponse{
 			AttestationData: data,
-			SpiffeId:        id.String(),
+			SpiffeId:        spiffeID,
 		}, nil
 	}
 
@@ -200,17 +294,16 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	primary, additional, err := a.nodeAttestors()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	attestorName := "join_token"
 	var fetchStream nodeattestor.FetchAttestationData_Stream
-	if a.c.JoinToken == "" {
-		plugins := a.c.Catalog.NodeAttestors()
-		if len(plugins) > 1 {
-			return nil, nil, errors.New("more than one node attestor configured")
-		}
-		attestor := plugins[0]
-		attestorName = attestor.Config().PluginName
-		var err error
-		fetchStream, err = attestor.FetchAttestationData(ctx)
+	if primary != nil {
+		attestorName = primary.Config().PluginName
+		fetchStream, err = primary.FetchAttestationData(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("opening stream for fetching attestation: %v", err)
 		}
@@ -218,42 +311,80 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 
 	counter.AddLabel("type", attestorName)
 
+	// the attestation data of the additional node attestors is sent along
+	// with the initial request. Their challenges are answered as they come.
+	var additionalStreams []nodeattestor.FetchAttestationData_Stream
+	var additionalData []*common.AttestationData
+	for _, attestor := range additional {
+		additionalStream, err := attestor.FetchAttestationData(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening stream for fetching %q attestation: %v", attestor.Config().PluginName, err)
+		}
+		data, err := a.fetchAttestationData(additionalStream, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%q: %v", attestor.Config().PluginName, err)
+		}
+		additionalStreams = append(additionalStreams, additionalStream)
+		additionalData = append(additionalData, data.AttestationData)
+	}
+
 	conn, err := a.serverConn(ctx, bundle.RootCAs())
 	if err != nil {
 		return nil, nil, fmt.Errorf("create attestation client: %v", err)
 	}
 	defer conn.Close()
-	if a.c.NodeClient == nil {
-		a.c.NodeClient = node.NewNodeClient(conn)
+	nodeClient := a.c.NodeClient
+	if nodeClient == nil {
+		nodeClient = node.NewNodeClient(conn)
 	}
 
-	attestStream, err := a.c.NodeClient.Attest(ctx)
+	attestStream, err := nodeClient.Attest(ctx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("opening stream for attestation: %v", err)
 	}
 
 	var spiffeID string
 	var csr []byte
+	var primaryData *common.AttestationData
 	attestResp := new(node.AttestResponse)
 	for {
-		data, err := a.fetchAttestationData(fetchStream, attestResp.Challenge)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		// (re)generate the SVID if the spiffeid changes.
-		if spiffeID != data.SpiffeId {
-			csr, err = util.MakeCSR(key, data.SpiffeId)
+		var attestReq *node.AttestRequest
+		if index := attestResp.ChallengeAttestorIndex; index > 0 {
+			// the challenge was issued for an additional node attestor
+			if int(index) > len(additionalStreams) {
+				return nil, nil, fmt.Errorf("challenge issued for unknown node attestor %d", index)
+			}
+			data, err := a.fetchAttestationData(additionalStreams[index-1], attestResp.Challenge)
 			if err != nil {
-				return nil, nil, fmt.Errorf("generate CSR for agent SVID: %v", err)
+				return nil, nil, fmt.Errorf("%q: %v", additional[index-1].Config().PluginName, err)
+			}
+			attestReq = &node.AttestRequest{
+				Response: data.Response,
+			}
+		} else {
+			data, err := a.fetchAttestationData(fetchStream, attestResp.Challenge)
+			if err != nil {
+				return nil, nil, err
+			}
+			if primaryData == nil {
+				primaryData = data.AttestationData
+			}
+
+			// (re)generate the SVID if the spiffeid changes.
+			if spiffeID != data.SpiffeId {
+				csr, err = util.MakeCSR(key, data.SpiffeId)
+				if err != nil {
+					return nil, nil, fmt.Errorf("generate CSR for agent SVID: %v", err)
+				}
+				spiffeID = data.SpiffeId
 			}
-			spiffeID = data.SpiffeId
-		}
 
-		attestReq := &node.AttestRequest{
-			AttestationData: data.AttestationData,
-			Csr:             csr,
-			Response:        data.Response,
+			attestReq = &node.AttestRequest{
+				AttestationData:           data.AttestationData,
+				Csr:                       csr,
+				Response:                  data.Response,
+				AdditionalAttestationData: additionalData,
+			}
 		}
 
 		if err := attestStream.Send(attestReq); err != nil {
@@ -274,10 +405,10 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 	counter.AddLabel("spiffe_id", spiffeID)
 
 	if fetchStream != nil {
-		fetchStream.CloseSend()
-		if _, err := fetchStream.Recv(); err != io.EOF {
-			a.c.Log.Warnf("received unexpected result on trailing recv: %v", err)
-		}
+		a.closeFetchStream(fetchStream)
+	}
+	for _, additionalStream := range additionalStreams {
+		a.closeFetchStream(additionalStream)
 	}
 	attestStream.CloseSend()
 	if _, err := attestStream.Recv(); err != io.EOF {
@@ -289,9 +420,108 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 		return nil, nil, fmt.Errorf("parse attestation response: %v", err)
 	}
 
+	var changingData []*common.AttestationData
+	if a.hasChangingData(primary) {
+		changingData = append(changingData, primaryData)
+	}
+	for i, attestor := range additional {
+		if a.hasChangingData(attestor) {
+			changingData = append(changingData, additionalData[i])
+		}
+	}
+	a.mu.Lock()
+	a.dataDigest = attestationDataDigest(changingData)
+	a.mu.Unlock()
+
 	return svid, bundle, nil
 }
 
+// nodeAttestors returns the node attestor that determines the agent ID, which
+// is nil when attesting with a join token, and the additional node attestors
+// that only contribute selectors, in the configured order.
+func (a *attestor) nodeAttestors() (*catalog.ManagedNodeAttestor, []*catalog.ManagedNodeAttestor, error) {
+	plugins := a.c.Catalog.NodeAttestors()
+	if len(a.c.NodeAttestors) == 0 {
+		if a.c.JoinToken != "" {
+			return nil, nil, nil
+		}
+		if len(plugins) > 1 {
+			return nil, nil, errors.New("more than one node attestor configured; node_attestors must list them in order")
+		}
+		if len(plugins) == 0 {
+			return nil, nil, errors.New("no node attestor configured")
+		}
+		return plugins[0], nil, nil
+	}
+
+	byName := make(map[string]*catalog.ManagedNodeAttestor)
+	for _, plugin := range plugins {
+		byName[plugin.Config().PluginName] = plugin
+	}
+
+	var attestors []*catalog.ManagedNodeAttestor
+	seen := make(map[string]bool)
+	for _, name := range a.c.NodeAttestors {
+		if seen[name] {
+			return nil, nil, fmt.Errorf("node attestor %q listed more than once", name)
+		}
+		seen[name] = true
+
+		// the join token is always used to determine the agent ID when
+		// provided
+		if name == "join_token" && a.c.JoinToken != "" {
+			continue
+		}
+		plugin, ok := byName[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("node attestor %q is not configured", name)
+		}
+		attestors = append(attestors, plugin)
+	}
+
+	if a.c.JoinToken != "" {
+		return nil, attestors, nil
+	}
+	if len(attestors) == 0 {
+		return nil, nil, errors.New("no node attestor configured")
+	}
+	return attestors[0], attestors[1:], nil
+}
+
+// hasChangingData returns true if the attestation data of the node attestor
+// changes over time. The join token (i.e. a nil node attestor) never does.
+func (a *attestor) hasChangingData(attestor *catalog.ManagedNodeAttestor) bool {
+	if attestor == nil {
+		return false
+	}
+	for _, name := range a.c.ReattestNodeAttestors {
+		if name == attestor.Config().PluginName {
+			return true
+		}
+	}
+	return false
+}
+
+// attestationDataDigest returns a digest of the type and data of the given
+// attestation data, in order.
+func attestationDataDigest(attestationData []*common.AttestationData) []byte {
+	h := sha256.New()
+	for _, data := range attestationData {
+		for _, field := range [][]byte{[]byte(data.GetType()), data.GetData()} {
+			binary.Write(h, binary.BigEndian, uint32(len(field)))
+			h.Write(field)
+		}
+	}
+	return h.Sum(nil)
+}
+
+func (a *attestor) closeFetchStream(fetchStream nodeattestor.FetchAttestationData_Stream) {
+	fetchStream.CloseSend()
+	if _, err := fetchStream.Recv(); err != io.EOF {
+		a.c.Log.Warnf("received unexpected result on trailing recv: %v", err)
+	}
+}
+
 func (a *attestor) serverConn(ctx context.Context, bundle []*x509.Certificate) (*grpc.ClientConn, error) {
 	config := grpcutil.GRPCDialerConfig{
 		Log:      grpcutil.LoggerFromFieldLogger(a.c.Log),
@@ -329,7 +559,16 @@ func (a *attestor) parseAttestationResponse(id string, r *node.AttestResponse) (
 
 	svidMsg, ok := r.SvidUpdate.Svids[id]
 	if !ok {
-		return nil, nil, fmt.Errorf("incorrect svid: %s", id)
+		// the server may assign an agent ID that differs from the one that
+		// was requested (e.g. when derived from data only the server has
+		// access to), in which case it returns a single SVID for it.
+		if len(r.SvidUpdate.Svids) > 1 {
+			return nil, nil, fmt.Errorf("incorrect svid: %s", id)
+		}
+		for assignedID, assignedSVID := range r.SvidUpdate.Svids {
+			a.c.Log.Infof("Server assigned agent ID %q instead of %q", assignedID, id)
+			svidMsg = assignedSVID
+		}
 	}
 
 	svid, err := x509.ParseCertificates(svidMsg.CertChain)
@@ -141,6 +141,23 @@ func (s *NodeAttestorTestSuite) TestAttestNodeWithChallengeResponse() {
 	s.Assert().Equal([]*x509.Certificate{svid}, as.SVID)
 }
 
+func (s *NodeAttestorTestSuite) TestAttestNodeWithServerAssignedID() {
+	s.linkBundle()
+	s.setCatalog(true)
+	s.setFetchPrivateKeyResponse()
+	s.setGenerateKeyPairResponse()
+	s.setFetchAttestationDataResponse(nil)
+	s.setAttestResponseFor("spiffe://example.com/spire/agent/assigned", nil, nil)
+	as, err := s.attestor.Attest(ctx)
+	s.Require().NoError(err)
+
+	svid, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+
+	s.Assert().Equal(key, as.Key)
+	s.Assert().Equal([]*x509.Certificate{svid}, as.SVID)
+}
+
 func (s *NodeAttestorTestSuite) TestAttestJoinToken() {
 	s.config.JoinToken = "foobar"
 	s.linkBundle()
@@ -159,6 +176,237 @@ func (s *NodeAttestorTestSuite) TestAttestJoinToken() {
 	s.Assert().Equal([]*x509.Certificate{svid}, as.SVID)
 }
 
+func (s *NodeAttestorTestSuite) TestAttestJoinTokenWithAssignedID() {
+	assignedID := "spiffe://example.com/spire/agent/node1"
+
+	s.config.JoinToken = "foobar"
+	s.linkBundle()
+	s.setCatalog(false)
+	s.setFetchPrivateKeyResponse()
+	s.setGenerateKeyPairResponse()
+
+	// the agent first requests the ID derived from the token, then the ID
+	// assigned by the server
+	var requestedIDs []string
+	s.setAttestResponseFor(assignedID, []challengeResponse{
+		{challenge: `{"spiffe_id":"` + assignedID + `"}`},
+	}, func(req *node.AttestRequest) {
+		s.Require().Equal("foobar", string(req.AttestationData.Data))
+		csr, err := x509.ParseCertificateRequest(req.Csr)
+		s.Require().NoError(err)
+		s.Require().Len(csr.URIs, 1)
+		requestedIDs = append(requestedIDs, csr.URIs[0].String())
+	})
+
+	as, err := s.attestor.Attest(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{
+		"spiffe://example.com/spire/agent/join_token/foobar",
+		assignedID,
+	}, requestedIDs)
+
+	svid, _, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	s.Assert().Equal([]*x509.Certificate{svid}, as.SVID)
+}
+
+func (s *NodeAttestorTestSuite) TestAttestNodeWithAdditionalAttestors() {
+	additionalAttestor := mock_nodeattestor.NewMockNodeAttestor(s.ctrl)
+
+	// the second node attestor in the catalog determines the agent ID
+	s.config.NodeAttestors = []string{"fake_nodeattestor_2", "fake_nodeattestor_1"}
+	s.linkBundle()
+	s.catalog.SetNodeAttestors(additionalAttestor, s.nodeAttestor)
+	s.catalog.SetKeyManagers(s.keyManager)
+	s.setFetchPrivateKeyResponse()
+	s.setGenerateKeyPairResponse()
+	s.setFetchAttestationDataResponse(nil)
+
+	additionalData := &common.AttestationData{
+		Type: "other",
+		Data: []byte("data"),
+	}
+	stream := mock_nodeattestor.NewMockFetchAttestationData_Stream(s.ctrl)
+	stream.EXPECT().Recv().Return(&nodeattestor.FetchAttestationDataResponse{
+		AttestationData: additionalData,
+	}, nil)
+	stream.EXPECT().Send(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: []byte("challenge"),
+	})
+	stream.EXPECT().Recv().Return(&nodeattestor.FetchAttestationDataResponse{
+		Response: []byte("response"),
+	}, nil)
+	stream.EXPECT().CloseSend()
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+	additionalAttestor.EXPECT().FetchAttestationData(gomock.Any()).Return(stream, nil)
+
+	// the challenge is tagged with the index of the additional attestor
+	var requests []*node.AttestRequest
+	s.setAttestResponseForIndex("spiffe://example.com/spire/agent/join_token/foobar", []challengeResponse{
+		{challenge: "challenge"},
+	}, 1, func(req *node.AttestRequest) {
+		requests = append(requests, req)
+	})
+
+	as, err := s.attestor.Attest(ctx)
+	s.Require().NoError(err)
+
+	svid, _, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	s.Assert().Equal([]*x509.Certificate{svid}, as.SVID)
+
+	s.Require().Len(requests, 2)
+	s.Require().Equal("join_token", requests[0].AttestationData.Type)
+	s.Require().Equal([]*common.AttestationData{additionalData}, requests[0].AdditionalAttestationData)
+	s.Require().Equal(&node.AttestRequest{Response: []byte("response")}, requests[1])
+}
+
+func (s *NodeAttestorTestSuite) TestNodeAttestorsSelection() {
+	other := mock_nodeattestor.NewMockNodeAttestor(s.ctrl)
+	s.catalog.SetNodeAttestors(s.nodeAttestor, other)
+
+	for _, tt := range []struct {
+		name          string
+		joinToken     string
+		nodeAttestors []string
+		primary       string
+		additional    []string
+		err           string
+	}{
+		{
+			name: "more than one without order",
+			err:  "more than one node attestor configured; node_attestors must list them in order",
+		},
+		{
+			name:      "join token without order",
+			joinToken: "foobar",
+		},
+		{
+			name:          "ordered",
+			nodeAttestors: []string{"fake_nodeattestor_2", "fake_nodeattestor_1"},
+			primary:       "fake_nodeattestor_2",
+			additional:    []string{"fake_nodeattestor_1"},
+		},
+		{
+			name:          "subset",
+			nodeAttestors: []string{"fake_nodeattestor_1"},
+			primary:       "fake_nodeattestor_1",
+		},
+		{
+			name:          "join token with order",
+			joinToken:     "foobar",
+			nodeAttestors: []string{"join_token", "fake_nodeattestor_1"},
+			additional:    []string{"fake_nodeattestor_1"},
+		},
+		{
+			name:          "unknown",
+			nodeAttestors: []string{"fake_nodeattestor_1", "fake_nodeattestor_3"},
+			err:           `node attestor "fake_nodeattestor_3" is not configured`,
+		},
+		{
+			name:          "duplicate",
+			nodeAttestors: []string{"fake_nodeattestor_1", "fake_nodeattestor_1"},
+			err:           `node attestor "fake_nodeattestor_1" listed more than once`,
+		},
+	} {
+		s.T().Logf("case %s", tt.name)
+		s.config.JoinToken = tt.joinToken
+		s.config.NodeAttestors = tt.nodeAttestors
+
+		primary, additional, err := s.attestor.(*attestor).nodeAttestors()
+		if tt.err != "" {
+			s.Require().EqualError(err, tt.err)
+			continue
+		}
+		s.Require().NoError(err)
+		if tt.primary == "" {
+			s.Require().Nil(primary)
+		} else {
+			s.Require().NotNil(primary)
+			s.Require().Equal(tt.primary, primary.Config().PluginName)
+		}
+		var additionalNames []string
+		for _, attestor := range additional {
+			additionalNames = append(additionalNames, attestor.Config().PluginName)
+		}
+		s.Require().Equal(tt.additional, additionalNames)
+	}
+}
+
+func (s *NodeAttestorTestSuite) TestAttestationDataChanged() {
+	s.setCatalog(true)
+	s.config.ReattestNodeAttestors = []string{"fake_nodeattestor_1"}
+
+	// the data the agent loaded from disk with becomes the baseline
+	s.setFetchInitialAttestationDataResponse("foo")
+	changed, err := s.attestor.AttestationDataChanged(ctx)
+	s.Require().NoError(err)
+	s.Require().False(changed)
+
+	s.setFetchInitialAttestationDataResponse("foo")
+	changed, err = s.attestor.AttestationDataChanged(ctx)
+	s.Require().NoError(err)
+	s.Require().False(changed)
+
+	s.setFetchInitialAttestationDataResponse("bar")
+	changed, err = s.attestor.AttestationDataChanged(ctx)
+	s.Require().NoError(err)
+	s.Require().True(changed)
+}
+
+func (s *NodeAttestorTestSuite) TestAttestationDataChangedWithUnchangingData() {
+	s.setCatalog(true)
+
+	// the attestation data of the node attestor is not fetched
+	for i := 0; i < 2; i++ {
+		changed, err := s.attestor.AttestationDataChanged(ctx)
+		s.Require().NoError(err)
+		s.Require().False(changed)
+	}
+}
+
+func (s *NodeAttestorTestSuite) TestAttestationDataChangedWithJoinToken() {
+	s.config.JoinToken = "foobar"
+	s.setCatalog(false)
+
+	for i := 0; i < 2; i++ {
+		changed, err := s.attestor.AttestationDataChanged(ctx)
+		s.Require().NoError(err)
+		s.Require().False(changed)
+	}
+}
+
+func (s *NodeAttestorTestSuite) TestReattest() {
+	s.setCatalog(true)
+	s.config.ReattestNodeAttestors = []string{"fake_nodeattestor_1"}
+	s.setFetchAttestationDataResponse(nil)
+	s.setAttestResponse(nil)
+
+	svid, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	bundle, err := util.LoadBundleFixture()
+	s.Require().NoError(err)
+
+	// no root CAs to authenticate the server with
+	_, err = s.attestor.Reattest(ctx, key, nil)
+	s.Require().EqualError(err, "no root CAs to authenticate the server")
+
+	certs, err := s.attestor.Reattest(ctx, key, bundle)
+	s.Require().NoError(err)
+	s.Require().Equal([]*x509.Certificate{svid}, certs)
+
+	// the data the agent re-attested with is the new baseline
+	s.setFetchInitialAttestationDataResponse("foobar")
+	changed, err := s.attestor.AttestationDataChanged(ctx)
+	s.Require().NoError(err)
+	s.Require().False(changed)
+
+	s.setFetchInitialAttestationDataResponse("rotated")
+	changed, err = s.attestor.AttestationDataChanged(ctx)
+	s.Require().NoError(err)
+	s.Require().True(changed)
+}
+
 func (s *NodeAttestorTestSuite) linkAgentSVIDPath() {
 	err := os.Symlink(
 		path.Join(util.ProjectRoot(), "test/fixture/certs/agent_svid.der"),
@@ -204,6 +452,20 @@ func (s *NodeAttestorTestSuite) setFetchAttestationDataResponse(challenges []cha
 	s.nodeAttestor.EXPECT().FetchAttestationData(gomock.Any()).Return(stream, nil)
 }
 
+func (s *NodeAttestorTestSuite) setFetchInitialAttestationDataResponse(data string) {
+	stream := mock_nodeattestor.NewMockFetchAttestationData_Stream(s.ctrl)
+	stream.EXPECT().Recv().Return(&nodeattestor.FetchAttestationDataResponse{
+		AttestationData: &common.AttestationData{
+			Type: "join_token",
+			Data: []byte(data),
+		},
+		SpiffeId: "spiffe://example.com/spire/agent/join_token/" + data,
+	}, nil)
+	stream.EXPECT().CloseSend()
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+	s.nodeAttestor.EXPECT().FetchAttestationData(gomock.Any()).Return(stream, nil)
+}
+
 func (s *NodeAttestorTestSuite) setFetchPrivateKeyResponse() {
 	_, key, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -236,24 +498,39 @@ func (s *NodeAttestorTestSuite) setCatalog(usesNodeAttestor bool) {
 }
 
 func (s *NodeAttestorTestSuite) setAttestResponse(challenges []challengeResponse) {
+	s.setAttestResponseFor("spiffe://example.com/spire/agent/join_token/foobar", challenges, nil)
+}
+
+func (s *NodeAttestorTestSuite) setAttestResponseFor(spiffeID string, challenges []challengeResponse, onSend func(*node.AttestRequest)) {
+	s.setAttestResponseForIndex(spiffeID, challenges, 0, onSend)
+}
+
+func (s *NodeAttestorTestSuite) setAttestResponseForIndex(spiffeID string, challenges []challengeResponse, attestorIndex uint32, onSend func(*node.AttestRequest)) {
 	svid, _, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
 
 	bundle, err := util.LoadBundleFixture()
 	s.Require().NoError(err)
 
+	send := func(req *node.AttestRequest) {
+		if onSend != nil {
+			onSend(req)
+		}
+	}
+
 	stream := mock_node.NewMockNode_AttestClient(s.ctrl)
-	stream.EXPECT().Send(gomock.Any())
+	stream.EXPECT().Send(gomock.Any()).Do(send)
 	for _, challenge := range challenges {
-		stream.EXPECT().Send(gomock.Any())
+		stream.EXPECT().Send(gomock.Any()).Do(send)
 		stream.EXPECT().Recv().Return(&node.AttestResponse{
-			Challenge: []byte(challenge.challenge),
+			Challenge:              []byte(challenge.challenge),
+			ChallengeAttestorIndex: attestorIndex,
 		}, nil)
 	}
 	stream.EXPECT().Recv().Return(&node.AttestResponse{
 		SvidUpdate: &node.X509SVIDUpdate{
 			Svids: map[string]*node.X509SVID{
-				"spiffe://example.com/spire/agent/join_token/foobar": &node.X509SVID{
+				spiffeID: &node.X509SVID{
 					CertChain: svid.Raw,
 					ExpiresAt: svid.NotAfter.Unix(),
 				},
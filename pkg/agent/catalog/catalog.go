@@ -14,9 +14,13 @@ import (
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/jointoken"
 	k8s_na_psat "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/k8s/psat"
 	k8s_na_sat "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/k8s/sat"
+	sshpop_na "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/sshpop"
+	tpm_na "github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/tpm"
 	"github.com/spiffe/spire/pkg/agent/plugin/nodeattestor/x509pop"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/cri"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/docker"
 	k8s_wa "github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/k8s"
+	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/systemd"
 	"github.com/spiffe/spire/pkg/agent/plugin/workloadattestor/unix"
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
@@ -58,11 +62,15 @@ var (
 			"azure_msi":  nodeattestor.NewBuiltIn(azure.NewMSIAttestorPlugin()),
 			"k8s_sat":    nodeattestor.NewBuiltIn(k8s_na_sat.NewAttestorPlugin()),
 			"k8s_psat":   nodeattestor.NewBuiltIn(k8s_na_psat.NewAttestorPlugin()),
+			"tpm":        nodeattestor.NewBuiltIn(tpm_na.New()),
+			"sshpop":     nodeattestor.NewBuiltIn(sshpop_na.New()),
 		},
 		WorkloadAttestorType: {
-			"k8s":    workloadattestor.NewBuiltIn(k8s_wa.New()),
-			"unix":   workloadattestor.NewBuiltIn(unix.New()),
-			"docker": workloadattestor.NewBuiltIn(docker.New()),
+			"k8s":     workloadattestor.NewBuiltIn(k8s_wa.New()),
+			"unix":    workloadattestor.NewBuiltIn(unix.New()),
+			"docker":  workloadattestor.NewBuiltIn(docker.New()),
+			"cri":     workloadattestor.NewBuiltIn(cri.New()),
+			"systemd": workloadattestor.NewBuiltIn(systemd.New()),
 		},
 	}
 )
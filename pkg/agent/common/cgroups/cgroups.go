@@ -20,14 +20,24 @@ type Cgroup struct {
 	GroupPath      string
 }
 
+// IsUnified returns true if the cgroup belongs to the cgroup v2 unified
+// hierarchy.
+func (c Cgroup) IsUnified() bool {
+	return c.HierarchyID == "0" && c.ControllerList == ""
+}
+
 // GetCGroups returns a slice of cgroups for pid using fs for filesystem calls.
 //
 // The expected cgroup format is "hierarchy-ID:controller-list:cgroup-path", and
 // this function will return an error if every cgroup does not meet that format.
+// On hosts using cgroup v2, the only entry is the one for the unified
+// hierarchy, which has the "0::cgroup-path" format (i.e. a hierarchy ID of 0
+// and an empty controller list). Hosts in "hybrid" mode have both cgroup v1
+// entries and the unified hierarchy entry.
 //
 // For more information, see:
-//  - http://man7.org/linux/man-pages/man7/cgroups.7.html
-//  - https://www.kernel.org/doc/Documentation/cgroup-v2.txt
+//   - http://man7.org/linux/man-pages/man7/cgroups.7.html
+//   - https://www.kernel.org/doc/Documentation/cgroup-v2.txt
 func GetCgroups(pid int32, fs FileSystem) ([]Cgroup, error) {
 	path := fmt.Sprintf("/proc/%v/cgroup", pid)
 	file, err := fs.Open(path)
@@ -12,6 +12,8 @@ import (
 const (
 	cgSimple    = "../../../../test/fixture/workloadattestor/agentutil/cgroups_simple.txt"
 	cgBadFormat = "../../../../test/fixture/workloadattestor/agentutil/cgroups_bad_format.txt"
+	cgV2        = "../../../../test/fixture/workloadattestor/agentutil/cgroups_v2.txt"
+	cgHybrid    = "../../../../test/fixture/workloadattestor/agentutil/cgroups_hybrid.txt"
 )
 
 var (
@@ -43,6 +45,37 @@ func TestCgroups(t *testing.T) {
 	require.Equal(t, expectSimpleCgroup, cgroups)
 }
 
+func TestCgroupsV2(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFileSystem := filesystem_mock.NewMockfileSystem(mockCtrl)
+	mockFileSystem.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgV2))
+
+	cgroups, err := GetCgroups(123, mockFileSystem)
+	require.NoError(t, err)
+	require.Equal(t, []Cgroup{
+		{"0", "", "/system.slice/docker-6469646e742065787065637420616e796f6e6520746f20726561642074686973.scope"},
+	}, cgroups)
+	require.True(t, cgroups[0].IsUnified())
+}
+
+func TestCgroupsHybrid(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFileSystem := filesystem_mock.NewMockfileSystem(mockCtrl)
+	mockFileSystem.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgHybrid))
+
+	cgroups, err := GetCgroups(123, mockFileSystem)
+	require.NoError(t, err)
+	require.Equal(t, append(expectSimpleCgroup, Cgroup{"0", "", "/user.slice/user-1000.slice/session-2.scope"}), cgroups)
+	for _, cgroup := range cgroups[:len(cgroups)-1] {
+		require.False(t, cgroup.IsUnified())
+	}
+	require.True(t, cgroups[len(cgroups)-1].IsUnified())
+}
+
 func TestCgroupsBadFormat(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
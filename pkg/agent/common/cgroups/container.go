@@ -0,0 +1,58 @@
+package cgroups
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// containerIDRE matches a cgroup path element named after a container by one
+// of the known container runtimes, capturing the container ID. The element is
+// the ID itself for runtimes using the cgroupfs driver (e.g.
+// "/docker/<id>" or "/kubepods/burstable/pod<uid>/<id>"), a scope for
+// runtimes using the systemd driver (e.g. "docker-<id>.scope",
+// "cri-containerd-<id>.scope", "crio-<id>.scope" or "libpod-<id>.scope") or
+// a "slice:runtime:id" triple for containerd's systemd driver (e.g.
+// "kubepods-besteffort-pod<uid>.slice:cri-containerd:<id>").
+var containerIDRE = regexp.MustCompile(`^(?:` +
+	`(?:docker-|cri-containerd-|crio-|libpod-)?([[:xdigit:]]{64})(?:\.scope)?` +
+	`|` +
+	`[^:]+\.slice:(?:docker|cri-containerd|crio):([[:xdigit:]]{64})` +
+	`)$`)
+
+// GetContainerID returns the ID of the container the cgroups belong to, or
+// an empty string if the cgroups do not belong to a container. Both cgroup v1
+// and cgroup v2 (unified hierarchy) entries are supported.
+//
+// The deepest path element that looks like a container is used, so the ID of
+// the innermost container is returned for nested containers. Elements below
+// it (e.g. cgroups created by an init system inside the container) are
+// ignored. An error is returned if the entries disagree on the container ID.
+func GetContainerID(cgroups []Cgroup) (string, error) {
+	var containerID string
+	for _, cgroup := range cgroups {
+		id := getContainerIDFromPath(cgroup.GroupPath)
+		switch {
+		case id == "":
+			continue
+		case containerID == "":
+			containerID = id
+		case containerID != id:
+			return "", fmt.Errorf("multiple container IDs found in cgroups (%s, %s)", containerID, id)
+		}
+	}
+	return containerID, nil
+}
+
+func getContainerIDFromPath(path string) string {
+	elements := strings.Split(path, "/")
+	for i := len(elements) - 1; i >= 0; i-- {
+		if m := containerIDRE.FindStringSubmatch(elements[i]); m != nil {
+			if m[1] != "" {
+				return m[1]
+			}
+			return m[2]
+		}
+	}
+	return ""
+}
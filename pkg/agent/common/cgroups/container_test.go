@@ -0,0 +1,113 @@
+package cgroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testContainerID  = "6469646e742065787065637420616e796f6e6520746f20726561642074686973"
+	otherContainerID = "9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961"
+)
+
+func TestGetContainerID(t *testing.T) {
+	tests := []struct {
+		desc      string
+		cgroups   []Cgroup
+		expectID  string
+		expectErr string
+	}{
+		{
+			desc:     "docker with cgroupfs driver",
+			cgroups:  []Cgroup{{"10", "devices", "/docker/" + testContainerID}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "docker with systemd driver",
+			cgroups:  []Cgroup{{"0", "", "/system.slice/docker-" + testContainerID + ".scope"}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "kubepods with cgroupfs driver",
+			cgroups:  []Cgroup{{"11", "hugetlb", "/kubepods/burstable/pod2c48913c-b29f-11e7-9350-020968147796/" + testContainerID}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "kubepods guaranteed pod",
+			cgroups:  []Cgroup{{"11", "hugetlb", "/kubepods/pod2c48913c-b29f-11e7-9350-020968147796/" + testContainerID}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "kubepods with systemd driver and containerd",
+			cgroups:  []Cgroup{{"0", "", "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2c48913c.slice/cri-containerd-" + testContainerID + ".scope"}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "kubepods with systemd driver and cri-o",
+			cgroups:  []Cgroup{{"0", "", "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c.slice/crio-" + testContainerID + ".scope"}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "containerd systemd driver triple",
+			cgroups:  []Cgroup{{"0", "", "/system.slice/containerd.service/kubepods-besteffort-pod2c48913c.slice:cri-containerd:" + testContainerID}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "podman",
+			cgroups:  []Cgroup{{"0", "", "/machine.slice/libpod-" + testContainerID + ".scope"}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "cgroups created inside the container",
+			cgroups:  []Cgroup{{"0", "", "/system.slice/docker-" + testContainerID + ".scope/init.scope"}},
+			expectID: testContainerID,
+		},
+		{
+			desc:     "nested containers",
+			cgroups:  []Cgroup{{"10", "devices", "/docker/" + otherContainerID + "/docker/" + testContainerID}},
+			expectID: testContainerID,
+		},
+		{
+			desc: "hybrid hierarchy",
+			cgroups: []Cgroup{
+				{"10", "devices", "/"},
+				{"1", "name=systemd", "/system.slice/docker-" + testContainerID + ".scope"},
+				{"0", "", "/system.slice/docker-" + testContainerID + ".scope"},
+			},
+			expectID: testContainerID,
+		},
+		{
+			desc:    "not a container",
+			cgroups: []Cgroup{{"1", "name=systemd", "/user.slice/user-1000.slice/session-2.scope"}},
+		},
+		{
+			desc:    "cri-o monitor",
+			cgroups: []Cgroup{{"0", "", "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod2c48913c.slice/crio-conmon-" + testContainerID + ".scope"}},
+		},
+		{
+			desc:    "truncated id",
+			cgroups: []Cgroup{{"10", "devices", "/docker/6469646e7420657870656374"}},
+		},
+		{
+			desc: "conflicting ids",
+			cgroups: []Cgroup{
+				{"10", "devices", "/docker/" + testContainerID},
+				{"9", "pids", "/docker/" + otherContainerID},
+			},
+			expectErr: "multiple container IDs found in cgroups (" + testContainerID + ", " + otherContainerID + ")",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			id, err := GetContainerID(tt.cgroups)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectID, id)
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"crypto/x509"
 	"net"
 	"net/url"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/common"
 )
 
 type Config struct {
@@ -34,6 +36,29 @@ type Config struct {
 	// Join token to use for attestation, if needed
 	JoinToken string
 
+	// Names of the node attestors to attest with, in order. The first one
+	// determines the agent ID unless a join token is used.
+	NodeAttestors []string
+
+	// WorkloadKeyType is the type of the keys generated for workload SVIDs
+	WorkloadKeyType common.KeyType
+
+	// If true, workload SVIDs are only minted for registration entries
+	// matched by an active Workload API or SDS subscriber.
+	LazySVIDs bool
+
+	// SVIDIdleTimeout is how long a lazily minted workload SVID is kept
+	// after its last subscriber goes away.
+	SVIDIdleTimeout time.Duration
+
+	// If true, the agent re-attests when the attestation data of its node
+	// attestors changes, e.g. when a projected token is rotated.
+	ReattestOnDataChange bool
+
+	// Names of the node attestors whose attestation data is checked for
+	// changes when ReattestOnDataChange is true.
+	ReattestNodeAttestors []string
+
 	// If true enables profiling.
 	ProfilingEnabled bool
 
@@ -3,24 +3,35 @@ package workload
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
 	"errors"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/api/workload"
 	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
 	mock_manager "github.com/spiffe/spire/test/mock/agent/manager"
 	mock_cache "github.com/spiffe/spire/test/mock/agent/manager/cache"
@@ -28,9 +39,12 @@ import (
 	mock_workloadattestor "github.com/spiffe/spire/test/mock/proto/agent/workloadattestor"
 	mock_workload "github.com/spiffe/spire/test/mock/proto/api/workload"
 	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -602,6 +616,98 @@ func (s *HandlerTestSuite) TestCallerPID() {
 	s.Assert().Error(err)
 }
 
+func TestFetchX509SVIDWithLazySVIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	clk := clock.New()
+	nodeAPI := newFakeNodeAPI(t, clk)
+	defer nodeAPI.stop()
+	agentSVID, agentKey := nodeAPI.newSVID("spiffe://example.org/spire/agent/join_token/abcd")
+
+	dir, err := ioutil.TempDir("", "workload-handler-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log, _ := test.NewNullLogger()
+	catalog := fakeagentcatalog.New()
+	catalog.SetKeyManagers(memory.New())
+	attestor := mock_workloadattestor.NewMockWorkloadAttestor(ctrl)
+	catalog.SetWorkloadAttestors(attestor)
+
+	m, err := manager.New(&manager.Config{
+		SVID:             agentSVID,
+		SVIDKey:          agentKey,
+		Bundle:           nodeAPI.bundle,
+		Catalog:          catalog,
+		TrustDomain:      url.URL{Scheme: "spiffe", Host: "example.org"},
+		Log:              log,
+		Metrics:          telemetry.Blackhole{},
+		ServerAddr:       nodeAPI.addr(),
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		SyncInterval:     time.Hour,
+		RotationInterval: time.Hour,
+		LazySVIDs:        true,
+		SVIDIdleTimeout:  time.Hour,
+		Clk:              clk,
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runDone := make(chan error, 1)
+	go func() { runDone <- m.Run(ctx) }()
+	defer func() {
+		cancel()
+		<-runDone
+	}()
+
+	h := &Handler{
+		Manager: m,
+		Catalog: catalog,
+		L:       log,
+		M:       telemetry.Blackhole{},
+	}
+
+	// the SVID of the workload is only minted once it asks for it, which
+	// must not make its first request fail
+	streamCtx, streamCancel := context.WithCancel(makeContext(1))
+	defer streamCancel()
+	attestor.EXPECT().Attest(gomock.Any(), &workloadattestor.AttestRequest{Pid: int32(1)}).Return(&workloadattestor.AttestResponse{
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+	}, nil)
+	responses := make(chan *workload.X509SVIDResponse, 1)
+	stream := mock_workload.NewMockSpiffeWorkloadAPI_FetchX509SVIDServer(ctrl)
+	stream.EXPECT().Context().Return(streamCtx).AnyTimes()
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *workload.X509SVIDResponse) error {
+		responses <- resp
+		return nil
+	})
+
+	result := make(chan error, 1)
+	go func() { result <- h.FetchX509SVID(nil, stream) }()
+
+	select {
+	case resp := <-responses:
+		require.Len(t, resp.Svids, 1)
+		require.Equal(t, "spiffe://example.org/workload", resp.Svids[0].SpiffeId)
+	case err := <-result:
+		t.Fatalf("handler failed before sending the SVID: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the SVID")
+	}
+
+	streamCancel()
+	select {
+	case err := <-result:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("workload handler hung, shutdown timer exceeded")
+	}
+}
+
 func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
 	svid, key, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -648,3 +754,112 @@ func makeContext(pid int) context.Context {
 
 	return ctx
 }
+
+// fakeNodeAPI is a node API server that registers a single workload, with
+// the "unix:uid:1111" selector, and signs its SVIDs.
+type fakeNodeAPI struct {
+	t   *testing.T
+	clk clock.Clock
+
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	bundle *bundleutil.Bundle
+
+	listener net.Listener
+	server   *grpc.Server
+}
+
+func newFakeNodeAPI(t *testing.T, clk clock.Clock) *fakeNodeAPI {
+	caTmpl, err := util.NewCATemplate(clk, "example.org")
+	require.NoError(t, err)
+	ca, caKey, err := util.SelfSign(caTmpl)
+	require.NoError(t, err)
+
+	n := &fakeNodeAPI{
+		t:      t,
+		clk:    clk,
+		ca:     ca,
+		caKey:  caKey,
+		bundle: bundleutil.BundleFromRootCA("spiffe://example.org", ca),
+	}
+
+	serverSVID, serverKey := n.newSVID("spiffe://example.org/spire/server")
+	n.listener, err = net.Listen("tcp", "localhost:")
+	require.NoError(t, err)
+	n.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{serverSVID[0].Raw},
+			PrivateKey:  serverKey,
+		}},
+		ClientAuth: tls.RequireAnyClientCert,
+	})))
+	node.RegisterNodeServer(n.server, n)
+	go n.server.Serve(n.listener)
+	return n
+}
+
+func (n *fakeNodeAPI) addr() string {
+	return n.listener.Addr().String()
+}
+
+func (n *fakeNodeAPI) stop() {
+	n.server.Stop()
+}
+
+func (n *fakeNodeAPI) newSVID(spiffeID string) ([]*x509.Certificate, *ecdsa.PrivateKey) {
+	tmpl, err := util.NewSVIDTemplate(n.clk, spiffeID)
+	require.NoError(n.t, err)
+	svid, key, err := util.Sign(tmpl, n.ca, n.caKey)
+	require.NoError(n.t, err)
+	return []*x509.Certificate{svid}, key
+}
+
+func (n *fakeNodeAPI) Attest(node.Node_AttestServer) error {
+	return errors.New("not implemented")
+}
+
+func (n *fakeNodeAPI) FetchX509SVID(stream node.Node_FetchX509SVIDServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	svids := make(map[string]*node.X509SVID)
+	for _, csr := range req.Csrs {
+		tmpl, err := util.NewSVIDTemplateFromCSR(n.clk, csr, n.ca, 3600)
+		if err != nil {
+			return err
+		}
+		svid, _, err := util.Sign(tmpl, n.ca, n.caKey)
+		if err != nil {
+			return err
+		}
+		svids["spiffe://example.org/workload"] = &node.X509SVID{
+			CertChain: svid.Raw,
+			ExpiresAt: svid.NotAfter.Unix(),
+		}
+	}
+
+	return stream.Send(&node.FetchX509SVIDResponse{
+		SvidUpdate: &node.X509SVIDUpdate{
+			RegistrationEntries: []*common.RegistrationEntry{{
+				EntryId:   "workload",
+				ParentId:  "spiffe://example.org/spire/agent/join_token/abcd",
+				SpiffeId:  "spiffe://example.org/workload",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1111"}},
+			}},
+			Svids: svids,
+			Bundles: map[string]*common.Bundle{
+				"spiffe://example.org": n.bundle.Proto(),
+			},
+		},
+	})
+}
+
+func (n *fakeNodeAPI) FetchJWTSVID(context.Context, *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (n *fakeNodeAPI) PushJWTKeyUpstream(context.Context, *node.PushJWTKeyUpstreamRequest) (*node.PushJWTKeyUpstreamResponse, error) {
+	return nil, errors.New("not implemented")
+}
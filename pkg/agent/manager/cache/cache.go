@@ -1,7 +1,7 @@
 package cache
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/sha1"
 	"crypto/x509"
 	"encoding/base64"
@@ -15,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/proto/common"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 type Selectors []*common.Selector
@@ -24,7 +25,7 @@ type Bundle = bundleutil.Bundle
 type Entry struct {
 	RegistrationEntry *common.RegistrationEntry
 	SVID              []*x509.Certificate
-	PrivateKey        *ecdsa.PrivateKey
+	PrivateKey        crypto.Signer
 }
 
 // Wraps an observer stream to provide a type safe interface
@@ -88,12 +89,19 @@ type Cache interface {
 	DeleteEntry(regEntry *common.RegistrationEntry) bool
 	// Entries returns all the in force cached entries.
 	Entries() []*Entry
+	// MatchingEntries returns the cached entries whose registration entry
+	// selectors are included in the specified selectors.
+	MatchingEntries(selectors Selectors) []*Entry
 	// Registers and returns a Subscriber, and then sends latest WorkloadUpdate on its channel
 	Subscribe(selectors Selectors) Subscriber
+	// SubscriberSelectors returns the selectors of the active subscribers
+	SubscriberSelectors() []Selectors
 	// Set the bundles
 	SetBundles(map[string]*Bundle)
 	// Retrieve the bundle for the trust domain
 	Bundle() *Bundle
+	// Retrieve the bundles, keyed by trust domain ID
+	Bundles() map[string]*Bundle
 	// SubscribeToBundleChanges returns a bundle stream. Each
 	// time bundles are updated, a new bundle mapping is streamed.
 	SubscribeToBundleChanges() *BundleStream
@@ -110,6 +118,7 @@ type Cache interface {
 type cacheImpl struct {
 	// Map keyed by RegistrationEntry.EntryId holding Entry instances.
 	cache       map[string]*Entry
+	entryIndex  *selectorIndex // index of cache entry selectors, protected by m
 	log         logrus.FieldLogger
 	m           sync.Mutex
 	subscribers *subscribers
@@ -126,6 +135,7 @@ func New(log logrus.FieldLogger, trustDomain string, bundle *Bundle) *cacheImpl
 	}
 	return &cacheImpl{
 		cache:       make(map[string]*Entry),
+		entryIndex:  newSelectorIndex(),
 		log:         log.WithField("subsystem_name", "cache"),
 		trustDomain: trustDomain,
 		bundles:     observer.NewProperty(bundles),
@@ -161,6 +171,9 @@ func (c *cacheImpl) SetBundles(newBundles map[string]*Bundle) {
 	// TODO: be more selective about which subscribers get updated to reduce
 	// unnecessary workload updates.
 	if changed {
+		if bundle := newBundles[c.trustDomain]; bundle != nil {
+			c.dropRevokedJWTSVIDs(bundle)
+		}
 		c.bundles.Update(bundles)
 		subs := c.subscribers.getAll()
 		c.notifySubscribers(subs)
@@ -189,6 +202,16 @@ func (c *cacheImpl) Entries() []*Entry {
 	return entries
 }
 
+func (c *cacheImpl) MatchingEntries(selectors Selectors) []*Entry {
+	c.m.Lock()
+	defer c.m.Unlock()
+	entries := []*Entry{}
+	for _, entryID := range c.entryIndex.subsetsOf(selectors) {
+		entries = append(entries, c.cache[entryID])
+	}
+	return entries
+}
+
 func (c *cacheImpl) Subscribe(selectors Selectors) Subscriber {
 	// creates a subscriber
 	// adds it to the manager
@@ -202,6 +225,20 @@ func (c *cacheImpl) Subscribe(selectors Selectors) Subscriber {
 	return sub
 }
 
+func (c *cacheImpl) SubscriberSelectors() []Selectors {
+	var selectors []Selectors
+	for _, sub := range c.subscribers.getAll() {
+		sub.m.Lock()
+		if sub.active {
+			selectors = append(selectors, sub.sel)
+		} else {
+			c.subscribers.remove(sub)
+		}
+		sub.m.Unlock()
+	}
+	return selectors
+}
+
 func (c *cacheImpl) FetchEntry(entryID string) *Entry {
 	c.m.Lock()
 	defer c.m.Unlock()
@@ -212,11 +249,22 @@ func (c *cacheImpl) FetchEntry(entryID string) *Entry {
 }
 
 func (c *cacheImpl) SetEntry(entry *Entry) {
+	entryID := entry.RegistrationEntry.EntryId
+
 	c.m.Lock()
-	c.cache[entry.RegistrationEntry.EntryId] = entry
+	var oldSelectors Selectors
+	if oldEntry, found := c.cache[entryID]; found {
+		oldSelectors = oldEntry.RegistrationEntry.Selectors
+	}
+	c.cache[entryID] = entry
+	c.entryIndex.add(entryID, entry.RegistrationEntry.Selectors)
 	c.m.Unlock()
 
 	subs := c.subscribers.get(entry.RegistrationEntry.Selectors)
+	if oldSelectors != nil && !selector.NewSetFromRaw(oldSelectors).Equal(selector.NewSetFromRaw(entry.RegistrationEntry.Selectors)) {
+		// subscribers that no longer match the entry need to be notified too
+		subs = mergeSubscribers(subs, c.subscribers.get(oldSelectors))
+	}
 	c.notifySubscribers(subs)
 }
 
@@ -228,7 +276,6 @@ func (c *cacheImpl) notifySubscribers(subs []*subscriber) {
 	c.notifyMutex.Lock()
 	defer c.notifyMutex.Unlock()
 
-	entries := c.Entries()
 	bundles := c.Bundles()
 
 	for _, sub := range subs {
@@ -247,7 +294,7 @@ func (c *cacheImpl) notifySubscribers(subs []*subscriber) {
 			// To prevent blocking if there is no update available.
 		}
 
-		subEntries := subscriberEntries(sub, entries)
+		subEntries := c.MatchingEntries(sub.sel)
 
 		update := c.makeWorkloadUpdate(subEntries, bundles)
 
@@ -281,6 +328,7 @@ func (c *cacheImpl) DeleteEntry(regEntry *common.RegistrationEntry) (deleted boo
 	if entry, found := c.cache[regEntry.EntryId]; found {
 		subs = c.subscribers.get(entry.RegistrationEntry.Selectors)
 		delete(c.cache, regEntry.EntryId)
+		c.entryIndex.remove(regEntry.EntryId)
 		deleted = true
 	}
 	c.m.Unlock()
@@ -292,10 +340,10 @@ func (c *cacheImpl) DeleteEntry(regEntry *common.RegistrationEntry) (deleted boo
 }
 
 func (c *cacheImpl) FetchWorkloadUpdate(selectors Selectors) *WorkloadUpdate {
-	entries := c.Entries()
+	entries := c.MatchingEntries(selectors)
 	bundles := c.Bundles()
 
-	return c.makeWorkloadUpdate(selectorsEntries(selectors, entries), bundles)
+	return c.makeWorkloadUpdate(entries, bundles)
 }
 
 func (c *cacheImpl) GetJWTSVID(spiffeID string, audience []string) (*client.JWTSVID, bool) {
@@ -314,18 +362,37 @@ func (c *cacheImpl) SetJWTSVID(spiffeID string, audience []string, svid *client.
 	c.jwtSVIDS[key] = svid
 }
 
-func subscriberEntries(sub *subscriber, entries []*Entry) (subentries []*Entry) {
-	return selectorsEntries(sub.sel, entries)
+// dropRevokedJWTSVIDs removes the cached JWT-SVIDs signed by keys that are
+// no longer in the bundle, e.g. because the key has been revoked.
+func (c *cacheImpl) dropRevokedJWTSVIDs(bundle *Bundle) {
+	jwtSigningKeys := bundle.JWTSigningKeys()
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	for key, svid := range c.jwtSVIDS {
+		token, err := jwt.ParseSigned(svid.Token)
+		if err == nil && len(token.Headers) == 1 {
+			if _, ok := jwtSigningKeys[token.Headers[0].KeyID]; ok {
+				continue
+			}
+		}
+		c.log.Debug("Dropping cached JWT-SVID signed by a key that is no longer in the bundle")
+		delete(c.jwtSVIDS, key)
+	}
 }
 
-func selectorsEntries(selectors Selectors, entries []*Entry) (subentries []*Entry) {
-	for _, e := range entries {
-		regEntrySelectors := selector.NewSetFromRaw(e.RegistrationEntry.Selectors)
-		if selector.NewSetFromRaw(selectors).IncludesSet(regEntrySelectors) {
-			subentries = append(subentries, e)
+func mergeSubscribers(a, b []*subscriber) []*subscriber {
+	merged := make([]*subscriber, 0, len(a)+len(b))
+	seen := make(map[*subscriber]bool)
+	for _, subs := range [][]*subscriber{a, b} {
+		for _, sub := range subs {
+			if !seen[sub] {
+				seen[sub] = true
+				merged = append(merged, sub)
+			}
 		}
 	}
-	return subentries
+	return merged
 }
 
 func certsEqual(a, b []*x509.Certificate) bool {
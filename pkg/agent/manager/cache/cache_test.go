@@ -19,6 +19,7 @@ import (
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
@@ -311,6 +312,23 @@ func TestSubscriberFinish(t *testing.T) {
 	})
 }
 
+func TestSubscriberSelectors(t *testing.T) {
+	cache := New(logger, "spiffe://example.org", nil)
+	assert.Empty(t, cache.SubscriberSelectors())
+
+	sel1 := Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}}
+	sel2 := Selectors{&common.Selector{Type: "unix", Value: "uid:2222"}}
+	sub1 := cache.Subscribe(sel1)
+	sub2 := cache.Subscribe(sel2)
+	defer sub2.Finish()
+
+	assert.ElementsMatch(t, []Selectors{sel1, sel2}, cache.SubscriberSelectors())
+
+	// finished subscribers are not active anymore
+	sub1.Finish()
+	assert.Equal(t, []Selectors{sel2}, cache.SubscriberSelectors())
+}
+
 func TestFetchWorkloadUpdate(t *testing.T) {
 	one := &Entry{
 		RegistrationEntry: &common.RegistrationEntry{
@@ -370,6 +388,43 @@ func TestFetchWorkloadUpdate(t *testing.T) {
 	assert.NotNil(t, update.FederatedBundles["spiffe://bar.test"])
 }
 
+func TestSetEntryNotifiesSubscribersOfSelectorChanges(t *testing.T) {
+	cache := New(logger, "spiffe://example.org", nil)
+
+	subA := cache.Subscribe(Selectors{{Type: "A", Value: "a"}})
+	defer subA.Finish()
+	subB := cache.Subscribe(Selectors{{Type: "B", Value: "b"}})
+	defer subB.Finish()
+	<-subA.Updates()
+	<-subB.Updates()
+
+	entry := &Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:   "1",
+			Selectors: Selectors{{Type: "A", Value: "a"}},
+			ParentId:  "spiffe:parent",
+			SpiffeId:  "spiffe:id1",
+		},
+	}
+	cache.SetEntry(entry)
+	assert.Equal(t, []*Entry{entry}, (<-subA.Updates()).Entries)
+	assertNoUpdate(t, subB)
+
+	// moving the entry to other selectors notifies both the subscribers that
+	// lose it and the ones that gain it
+	moved := &Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:   "1",
+			Selectors: Selectors{{Type: "B", Value: "b"}},
+			ParentId:  "spiffe:parent",
+			SpiffeId:  "spiffe:id1",
+		},
+	}
+	cache.SetEntry(moved)
+	assert.Empty(t, (<-subA.Updates()).Entries)
+	assert.Equal(t, []*Entry{moved}, (<-subB.Updates()).Entries)
+}
+
 func TestJWTSVID(t *testing.T) {
 	now := time.Now()
 	expected := &client.JWTSVID{Token: "X", IssuedAt: now, ExpiresAt: now.Add(time.Second)}
@@ -388,8 +443,98 @@ func TestJWTSVID(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestJWTSVIDsDroppedWhenSigningKeyLeavesBundle(t *testing.T) {
+	now := time.Now()
+	newJWTSVID := func(kid string) *client.JWTSVID {
+		token, err := jwtsvid.SignToken("spiffe://example.org/blog", []string{"bar"}, now.Add(time.Hour), privateKey, kid)
+		require.NoError(t, err)
+		return &client.JWTSVID{Token: token, IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+	}
+
+	bundle := bundleutil.New("spiffe://example.org")
+	require.NoError(t, bundle.AppendJWTSigningKey("kid1", privateKey.Public()))
+	require.NoError(t, bundle.AppendJWTSigningKey("kid2", privateKey.Public()))
+	cache := New(logger, "spiffe://example.org", bundle)
+
+	svid1 := newJWTSVID("kid1")
+	svid2 := newJWTSVID("kid2")
+	cache.SetJWTSVID("spiffe://example.org/blog", []string{"bar"}, svid1)
+	cache.SetJWTSVID("spiffe://example.org/blog", []string{"baz"}, svid2)
+
+	// kid1 is revoked
+	bundle = bundleutil.New("spiffe://example.org")
+	require.NoError(t, bundle.AppendJWTSigningKey("kid2", privateKey.Public()))
+	cache.SetBundles(map[string]*Bundle{
+		"spiffe://example.org": bundle,
+	})
+
+	_, ok := cache.GetJWTSVID("spiffe://example.org/blog", []string{"bar"})
+	assert.False(t, ok)
+	actual, ok := cache.GetJWTSVID("spiffe://example.org/blog", []string{"baz"})
+	assert.True(t, ok)
+	assert.Equal(t, svid2, actual)
+}
+
 func sortCacheEntries(entries []*Entry) {
 	sort.Slice(entries, func(a, b int) bool {
 		return entries[a].RegistrationEntry.EntryId < entries[b].RegistrationEntry.EntryId
 	})
 }
+
+func assertNoUpdate(t *testing.T, sub Subscriber) {
+	select {
+	case update := <-sub.Updates():
+		t.Fatalf("unexpected update: %v", update)
+	default:
+	}
+}
+
+func BenchmarkFetchWorkloadUpdate(b *testing.B) {
+	cache := newBenchmarkCache(10000)
+	selectors := Selectors{
+		{Type: "unix", Value: "uid:42"},
+		{Type: "unix", Value: "gid:1000"},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.FetchWorkloadUpdate(selectors)
+	}
+}
+
+func BenchmarkSetEntryWithSubscribers(b *testing.B) {
+	cache := newBenchmarkCache(10000)
+	for i := 0; i < 1000; i++ {
+		sub := cache.Subscribe(Selectors{
+			{Type: "unix", Value: fmt.Sprintf("uid:%d", i)},
+			{Type: "unix", Value: "gid:1000"},
+		})
+		defer sub.Finish()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.SetEntry(newBenchmarkEntry(i % 10000))
+	}
+}
+
+func newBenchmarkCache(numEntries int) *cacheImpl {
+	cache := New(logger, "spiffe://example.org", nil)
+	for i := 0; i < numEntries; i++ {
+		cache.SetEntry(newBenchmarkEntry(i))
+	}
+	return cache
+}
+
+func newBenchmarkEntry(i int) *Entry {
+	return &Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:   strconv.Itoa(i),
+			Selectors: Selectors{{Type: "unix", Value: fmt.Sprintf("uid:%d", i)}},
+			ParentId:  "spiffe://example.org/spire/agent",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/workload%d", i),
+		},
+		SVID:       []*x509.Certificate{{}},
+		PrivateKey: privateKey,
+	}
+}
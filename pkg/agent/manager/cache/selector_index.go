@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/proto/common"
+)
+
+// selectorIndex indexes the selector sets of cache entries or subscribers,
+// keyed by ID, by each of the selectors in the set. Lookups only visit the
+// IDs that share selectors with the set being matched instead of every ID
+// in the index. It is not safe for concurrent use.
+type selectorIndex struct {
+	// sets holds the selector set of each ID
+	sets map[string]selector.Set
+	// index holds the IDs whose selector set includes a given selector
+	index map[selector.Selector]map[string]struct{}
+	// empty holds the IDs with an empty selector set
+	empty map[string]struct{}
+}
+
+func newSelectorIndex() *selectorIndex {
+	return &selectorIndex{
+		sets:  make(map[string]selector.Set),
+		index: make(map[selector.Selector]map[string]struct{}),
+		empty: make(map[string]struct{}),
+	}
+}
+
+// add indexes the selectors for the ID, replacing the ones previously
+// indexed for it.
+func (x *selectorIndex) add(id string, selectors []*common.Selector) {
+	x.remove(id)
+
+	set := selector.NewSetFromRaw(selectors)
+	x.sets[id] = set
+	if set.Size() == 0 {
+		x.empty[id] = struct{}{}
+		return
+	}
+	for _, s := range set.Array() {
+		ids, ok := x.index[*s]
+		if !ok {
+			ids = make(map[string]struct{})
+			x.index[*s] = ids
+		}
+		ids[id] = struct{}{}
+	}
+}
+
+// remove removes the selectors indexed for the ID, if any.
+func (x *selectorIndex) remove(id string) {
+	set, ok := x.sets[id]
+	if !ok {
+		return
+	}
+	delete(x.sets, id)
+	delete(x.empty, id)
+	for _, s := range set.Array() {
+		ids := x.index[*s]
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(x.index, *s)
+		}
+	}
+}
+
+// subsetsOf returns the IDs whose selector sets are included in the
+// selectors (i.e. the entries a workload with those selectors is entitled
+// to).
+func (x *selectorIndex) subsetsOf(selectors []*common.Selector) []string {
+	set := selector.NewSetFromRaw(selectors)
+
+	var matches []string
+	for id := range x.empty {
+		matches = append(matches, id)
+	}
+
+	visited := make(map[string]bool)
+	for _, s := range set.Array() {
+		for id := range x.index[*s] {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			if set.IncludesSet(x.sets[id]) {
+				matches = append(matches, id)
+			}
+		}
+	}
+	return matches
+}
+
+// supersetsOf returns the IDs whose selector sets include the selectors
+// (i.e. the subscribers affected by an entry with those selectors).
+func (x *selectorIndex) supersetsOf(selectors []*common.Selector) []string {
+	set := selector.NewSetFromRaw(selectors)
+
+	var matches []string
+	if set.Size() == 0 {
+		for id := range x.sets {
+			matches = append(matches, id)
+		}
+		return matches
+	}
+
+	// every superset is indexed under each of the selectors, so only the
+	// smallest group of candidates needs to be checked.
+	var candidates map[string]struct{}
+	for _, s := range set.Array() {
+		ids := x.index[*s]
+		if candidates == nil || len(ids) < len(candidates) {
+			candidates = ids
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+	}
+
+	for id := range candidates {
+		if x.sets[id].IncludesSet(set) {
+			matches = append(matches, id)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorIndex(t *testing.T) {
+	a := &common.Selector{Type: "A", Value: "a"}
+	b := &common.Selector{Type: "B", Value: "b"}
+	c := &common.Selector{Type: "C", Value: "c"}
+
+	x := newSelectorIndex()
+	x.add("none", nil)
+	x.add("a", Selectors{a})
+	x.add("ab", Selectors{a, b})
+	x.add("bc", Selectors{b, c})
+
+	assert.ElementsMatch(t, []string{"none"}, x.subsetsOf(nil))
+	assert.ElementsMatch(t, []string{"none", "a"}, x.subsetsOf(Selectors{a}))
+	assert.ElementsMatch(t, []string{"none", "a", "ab"}, x.subsetsOf(Selectors{a, b}))
+	assert.ElementsMatch(t, []string{"none", "a", "ab", "bc"}, x.subsetsOf(Selectors{a, b, c}))
+
+	assert.ElementsMatch(t, []string{"none", "a", "ab", "bc"}, x.supersetsOf(nil))
+	assert.ElementsMatch(t, []string{"a", "ab"}, x.supersetsOf(Selectors{a}))
+	assert.ElementsMatch(t, []string{"ab", "bc"}, x.supersetsOf(Selectors{b}))
+	assert.ElementsMatch(t, []string{"ab"}, x.supersetsOf(Selectors{a, b}))
+	assert.Empty(t, x.supersetsOf(Selectors{a, b, c}))
+	assert.Empty(t, x.supersetsOf(Selectors{{Type: "D", Value: "d"}}))
+
+	// replacing the selectors for an ID reindexes it
+	x.add("ab", Selectors{c})
+	assert.ElementsMatch(t, []string{"a"}, x.supersetsOf(Selectors{a}))
+	assert.ElementsMatch(t, []string{"ab", "bc"}, x.supersetsOf(Selectors{c}))
+
+	// removed IDs are no longer matched
+	x.remove("ab")
+	x.remove("none")
+	x.remove("unknown")
+	assert.ElementsMatch(t, []string{"a", "bc"}, x.subsetsOf(Selectors{a, b, c}))
+	assert.ElementsMatch(t, []string{"bc"}, x.supersetsOf(Selectors{c}))
+	assert.Len(t, x.index, 3)
+
+	x.remove("a")
+	x.remove("bc")
+	assert.Empty(t, x.sets)
+	assert.Empty(t, x.index)
+	assert.Empty(t, x.empty)
+}
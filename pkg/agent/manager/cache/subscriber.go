@@ -4,7 +4,6 @@ import (
 	"sync"
 
 	"github.com/gofrs/uuid"
-	"github.com/spiffe/spire/pkg/common/selector"
 )
 
 type Subscriber interface {
@@ -27,8 +26,8 @@ type subscriber struct {
 }
 
 type subscribers struct {
-	selMap map[string][]uuid.UUID // map of selector to UID
-	sidMap map[uuid.UUID]*subscriber
+	index  *selectorIndex         // index of subscriber selectors by ID
+	sidMap map[string]*subscriber // map of ID to subscriber
 	m      sync.Mutex
 }
 
@@ -62,24 +61,19 @@ func (sub *subscriber) Finish() {
 func (s *subscribers) add(sub *subscriber) error {
 	s.m.Lock()
 	defer s.m.Unlock()
-	s.sidMap[sub.sid] = sub
-
-	selSet := selector.NewSetFromRaw(sub.sel)
-	selPSet := selSet.Power()
-	for sel := range selPSet {
-		selStr := sel.String()
-		s.selMap[selStr] = append(s.selMap[selStr], sub.sid)
-	}
-
+	sid := sub.sid.String()
+	s.sidMap[sid] = sub
+	s.index.add(sid, sub.sel)
 	return nil
 }
 
+// get returns the subscribers whose selectors include the given selectors
+// (i.e. the ones interested in an entry with those selectors).
 func (s *subscribers) get(sels Selectors) (subs []*subscriber) {
 	s.m.Lock()
 	defer s.m.Unlock()
-	sids := s.getSubIds(sels)
-	for _, id := range sids {
-		subs = append(subs, s.sidMap[id])
+	for _, sid := range s.index.supersetsOf(sels) {
+		subs = append(subs, s.sidMap[sid])
 	}
 	return
 }
@@ -97,46 +91,14 @@ func (s *subscribers) getAll() (subs []*subscriber) {
 func (s *subscribers) remove(sub *subscriber) {
 	s.m.Lock()
 	defer s.m.Unlock()
-	delete(s.sidMap, sub.sid)
-	for sel, sids := range s.selMap {
-		for i, uid := range sids {
-			if uid == sub.sid {
-				s.selMap[sel] = append(sids[:i], sids[i+1:]...)
-			}
-		}
-	}
-}
-
-func (s *subscribers) getSubIds(sels Selectors) []uuid.UUID {
-	subIds := []uuid.UUID{}
-
-	selSet := selector.NewSetFromRaw(sels)
-	selPSet := selSet.Power()
-
-	for sel := range selPSet {
-		selStr := sel.String()
-		subIds = append(subIds, s.selMap[selStr]...)
-	}
-
-	subIds = dedupe(subIds)
-
-	return subIds
+	sid := sub.sid.String()
+	delete(s.sidMap, sid)
+	s.index.remove(sid)
 }
 
 func NewSubscribers() *subscribers {
 	return &subscribers{
-		selMap: make(map[string][]uuid.UUID),
-		sidMap: make(map[uuid.UUID]*subscriber),
-	}
-}
-
-func dedupe(ids []uuid.UUID) (deduped []uuid.UUID) {
-	uniqueMap := map[uuid.UUID]bool{}
-	for i := range ids {
-		uniqueMap[ids[i]] = true
+		index:  newSelectorIndex(),
+		sidMap: make(map[string]*subscriber),
 	}
-	for key := range uniqueMap {
-		deduped = append(deduped, key)
-	}
-	return
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/common"
 )
 
 // Config holds a cache manager configuration
@@ -32,6 +33,25 @@ type Config struct {
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
 
+	// WorkloadKeyType is the type of the keys generated for workload SVIDs
+	WorkloadKeyType common.KeyType
+
+	// CachePath is the path where the cache entries, workload SVIDs and
+	// bundles are persisted so they can be served right away after a
+	// restart. Workload private keys are persisted through the KeyManager.
+	// If empty, the cache is not persisted.
+	CachePath string
+
+	// LazySVIDs, if true, makes the manager only mint workload SVIDs for
+	// the registration entries matched by an active subscriber. SVIDs that
+	// have not been used for SVIDIdleTimeout are evicted.
+	LazySVIDs       bool
+	SVIDIdleTimeout time.Duration
+
+	// Reattestor, if set, re-attests the agent when its node attestation
+	// data changes.
+	Reattestor svid.Reattestor
+
 	// Clk is the clock the manager will use to get time
 	Clk clock.Clock
 }
@@ -51,10 +71,18 @@ func New(c *Config) (*manager, error) {
 		c.RotationInterval = 60 * time.Second
 	}
 
+	if c.SVIDIdleTimeout == 0 {
+		c.SVIDIdleTimeout = time.Hour
+	}
+
 	if c.Clk == nil {
 		c.Clk = clock.New()
 	}
 
+	if c.WorkloadKeyType == common.KeyType_UNSPECIFIED_KEY_TYPE {
+		c.WorkloadKeyType = common.KeyType_EC_P256
+	}
+
 	cache := cache.New(c.Log, c.TrustDomain.String(), c.Bundle)
 
 	rotCfg := &svid.RotatorConfig{
@@ -69,6 +97,7 @@ func New(c *Config) (*manager, error) {
 		TrustDomain:  c.TrustDomain,
 		Interval:     c.RotationInterval,
 		Clk:          c.Clk,
+		Reattestor:   c.Reattestor,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -80,6 +109,10 @@ func New(c *Config) (*manager, error) {
 		spiffeID:        spiffeID,
 		svidCachePath:   c.SVIDCachePath,
 		bundleCachePath: c.BundleCachePath,
+		cachePath:       c.CachePath,
+		lastUsed:        make(map[string]time.Time),
+		demand:          make(chan struct{}, 1),
+		nextSync:        make(chan struct{}),
 		client:          client,
 		clk:             c.Clk,
 	}
@@ -1,7 +1,9 @@
 package manager
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -13,18 +15,28 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
+	"github.com/spiffe/spire/pkg/common/diskutil"
 	"github.com/andres-erbsen/clock"
-	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Cache Manager errors
 var (
 	ErrNotCached = errors.New("not cached")
+
+	errEntryKeyNotFound = errors.New("private key not found")
 )
 
+// keyIDProbe is the identifier of a private key that never exists. It is
+// deleted to check whether the KeyManager supports key identifiers.
+const keyIDProbe = "spire-agent-key-id-probe"
+
 // Manager provides cache management functionalities for agents.
 type Manager interface {
 	// Initialize initializes the manager.
@@ -48,7 +60,8 @@ type Manager interface {
 
 	// MatchingEntries takes a slice of selectors, and iterates over all the in force entries
 	// in order to find matching cache entries. A cache entry is matched when its RegistrationEntry's
-	// selectors are included in the set of selectors passed as parameter.
+	// selectors are included in the set of selectors passed as parameter. When SVIDs are minted
+	// lazily, the returned entries might not have an SVID yet.
 	MatchingEntries(selectors []*common.Selector) []*cache.Entry
 
 	// FetchWorkloadUpdates gets the latest workload update for the selectors
@@ -72,6 +85,35 @@ type manager struct {
 
 	svidCachePath   string
 	bundleCachePath string
+	cachePath       string
+
+	// storedCache is the encoded cache that was last stored at cachePath.
+	// Only accessed while synchronizing.
+	storedCache []byte
+
+	// restored is true if the cache was restored from cachePath during
+	// initialization, in which case it is synchronized in the background.
+	restored bool
+
+	// regEntries holds the registration entries last fetched from the
+	// server. Only used when SVIDs are minted lazily.
+	regEntries map[string]*common.RegistrationEntry
+
+	// lastUsed holds, keyed by registration entry ID, when an SVID was last
+	// used by a subscriber. Only used when SVIDs are minted lazily.
+	lastUsed map[string]time.Time
+
+	// demand is signaled to synchronize right away when a subscriber needs
+	// SVIDs that have not been minted yet.
+	demand chan struct{}
+
+	// nextSync is closed when the next synchronization to start completes.
+	// Only used when SVIDs are minted lazily.
+	nextSync chan struct{}
+
+	// chainCheckedBundle is the bundle the cached SVIDs were last checked to
+	// chain to. Only accessed while synchronizing.
+	chainCheckedBundle *cache.Bundle
 
 	client client.Client
 
@@ -82,6 +124,23 @@ func (m *manager) Initialize(ctx context.Context) error {
 	m.storeSVID(m.svid.State().SVID)
 	m.storeBundle(m.cache.Bundle())
 
+	if m.cachePath != "" {
+		if err := m.checkKeyIDSupport(ctx); err != nil {
+			// persisting workload keys in a KeyManager that ignores the key
+			// identifiers would overwrite the agent key.
+			m.c.Log.Warnf("Not persisting the SVID cache: %v", err)
+			m.cachePath = ""
+		}
+	}
+
+	if m.restoreCache(ctx) {
+		// serve the restored cache right away and revalidate it with the
+		// server once the manager runs, so workloads don't have to wait
+		// for (or depend on) the server after a restart.
+		m.restored = true
+		return nil
+	}
+
 	return m.synchronize(ctx)
 }
 
@@ -103,7 +162,19 @@ func (m *manager) Run(ctx context.Context) error {
 }
 
 func (m *manager) SubscribeToCacheChanges(selectors cache.Selectors) cache.Subscriber {
-	return m.cache.Subscribe(selectors)
+	if !m.c.LazySVIDs {
+		return m.cache.Subscribe(selectors)
+	}
+
+	// the updates are held back until the SVIDs requested for the
+	// subscriber have been minted, since an update without them would tell
+	// the subscriber that no identity is issued to it.
+	synced := m.requestSVIDs(selectors)
+	sub := m.cache.Subscribe(selectors)
+	if synced == nil {
+		return sub
+	}
+	return newPendingSubscriber(sub, synced)
 }
 
 func (m *manager) SubscribeToSVIDChanges() observer.Stream {
@@ -115,17 +186,27 @@ func (m *manager) SubscribeToBundleChanges() *cache.BundleStream {
 }
 
 func (m *manager) MatchingEntries(selectors []*common.Selector) (entries []*cache.Entry) {
-	for _, entry := range m.cache.Entries() {
-		regEntrySelectors := selector.NewSetFromRaw(entry.RegistrationEntry.Selectors)
-		if selector.NewSetFromRaw(selectors).IncludesSet(regEntrySelectors) {
-			entries = append(entries, entry)
+	if m.c.LazySVIDs {
+		// entries are matched without minting SVIDs since callers (i.e.
+		// JWT-SVID requests) only care about the registration entries.
+		m.mtx.RLock()
+		defer m.mtx.RUnlock()
+		for _, regEntry := range m.regEntries {
+			if selectorsMatch(selectors, regEntry) {
+				entries = append(entries, &cache.Entry{RegistrationEntry: regEntry})
+			}
 		}
+		return entries
 	}
-	return entries
+
+	return m.cache.MatchingEntries(selectors)
 }
 
 // FetchWorkloadUpdates gets the latest workload update for the selectors
 func (m *manager) FetchWorkloadUpdate(selectors []*common.Selector) *cache.WorkloadUpdate {
+	if m.c.LazySVIDs {
+		m.requestSVIDs(selectors)
+	}
 	return m.cache.FetchWorkloadUpdate(selectors)
 }
 
@@ -157,6 +238,12 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 }
 
 func (m *manager) runSynchronizer(ctx context.Context) error {
+	if m.restored {
+		if err := m.synchronize(ctx); err != nil {
+			m.c.Log.Errorf("synchronize failed: %v", err)
+		}
+	}
+
 	t := m.clk.Ticker(m.c.SyncInterval)
 	defer t.Stop()
 
@@ -168,6 +255,10 @@ func (m *manager) runSynchronizer(ctx context.Context) error {
 				// Just log the error to keep waiting for next sinchronization...
 				m.c.Log.Errorf("synchronize failed: %v", err)
 			}
+		case <-m.demand:
+			if err := m.synchronize(ctx); err != nil {
+				m.c.Log.Errorf("synchronize failed: %v", err)
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -218,6 +309,177 @@ func (m *manager) storeBundle(bundle *bundleutil.Bundle) {
 	}
 }
 
+// restoreCache restores the cache entries and bundles persisted at the cache
+// path. Entries are only restored if their SVID has not expired and their
+// private key can be recovered from the KeyManager. Returns true if any
+// entries were restored.
+func (m *manager) restoreCache(ctx context.Context) bool {
+	if m.cachePath == "" {
+		return false
+	}
+
+	entries, bundles, err := ReadCache(m.cachePath)
+	switch {
+	case err == ErrNotCached:
+		return false
+	case err != nil:
+		m.c.Log.Warnf("could not restore cache: %v", err)
+		return false
+	}
+
+	// the trust domain bundle obtained during attestation is more recent
+	// than the persisted one if the roots differ.
+	trustDomainID := m.c.TrustDomain.String()
+	if bundle, restoredBundle := m.cache.Bundle(), bundles[trustDomainID]; bundle != nil && restoredBundle != nil {
+		if !certsEqual(bundle.RootCAs(), restoredBundle.RootCAs()) {
+			delete(bundles, trustDomainID)
+		}
+	}
+	m.cache.SetBundles(bundles)
+
+	now := m.clk.Now()
+	restored := 0
+	missingKeys := 0
+	for _, entry := range entries {
+		if !now.Before(entry.SVID[0].NotAfter) {
+			m.c.Log.Debugf("not restoring expired cache entry for %s", entry.RegistrationEntry.SpiffeId)
+			continue
+		}
+		privateKey, err := m.fetchEntryKey(ctx, entry)
+		switch {
+		case err == errEntryKeyNotFound:
+			// expected with KeyManagers that do not persist keys across
+			// restarts, so it is only reported once.
+			missingKeys++
+			continue
+		case err != nil:
+			m.c.Log.Warnf("could not restore cache entry for %s: %v", entry.RegistrationEntry.SpiffeId, err)
+			continue
+		}
+		entry.PrivateKey = privateKey
+		m.cache.SetEntry(entry)
+		restored++
+	}
+
+	if m.c.LazySVIDs {
+		// until the manager synchronizes, the restored entries are the only
+		// ones known. they are given a whole idle period to be used.
+		m.mtx.Lock()
+		m.regEntries = make(map[string]*common.RegistrationEntry)
+		for _, entry := range m.cache.Entries() {
+			m.regEntries[entry.RegistrationEntry.EntryId] = entry.RegistrationEntry
+			m.lastUsed[entry.RegistrationEntry.EntryId] = now
+		}
+		m.mtx.Unlock()
+	}
+
+	if missingKeys > 0 {
+		m.c.Log.Warnf("could not restore %d cache entries: private keys not found in the KeyManager", missingKeys)
+	}
+	m.c.Log.Infof("Restored %d of %d cache entries", restored, len(entries))
+	return restored > 0
+}
+
+// storeCache persists the cache entries and bundles to the cache path if they
+// have changed since they were last stored.
+func (m *manager) storeCache() {
+	if m.cachePath == "" {
+		return
+	}
+
+	data, err := marshalCache(m.cache.Entries(), m.cache.Bundles())
+	if err != nil {
+		m.c.Log.Warnf("could not store cache: %v", err)
+		return
+	}
+	if bytes.Equal(data, m.storedCache) {
+		return
+	}
+	if err := diskutil.AtomicWriteFile(m.cachePath, data, 0600); err != nil {
+		m.c.Log.Warnf("could not store cache: %v", err)
+		return
+	}
+	m.storedCache = data
+}
+
+func (m *manager) fetchEntryKey(ctx context.Context, entry *cache.Entry) (crypto.Signer, error) {
+	km := m.c.Catalog.KeyManagers()[0]
+	resp, err := km.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{
+		Id: entry.RegistrationEntry.EntryId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.PrivateKey) == 0 {
+		return nil, errEntryKeyNotFound
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(resp.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unexpected private key type %T", key)
+	}
+	matches, err := cryptoutil.KeyMatches(privateKey, entry.SVID[0].PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, errors.New("private key does not match SVID")
+	}
+	return privateKey, nil
+}
+
+// checkKeyIDSupport checks that the KeyManager supports storing private keys
+// by identifier. KeyManagers that predate key identifiers ignore them and do
+// not implement DeletePrivateKey.
+func (m *manager) checkKeyIDSupport(ctx context.Context) error {
+	km := m.c.Catalog.KeyManagers()[0]
+	_, err := km.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{
+		Id: keyIDProbe,
+	})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		return errors.New("the KeyManager does not support private key identifiers")
+	case err != nil:
+		return fmt.Errorf("unable to check KeyManager support for private key identifiers: %v", err)
+	}
+	return nil
+}
+
+func (m *manager) storeEntryKey(ctx context.Context, entry *cache.Entry) {
+	if m.cachePath == "" {
+		return
+	}
+
+	keyData, err := x509.MarshalPKCS8PrivateKey(entry.PrivateKey)
+	if err == nil {
+		km := m.c.Catalog.KeyManagers()[0]
+		_, err = km.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{
+			Id:         entry.RegistrationEntry.EntryId,
+			PrivateKey: keyData,
+		})
+	}
+	if err != nil {
+		m.c.Log.Warnf("could not store private key for %s: %v", entry.RegistrationEntry.SpiffeId, err)
+	}
+}
+
+func (m *manager) deleteEntryKey(ctx context.Context, entry *cache.Entry) {
+	if m.cachePath == "" {
+		return
+	}
+
+	km := m.c.Catalog.KeyManagers()[0]
+	if _, err := km.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{
+		Id: entry.RegistrationEntry.EntryId,
+	}); err != nil {
+		m.c.Log.Warnf("could not delete private key for %s: %v", entry.RegistrationEntry.SpiffeId, err)
+	}
+}
+
 func jwtSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
 	if jwtSVIDExpired(svid, now) {
 		return true
@@ -235,3 +497,60 @@ func jwtSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
 func jwtSVIDExpired(svid *client.JWTSVID, now time.Time) bool {
 	return !now.Before(svid.ExpiresAt)
 }
+
+// pendingSubscriber holds back the updates of a subscription until the
+// synchronization minting the SVIDs requested for it completes. Only the
+// latest update is delivered then, since the cache only keeps the latest
+// update of a subscription.
+type pendingSubscriber struct {
+	sub     cache.Subscriber
+	updates chan *cache.WorkloadUpdate
+
+	finish     chan struct{}
+	finishOnce sync.Once
+}
+
+func newPendingSubscriber(sub cache.Subscriber, synced <-chan struct{}) *pendingSubscriber {
+	s := &pendingSubscriber{
+		sub:     sub,
+		updates: make(chan *cache.WorkloadUpdate),
+		finish:  make(chan struct{}),
+	}
+	go s.forwardUpdates(synced)
+	return s
+}
+
+func (s *pendingSubscriber) Updates() <-chan *cache.WorkloadUpdate {
+	return s.updates
+}
+
+func (s *pendingSubscriber) Finish() {
+	s.finishOnce.Do(func() {
+		close(s.finish)
+		s.sub.Finish()
+	})
+}
+
+func (s *pendingSubscriber) forwardUpdates(synced <-chan struct{}) {
+	select {
+	case <-synced:
+	case <-s.finish:
+		return
+	}
+
+	for {
+		select {
+		case update, ok := <-s.sub.Updates():
+			if !ok {
+				return
+			}
+			select {
+			case s.updates <- update:
+			case <-s.finish:
+				return
+			}
+		case <-s.finish:
+			return
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	testlog "github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
@@ -25,6 +26,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
@@ -32,8 +34,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -471,6 +475,288 @@ func TestSynchronization(t *testing.T) {
 	}
 }
 
+func TestCacheIsRestoredOnStartup(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVID,
+		svidTTL:       200,
+	}, mockClk)
+	apiHandler.start()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	km := memory.New()
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManagers(km)
+
+	c := &Config{
+		Catalog:          cat,
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		CachePath:        path.Join(dir, "cache.json"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: time.Hour,
+		SyncInterval:     time.Hour,
+		Clk:              mockClk,
+	}
+
+	m := newManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.False(t, m.restored)
+	entries := cacheEntriesAsMap(m.cache.Entries())
+	require.Len(t, entries, 3)
+
+	// the server goes away. the cache is restored on startup without
+	// contacting the server.
+	apiHandler.stop()
+
+	m = newManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.True(t, m.restored)
+	require.True(t, m.cache.Bundle().EqualTo(apiHandler.bundle))
+	restoredEntries := cacheEntriesAsMap(m.cache.Entries())
+	require.Len(t, restoredEntries, len(entries))
+	for entryID, entry := range entries {
+		restoredEntry := restoredEntries[entryID]
+		require.NotNil(t, restoredEntry)
+		require.True(t, proto.Equal(entry.RegistrationEntry, restoredEntry.RegistrationEntry))
+		require.True(t, svidsEqual(entry.SVID, restoredEntry.SVID))
+		require.Equal(t, entry.PrivateKey, restoredEntry.PrivateKey)
+	}
+}
+
+func TestCacheEntriesAreNotRestoredWithoutKeyOrWhenExpired(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVID,
+		svidTTL:       200,
+	}, mockClk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	km := memory.New()
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManagers(km)
+
+	c := &Config{
+		Catalog:          cat,
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		CachePath:        path.Join(dir, "cache.json"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: time.Hour,
+		SyncInterval:     time.Hour,
+		Clk:              mockClk,
+	}
+
+	m := newManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+	entries := m.cache.Entries()
+	require.Len(t, entries, 3)
+
+	// entries without a private key are not restored
+	_, err = km.DeletePrivateKey(context.Background(), &keymanager.DeletePrivateKeyRequest{
+		Id: entries[0].RegistrationEntry.EntryId,
+	})
+	require.NoError(t, err)
+
+	m = newManager(t, c)
+	require.True(t, m.restoreCache(context.Background()))
+	restoredEntries := cacheEntriesAsMap(m.cache.Entries())
+	require.Len(t, restoredEntries, 2)
+	require.Nil(t, restoredEntries[entries[0].RegistrationEntry.EntryId])
+
+	// expired entries are not restored
+	mockClk.Add(201 * time.Second)
+	m = newManager(t, c)
+	require.False(t, m.restoreCache(context.Background()))
+	require.Empty(t, m.cache.Entries())
+}
+
+func TestCacheIsNotPersistedWithoutKeyIDSupport(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVID,
+		svidTTL:       200,
+	}, mockClk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	km := &legacyKeyManager{MemoryPlugin: memory.New()}
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManagers(km)
+
+	c := &Config{
+		Catalog:          cat,
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		CachePath:        path.Join(dir, "cache.json"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: time.Hour,
+		SyncInterval:     time.Hour,
+		Clk:              mockClk,
+	}
+
+	// the workload keys would overwrite the agent key, so neither they nor
+	// the cache are persisted
+	m := newManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+	require.Len(t, m.cache.Entries(), 3)
+	require.Empty(t, km.storedIDs)
+	_, err = os.Stat(path.Join(dir, "cache.json"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestLazySVIDs(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	mockClk := clock.NewMock(t)
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetchX509SVID,
+		svidTTL:       3600,
+	}, mockClk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr:       l.Addr().String(),
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      trustDomainID,
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		Bundle:           apiHandler.bundle,
+		Metrics:          &telemetry.Blackhole{},
+		RotationInterval: time.Hour,
+		SyncInterval:     time.Hour,
+		LazySVIDs:        true,
+		SVIDIdleTimeout:  time.Minute,
+		Clk:              mockClk,
+	}
+
+	m := newManager(t, c)
+	require.NoError(t, m.Initialize(context.Background()))
+
+	// no SVIDs are minted without subscribers but the registration entries
+	// are still matched
+	require.Empty(t, m.cache.Entries())
+	selectors := cache.Selectors{{Type: "unix", Value: "uid:1111"}}
+	entries := cacheEntriesAsMap(m.MatchingEntries(selectors))
+	require.Len(t, entries, 2)
+	require.Contains(t, entries, "0002")
+	require.Contains(t, entries, "0003")
+
+	// subscribing signals the demand for the missing SVIDs, which are minted
+	// on the next synchronization. the subscriber gets no update until then.
+	sub := m.SubscribeToCacheChanges(selectors)
+	select {
+	case <-m.demand:
+	default:
+		t.Fatal("expected demand for SVIDs to be signaled")
+	}
+	select {
+	case <-sub.Updates():
+		t.Fatal("unexpected update before the SVIDs are minted")
+	case <-time.After(10 * time.Millisecond):
+	}
+	require.NoError(t, m.synchronize(context.Background()))
+	entries = cacheEntriesAsMap(m.cache.Entries())
+	require.Len(t, entries, 2)
+	require.Contains(t, entries, "0002")
+	require.Contains(t, entries, "0003")
+	for _, entry := range entries {
+		require.NotEmpty(t, entry.SVID)
+	}
+	select {
+	case update := <-sub.Updates():
+		require.Len(t, update.Entries, 2)
+	case <-time.After(time.Second):
+		t.Fatal("expected an update once the SVIDs are minted")
+	}
+
+	// once the subscriber goes away the SVIDs are kept until they have been
+	// idle for the timeout
+	sub.Finish()
+	mockClk.Add(30 * time.Second)
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Len(t, m.cache.Entries(), 2)
+
+	mockClk.Add(31 * time.Second)
+	require.NoError(t, m.synchronize(context.Background()))
+	require.Empty(t, m.cache.Entries())
+}
+
 func TestSynchronizationClearsStaleCacheEntries(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -705,6 +991,40 @@ func TestSurvivesCARotation(t *testing.T) {
 	require.Len(t, newRoots, 2)
 }
 
+func TestSVIDChainsOnlyCheckedWhenRequested(t *testing.T) {
+	clk := clock.New()
+	ca, cakey := createCA(t, clk, trustDomain)
+	otherCA, _ := createCA(t, clk, trustDomain)
+	svid, key := createSVID(t, clk, ca, cakey, "spiffe://"+trustDomain+"/workload", time.Hour)
+
+	c := cache.New(testLogger, trustDomainID.String(), bundleutil.BundleFromRootCA(trustDomainID.String(), otherCA))
+	c.SetEntry(&cache.Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:  "entry",
+			SpiffeId: "spiffe://" + trustDomain + "/workload",
+		},
+		SVID:       svid,
+		PrivateKey: key,
+	})
+	m := &manager{
+		c: &Config{
+			Log:             testLogger,
+			Metrics:         &telemetry.Blackhole{},
+			WorkloadKeyType: common.KeyType_EC_P256,
+		},
+		cache: c,
+		clk:   clk,
+	}
+
+	// the SVID is not expiring, so it is only renewed when its chain to the
+	// bundle is checked
+	requests := entryRequests{}
+	require.NoError(t, m.checkExpiredCacheEntries(requests, false))
+	require.Empty(t, requests)
+	require.NoError(t, m.checkExpiredCacheEntries(requests, true))
+	require.Contains(t, requests, "entry")
+}
+
 func TestFetchJWTSVID(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -1089,6 +1409,11 @@ func (h *mockNodeAPIHandler) FetchJWTSVID(ctx context.Context, req *node.FetchJW
 	return nil, errors.New("oh noes")
 }
 
+func (h *mockNodeAPIHandler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUpstreamRequest) (*node.PushJWTKeyUpstreamResponse, error) {
+	h.countRequest()
+	return nil, errors.New("oh noes")
+}
+
 func (h *mockNodeAPIHandler) start() {
 	s := grpc.NewServer(h.creds)
 	node.RegisterNodeServer(s, h)
@@ -1168,6 +1493,26 @@ func (h *mockNodeAPIHandler) getCertFromCtx(ctx context.Context) (certificate *x
 	return chain[0], nil
 }
 
+// legacyKeyManager is a KeyManager that predates private key identifiers.
+type legacyKeyManager struct {
+	*memory.MemoryPlugin
+
+	storedIDs []string
+}
+
+func (km *legacyKeyManager) StorePrivateKey(ctx context.Context, req *keymanager.StorePrivateKeyRequest) (*keymanager.StorePrivateKeyResponse, error) {
+	if req.Id != "" {
+		km.storedIDs = append(km.storedIDs, req.Id)
+	}
+	return km.MemoryPlugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{
+		PrivateKey: req.PrivateKey,
+	})
+}
+
+func (km *legacyKeyManager) DeletePrivateKey(ctx context.Context, req *keymanager.DeletePrivateKeyRequest) (*keymanager.DeletePrivateKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method DeletePrivateKey")
+}
+
 func createTempDir(t *testing.T) string {
 	dir, err := ioutil.TempDir("", tmpSubdirName)
 	if err != nil {
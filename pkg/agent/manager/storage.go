@@ -3,13 +3,34 @@ package manager
 import (
 	"bytes"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/proto/common"
 )
 
+// cacheData is the on-disk representation of the cache. Private keys are not
+// part of it; they are persisted through the KeyManager.
+type cacheData struct {
+	Entries []cacheDataEntry `json:"entries"`
+	// Bundles holds protobuf encoded common.Bundle messages
+	Bundles [][]byte `json:"bundles"`
+}
+
+type cacheDataEntry struct {
+	// RegistrationEntry holds a protobuf encoded common.RegistrationEntry
+	RegistrationEntry []byte `json:"registration_entry"`
+	// SVID holds the ASN.1 DER encoded SVID certificate chain
+	SVID []byte `json:"svid"`
+}
+
 // ReadBundle returns the bundle located at bundleCachePath. Returns nil
 // if there was some reason by which the bundle couldn't be loaded along with
 // the error reason.
@@ -71,3 +92,97 @@ func StoreSVID(svidCachePath string, svidChain []*x509.Certificate) error {
 	}
 	return diskutil.AtomicWriteFile(svidCachePath, data.Bytes(), 0600)
 }
+
+// ReadCache returns the cache entries and bundles located at cachePath. The
+// returned entries do not have a private key. Returns ErrNotCached if the
+// cache has not been stored.
+func ReadCache(cachePath string) ([]*cache.Entry, map[string]*cache.Bundle, error) {
+	data, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, ErrNotCached
+		}
+		return nil, nil, fmt.Errorf("error reading cache at %s: %s", cachePath, err)
+	}
+
+	cacheData := new(cacheData)
+	if err := json.Unmarshal(data, cacheData); err != nil {
+		return nil, nil, fmt.Errorf("error parsing cache at %s: %s", cachePath, err)
+	}
+
+	var entries []*cache.Entry
+	for _, cacheDataEntry := range cacheData.Entries {
+		regEntry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(cacheDataEntry.RegistrationEntry, regEntry); err != nil {
+			return nil, nil, fmt.Errorf("error parsing cache entry at %s: %s", cachePath, err)
+		}
+		svid, err := x509.ParseCertificates(cacheDataEntry.SVID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing SVID for %s at %s: %s", regEntry.SpiffeId, cachePath, err)
+		}
+		if len(svid) == 0 {
+			return nil, nil, fmt.Errorf("error parsing SVID for %s at %s: no certificates", regEntry.SpiffeId, cachePath)
+		}
+		entries = append(entries, &cache.Entry{
+			RegistrationEntry: regEntry,
+			SVID:              svid,
+		})
+	}
+
+	bundles := make(map[string]*cache.Bundle)
+	for _, bundleData := range cacheData.Bundles {
+		bundleProto := new(common.Bundle)
+		if err := proto.Unmarshal(bundleData, bundleProto); err != nil {
+			return nil, nil, fmt.Errorf("error parsing bundle at %s: %s", cachePath, err)
+		}
+		bundle, err := bundleutil.BundleFromProto(bundleProto)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing bundle at %s: %s", cachePath, err)
+		}
+		bundles[bundle.TrustDomainID()] = bundle
+	}
+
+	return entries, bundles, nil
+}
+
+// marshalCache encodes the cache entries and bundles. The encoding is stable
+// (i.e. sorted by entry ID and trust domain ID) so that it can be compared.
+func marshalCache(entries []*cache.Entry, bundles map[string]*cache.Bundle) ([]byte, error) {
+	cacheData := new(cacheData)
+
+	entries = append([]*cache.Entry(nil), entries...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RegistrationEntry.EntryId < entries[j].RegistrationEntry.EntryId
+	})
+	for _, entry := range entries {
+		regEntryData, err := proto.Marshal(entry.RegistrationEntry)
+		if err != nil {
+			return nil, err
+		}
+		svidData := &bytes.Buffer{}
+		for _, cert := range entry.SVID {
+			svidData.Write(cert.Raw)
+		}
+		cacheData.Entries = append(cacheData.Entries, cacheDataEntry{
+			RegistrationEntry: regEntryData,
+			SVID:              svidData.Bytes(),
+		})
+	}
+
+	var trustDomainIDs []string
+	for trustDomainID, bundle := range bundles {
+		if bundle != nil {
+			trustDomainIDs = append(trustDomainIDs, trustDomainID)
+		}
+	}
+	sort.Strings(trustDomainIDs)
+	for _, trustDomainID := range trustDomainIDs {
+		bundleData, err := proto.Marshal(bundles[trustDomainID].Proto())
+		if err != nil {
+			return nil, err
+		}
+		cacheData.Bundles = append(cacheData.Bundles, bundleData)
+	}
+
+	return json.Marshal(cacheData)
+}
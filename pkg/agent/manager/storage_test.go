@@ -1,9 +1,17 @@
 package manager
 
 import (
-	"github.com/spiffe/spire/test/util"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
 	"path"
 	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/util"
 )
 
 func TestReadBundle(t *testing.T) {
@@ -30,3 +38,66 @@ func TestReadBundle(t *testing.T) {
 		}
 	}
 }
+
+func TestReadCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-agent-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cachePath := path.Join(dir, "cache.json")
+
+	_, _, err = ReadCache(cachePath)
+	if err != ErrNotCached {
+		t.Fatalf("expected ErrNotCached, got %v", err)
+	}
+
+	svid, _, err := util.LoadSVIDFixture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _, err := util.LoadCAFixture()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &cache.Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:  "0001",
+			ParentId: "spiffe://example.org/spire/agent",
+			SpiffeId: "spiffe://example.org/workload",
+		},
+		SVID: []*x509.Certificate{svid},
+	}
+	bundle := bundleutil.BundleFromRootCA("spiffe://example.org", ca)
+
+	data, err := marshalCache([]*cache.Entry{entry}, map[string]*cache.Bundle{
+		"spiffe://example.org": bundle,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(cachePath, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, bundles, err := ReadCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("wrong number of entries, want: 1, got: %d", len(entries))
+	}
+	if !proto.Equal(entries[0].RegistrationEntry, entry.RegistrationEntry) {
+		t.Errorf("registration entry is not as expected")
+	}
+	if len(entries[0].SVID) != 1 || !entries[0].SVID[0].Equal(svid) {
+		t.Errorf("SVID is not as expected")
+	}
+	if entries[0].PrivateKey != nil {
+		t.Errorf("private key is not expected to be cached")
+	}
+	if len(bundles) != 1 || !bundles["spiffe://example.org"].EqualTo(bundle) {
+		t.Errorf("bundles are not as expected")
+	}
+}
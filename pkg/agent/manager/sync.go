@@ -2,16 +2,16 @@ package manager
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"crypto"
 	"crypto/x509"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/cryptoutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/zeebo/errs"
@@ -22,14 +22,35 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	var regEntries map[string]*common.RegistrationEntry
 	var cEntryRequests = entryRequests{}
 
+	if m.c.LazySVIDs {
+		m.mtx.Lock()
+		synced := m.nextSync
+		m.nextSync = make(chan struct{})
+		m.mtx.Unlock()
+		defer close(synced)
+	}
+
+	// persist whatever changed, even if synchronization fails midway
+	defer m.storeCache()
+
 	regEntries, _, err = m.fetchUpdates(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	m.clearStaleCacheEntries(regEntries)
+	m.clearStaleCacheEntries(ctx, regEntries)
+
+	if m.c.LazySVIDs {
+		// only the SVIDs in use are kept and minted
+		regEntries = m.updateUsedRegEntries(ctx, regEntries)
+	}
+
+	// the cached SVIDs only need to be checked against the bundle when it
+	// changes, e.g. when a CA has been revoked.
+	bundle := m.cache.Bundle()
+	checkChains := bundle != m.chainCheckedBundle
 
-	err = m.checkExpiredCacheEntries(cEntryRequests)
+	err = m.checkExpiredCacheEntries(cEntryRequests, checkChains)
 	if err != nil {
 		return err
 	}
@@ -39,11 +60,18 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 		return err
 	}
 
+	// requests past the CSR limit are dropped until the next pass, which
+	// has to check the chains again.
+	complete := len(cEntryRequests) <= node.CSRLimit
+
 	err = m.processEntryRequests(ctx, cEntryRequests)
 	if err != nil {
 		return err
 	}
 
+	if checkChains && complete {
+		m.chainCheckedBundle = bundle
+	}
 	return nil
 }
 
@@ -92,14 +120,14 @@ func (m *manager) processEntryRequests(ctx context.Context, entryRequests entryR
 		return err
 	}
 
-	if err := m.updateEntriesSVIDs(entryRequests, svids); err != nil {
+	if err := m.updateEntriesSVIDs(ctx, entryRequests, svids); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (m *manager) updateEntriesSVIDs(entryRequestsMap map[string]*entryRequest, svids map[string]*node.X509SVID) error {
+func (m *manager) updateEntriesSVIDs(ctx context.Context, entryRequestsMap map[string]*entryRequest, svids map[string]*node.X509SVID) error {
 	for _, entryRequest := range entryRequestsMap {
 		ce := entryRequest.entry
 		svid, ok := svids[ce.RegistrationEntry.SpiffeId]
@@ -113,31 +141,117 @@ func (m *manager) updateEntriesSVIDs(entryRequestsMap map[string]*entryRequest,
 			}
 			// Complete the pre-built cache entry with the SVID and put it on the cache.
 			ce.SVID = certs
+			m.storeEntryKey(ctx, ce)
 			m.cache.SetEntry(ce)
 		}
 	}
 	return nil
 }
 
-func (m *manager) clearStaleCacheEntries(regEntries map[string]*common.RegistrationEntry) {
+func (m *manager) clearStaleCacheEntries(ctx context.Context, regEntries map[string]*common.RegistrationEntry) {
 	for _, entry := range m.cache.Entries() {
 		if _, ok := regEntries[entry.RegistrationEntry.EntryId]; !ok {
 			m.cache.DeleteEntry(entry.RegistrationEntry)
+			m.deleteEntryKey(ctx, entry)
 		}
 	}
 }
 
-func (m *manager) checkExpiredCacheEntries(cEntryRequests entryRequests) error {
+// updateUsedRegEntries keeps track of the registration entries and when their
+// SVIDs were last used by a subscriber, evicting the cached entries that have
+// been idle for longer than the idle timeout. Returns the registration
+// entries whose SVIDs are in use.
+func (m *manager) updateUsedRegEntries(ctx context.Context, regEntries map[string]*common.RegistrationEntry) map[string]*common.RegistrationEntry {
+	now := m.clk.Now()
+	subscriberSelectors := m.cache.SubscriberSelectors()
+
+	m.mtx.Lock()
+	m.regEntries = regEntries
+	usedRegEntries := make(map[string]*common.RegistrationEntry)
+	for entryID, regEntry := range regEntries {
+		for _, selectors := range subscriberSelectors {
+			if selectorsMatch(selectors, regEntry) {
+				m.lastUsed[entryID] = now
+				break
+			}
+		}
+		if lastUsed, ok := m.lastUsed[entryID]; ok && now.Sub(lastUsed) < m.c.SVIDIdleTimeout {
+			usedRegEntries[entryID] = regEntry
+		}
+	}
+	for entryID := range m.lastUsed {
+		if _, ok := usedRegEntries[entryID]; !ok {
+			delete(m.lastUsed, entryID)
+		}
+	}
+	m.mtx.Unlock()
+
+	for _, entry := range m.cache.Entries() {
+		if _, ok := usedRegEntries[entry.RegistrationEntry.EntryId]; !ok {
+			m.c.Log.Debugf("Evicting idle SVID for %s", entry.RegistrationEntry.SpiffeId)
+			m.cache.DeleteEntry(entry.RegistrationEntry)
+			m.deleteEntryKey(ctx, entry)
+		}
+	}
+
+	return usedRegEntries
+}
+
+// requestSVIDs marks the SVIDs for the registration entries matching the
+// selectors as used, waking up the synchronizer if any of them has not been
+// minted yet. In that case, it returns a channel that is closed once the
+// synchronization minting them completes.
+func (m *manager) requestSVIDs(selectors []*common.Selector) <-chan struct{} {
+	now := m.clk.Now()
+
+	missing := false
+	m.mtx.Lock()
+	for entryID, regEntry := range m.regEntries {
+		if selectorsMatch(selectors, regEntry) {
+			m.lastUsed[entryID] = now
+			if m.cache.FetchEntry(entryID) == nil {
+				missing = true
+			}
+		}
+	}
+	synced := m.nextSync
+	m.mtx.Unlock()
+
+	if !missing {
+		return nil
+	}
+	select {
+	case m.demand <- struct{}{}:
+	default:
+	}
+	return synced
+}
+
+// checkExpiredCacheEntries prepares requests for the cached SVIDs that are
+// about to expire and, if checkChains is set, for those that no longer chain
+// to the bundle.
+func (m *manager) checkExpiredCacheEntries(cEntryRequests entryRequests, checkChains bool) error {
 	now := m.clk.Now()
 	defer m.c.Metrics.MeasureSince([]string{"cache_manager", "expiry_check_duration"}, now)
 
+	var rootCAs []*x509.Certificate
+	if bundle := m.cache.Bundle(); bundle != nil && checkChains {
+		rootCAs = bundle.RootCAs()
+	}
+
 	for _, entry := range m.cache.Entries() {
 		ttl := entry.SVID[0].NotAfter.Sub(now)
 		lifetime := entry.SVID[0].NotAfter.Sub(entry.SVID[0].NotBefore)
-		// If the cached SVID has a remaining lifetime less than 50%, prepare a
-		// new entryRequest.
-		if ttl < lifetime/2 {
-			m.c.Log.Debugf("cache entry ttl for spiffeId %s is less than a half its lifetime", entry.RegistrationEntry.SpiffeId)
+		// If the cached SVID has a remaining lifetime less than 50%, or no
+		// longer chains to the bundle (i.e. its CA has been revoked), prepare
+		// a new entryRequest.
+		expiring := ttl < lifetime/2
+		if expiring || (checkChains && !x509util.ChainsToRoots(entry.SVID, rootCAs)) {
+			if expiring {
+				m.c.Log.Debugf("cache entry ttl for spiffeId %s is less than a half its lifetime", entry.RegistrationEntry.SpiffeId)
+			} else {
+				m.c.Log.Debugf("cache entry SVID for spiffeId %s no longer chains to the bundle", entry.RegistrationEntry.SpiffeId)
+			}
 			privateKey, csr, err := m.newCSR(entry.RegistrationEntry.SpiffeId)
 			if err != nil {
 				return err
@@ -188,8 +302,8 @@ func (m *manager) checkForNewCacheEntries(regEntries map[string]*common.Registra
 	return nil
 }
 
-func (m *manager) newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err error) {
-	pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+func (m *manager) newCSR(spiffeID string) (pk crypto.Signer, csr []byte, err error) {
+	pk, err = cryptoutil.GenerateSigner(m.c.WorkloadKeyType)
 	if err != nil {
 		return
 	}
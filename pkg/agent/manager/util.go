@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/spiffe/go-spiffe/uri"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/proto/common"
 )
 
 func getSpiffeIDFromSVID(svid *x509.Certificate) (string, error) {
@@ -19,3 +21,22 @@ func getSpiffeIDFromSVID(svid *x509.Certificate) (string, error) {
 
 	return URIs[0], nil
 }
+
+func certsEqual(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectorsMatch returns true if the registration entry selectors are
+// included in the given selectors.
+func selectorsMatch(selectors []*common.Selector, regEntry *common.RegistrationEntry) bool {
+	regEntrySelectors := selector.NewSetFromRaw(regEntry.Selectors)
+	return selector.NewSetFromRaw(selectors).IncludesSet(regEntrySelectors)
+}
@@ -7,19 +7,30 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
+	"regexp"
 	"sync"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/diskutil"
 	"github.com/spiffe/spire/proto/agent/keymanager"
+	"github.com/spiffe/spire/proto/common"
 
 	spi "github.com/spiffe/spire/proto/common/plugin"
 )
 
-const keyFileName = "svid.key"
+const (
+	keyFileName = "svid.key"
+
+	// keysDirName is the name of the directory, relative to the configured
+	// directory, holding the private keys stored with an identifier.
+	keysDirName = "keys"
+)
+
+var keyIDRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 type pluginConfig struct {
 	Directory string `hcl:"directory" json:"directory"`
@@ -30,7 +41,13 @@ type diskPlugin struct {
 	dir string
 }
 
-func (d *diskPlugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+func (d *diskPlugin) GenerateKeyPair(ctx context.Context, req *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+	switch req.KeyType {
+	case common.KeyType_UNSPECIFIED_KEY_TYPE, common.KeyType_EC_P256:
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", req.KeyType)
+	}
+
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, err
@@ -59,6 +76,20 @@ func (d *diskPlugin) StorePrivateKey(ctx context.Context, req *keymanager.StoreP
 	}
 	keyPath := path.Join(d.dir, keyFileName)
 
+	if req.Id != "" {
+		if _, err := x509.ParsePKCS8PrivateKey(req.PrivateKey); err != nil {
+			return nil, fmt.Errorf("unable to parse private key %q: %v", req.Id, err)
+		}
+		var err error
+		keyPath, err = d.keyPath(req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(path.Dir(keyPath), 0700); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := diskutil.AtomicWriteFile(keyPath, req.PrivateKey, 0600); err != nil {
 		return nil, err
 	}
@@ -66,7 +97,11 @@ func (d *diskPlugin) StorePrivateKey(ctx context.Context, req *keymanager.StoreP
 	return &keymanager.StorePrivateKeyResponse{}, nil
 }
 
-func (d *diskPlugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
+func (d *diskPlugin) FetchPrivateKey(ctx context.Context, req *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
+	if req.Id != "" {
+		return d.fetchPrivateKeyWithID(req.Id)
+	}
+
 	// Start with empty response
 	resp := &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}
 
@@ -92,6 +127,59 @@ func (d *diskPlugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKe
 	return resp, nil
 }
 
+func (d *diskPlugin) DeletePrivateKey(ctx context.Context, req *keymanager.DeletePrivateKeyRequest) (*keymanager.DeletePrivateKeyResponse, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	keyPath, err := d.keyPath(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(keyPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return &keymanager.DeletePrivateKeyResponse{}, nil
+}
+
+func (d *diskPlugin) fetchPrivateKeyWithID(id string) (*keymanager.FetchPrivateKeyResponse, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	keyPath, err := d.keyPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}, nil
+		}
+		return nil, err
+	}
+
+	// Check key integrity first
+	if _, err := x509.ParsePKCS8PrivateKey(data); err != nil {
+		return nil, fmt.Errorf("unable to parse private key %q: %v", id, err)
+	}
+
+	return &keymanager.FetchPrivateKeyResponse{PrivateKey: data}, nil
+}
+
+// keyPath returns the path of the private key stored with the identifier.
+// The caller must hold the mutex.
+func (d *diskPlugin) keyPath(id string) (string, error) {
+	if d.dir == "" {
+		return "", errors.New("path not configured")
+	}
+	if !keyIDRE.MatchString(id) {
+		return "", fmt.Errorf("invalid private key id %q", id)
+	}
+	return path.Join(d.dir, keysDirName, id+".key"), nil
+}
+
 func (d *diskPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &pluginConfig{}
 	hclTree, err := hcl.Parse(req.Configuration)
@@ -2,6 +2,9 @@ package disk
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"io/ioutil"
 	"os"
@@ -12,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/spiffe/spire/proto/agent/keymanager"
+	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 )
 
@@ -42,6 +46,18 @@ func TestDisk_GenerateKeyPair(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDisk_GenerateKeyPairWithKeyType(t *testing.T) {
+	plugin := New()
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{KeyType: common.KeyType_EC_P256})
+	require.NoError(t, err)
+	key, err := x509.ParseECPrivateKey(genResp.PrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, elliptic.P256(), key.Curve)
+
+	_, err = plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{KeyType: common.KeyType_RSA_2048})
+	assert.EqualError(t, err, "unsupported key type RSA_2048")
+}
+
 func TestDisk_FetchPrivateKey(t *testing.T) {
 	plugin := New()
 	tempDir, err := ioutil.TempDir("", "km-disk-test")
@@ -59,6 +75,73 @@ func TestDisk_FetchPrivateKey(t *testing.T) {
 	assert.Equal(t, genResp.PrivateKey, fetchResp.PrivateKey)
 }
 
+func TestDisk_PrivateKeyWithID(t *testing.T) {
+	plugin := New()
+	tempDir, err := ioutil.TempDir("", "km-disk-test")
+	require.NoError(t, err)
+	plugin.dir = tempDir
+	defer os.RemoveAll(tempDir)
+
+	// keys that have not been stored are empty
+	fetchResp, err := plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Empty(t, fetchResp.PrivateKey)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyData, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: keyData, Id: "ENTRYID"})
+	require.NoError(t, err)
+	fileData, err := ioutil.ReadFile(path.Join(tempDir, keysDirName, "ENTRYID.key"))
+	require.NoError(t, err)
+	assert.Equal(t, keyData, fileData)
+
+	// the agent key is not affected
+	_, err = os.Stat(path.Join(tempDir, keyFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	fetchResp, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Equal(t, keyData, fetchResp.PrivateKey)
+
+	_, err = plugin.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	fetchResp, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Empty(t, fetchResp.PrivateKey)
+
+	// deleting a key that does not exist is not an error
+	_, err = plugin.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+}
+
+func TestDisk_PrivateKeyWithInvalidID(t *testing.T) {
+	plugin := New()
+	tempDir, err := ioutil.TempDir("", "km-disk-test")
+	require.NoError(t, err)
+	plugin.dir = tempDir
+	defer os.RemoveAll(tempDir)
+
+	_, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "../svid"})
+	require.EqualError(t, err, `invalid private key id "../svid"`)
+	_, err = plugin.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{Id: ""})
+	require.EqualError(t, err, `invalid private key id ""`)
+}
+
+func TestDisk_StoreMalformedPrivateKeyWithID(t *testing.T) {
+	plugin := New()
+	tempDir, err := ioutil.TempDir("", "km-disk-test")
+	require.NoError(t, err)
+	plugin.dir = tempDir
+	defer os.RemoveAll(tempDir)
+
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: []byte("MALFORMED"), Id: "ENTRYID"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unable to parse private key "ENTRYID"`)
+}
+
 func TestDisk_Configure(t *testing.T) {
 	plugin := New()
 	cReq := &spi.ConfigureRequest{
@@ -6,18 +6,27 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
+	"fmt"
 	"sync"
 
 	"github.com/spiffe/spire/proto/agent/keymanager"
+	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 )
 
 type MemoryPlugin struct {
-	key *ecdsa.PrivateKey
-	mtx sync.RWMutex
+	key  *ecdsa.PrivateKey
+	keys map[string][]byte
+	mtx  sync.RWMutex
 }
 
-func (m *MemoryPlugin) GenerateKeyPair(context.Context, *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+func (m *MemoryPlugin) GenerateKeyPair(ctx context.Context, req *keymanager.GenerateKeyPairRequest) (*keymanager.GenerateKeyPairResponse, error) {
+	switch req.KeyType {
+	case common.KeyType_UNSPECIFIED_KEY_TYPE, common.KeyType_EC_P256:
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", req.KeyType)
+	}
+
 	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, err
@@ -40,6 +49,14 @@ func (m *MemoryPlugin) StorePrivateKey(ctx context.Context, req *keymanager.Stor
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	if req.Id != "" {
+		if _, err := x509.ParsePKCS8PrivateKey(req.PrivateKey); err != nil {
+			return nil, fmt.Errorf("unable to parse private key %q: %v", req.Id, err)
+		}
+		m.keys[req.Id] = req.PrivateKey
+		return &keymanager.StorePrivateKeyResponse{}, nil
+	}
+
 	key, err := x509.ParseECPrivateKey(req.PrivateKey)
 	if err != nil {
 		return nil, err
@@ -49,10 +66,14 @@ func (m *MemoryPlugin) StorePrivateKey(ctx context.Context, req *keymanager.Stor
 	return &keymanager.StorePrivateKeyResponse{}, nil
 }
 
-func (m *MemoryPlugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
+func (m *MemoryPlugin) FetchPrivateKey(ctx context.Context, req *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
 
+	if req.Id != "" {
+		return &keymanager.FetchPrivateKeyResponse{PrivateKey: append([]byte{}, m.keys[req.Id]...)}, nil
+	}
+
 	if m.key == nil {
 		// No key set yet
 		return &keymanager.FetchPrivateKeyResponse{PrivateKey: []byte{}}, nil
@@ -66,6 +87,14 @@ func (m *MemoryPlugin) FetchPrivateKey(context.Context, *keymanager.FetchPrivate
 	return &keymanager.FetchPrivateKeyResponse{PrivateKey: privateKey}, nil
 }
 
+func (m *MemoryPlugin) DeletePrivateKey(ctx context.Context, req *keymanager.DeletePrivateKeyRequest) (*keymanager.DeletePrivateKeyResponse, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.keys, req.Id)
+	return &keymanager.DeletePrivateKeyResponse{}, nil
+}
+
 func (m *MemoryPlugin) Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return &spi.ConfigureResponse{}, nil
 }
@@ -75,5 +104,7 @@ func (m *MemoryPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest)
 }
 
 func New() *MemoryPlugin {
-	return &MemoryPlugin{}
+	return &MemoryPlugin{
+		keys: make(map[string][]byte),
+	}
 }
@@ -2,6 +2,9 @@ package memory
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/spiffe/spire/proto/agent/keymanager"
+	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 )
 
@@ -27,6 +31,18 @@ func TestMemory_GenerateKeyPair(t *testing.T) {
 	assert.Equal(t, plugin.key, priv)
 }
 
+func TestMemory_GenerateKeyPairWithKeyType(t *testing.T) {
+	plugin := New()
+	genResp, err := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{KeyType: common.KeyType_EC_P256})
+	require.NoError(t, err)
+	key, err := x509.ParseECPrivateKey(genResp.PrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, elliptic.P256(), key.Curve)
+
+	_, err = plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{KeyType: common.KeyType_RSA_2048})
+	assert.EqualError(t, err, "unsupported key type RSA_2048")
+}
+
 func TestMemory_FetchPrivateKey(t *testing.T) {
 	plugin := New()
 	data, e := plugin.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
@@ -39,6 +55,29 @@ func TestMemory_FetchPrivateKey(t *testing.T) {
 	assert.Equal(t, priv.PrivateKey, data.PrivateKey)
 }
 
+func TestMemory_PrivateKeyWithID(t *testing.T) {
+	plugin := New()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	keyData, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	_, err = plugin.StorePrivateKey(ctx, &keymanager.StorePrivateKeyRequest{PrivateKey: keyData, Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Nil(t, plugin.key)
+
+	priv, err := plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Equal(t, keyData, priv.PrivateKey)
+
+	_, err = plugin.DeletePrivateKey(ctx, &keymanager.DeletePrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	priv, err = plugin.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{Id: "ENTRYID"})
+	require.NoError(t, err)
+	assert.Empty(t, priv.PrivateKey)
+}
+
 func TestMemory_Configure(t *testing.T) {
 	plugin := New()
 	data, e := plugin.Configure(ctx, &spi.ConfigureRequest{})
@@ -2,12 +2,16 @@ package jointoken
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/url"
 	"path"
 	"sync"
 
 	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/plugin/jointoken"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
@@ -55,9 +59,34 @@ func (p *JoinTokenPlugin) FetchAttestationData(stream nodeattestor.FetchAttestat
 		Data: []byte(p.joinToken),
 	}
 
-	return stream.Send(&nodeattestor.FetchAttestationDataResponse{
+	if err := stream.Send(&nodeattestor.FetchAttestationDataResponse{
 		AttestationData: data,
 		SpiffeId:        p.spiffeID().String(),
+	}); err != nil {
+		return err
+	}
+
+	// The server challenges the agent when the token assigns it a different
+	// SPIFFE ID. Otherwise the stream is closed once attestation completes.
+	req, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	challenge := new(jointoken.Challenge)
+	if err := json.Unmarshal(req.Challenge, challenge); err != nil {
+		return fmt.Errorf("unable to unmarshal challenge: %v", err)
+	}
+	if challenge.SpiffeID == "" {
+		return errors.New("challenge is missing the assigned SPIFFE ID")
+	}
+
+	return stream.Send(&nodeattestor.FetchAttestationDataResponse{
+		AttestationData: data,
+		SpiffeId:        challenge.SpiffeID,
 	})
 }
 
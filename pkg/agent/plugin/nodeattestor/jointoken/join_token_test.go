@@ -26,13 +26,13 @@ var (
 )
 
 type fakeFetchAttestationDataStream struct {
-	req  *nodeattestor.FetchAttestationDataRequest
-	resp *nodeattestor.FetchAttestationDataResponse
+	reqs  []*nodeattestor.FetchAttestationDataRequest
+	resps []*nodeattestor.FetchAttestationDataResponse
 }
 
-func newFakeFetchAttestationStream() *fakeFetchAttestationDataStream {
+func newFakeFetchAttestationStream(reqs ...*nodeattestor.FetchAttestationDataRequest) *fakeFetchAttestationDataStream {
 	return &fakeFetchAttestationDataStream{
-		req: new(nodeattestor.FetchAttestationDataRequest),
+		reqs: reqs,
 	}
 }
 
@@ -41,19 +41,16 @@ func (f *fakeFetchAttestationDataStream) Context() context.Context {
 }
 
 func (f *fakeFetchAttestationDataStream) Recv() (*nodeattestor.FetchAttestationDataRequest, error) {
-	req := f.req
-	f.req = nil
-	if req == nil {
+	if len(f.reqs) == 0 {
 		return nil, io.EOF
 	}
+	req := f.reqs[0]
+	f.reqs = f.reqs[1:]
 	return req, nil
 }
 
 func (f *fakeFetchAttestationDataStream) Send(resp *nodeattestor.FetchAttestationDataResponse) error {
-	if f.resp != nil {
-		return io.EOF
-	}
-	f.resp = resp
+	f.resps = append(f.resps, resp)
 	return nil
 }
 
@@ -102,7 +99,44 @@ func TestJoinToken_FetchAttestationData_TokenPresent(t *testing.T) {
 	stream := newFakeFetchAttestationStream()
 	assert.NoError(p.FetchAttestationData(stream))
 	assert.Nil(err)
-	assert.Equal(expectedResp, stream.resp)
+	assert.Equal([]*nodeattestor.FetchAttestationDataResponse{expectedResp}, stream.resps)
+}
+
+func TestJoinToken_FetchAttestationData_AssignedID(t *testing.T) {
+	assert := assert.New(t)
+
+	attestationData := &common.AttestationData{
+		Type: "join_token",
+		Data: []byte(token),
+	}
+
+	p, _, err := PluginGenerator(goodConfig, trustDomain)
+	assert.Nil(err)
+
+	// the server assigns another SPIFFE ID
+	stream := newFakeFetchAttestationStream(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: []byte(`{"spiffe_id":"spiffe://example.com/spire/agent/node1"}`),
+	})
+	assert.NoError(p.FetchAttestationData(stream))
+	assert.Equal([]*nodeattestor.FetchAttestationDataResponse{
+		{AttestationData: attestationData, SpiffeId: spiffeId},
+		{AttestationData: attestationData, SpiffeId: "spiffe://example.com/spire/agent/node1"},
+	}, stream.resps)
+
+	// malformed challenge
+	stream = newFakeFetchAttestationStream(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: []byte("malformed"),
+	})
+	err = p.FetchAttestationData(stream)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "unable to unmarshal challenge")
+	}
+
+	// challenge without a SPIFFE ID
+	stream = newFakeFetchAttestationStream(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: []byte("{}"),
+	})
+	assert.EqualError(p.FetchAttestationData(stream), "challenge is missing the assigned SPIFFE ID")
 }
 
 func TestJoinToken_FetchAttestationData_TokenNotPresent(t *testing.T) {
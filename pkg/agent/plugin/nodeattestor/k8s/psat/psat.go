@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"sync"
+	"time"
 
 	"gopkg.in/square/go-jose.v2/jwt"
 
@@ -28,13 +29,19 @@ var (
 
 // NewAttestorPlugin creates a new PSAT attestor plugin
 func NewAttestorPlugin() *AttestorPlugin {
-	return &AttestorPlugin{}
+	p := &AttestorPlugin{}
+	p.hooks.now = time.Now
+	return p
 }
 
 // AttestorPlugin is a PSAT (projected SAT) attestor plugin
 type AttestorPlugin struct {
 	mu     sync.RWMutex
 	config *attestorConfig
+
+	hooks struct {
+		now func() time.Time
+	}
 }
 
 // AttestorConfig holds configuration for AttestorPlugin
@@ -43,15 +50,20 @@ type AttestorConfig struct {
 	Cluster string `hcl:"cluster"`
 	// File path of PSAT
 	TokenPath string `hcl:"token_path"`
+	// Audiences the PSAT must have been issued for. If empty, the audience
+	// is left for the server to validate.
+	Audience []string `hcl:"audience"`
 }
 
 type attestorConfig struct {
 	trustDomain string
 	cluster     string
 	tokenPath   string
+	audience    []string
 }
 
-// FetchAttestationData loads PSAT from the configured path and send it to server node attestor
+// FetchAttestationData loads PSAT from the configured path and send it to server node attestor.
+// The PSAT is read on every attestation since kubelet rotates it.
 func (p *AttestorPlugin) FetchAttestationData(stream nodeattestor.FetchAttestationData_PluginStream) error {
 	config, err := p.getConfig()
 	if err != nil {
@@ -79,6 +91,17 @@ func (p *AttestorPlugin) FetchAttestationData(stream nodeattestor.FetchAttestati
 		return psatError.New("token claim pod UID is empty")
 	}
 
+	// Catch stale tokens and tokens bound to the wrong audience here, where
+	// the error is more helpful than the one the server would return.
+	if claims.Expiry != 0 && !p.hooks.now().Before(claims.Expiry.Time()) {
+		return psatError.New("token loaded from %s has expired", config.tokenPath)
+	}
+	for _, audience := range config.audience {
+		if !claims.Audience.Contains(audience) {
+			return psatError.New("token audience %q does not include %q", []string(claims.Audience), audience)
+		}
+	}
+
 	data, err := json.Marshal(k8s.PSATAttestationData{
 		Cluster: config.cluster,
 		Token:   tokenStr,
@@ -117,6 +140,7 @@ func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReques
 		trustDomain: req.GlobalConfig.TrustDomain,
 		cluster:     hclConfig.Cluster,
 		tokenPath:   hclConfig.TokenPath,
+		audience:    hclConfig.Audience,
 	}
 	if config.tokenPath == "" {
 		config.tokenPath = defaultTokenPath
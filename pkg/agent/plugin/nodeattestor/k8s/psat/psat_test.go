@@ -7,7 +7,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	sat_common "github.com/spiffe/spire/pkg/common/plugin/k8s"
@@ -113,6 +115,55 @@ func (s *AttestorSuite) TestFetchAttestationDataSuccess() {
 	s.Require().Equal(io.EOF, err)
 }
 
+func (s *AttestorSuite) TestFetchAttestationDataRereadsToken() {
+	token, err := createPSAT("POD-UID")
+	s.Require().NoError(err)
+	tokenPath := s.writeValue("token", token)
+	s.configure(AttestorConfig{
+		TokenPath: tokenPath,
+	})
+	s.Require().Contains(s.fetchToken(), token)
+
+	// kubelet rotates the token in place
+	rotated, err := createPSATWithClaims("POD-UID", sat_common.PSATClaims{
+		Claims: jwt.Claims{IssuedAt: jwt.NewNumericDate(time.Now())},
+	})
+	s.Require().NoError(err)
+	s.Require().NotEqual(token, rotated)
+	s.writeValue("token", rotated)
+	s.Require().Contains(s.fetchToken(), rotated)
+}
+
+func (s *AttestorSuite) TestFetchAttestationDataExpiredToken() {
+	token, err := createPSATWithClaims("POD-UID", sat_common.PSATClaims{
+		Claims: jwt.Claims{Expiry: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	})
+	s.Require().NoError(err)
+	s.configure(AttestorConfig{
+		TokenPath: s.writeValue("token", token),
+	})
+	s.requireFetchError("has expired")
+}
+
+func (s *AttestorSuite) TestFetchAttestationDataAudience() {
+	token, err := createPSATWithClaims("POD-UID", sat_common.PSATClaims{
+		Claims: jwt.Claims{Audience: jwt.Audience{"other"}},
+	})
+	s.Require().NoError(err)
+	s.configure(AttestorConfig{
+		TokenPath: s.writeValue("token", token),
+		Audience:  []string{"spire-server"},
+	})
+	s.requireFetchError(`token audience ["other"] does not include "spire-server"`)
+
+	token, err = createPSATWithClaims("POD-UID", sat_common.PSATClaims{
+		Claims: jwt.Claims{Audience: jwt.Audience{"other", "spire-server"}},
+	})
+	s.Require().NoError(err)
+	s.writeValue("token", token)
+	s.Require().Contains(s.fetchToken(), token)
+}
+
 func (s *AttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -165,11 +216,22 @@ func (s *AttestorSuite) configure(config AttestorConfig) {
 		},
 		Configuration: fmt.Sprintf(`
 			cluster = "production"
-			token_path = %q`, config.TokenPath),
+			token_path = %q
+			audience = %s`, config.TokenPath, hclStringList(config.Audience)),
 	})
 	s.Require().NoError(err)
 
 }
+
+func (s *AttestorSuite) fetchToken() string {
+	stream, err := s.attestor.FetchAttestationData(context.Background())
+	s.Require().NoError(err)
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.AttestationData)
+	return string(resp.AttestationData.Data)
+}
+
 func (s *AttestorSuite) joinPath(path string) string {
 	return filepath.Join(s.dir, path)
 }
@@ -200,12 +262,16 @@ func (s *AttestorSuite) requireErrorContains(err error, contains string) {
 
 // Creates a PSAT using the given podUID (just for testing)
 func createPSAT(podUID string) (string, error) {
+	return createPSATWithClaims(podUID, sat_common.PSATClaims{})
+}
+
+// Creates a PSAT using the given podUID and claims (just for testing)
+func createPSATWithClaims(podUID string, claims sat_common.PSATClaims) (string, error) {
 	// Create a jwt builder
 	s, err := createSigner()
 	builder := jwt.Signed(s)
 
 	// Set useful claims for testing
-	claims := sat_common.PSATClaims{}
 	claims.K8s.Pod.UID = podUID
 	builder = builder.Claims(claims)
 
@@ -219,6 +285,14 @@ func createPSAT(podUID string) (string, error) {
 	return token, nil
 }
 
+func hclStringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
 func createSigner() (jose.Signer, error) {
 	sampleKey, err := pemutil.ParseRSAPrivateKey(sampleKeyPEM)
 	if err != nil {
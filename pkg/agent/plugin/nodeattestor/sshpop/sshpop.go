@@ -0,0 +1,201 @@
+package sshpop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/plugin/sshpop"
+	"github.com/spiffe/spire/proto/agent/nodeattestor"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pluginName = "sshpop"
+
+	defaultHostKeyPath  = "/etc/ssh/ssh_host_rsa_key"
+	defaultHostCertPath = "/etc/ssh/ssh_host_rsa_key-cert.pub"
+)
+
+type configData struct {
+	spiffeID        string
+	signer          ssh.Signer
+	attestationData *common.AttestationData
+}
+
+type SSHPoPConfig struct {
+	trustDomain  string
+	HostKeyPath  string `hcl:"host_key_path"`
+	HostCertPath string `hcl:"host_cert_path"`
+}
+
+type SSHPoPPlugin struct {
+	m sync.Mutex
+	c *SSHPoPConfig
+}
+
+var _ nodeattestor.Plugin = (*SSHPoPPlugin)(nil)
+
+func New() *SSHPoPPlugin {
+	return &SSHPoPPlugin{}
+}
+
+func (p *SSHPoPPlugin) FetchAttestationData(stream nodeattestor.FetchAttestationData_PluginStream) (err error) {
+	data, err := p.loadConfigData()
+	if err != nil {
+		return err
+	}
+
+	// send the attestation data back to the agent
+	if err := stream.Send(&nodeattestor.FetchAttestationDataResponse{
+		AttestationData: data.attestationData,
+		SpiffeId:        data.spiffeID,
+	}); err != nil {
+		return err
+	}
+
+	// receive challenge
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	challenge := new(sshpop.Challenge)
+	if err := json.Unmarshal(resp.Challenge, challenge); err != nil {
+		return fmt.Errorf("sshpop: unable to unmarshal challenge: %v", err)
+	}
+
+	// calculate and send the challenge response
+	response, err := sshpop.CalculateResponse(data.signer, challenge)
+	if err != nil {
+		return fmt.Errorf("sshpop: failed to calculate challenge response: %v", err)
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("sshpop: unable to marshal challenge response: %v", err)
+	}
+
+	if err := stream.Send(&nodeattestor.FetchAttestationDataResponse{
+		SpiffeId: data.spiffeID,
+		Response: responseBytes,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *SSHPoPPlugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	// Parse HCL config payload into config struct
+	config := new(SSHPoPConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, fmt.Errorf("sshpop: unable to decode configuration: %v", err)
+	}
+
+	if req.GlobalConfig == nil {
+		return nil, errors.New("sshpop: global configuration is required")
+	}
+	if req.GlobalConfig.TrustDomain == "" {
+		return nil, errors.New("sshpop: trust_domain is required")
+	}
+	config.trustDomain = req.GlobalConfig.TrustDomain
+
+	if config.HostKeyPath == "" {
+		config.HostKeyPath = defaultHostKeyPath
+	}
+	if config.HostCertPath == "" {
+		config.HostCertPath = defaultHostCertPath
+	}
+
+	// make sure the configuration produces valid data
+	if _, err := loadConfigData(config); err != nil {
+		return nil, err
+	}
+
+	p.setConfig(config)
+
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (p *SSHPoPPlugin) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return &plugin.GetPluginInfoResponse{}, nil
+}
+
+func (p *SSHPoPPlugin) getConfig() *SSHPoPConfig {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
+
+func (p *SSHPoPPlugin) setConfig(c *SSHPoPConfig) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+}
+
+func (p *SSHPoPPlugin) loadConfigData() (*configData, error) {
+	config := p.getConfig()
+	if config == nil {
+		return nil, errors.New("sshpop: not configured")
+	}
+	return loadConfigData(config)
+}
+
+func loadConfigData(config *SSHPoPConfig) (*configData, error) {
+	keyBytes, err := ioutil.ReadFile(config.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: unable to read host key: %v", err)
+	}
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: unable to parse host key: %v", err)
+	}
+
+	certBytes, err := ioutil.ReadFile(config.HostCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: unable to read host certificate: %v", err)
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: unable to parse host certificate: %v", err)
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("sshpop: expected host certificate; got %s key", key.Type())
+	}
+	if cert.CertType != ssh.HostCert {
+		return nil, errors.New("sshpop: certificate is not a host certificate")
+	}
+	if !bytes.Equal(cert.Key.Marshal(), signer.PublicKey().Marshal()) {
+		return nil, errors.New("sshpop: host certificate does not match the host key")
+	}
+
+	spiffeID, err := sshpop.SpiffeID(config.trustDomain, cert)
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: %v", err)
+	}
+
+	attestationDataBytes, err := json.Marshal(sshpop.AttestationData{
+		Certificate: cert.Marshal(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sshpop: unable to marshal attestation data: %v", err)
+	}
+
+	return &configData{
+		spiffeID: spiffeID,
+		signer:   signer,
+		attestationData: &common.AttestationData{
+			Type: pluginName,
+			Data: attestationDataBytes,
+		},
+	}, nil
+}
@@ -0,0 +1,259 @@
+package sshpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/plugin/sshpop"
+	"github.com/spiffe/spire/proto/agent/nodeattestor"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHPoP(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+type Suite struct {
+	suite.Suite
+
+	dir      string
+	hostKey  ssh.PublicKey
+	hostCert *ssh.Certificate
+	p        *nodeattestor.BuiltIn
+}
+
+func (s *Suite) SetupTest() {
+	require := s.Require()
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "agent-sshpop-test")
+	require.NoError(err)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	s.hostKey, s.hostCert = s.writeHostKey("host", caKey, ssh.HostCert)
+
+	s.p = nodeattestor.NewBuiltIn(New())
+	s.configure(fmt.Sprintf(`
+		host_key_path = %q
+		host_cert_path = %q`,
+		s.join("host"), s.join("host-cert.pub")))
+}
+
+func (s *Suite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *Suite) TestFetchAttestationDataSuccess() {
+	require := s.Require()
+
+	stream, done := s.fetchAttestationData()
+	defer done()
+
+	spiffeID := "spiffe://example.org/spire/agent/sshpop/node1"
+
+	// first response has the spiffeid and attestation data
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.NotNil(resp)
+	require.Equal(spiffeID, resp.SpiffeId)
+	require.Equal("sshpop", resp.AttestationData.Type)
+	require.JSONEq(string(s.marshal(sshpop.AttestationData{
+		Certificate: s.hostCert.Marshal(),
+	})), string(resp.AttestationData.Data))
+	require.Nil(resp.Response)
+
+	// send a challenge
+	challenge, err := sshpop.GenerateChallenge()
+	require.NoError(err)
+	err = stream.Send(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: s.marshal(challenge),
+	})
+	require.NoError(err)
+
+	// recv the response
+	resp, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(spiffeID, resp.SpiffeId)
+	require.Nil(resp.AttestationData)
+	require.NotEmpty(resp.Response)
+
+	// verify signature
+	response := new(sshpop.Response)
+	s.unmarshal(resp.Response, response)
+	err = sshpop.VerifyChallengeResponse(s.hostKey, challenge, response)
+	require.NoError(err)
+}
+
+func (s *Suite) TestFetchAttestationDataFailure() {
+	require := s.Require()
+
+	challengeFails := func(challenge []byte, expected string) {
+		stream, done := s.fetchAttestationData()
+		defer done()
+
+		resp, err := stream.Recv()
+		require.NoError(err)
+		require.NotNil(resp)
+
+		require.NoError(stream.Send(&nodeattestor.FetchAttestationDataRequest{
+			Challenge: challenge,
+		}))
+
+		resp, err = stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	// not configured
+	stream, err := nodeattestor.NewBuiltIn(New()).FetchAttestationData(context.Background())
+	require.NoError(err)
+	defer stream.CloseSend()
+	resp, err := stream.Recv()
+	s.errorContains(err, "sshpop: not configured")
+	require.Nil(resp)
+
+	// malformed challenge
+	challengeFails(nil, "sshpop: unable to unmarshal challenge")
+
+	// empty challenge
+	challengeFails(s.marshal(sshpop.Challenge{}), "sshpop: failed to calculate challenge response")
+}
+
+func (s *Suite) TestConfigure() {
+	require := s.Require()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	s.writeHostKey("user", caKey, ssh.UserCert)
+	s.writeHostKey("other", caKey, ssh.HostCert)
+
+	// malformed
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `bad juju`,
+	})
+	s.errorContains(err, "sshpop: unable to decode configuration")
+	require.Nil(resp)
+
+	// missing global configuration
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{})
+	require.EqualError(err, "sshpop: global configuration is required")
+	require.Nil(resp)
+
+	// missing trust_domain
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{},
+	})
+	require.EqualError(err, "sshpop: trust_domain is required")
+	require.Nil(resp)
+
+	configureFails := func(hostKey, hostCert, expected string) {
+		resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+			Configuration: fmt.Sprintf(`
+				host_key_path = %q
+				host_cert_path = %q`,
+				s.join(hostKey), s.join(hostCert)),
+			GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+		})
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	configureFails("missing", "host-cert.pub", "sshpop: unable to read host key")
+	configureFails("host-cert.pub", "host-cert.pub", "sshpop: unable to parse host key")
+	configureFails("host", "missing", "sshpop: unable to read host certificate")
+	configureFails("host", "host", "sshpop: unable to parse host certificate")
+	configureFails("host", "host.pub", "sshpop: expected host certificate; got ecdsa-sha2-nistp256 key")
+	configureFails("user", "user-cert.pub", "sshpop: certificate is not a host certificate")
+	configureFails("host", "other-cert.pub", "sshpop: host certificate does not match the host key")
+}
+
+func (s *Suite) TestGetPluginInfo() {
+	require := s.Require()
+
+	p := New()
+	resp, err := p.GetPluginInfo(context.Background(), &plugin.GetPluginInfoRequest{})
+	require.NoError(err)
+	require.Equal(resp, &plugin.GetPluginInfoResponse{})
+}
+
+// writeHostKey generates a host key and a certificate for it signed by the
+// CA key, writing them in OpenSSH layout (<name>, <name>.pub and
+// <name>-cert.pub) to the test directory.
+func (s *Suite) writeHostKey(name string, caKey *ecdsa.PrivateKey, certType uint32) (ssh.PublicKey, *ssh.Certificate) {
+	require := s.Require()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(err)
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	require.NoError(err)
+
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(err)
+	cert := &ssh.Certificate{
+		Key:             publicKey,
+		CertType:        certType,
+		KeyId:           "node1",
+		ValidPrincipals: []string{"node1.example.org"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(cert.SignCert(rand.Reader, caSigner))
+
+	require.NoError(ioutil.WriteFile(s.join(name), pem.EncodeToMemory(&pem.Block{
+		Type:  "EC PRIVATE KEY",
+		Bytes: keyBytes,
+	}), 0600))
+	require.NoError(ioutil.WriteFile(s.join(name+".pub"), ssh.MarshalAuthorizedKey(publicKey), 0644))
+	require.NoError(ioutil.WriteFile(s.join(name+"-cert.pub"), ssh.MarshalAuthorizedKey(cert), 0644))
+	return publicKey, cert
+}
+
+func (s *Suite) join(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *Suite) configure(config string) {
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: config,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *Suite) fetchAttestationData() (nodeattestor.FetchAttestationData_Stream, func()) {
+	stream, err := s.p.FetchAttestationData(context.Background())
+	s.Require().NoError(err)
+	return stream, func() {
+		s.Require().NoError(stream.CloseSend())
+	}
+}
+
+func (s *Suite) marshal(obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *Suite) unmarshal(data []byte, obj interface{}) {
+	s.Require().NoError(json.Unmarshal(data, obj))
+}
+
+func (s *Suite) errorContains(err error, substring string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), substring)
+}
@@ -0,0 +1,230 @@
+package tpm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/plugin/tpm"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/agent/nodeattestor"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+)
+
+const (
+	defaultDevicePath = "/dev/tpmrm0"
+)
+
+var (
+	// defaultPCRs are the PCRs holding the measurements of the firmware and
+	// boot loader on PC client platforms.
+	defaultPCRs = []int{0, 1, 2, 3, 4, 5, 6, 7}
+)
+
+type TPMConfig struct {
+	trustDomain       string
+	ekCertificate     []byte
+	DevicePath        string `hcl:"device_path"`
+	EKCertificatePath string `hcl:"ek_certificate_path"`
+	PCRs              []int  `hcl:"pcrs"`
+}
+
+type TPMPlugin struct {
+	m sync.Mutex
+	c *TPMConfig
+
+	hooks struct {
+		openDevice func(path string) (io.ReadWriteCloser, error)
+	}
+}
+
+var _ nodeattestor.Plugin = (*TPMPlugin)(nil)
+
+func New() *TPMPlugin {
+	p := &TPMPlugin{}
+	p.hooks.openDevice = openDevice
+	return p
+}
+
+func (p *TPMPlugin) FetchAttestationData(stream nodeattestor.FetchAttestationData_PluginStream) (err error) {
+	config := p.getConfig()
+	if config == nil {
+		return errors.New("tpm: not configured")
+	}
+
+	rwc, err := p.hooks.openDevice(config.DevicePath)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to open device: %v", err)
+	}
+	defer rwc.Close()
+	device := tpm.NewDevice(rwc)
+
+	ekHandle, ekPublicBytes, err := device.CreateEK()
+	if err != nil {
+		return fmt.Errorf("tpm: unable to create endorsement key: %v", err)
+	}
+	defer device.Flush(ekHandle)
+
+	ekCertificate := config.ekCertificate
+	if ekCertificate == nil {
+		ekCertificate, err = device.ReadEKCertificate()
+		if err != nil {
+			return fmt.Errorf("tpm: unable to read endorsement key certificate: %v", err)
+		}
+	}
+
+	akHandle, akPublicBytes, err := device.CreateAK()
+	if err != nil {
+		return fmt.Errorf("tpm: unable to create attestation key: %v", err)
+	}
+	defer device.Flush(akHandle)
+
+	ekPublic, err := tpm2.DecodePublic(ekPublicBytes)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to decode endorsement key: %v", err)
+	}
+	ekKey, err := ekPublic.Key()
+	if err != nil {
+		return fmt.Errorf("tpm: %v", err)
+	}
+	ekPubHash, err := tpm.PubKeyHash(ekKey)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to hash endorsement key: %v", err)
+	}
+	spiffeID := tpm.SpiffeID(config.trustDomain, ekPubHash)
+
+	attestationDataBytes, err := json.Marshal(tpm.AttestationData{
+		EKCertificate: ekCertificate,
+		EKPublic:      ekPublicBytes,
+		AKPublic:      akPublicBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("tpm: unable to marshal attestation data: %v", err)
+	}
+
+	// send the attestation data back to the agent
+	if err := stream.Send(&nodeattestor.FetchAttestationDataResponse{
+		AttestationData: &common.AttestationData{
+			Type: tpm.PluginName,
+			Data: attestationDataBytes,
+		},
+		SpiffeId: spiffeID,
+	}); err != nil {
+		return err
+	}
+
+	// receive challenge
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	challenge := new(tpm.Challenge)
+	if err := json.Unmarshal(resp.Challenge, challenge); err != nil {
+		return fmt.Errorf("tpm: unable to unmarshal challenge: %v", err)
+	}
+
+	// prove that the attestation key lives in the same TPM as the
+	// endorsement key by activating the credential, then quote the PCRs
+	// with the attestation key.
+	secret, err := device.ActivateCredential(akHandle, ekHandle, challenge.CredentialBlob, challenge.EncryptedSecret)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to activate credential: %v", err)
+	}
+	pcrValues, err := device.ReadPCRs(config.PCRs)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to read PCRs: %v", err)
+	}
+	quote, quoteSignature, err := device.Quote(akHandle, challenge.Nonce, config.PCRs)
+	if err != nil {
+		return fmt.Errorf("tpm: unable to quote PCRs: %v", err)
+	}
+
+	responseBytes, err := json.Marshal(tpm.Response{
+		Secret:         secret,
+		Quote:          quote,
+		QuoteSignature: quoteSignature,
+		PCRValues:      pcrValues,
+	})
+	if err != nil {
+		return fmt.Errorf("tpm: unable to marshal challenge response: %v", err)
+	}
+
+	if err := stream.Send(&nodeattestor.FetchAttestationDataResponse{
+		SpiffeId: spiffeID,
+		Response: responseBytes,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *TPMPlugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	// Parse HCL config payload into config struct
+	config := new(TPMConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, fmt.Errorf("tpm: unable to decode configuration: %v", err)
+	}
+
+	if req.GlobalConfig == nil {
+		return nil, errors.New("tpm: global configuration is required")
+	}
+	if req.GlobalConfig.TrustDomain == "" {
+		return nil, errors.New("tpm: trust_domain is required")
+	}
+	config.trustDomain = req.GlobalConfig.TrustDomain
+
+	if config.DevicePath == "" {
+		config.DevicePath = defaultDevicePath
+	}
+	if config.PCRs == nil {
+		config.PCRs = defaultPCRs
+	}
+	if err := tpm.ValidatePCRs(config.PCRs); err != nil {
+		return nil, fmt.Errorf("tpm: %v", err)
+	}
+
+	// the endorsement key certificate is read from the TPM unless provided
+	if config.EKCertificatePath != "" {
+		certs, err := util.LoadCertificates(config.EKCertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("tpm: unable to load endorsement key certificate: %v", err)
+		}
+		if len(certs) != 1 {
+			return nil, fmt.Errorf("tpm: expected one endorsement key certificate; got %d", len(certs))
+		}
+		config.ekCertificate = certs[0].Raw
+	}
+
+	p.setConfig(config)
+
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (p *TPMPlugin) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return &plugin.GetPluginInfoResponse{}, nil
+}
+
+func (p *TPMPlugin) getConfig() *TPMConfig {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
+
+func (p *TPMPlugin) setConfig(c *TPMConfig) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+}
+
+func openDevice(path string) (io.ReadWriteCloser, error) {
+	return os.OpenFile(path, os.O_RDWR, 0)
+}
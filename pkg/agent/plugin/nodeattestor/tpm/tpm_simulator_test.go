@@ -0,0 +1,263 @@
+//go:build tpmsimulator
+// +build tpmsimulator
+
+package tpm
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/spiffe/spire/pkg/common/plugin/tpm"
+	"github.com/spiffe/spire/proto/agent/nodeattestor"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTPMWithSimulator(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+type Suite struct {
+	suite.Suite
+
+	dir        string
+	sim        *tpmsimulator.Simulator
+	devicePath string
+	p          *nodeattestor.BuiltIn
+}
+
+func (s *Suite) SetupTest() {
+	require := s.Require()
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "agent-tpm-test")
+	require.NoError(err)
+	s.sim, err = tpmsimulator.New()
+	require.NoError(err)
+
+	p := New()
+	p.hooks.openDevice = func(path string) (io.ReadWriteCloser, error) {
+		s.devicePath = path
+		// the plugin closes the device, which must not stop the simulator
+		return nopCloser{s.sim}, nil
+	}
+	s.p = nodeattestor.NewBuiltIn(p)
+	s.configure("")
+}
+
+func (s *Suite) TearDownTest() {
+	s.Require().NoError(s.sim.Close())
+	os.RemoveAll(s.dir)
+}
+
+func (s *Suite) configure(config string) {
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: config,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *Suite) TestFetchAttestationDataSuccess() {
+	require := s.Require()
+
+	require.NoError(s.sim.ExtendPCR(0, []byte("firmware")))
+	require.NoError(s.sim.ExtendPCR(7, []byte("secure boot")))
+
+	response := s.requireAttestationSucceeds()
+	require.Len(response.PCRValues, 8)
+	for pcr := 0; pcr < 8; pcr++ {
+		require.Equal(s.pcrValue(pcr), response.PCRValues[pcr])
+	}
+	require.Equal("/dev/tpmrm0", s.devicePath)
+	// every key and session was flushed
+	require.Equal(0, s.loadedHandles())
+}
+
+func (s *Suite) TestFetchAttestationDataWithCustomPCRs() {
+	// more PCRs than the TPM returns in a single PCR_Read
+	s.configure(`
+		device_path = "/dev/tpm0"
+		pcrs = [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 23]
+	`)
+	s.Require().NoError(s.sim.ExtendPCR(23, []byte("debug")))
+
+	response := s.requireAttestationSucceeds()
+	s.Require().Len(response.PCRValues, 11)
+	s.Require().Equal(s.pcrValue(23), response.PCRValues[23])
+	s.Require().Equal("/dev/tpm0", s.devicePath)
+}
+
+func (s *Suite) TestFetchAttestationDataWithEKCertificatePath() {
+	ekCertPath := filepath.Join(s.dir, "ek.pem")
+	s.Require().NoError(ioutil.WriteFile(ekCertPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.sim.EKCertificate(),
+	}), 0644))
+	s.configure(fmt.Sprintf("ek_certificate_path = %q", ekCertPath))
+
+	// the certificate isn't read from NV
+	s.Require().NoError(s.sim.RemoveEKCertificate())
+	s.requireAttestationSucceeds()
+}
+
+func (s *Suite) TestFetchAttestationDataFailure() {
+	require := s.Require()
+
+	challengeFails := func(challenge []byte, expected string) {
+		stream, done := s.fetchAttestationData()
+		defer done()
+
+		resp, err := stream.Recv()
+		require.NoError(err)
+		require.NotNil(resp)
+
+		require.NoError(stream.Send(&nodeattestor.FetchAttestationDataRequest{
+			Challenge: challenge,
+		}))
+
+		resp, err = stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	// malformed challenge
+	challengeFails(nil, "tpm: unable to unmarshal challenge")
+
+	// credential made for another key
+	ekPublic := s.ekPublic()
+	blob, encryptedSecret, err := tpm.MakeCredential(ekPublic, ekPublic, []byte("secret"))
+	require.NoError(err)
+	challengeFails(s.marshal(tpm.Challenge{
+		CredentialBlob:  blob,
+		EncryptedSecret: encryptedSecret,
+	}), "tpm: unable to activate credential")
+	require.Equal(0, s.loadedHandles())
+
+	// no EK certificate
+	require.NoError(s.sim.RemoveEKCertificate())
+	stream, done := s.fetchAttestationData()
+	defer done()
+	_, err = stream.Recv()
+	s.errorContains(err, "tpm: unable to read endorsement key certificate")
+}
+
+// requireAttestationSucceeds plays the part of the server plugin and
+// returns the verified challenge response.
+func (s *Suite) requireAttestationSucceeds() *tpm.Response {
+	require := s.Require()
+
+	stream, done := s.fetchAttestationData()
+	defer done()
+
+	ekPubHash, err := tpm.PubKeyHash(s.sim.EKPublicKey())
+	require.NoError(err)
+	spiffeID := "spiffe://example.org/spire/agent/tpm/" + ekPubHash
+
+	// first response has the spiffeid and attestation data
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.NotNil(resp)
+	require.Equal(spiffeID, resp.SpiffeId)
+	require.Equal("tpm", resp.AttestationData.Type)
+	require.Nil(resp.Response)
+
+	attestationData := new(tpm.AttestationData)
+	s.unmarshal(resp.AttestationData.Data, attestationData)
+	require.Equal(s.sim.EKCertificate(), attestationData.EKCertificate)
+	ekPublic, err := tpm2.DecodePublic(attestationData.EKPublic)
+	require.NoError(err)
+	akPublic, err := tpm2.DecodePublic(attestationData.AKPublic)
+	require.NoError(err)
+	require.NoError(tpm.VerifyAKPublic(akPublic))
+
+	// send a challenge
+	blob, encryptedSecret, err := tpm.MakeCredential(ekPublic, akPublic, []byte("secret"))
+	require.NoError(err)
+	nonce := []byte("nonce")
+	err = stream.Send(&nodeattestor.FetchAttestationDataRequest{
+		Challenge: s.marshal(tpm.Challenge{
+			CredentialBlob:  blob,
+			EncryptedSecret: encryptedSecret,
+			Nonce:           nonce,
+		}),
+	})
+	require.NoError(err)
+
+	// recv the response
+	resp, err = stream.Recv()
+	require.NoError(err)
+	require.Equal(spiffeID, resp.SpiffeId)
+	require.Nil(resp.AttestationData)
+	require.NotEmpty(resp.Response)
+
+	// verify the credential activation and the quote
+	response := new(tpm.Response)
+	s.unmarshal(resp.Response, response)
+	require.Equal([]byte("secret"), response.Secret)
+	require.NoError(tpm.VerifyQuote(akPublic, nonce, response))
+	return response
+}
+
+func (s *Suite) ekPublic() tpm2.Public {
+	device := tpm.NewDevice(s.sim)
+	ekHandle, ekPublicBytes, err := device.CreateEK()
+	s.Require().NoError(err)
+	s.Require().NoError(device.Flush(ekHandle))
+	ekPublic, err := tpm2.DecodePublic(ekPublicBytes)
+	s.Require().NoError(err)
+	return ekPublic
+}
+
+func (s *Suite) pcrValue(pcr int) []byte {
+	value, err := s.sim.PCRValue(pcr)
+	s.Require().NoError(err)
+	return value
+}
+
+func (s *Suite) loadedHandles() int {
+	loaded, err := s.sim.LoadedHandles()
+	s.Require().NoError(err)
+	return loaded
+}
+
+func (s *Suite) fetchAttestationData() (nodeattestor.FetchAttestationData_Stream, func()) {
+	stream, err := s.p.FetchAttestationData(context.Background())
+	s.Require().NoError(err)
+	return stream, func() {
+		s.Require().NoError(stream.CloseSend())
+	}
+}
+
+func (s *Suite) marshal(obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *Suite) unmarshal(data []byte, obj interface{}) {
+	s.Require().NoError(json.Unmarshal(data, obj))
+}
+
+func (s *Suite) errorContains(err error, substring string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), substring)
+}
+
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package tpm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/spiffe/spire/proto/agent/nodeattestor"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests that talk to a TPM run against the TPM simulator, which needs
+// cgo and OpenSSL 1.x. They are in tpm_simulator_test.go and only built with
+// the tpmsimulator build tag.
+
+func TestFetchAttestationDataWithoutDevice(t *testing.T) {
+	// not configured
+	stream, err := nodeattestor.NewBuiltIn(New()).FetchAttestationData(context.Background())
+	require.NoError(t, err)
+	defer stream.CloseSend()
+	resp, err := stream.Recv()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tpm: not configured")
+	require.Nil(t, resp)
+
+	// no device
+	p := New()
+	p.hooks.openDevice = func(string) (io.ReadWriteCloser, error) {
+		return nil, errors.New("no such file or directory")
+	}
+	_, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.NoError(t, err)
+	stream, err = nodeattestor.NewBuiltIn(p).FetchAttestationData(context.Background())
+	require.NoError(t, err)
+	defer stream.CloseSend()
+	_, err = stream.Recv()
+	require.EqualError(t, err, "tpm: unable to open device: no such file or directory")
+}
+
+func TestConfigure(t *testing.T) {
+	p := nodeattestor.NewBuiltIn(New())
+
+	// malformed
+	resp, err := p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `bad juju`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tpm: unable to decode configuration")
+	require.Nil(t, resp)
+
+	// missing global configuration
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{})
+	require.EqualError(t, err, "tpm: global configuration is required")
+	require.Nil(t, resp)
+
+	// missing trust_domain
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{},
+	})
+	require.EqualError(t, err, "tpm: trust_domain is required")
+	require.Nil(t, resp)
+
+	// invalid PCR
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `pcrs = [0, 24]`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.EqualError(t, err, "tpm: invalid PCR index 24")
+	require.Nil(t, resp)
+
+	// cannot load EK certificate
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `ek_certificate_path = "blah"`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tpm: unable to load endorsement key certificate")
+	require.Nil(t, resp)
+
+	// valid
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &plugin.ConfigureResponse{}, resp)
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	p := New()
+	resp, err := p.GetPluginInfo(context.Background(), &plugin.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, resp, &plugin.GetPluginInfoResponse{})
+}
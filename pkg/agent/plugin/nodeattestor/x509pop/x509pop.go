@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"text/template"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/plugin/x509pop"
@@ -31,9 +32,11 @@ type configData struct {
 
 type X509PoPConfig struct {
 	trustDomain       string
+	pathTemplate      *template.Template
 	PrivateKeyPath    string `hcl:"private_key_path"`
 	CertificatePath   string `hcl:"certificate_path"`
 	IntermediatesPath string `hcl:"intermediates_path"`
+	AgentPathTemplate string `hcl:"agent_path_template"`
 }
 
 type X509PoPPlugin struct {
@@ -115,6 +118,15 @@ func (p *X509PoPPlugin) Configure(ctx context.Context, req *plugin.ConfigureRequ
 		return nil, errors.New("x509pop: certificate_path is required")
 	}
 
+	config.pathTemplate = x509pop.DefaultAgentPathTemplate
+	if len(config.AgentPathTemplate) > 0 {
+		tmpl, err := template.New("agent-path").Parse(config.AgentPathTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("x509pop: failed to parse agent path template: %q", config.AgentPathTemplate)
+		}
+		config.pathTemplate = tmpl
+	}
+
 	// make sure the configuration produces valid data
 	if _, err := loadConfigData(config); err != nil {
 		return nil, err
@@ -181,8 +193,13 @@ func loadConfigData(config *X509PoPConfig) (*configData, error) {
 		return nil, fmt.Errorf("x509pop: unable to marshal attestation data: %v", err)
 	}
 
+	spiffeID, err := x509pop.MakeSpiffeID(config.trustDomain, config.pathTemplate, leaf)
+	if err != nil {
+		return nil, fmt.Errorf("x509pop: failed to create spiffe ID: %v", err)
+	}
+
 	return &configData{
-		spiffeID:   x509pop.SpiffeID(config.trustDomain, leaf),
+		spiffeID:   spiffeID.String(),
 		privateKey: certificate.PrivateKey,
 		attestationData: &common.AttestationData{
 			Type: pluginName,
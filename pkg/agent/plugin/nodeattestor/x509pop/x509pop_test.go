@@ -121,6 +121,29 @@ func (s *Suite) TestFetchAttestationDataSuccessWithIntermediates() {
 	s.TestFetchAttestationDataSuccess()
 }
 
+func (s *Suite) TestFetchAttestationDataWithAgentPathTemplate() {
+	require := s.Require()
+
+	leafKeyPath := fixture.Join("nodeattestor", "x509pop", "leaf-key.pem")
+	leafCertPath := fixture.Join("nodeattestor", "x509pop", "leaf-crt-bundle.pem")
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			private_key_path = %q
+			certificate_path = %q
+			agent_path_template = "{{ .PluginName }}/cn/{{ .Subject.CommonName }}"`, leafKeyPath, leafCertPath),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.NoError(err)
+	require.Equal(resp, &plugin.ConfigureResponse{})
+
+	stream, done := s.fetchAttestationData()
+	defer done()
+
+	fetchResp, err := stream.Recv()
+	require.NoError(err)
+	require.Equal("spiffe://example.org/spire/agent/x509pop/cn/some%20common%20name", fetchResp.SpiffeId)
+}
+
 func (s *Suite) TestFetchAttestationDataFailure() {
 	require := s.Require()
 
@@ -230,6 +253,17 @@ func (s *Suite) TestConfigure() {
 	})
 	s.errorContains(err, "x509pop: unable to load intermediate certificates")
 	require.Nil(resp)
+
+	// bad agent path template
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			private_key_path = %q
+			certificate_path = %q
+			agent_path_template = "{{ .Fingerprint "`, leafKeyPath, leafCertPath),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "spiffe://example.org"},
+	})
+	s.errorContains(err, "x509pop: failed to parse agent path template")
+	require.Nil(resp)
 }
 
 func (s *Suite) TestGetPluginInfo() {
@@ -0,0 +1,249 @@
+package cri
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/common/cgroups"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/common"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/cri"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	selectorType             = "cri"
+	defaultRuntimeSocketPath = "unix:///run/containerd/containerd.sock"
+	defaultRuntimeTimeout    = 2 * time.Second
+)
+
+type criPlugin struct {
+	conn    *grpc.ClientConn
+	runtime cri.RuntimeServiceClient
+	image   cri.ImageServiceClient
+	timeout time.Duration
+	fs      cgroups.FileSystem
+	mtx     *sync.RWMutex
+}
+
+type criPluginConfig struct {
+	// RuntimeSocketPath is the location of the CRI runtime socket (default:
+	// "unix:///run/containerd/containerd.sock").
+	RuntimeSocketPath string `hcl:"runtime_socket_path"`
+	// RuntimeTimeout is how long to wait for the CRI runtime to answer each
+	// request (default: "2s").
+	RuntimeTimeout string `hcl:"runtime_timeout"`
+}
+
+func (p *criPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if p.runtime == nil {
+		return nil, fmt.Errorf("workloadattestor/cri: not configured")
+	}
+
+	cgroupList, err := cgroups.GetCgroups(req.Pid, p.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	containerID, err := cgroups.GetContainerID(cgroupList)
+	if err != nil {
+		return nil, fmt.Errorf("workloadattestor/cri: %v", err)
+	}
+	if containerID == "" {
+		// Not a containerized workload. Since it is expected that
+		// non-containerized workloads will call the workload API, it is
+		// fine to return a response without any selectors.
+		return &workloadattestor.AttestResponse{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	listResp, err := p.runtime.ListContainers(ctx, &cri.ListContainersRequest{
+		Filter: &cri.ContainerFilter{Id: containerID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("workloadattestor/cri: unable to list container %q: %v", containerID, err)
+	}
+	if len(listResp.Containers) == 0 {
+		// The container is not managed by the CRI runtime (e.g. it belongs
+		// to another container runtime).
+		return &workloadattestor.AttestResponse{}, nil
+	}
+	container := listResp.Containers[0]
+
+	selectors := getContainerSelectors(container)
+
+	if imageSpec := container.Image; imageSpec != nil && imageSpec.Image != "" {
+		imageResp, err := p.image.ImageStatus(ctx, &cri.ImageStatusRequest{
+			Image: imageSpec,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("workloadattestor/cri: unable to get status of image %q: %v", imageSpec.Image, err)
+		}
+		// the image might have been removed since the container started
+		if imageResp.Image != nil {
+			selectors = append(selectors, getImageSelectors(imageResp.Image)...)
+		}
+	}
+
+	if container.PodSandboxId != "" {
+		podResp, err := p.runtime.PodSandboxStatus(ctx, &cri.PodSandboxStatusRequest{
+			PodSandboxId: container.PodSandboxId,
+		})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return nil, fmt.Errorf("workloadattestor/cri: pod sandbox %q of container %q not found", container.PodSandboxId, containerID)
+		case err != nil:
+			return nil, fmt.Errorf("workloadattestor/cri: unable to get status of pod sandbox %q: %v", container.PodSandboxId, err)
+		}
+		if podResp.Status != nil {
+			selectors = append(selectors, getPodSandboxSelectors(podResp.Status)...)
+		}
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func getContainerSelectors(container *cri.Container) []*common.Selector {
+	var selectors []*common.Selector
+	if container.Metadata != nil && container.Metadata.Name != "" {
+		selectors = append(selectors, makeSelector("container_name:%s", container.Metadata.Name))
+	}
+	if container.Image != nil && container.Image.Image != "" {
+		selectors = append(selectors, makeSelector("image:%s", container.Image.Image))
+	}
+	if container.ImageRef != "" {
+		selectors = append(selectors, makeSelector("image_id:%s", container.ImageRef))
+	}
+	for label, value := range container.Labels {
+		selectors = append(selectors, makeSelector("label:%s:%s", label, value))
+	}
+	for annotation, value := range container.Annotations {
+		selectors = append(selectors, makeSelector("annotation:%s:%s", annotation, value))
+	}
+	return selectors
+}
+
+func getImageSelectors(image *cri.Image) []*common.Selector {
+	var selectors []*common.Selector
+	digests := make(map[string]bool)
+	for _, repoDigest := range image.RepoDigests {
+		// repo digests look like "docker.io/library/nginx@sha256:..."
+		i := strings.LastIndex(repoDigest, "@")
+		if i < 0 {
+			continue
+		}
+		digest := repoDigest[i+1:]
+		if digest != "" && !digests[digest] {
+			digests[digest] = true
+			selectors = append(selectors, makeSelector("image_digest:%s", digest))
+		}
+	}
+	return selectors
+}
+
+func getPodSandboxSelectors(pod *cri.PodSandboxStatus) []*common.Selector {
+	var selectors []*common.Selector
+	if pod.Metadata != nil {
+		if pod.Metadata.Name != "" {
+			selectors = append(selectors, makeSelector("pod_name:%s", pod.Metadata.Name))
+		}
+		if pod.Metadata.Namespace != "" {
+			selectors = append(selectors, makeSelector("pod_namespace:%s", pod.Metadata.Namespace))
+		}
+		if pod.Metadata.Uid != "" {
+			selectors = append(selectors, makeSelector("pod_uid:%s", pod.Metadata.Uid))
+		}
+	}
+	for label, value := range pod.Labels {
+		selectors = append(selectors, makeSelector("pod_label:%s:%s", label, value))
+	}
+	for annotation, value := range pod.Annotations {
+		selectors = append(selectors, makeSelector("pod_annotation:%s:%s", annotation, value))
+	}
+	return selectors
+}
+
+func makeSelector(format string, args ...interface{}) *common.Selector {
+	return &common.Selector{
+		Type:  selectorType,
+		Value: fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *criPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := &criPluginConfig{}
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, err
+	}
+
+	if config.RuntimeSocketPath == "" {
+		config.RuntimeSocketPath = defaultRuntimeSocketPath
+	}
+	timeout := defaultRuntimeTimeout
+	if config.RuntimeTimeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(config.RuntimeTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("workloadattestor/cri: invalid runtime timeout %q: %v", config.RuntimeTimeout, err)
+		}
+	}
+
+	conn, err := dialRuntime(ctx, config.RuntimeSocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("workloadattestor/cri: unable to dial runtime: %v", err)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	p.conn = conn
+	p.runtime = cri.NewRuntimeServiceClient(conn)
+	p.image = cri.NewImageServiceClient(conn)
+	p.timeout = timeout
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*criPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+// dialRuntime connects to the CRI runtime listening on the Unix domain
+// socket. The connection is established lazily so the agent can start before
+// the runtime does.
+func dialRuntime(ctx context.Context, socketPath string) (*grpc.ClientConn, error) {
+	socketPath = strings.TrimPrefix(socketPath, "unix://")
+	if socketPath == "" {
+		return nil, fmt.Errorf("socket path is empty")
+	}
+	return grpc.DialContext(ctx, socketPath,
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+}
+
+func New() *criPlugin {
+	return &criPlugin{
+		mtx: &sync.RWMutex{},
+		fs:  cgroups.OSFileSystem{},
+	}
+}
@@ -0,0 +1,315 @@
+package cri
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/pkg/agent/common/cgroups"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/cri"
+	filesystem_mock "github.com/spiffe/spire/test/mock/common/filesystem"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testContainerID = "6469646e742065787065637420616e796f6e6520746f20726561642074686973"
+)
+
+func TestContainerIDFromCgroups(t *testing.T) {
+	tests := []struct {
+		desc          string
+		cgroupEntries string
+		expectedID    string
+	}{
+		{
+			desc:          "cgroupfs driver",
+			cgroupEntries: "10:devices:/kubepods/besteffort/pod2c48913c-b29f-11e7-9350-020968147796/" + testContainerID,
+			expectedID:    testContainerID,
+		},
+		{
+			desc:          "systemd driver",
+			cgroupEntries: "10:devices:/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2c48913c.slice/cri-containerd-" + testContainerID + ".scope",
+			expectedID:    testContainerID,
+		},
+		{
+			desc:          "systemd driver with colons",
+			cgroupEntries: "0::/system.slice/containerd.service/kubepods-besteffort-pod2c48913c.slice:cri-containerd:" + testContainerID,
+			expectedID:    testContainerID,
+		},
+		{
+			desc:          "second entry",
+			cgroupEntries: "11:hugetlb:/\n10:devices:/default/" + testContainerID,
+			expectedID:    testContainerID,
+		},
+		{
+			desc:          "not a container",
+			cgroupEntries: "10:devices:/user.slice",
+		},
+		{
+			desc:          "truncated id",
+			cgroupEntries: "10:devices:/default/6469646e7420657870656374",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, done := newTestPlugin(t, tt.cgroupEntries)
+			defer done()
+
+			cgroupList, err := getCgroups(p)
+			require.NoError(t, err)
+			containerID, err := cgroups.GetContainerID(cgroupList)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedID, containerID)
+		})
+	}
+}
+
+func TestAttest(t *testing.T) {
+	runtime := newFakeRuntime()
+	runtime.containers[testContainerID] = &cri.Container{
+		Id:           testContainerID,
+		PodSandboxId: "pod1",
+		Metadata:     &cri.ContainerMetadata{Name: "blog"},
+		Image:        &cri.ImageSpec{Image: "docker.io/library/nginx:1.15"},
+		ImageRef:     "sha256:e1a8b3b2d0a1",
+		Labels:       map[string]string{"io.kubernetes.container.name": "blog"},
+		Annotations:  map[string]string{"io.kubernetes.container.restartCount": "0"},
+	}
+	runtime.images["docker.io/library/nginx:1.15"] = &cri.Image{
+		Id:       "sha256:e1a8b3b2d0a1",
+		RepoTags: []string{"docker.io/library/nginx:1.15"},
+		RepoDigests: []string{
+			"docker.io/library/nginx@sha256:2b6e8b6b3e52",
+			"mirror.example.org/library/nginx@sha256:2b6e8b6b3e52",
+		},
+	}
+	runtime.pods["pod1"] = &cri.PodSandboxStatus{
+		Id: "pod1",
+		Metadata: &cri.PodSandboxMetadata{
+			Name:      "blog-1234",
+			Namespace: "prod",
+			Uid:       "2c48913c-b29f-11e7-9350-020968147796",
+		},
+		Labels:      map[string]string{"app": "blog"},
+		Annotations: map[string]string{"owner": "web"},
+	}
+
+	p, done := newConfiguredTestPlugin(t, runtime, "10:devices:/kubepods/besteffort/pod2c48913c/"+testContainerID)
+	defer done()
+
+	resp, err := p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"cri:annotation:io.kubernetes.container.restartCount:0",
+		"cri:container_name:blog",
+		"cri:image:docker.io/library/nginx:1.15",
+		"cri:image_digest:sha256:2b6e8b6b3e52",
+		"cri:image_id:sha256:e1a8b3b2d0a1",
+		"cri:label:io.kubernetes.container.name:blog",
+		"cri:pod_annotation:owner:web",
+		"cri:pod_label:app:blog",
+		"cri:pod_name:blog-1234",
+		"cri:pod_namespace:prod",
+		"cri:pod_uid:2c48913c-b29f-11e7-9350-020968147796",
+	}, selectorStrings(resp))
+}
+
+func TestAttestWithoutPodOrImage(t *testing.T) {
+	runtime := newFakeRuntime()
+	runtime.containers[testContainerID] = &cri.Container{
+		Id:       testContainerID,
+		Metadata: &cri.ContainerMetadata{Name: "standalone"},
+		Image:    &cri.ImageSpec{Image: "docker.io/library/busybox:latest"},
+	}
+
+	p, done := newConfiguredTestPlugin(t, runtime, "10:devices:/default/"+testContainerID)
+	defer done()
+
+	resp, err := p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"cri:container_name:standalone",
+		"cri:image:docker.io/library/busybox:latest",
+	}, selectorStrings(resp))
+}
+
+func TestAttestNonCRIWorkloads(t *testing.T) {
+	runtime := newFakeRuntime()
+
+	// not a container
+	p, done := newConfiguredTestPlugin(t, runtime, "10:devices:/user.slice")
+	defer done()
+	resp, err := p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Empty(t, resp.Selectors)
+
+	// a container unknown to the runtime
+	p, done = newConfiguredTestPlugin(t, runtime, "10:devices:/docker/"+testContainerID)
+	defer done()
+	resp, err = p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Empty(t, resp.Selectors)
+}
+
+func TestAttestFailures(t *testing.T) {
+	runtime := newFakeRuntime()
+	runtime.containers[testContainerID] = &cri.Container{
+		Id:           testContainerID,
+		PodSandboxId: "pod1",
+	}
+
+	p, done := newConfiguredTestPlugin(t, runtime, "10:devices:/default/"+testContainerID)
+	defer done()
+
+	// the pod sandbox is missing
+	_, err := p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.EqualError(t, err, `workloadattestor/cri: pod sandbox "pod1" of container "`+testContainerID+`" not found`)
+
+	// the runtime fails
+	runtime.err = status.Error(codes.Unavailable, "oh no")
+	p, done = newConfiguredTestPlugin(t, runtime, "10:devices:/default/"+testContainerID)
+	defer done()
+	_, err = p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.EqualError(t, err, `workloadattestor/cri: unable to list container "`+testContainerID+`": rpc error: code = Unavailable desc = oh no`)
+}
+
+func TestAttestNotConfigured(t *testing.T) {
+	p := New()
+	_, err := p.Attest(context.Background(), &workloadattestor.AttestRequest{Pid: 123})
+	require.EqualError(t, err, "workloadattestor/cri: not configured")
+}
+
+func TestConfigure(t *testing.T) {
+	p := New()
+
+	_, err := p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `runtime_timeout = "forever"`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `workloadattestor/cri: invalid runtime timeout "forever"`)
+
+	_, err = p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `runtime_socket_path = "unix://"`,
+	})
+	require.EqualError(t, err, "workloadattestor/cri: unable to dial runtime: socket path is empty")
+
+	_, err = p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+runtime_socket_path = "unix:///run/crio/crio.sock"
+runtime_timeout = "5s"
+`,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, p.runtime)
+	require.Equal(t, "/run/crio/crio.sock", p.conn.Target())
+	require.Equal(t, "5s", p.timeout.String())
+}
+
+type fakeRuntime struct {
+	containers map[string]*cri.Container
+	images     map[string]*cri.Image
+	pods       map[string]*cri.PodSandboxStatus
+	err        error
+}
+
+func newFakeRuntime() *fakeRuntime {
+	return &fakeRuntime{
+		containers: make(map[string]*cri.Container),
+		images:     make(map[string]*cri.Image),
+		pods:       make(map[string]*cri.PodSandboxStatus),
+	}
+}
+
+func (r *fakeRuntime) ListContainers(ctx context.Context, req *cri.ListContainersRequest) (*cri.ListContainersResponse, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	resp := new(cri.ListContainersResponse)
+	if container, ok := r.containers[req.Filter.GetId()]; ok {
+		resp.Containers = append(resp.Containers, container)
+	}
+	return resp, nil
+}
+
+func (r *fakeRuntime) PodSandboxStatus(ctx context.Context, req *cri.PodSandboxStatusRequest) (*cri.PodSandboxStatusResponse, error) {
+	pod, ok := r.pods[req.PodSandboxId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pod sandbox %q not found", req.PodSandboxId)
+	}
+	return &cri.PodSandboxStatusResponse{Status: pod}, nil
+}
+
+func (r *fakeRuntime) ImageStatus(ctx context.Context, req *cri.ImageStatusRequest) (*cri.ImageStatusResponse, error) {
+	return &cri.ImageStatusResponse{Image: r.images[req.Image.GetImage()]}, nil
+}
+
+func newTestPlugin(t *testing.T, cgroupEntries string) (*criPlugin, func()) {
+	dir, err := ioutil.TempDir("", "cri-test")
+	require.NoError(t, err)
+	cgroupPath := filepath.Join(dir, "cgroup")
+	require.NoError(t, ioutil.WriteFile(cgroupPath, []byte(cgroupEntries), 0644))
+
+	mockCtrl := gomock.NewController(t)
+	mockFS := filesystem_mock.NewMockfileSystem(mockCtrl)
+	mockFS.EXPECT().Open("/proc/123/cgroup").DoAndReturn(func(string) (*os.File, error) {
+		return os.Open(cgroupPath)
+	}).AnyTimes()
+
+	p := New()
+	p.fs = mockFS
+	return p, func() {
+		mockCtrl.Finish()
+		os.RemoveAll(dir)
+	}
+}
+
+func newConfiguredTestPlugin(t *testing.T, runtime *fakeRuntime, cgroupEntries string) (*criPlugin, func()) {
+	p, done := newTestPlugin(t, cgroupEntries)
+
+	dir, err := ioutil.TempDir("", "cri-test")
+	require.NoError(t, err)
+	socketPath := filepath.Join(dir, "runtime.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	cri.RegisterRuntimeServiceServer(server, runtime)
+	cri.RegisterImageServiceServer(server, runtime)
+	go server.Serve(listener)
+
+	_, err = p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `runtime_socket_path = "unix://` + socketPath + `"`,
+	})
+	require.NoError(t, err)
+
+	return p, func() {
+		p.conn.Close()
+		server.Stop()
+		os.RemoveAll(dir)
+		done()
+	}
+}
+
+func getCgroups(p *criPlugin) ([]cgroups.Cgroup, error) {
+	return cgroups.GetCgroups(123, p.fs)
+}
+
+func selectorStrings(resp *workloadattestor.AttestResponse) []string {
+	var out []string
+	for _, selector := range resp.Selectors {
+		out = append(out, selector.Type+":"+selector.Value)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -18,10 +18,14 @@ import (
 )
 
 const (
-	selectorType        = "docker"
-	subselectorLabel    = "label"
-	subselectorImageID  = "image_id"
-	defaultCgroupPrefix = "/docker"
+	selectorType             = "docker"
+	subselectorLabel         = "label"
+	subselectorImageID       = "image_id"
+	subselectorEnv           = "env"
+	subselectorRepoDigest    = "repo_digest"
+	subselectorContainerName = "container_name"
+	subselectorNetworkMode   = "network_mode"
+	defaultCgroupPrefix      = "/docker"
 )
 
 var defaultContainerIndex = 1
@@ -29,12 +33,14 @@ var defaultContainerIndex = 1
 // DockerClient is a subset of the docker client functionality, useful for mocking.
 type DockerClient interface {
 	ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	ImageInspectWithRaw(ctx context.Context, imageID string) (types.ImageInspect, []byte, error)
 }
 
 type dockerPlugin struct {
 	docker               DockerClient
 	cgroupPrefix         string
 	cgroupContainerIndex int
+	envAllowlist         map[string]bool
 	fs                   cgroups.FileSystem
 	mtx                  *sync.RWMutex
 }
@@ -44,11 +50,23 @@ type dockerPluginConfig struct {
 	DockerSocketPath string `hcl:"docker_socket_path"`
 	// DockerVersion is the API version of the docker daemon (default: "1.40").
 	DockerVersion string `hcl:"docker_version"`
-	// CgroupPrefix is the cgroup prefix to look for in the cgroup entries (default: "/docker").
+	// CgroupPrefix is the cgroup prefix to look for in the cgroup entries. If neither this nor
+	// CgroupContainerIndex are set, the container ID is found by matching the cgroup paths
+	// against the ones used by known container runtimes, which works with both cgroup v1 and v2.
 	CgroupPrefix string `hcl:"cgroup_prefix"`
-	// CgroupContainerIndex is the index within the cgroup path where the container ID should be found (default: 1).
+	// CgroupContainerIndex is the index within the cgroup path where the container ID should be found
+	// (default: 1 when CgroupPrefix is set).
 	// This is a *int to allow differentiation between the default int value (0) and the absence of the field.
 	CgroupContainerIndex *int `hcl:"cgroup_container_index"`
+	// EnvAllowlist is the list of environment variables of the container that are turned into selectors.
+	// Since environment variables commonly hold secrets, none are used by default.
+	EnvAllowlist []string `hcl:"env_allowlist"`
+	// DockerTLSCAPath, DockerTLSCertPath and DockerTLSKeyPath are the paths to the CA bundle, client
+	// certificate and client key used to reach a docker daemon listening on a TLS-protected TCP socket
+	// (e.g. "tcp://docker.example.org:2376").
+	DockerTLSCAPath   string `hcl:"docker_tls_ca_path"`
+	DockerTLSCertPath string `hcl:"docker_tls_cert_path"`
+	DockerTLSKeyPath  string `hcl:"docker_tls_key_path"`
 }
 
 func (p *dockerPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
@@ -61,6 +79,116 @@ func (p *dockerPlugin) Attest(ctx context.Context, req *workloadattestor.AttestR
 	}
 
 	var containerID string
+	if p.cgroupPrefix != "" {
+		containerID, err = p.getContainerIDFromPrefix(cgroupList)
+	} else {
+		containerID, err = cgroups.GetContainerID(cgroupList)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("workloadattestor/docker: %v", err)
+	}
+
+	// Not a docker workload. Since it is expected that non-docker workloads will call the
+	// workload API, it is fine to return a response without any selectors.
+	if containerID == "" {
+		return &workloadattestor.AttestResponse{}, nil
+	}
+
+	container, err := p.docker.ContainerInspect(ctx, containerID)
+	switch {
+	case err == nil:
+	case p.cgroupPrefix == "" && dockerclient.IsErrNotFound(err):
+		// The container ID was detected from the cgroups of a container
+		// managed by another runtime (e.g. containerd through the CRI).
+		return &workloadattestor.AttestResponse{}, nil
+	default:
+		return nil, err
+	}
+
+	selectors := getSelectorsFromConfig(container.Config)
+	selectors = append(selectors, p.getEnvSelectors(container.Config)...)
+	if container.ContainerJSONBase != nil {
+		selectors = append(selectors, getSelectorsFromContainer(container.ContainerJSONBase)...)
+
+		repoDigestSelectors, err := p.getRepoDigestSelectors(ctx, container.Image)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, repoDigestSelectors...)
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+// getEnvSelectors returns the selectors for the allowed environment variables
+// of the container. Variables look like "KEY=VALUE".
+func (p *dockerPlugin) getEnvSelectors(cfg *container.Config) []*common.Selector {
+	if cfg == nil || len(p.envAllowlist) == 0 {
+		return nil
+	}
+	var selectors []*common.Selector
+	for _, env := range cfg.Env {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) != 2 || !p.envAllowlist[parts[0]] {
+			continue
+		}
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("%s:%s:%s", subselectorEnv, parts[0], parts[1]),
+		})
+	}
+	return selectors
+}
+
+func getSelectorsFromContainer(base *types.ContainerJSONBase) []*common.Selector {
+	var selectors []*common.Selector
+	// container names are reported with a leading slash (e.g. "/blog")
+	if name := strings.TrimPrefix(base.Name, "/"); name != "" {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("%s:%s", subselectorContainerName, name),
+		})
+	}
+	if base.HostConfig != nil && base.HostConfig.NetworkMode != "" {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("%s:%s", subselectorNetworkMode, base.HostConfig.NetworkMode),
+		})
+	}
+	return selectors
+}
+
+// getRepoDigestSelectors returns the selectors for the repository digests of
+// the image of the container (e.g. "docker.io/library/nginx@sha256:...").
+func (p *dockerPlugin) getRepoDigestSelectors(ctx context.Context, imageID string) ([]*common.Selector, error) {
+	if imageID == "" {
+		return nil, nil
+	}
+	image, _, err := p.docker.ImageInspectWithRaw(ctx, imageID)
+	switch {
+	case err == nil:
+	case dockerclient.IsErrNotFound(err):
+		// the image might have been removed since the container started
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	var selectors []*common.Selector
+	for _, repoDigest := range image.RepoDigests {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("%s:%s", subselectorRepoDigest, repoDigest),
+		})
+	}
+	return selectors, nil
+}
+
+// getContainerIDFromPrefix returns the container ID found at the configured
+// index of the cgroup entries with the configured prefix.
+func (p *dockerPlugin) getContainerIDFromPrefix(cgroupList []cgroups.Cgroup) (string, error) {
 	var hasDockerEntries bool
 	for _, cgroup := range cgroupList {
 		// We are only interested in cgroup entries that match our desired prefix. Example entry:
@@ -76,27 +204,13 @@ func (p *dockerPlugin) Attest(ctx context.Context, req *workloadattestor.AttestR
 			log.Printf("Docker entry found, but is missing the container id: %v", cgroup.GroupPath)
 			continue
 		}
-		containerID = parts[p.cgroupContainerIndex+1]
-		break
+		return parts[p.cgroupContainerIndex+1], nil
 	}
 
-	// Not a docker workload. Since it is expected that non-docker workloads will call the
-	// workload API, it is fine to return a response without any selectors.
 	if !hasDockerEntries {
-		return &workloadattestor.AttestResponse{}, nil
-	}
-	if containerID == "" {
-		return nil, fmt.Errorf("workloadattestor/docker: no cgroup %q entries found at index %d", p.cgroupPrefix, p.cgroupContainerIndex)
-	}
-
-	container, err := p.docker.ContainerInspect(ctx, containerID)
-	if err != nil {
-		return nil, err
+		return "", nil
 	}
-
-	return &workloadattestor.AttestResponse{
-		Selectors: getSelectorsFromConfig(container.Config),
-	}, nil
+	return "", fmt.Errorf("no cgroup %q entries found at index %d", p.cgroupPrefix, p.cgroupContainerIndex)
 }
 
 func getSelectorsFromConfig(cfg *container.Config) []*common.Selector {
@@ -133,11 +247,20 @@ func (p *dockerPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	if config.DockerVersion != "" {
 		opts = append(opts, dockerclient.WithVersion(config.DockerVersion))
 	}
+	switch {
+	case config.DockerTLSCAPath == "" && config.DockerTLSCertPath == "" && config.DockerTLSKeyPath == "":
+	case config.DockerTLSCAPath == "" || config.DockerTLSCertPath == "" || config.DockerTLSKeyPath == "":
+		return nil, fmt.Errorf("workloadattestor/docker: docker_tls_ca_path, docker_tls_cert_path and docker_tls_key_path must be set together")
+	default:
+		opts = append(opts, dockerclient.WithTLSClientConfig(config.DockerTLSCAPath, config.DockerTLSCertPath, config.DockerTLSKeyPath))
+	}
 	p.docker, err = dockerclient.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
-	if config.CgroupPrefix == "" {
+	// the prefix and index are only needed to override the detection of
+	// the container ID.
+	if config.CgroupPrefix == "" && config.CgroupContainerIndex != nil {
 		config.CgroupPrefix = defaultCgroupPrefix
 	}
 	if config.CgroupContainerIndex == nil {
@@ -145,6 +268,10 @@ func (p *dockerPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	}
 	p.cgroupPrefix = config.CgroupPrefix
 	p.cgroupContainerIndex = *config.CgroupContainerIndex
+	p.envAllowlist = make(map[string]bool)
+	for _, env := range config.EnvAllowlist {
+		p.envAllowlist[env] = true
+	}
 
 	return &spi.ConfigureResponse{}, nil
 }
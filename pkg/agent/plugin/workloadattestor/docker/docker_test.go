@@ -17,6 +17,10 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const (
+	testContainerID = "6469646e742065787065637420616e796f6e6520746f20726561642074686973"
+)
+
 func TestDockerLabels(t *testing.T) {
 	tests := []struct {
 		desc                string
@@ -161,6 +165,186 @@ func TestDockerCgroupFormatErrors(t *testing.T) {
 	}
 }
 
+func TestDockerContainerSelectors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDocker := NewMockDockerClient(mockCtrl)
+	mockFS := filesystem_mock.NewMockfileSystem(mockCtrl)
+
+	p := New()
+	p.docker = mockDocker
+	p.fs = mockFS
+	p.envAllowlist = map[string]bool{"APP_ENV": true, "EMPTY": true}
+
+	cgroupFile, cleanup := newTestFile(t, "10:devices:/docker/"+testContainerID)
+	defer cleanup()
+	ctx := context.Background()
+	mockFS.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgroupFile))
+	mockDocker.EXPECT().ContainerInspect(ctx, testContainerID).Return(types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name:  "/blog",
+			Image: "sha256:e1a8b3b2d0a1",
+			HostConfig: &container.HostConfig{
+				NetworkMode: "host",
+			},
+		},
+		Config: &container.Config{
+			Image: "nginx:1.15",
+			Env:   []string{"APP_ENV=prod", "DB_PASSWORD=secret", "EMPTY=", "MALFORMED"},
+		},
+	}, nil)
+	mockDocker.EXPECT().ImageInspectWithRaw(ctx, "sha256:e1a8b3b2d0a1").Return(types.ImageInspect{
+		RepoDigests: []string{
+			"docker.io/library/nginx@sha256:2b6e8b6b3e52",
+			"mirror.example.org/library/nginx@sha256:2b6e8b6b3e52",
+		},
+	}, nil, nil)
+
+	res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"image_id:nginx:1.15",
+		"env:APP_ENV:prod",
+		"env:EMPTY:",
+		"container_name:blog",
+		"network_mode:host",
+		"repo_digest:docker.io/library/nginx@sha256:2b6e8b6b3e52",
+		"repo_digest:mirror.example.org/library/nginx@sha256:2b6e8b6b3e52",
+	}, selectorValues(res))
+}
+
+func TestDockerImageNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDocker := NewMockDockerClient(mockCtrl)
+	mockFS := filesystem_mock.NewMockfileSystem(mockCtrl)
+
+	p := New()
+	p.docker = mockDocker
+	p.fs = mockFS
+
+	cgroupFile, cleanup := newTestFile(t, "10:devices:/docker/"+testContainerID)
+	defer cleanup()
+	ctx := context.Background()
+	mockFS.EXPECT().Open("/proc/123/cgroup").DoAndReturn(func(string) (*os.File, error) {
+		return os.Open(cgroupFile)
+	}).Times(2)
+	mockDocker.EXPECT().ContainerInspect(ctx, testContainerID).Return(types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			Name:  "/blog",
+			Image: "sha256:e1a8b3b2d0a1",
+		},
+		Config: &container.Config{},
+	}, nil).Times(2)
+
+	// the image was removed since the container started
+	mockDocker.EXPECT().ImageInspectWithRaw(ctx, "sha256:e1a8b3b2d0a1").Return(types.ImageInspect{}, nil, notFoundError{})
+	res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.Equal(t, []string{"container_name:blog"}, selectorValues(res))
+
+	// the daemon fails
+	mockDocker.EXPECT().ImageInspectWithRaw(ctx, "sha256:e1a8b3b2d0a1").Return(types.ImageInspect{}, nil, errors.New("docker error"))
+	_, err = p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+	require.EqualError(t, err, "docker error")
+}
+
+func TestDockerContainerIDDetection(t *testing.T) {
+	tests := []struct {
+		desc              string
+		mockCgroupEntries string
+		expectContainerID string
+		expectErr         string
+	}{
+		{
+			desc:              "cgroup v1 with cgroupfs driver",
+			mockCgroupEntries: "10:devices:/docker/" + testContainerID,
+			expectContainerID: testContainerID,
+		},
+		{
+			desc:              "cgroup v2 with systemd driver",
+			mockCgroupEntries: "0::/system.slice/docker-" + testContainerID + ".scope",
+			expectContainerID: testContainerID,
+		},
+		{
+			desc:              "kubepods",
+			mockCgroupEntries: "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2c48913c.slice/docker-" + testContainerID + ".scope",
+			expectContainerID: testContainerID,
+		},
+		{
+			desc:              "not a container",
+			mockCgroupEntries: "0::/user.slice/user-1000.slice/session-2.scope",
+		},
+		{
+			desc:              "conflicting container ids",
+			mockCgroupEntries: "10:devices:/docker/" + testContainerID + "\n9:pids:/docker/9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961",
+			expectErr:         "workloadattestor/docker: multiple container IDs found in cgroups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockDocker := NewMockDockerClient(mockCtrl)
+			mockFS := filesystem_mock.NewMockfileSystem(mockCtrl)
+
+			p := New()
+			p.docker = mockDocker
+			p.fs = mockFS
+
+			cgroupFile, cleanup := newTestFile(t, tt.mockCgroupEntries)
+			defer cleanup()
+			ctx := context.Background()
+			mockFS.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgroupFile))
+			if tt.expectContainerID != "" {
+				mockDocker.EXPECT().ContainerInspect(ctx, tt.expectContainerID).Return(types.ContainerJSON{
+					Config: &container.Config{Image: "my-docker-image"},
+				}, nil)
+			}
+
+			res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectErr)
+				require.Nil(t, res)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, res)
+			if tt.expectContainerID == "" {
+				require.Len(t, res.Selectors, 0)
+				return
+			}
+			require.Len(t, res.Selectors, 1)
+			require.Equal(t, "image_id:my-docker-image", res.Selectors[0].Value)
+		})
+	}
+}
+
+func TestDockerContainerNotFound(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockDocker := NewMockDockerClient(mockCtrl)
+	mockFS := filesystem_mock.NewMockfileSystem(mockCtrl)
+
+	p := New()
+	p.docker = mockDocker
+	p.fs = mockFS
+
+	// a container managed by another runtime
+	cgroupFile, cleanup := newTestFile(t, "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2c48913c.slice/cri-containerd-"+testContainerID+".scope")
+	defer cleanup()
+	ctx := context.Background()
+	mockFS.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgroupFile))
+	mockDocker.EXPECT().ContainerInspect(ctx, testContainerID).Return(types.ContainerJSON{}, notFoundError{})
+
+	res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Len(t, res.Selectors, 0)
+}
+
 func TestCgroupFileNotFound(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -187,11 +371,11 @@ func TestDockerError(t *testing.T) {
 	p.docker = mockDocker
 	p.fs = mockFS
 
-	cgroupFile, cleanup := newTestFile(t, "1:foo:/bar")
+	cgroupFile, cleanup := newTestFile(t, "10:devices:/docker/"+testContainerID)
 	defer cleanup()
 	ctx := context.Background()
 	mockFS.EXPECT().Open("/proc/123/cgroup").Return(os.Open(cgroupFile))
-	mockDocker.EXPECT().ContainerInspect(ctx, "bar").Return(types.ContainerJSON{}, errors.New("docker error"))
+	mockDocker.EXPECT().ContainerInspect(ctx, testContainerID).Return(types.ContainerJSON{}, errors.New("docker error"))
 
 	res, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
 	require.Error(t, err)
@@ -219,6 +403,38 @@ cgroup_container_index = 8
 	require.Equal(t, 8, p.cgroupContainerIndex)
 }
 
+func TestDockerConfigEnvAllowlist(t *testing.T) {
+	p := New()
+	cfg := &spi.ConfigureRequest{
+		Configuration: `env_allowlist = ["APP_ENV", "REGION"]`,
+	}
+	_, err := p.Configure(context.Background(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"APP_ENV": true, "REGION": true}, p.envAllowlist)
+}
+
+func TestDockerConfigTLS(t *testing.T) {
+	p := New()
+	_, err := p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+docker_socket_path = "tcp://docker.example.org:2376"
+docker_tls_ca_path = "ca.pem"
+`,
+	})
+	require.EqualError(t, err, "workloadattestor/docker: docker_tls_ca_path, docker_tls_cert_path and docker_tls_key_path must be set together")
+
+	_, err = p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: `
+docker_socket_path = "tcp://docker.example.org:2376"
+docker_tls_ca_path = "/no/such/ca.pem"
+docker_tls_cert_path = "/no/such/cert.pem"
+docker_tls_key_path = "/no/such/key.pem"
+`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to create tls config")
+}
+
 func TestDockerConfigDefault(t *testing.T) {
 	p := New()
 	cfg := &spi.ConfigureRequest{}
@@ -228,6 +444,30 @@ func TestDockerConfigDefault(t *testing.T) {
 	require.NotNil(t, p.docker)
 	require.Equal(t, dockerclient.DefaultDockerHost, p.docker.(*dockerclient.Client).DaemonHost())
 	require.Equal(t, "1.40", p.docker.(*dockerclient.Client).ClientVersion())
+	require.Equal(t, "", p.cgroupPrefix)
+}
+
+func TestDockerConfigContainerIndexOnly(t *testing.T) {
+	p := New()
+	cfg := &spi.ConfigureRequest{
+		Configuration: `cgroup_container_index = 2`,
+	}
+	_, err := p.Configure(context.Background(), cfg)
+	require.NoError(t, err)
 	require.Equal(t, "/docker", p.cgroupPrefix)
-	require.Equal(t, 1, p.cgroupContainerIndex)
+	require.Equal(t, 2, p.cgroupContainerIndex)
+}
+
+func selectorValues(res *workloadattestor.AttestResponse) []string {
+	var values []string
+	for _, selector := range res.Selectors {
+		values = append(values, selector.Value)
+	}
+	return values
 }
+
+type notFoundError struct{}
+
+func (notFoundError) NotFound() bool { return true }
+
+func (notFoundError) Error() string { return "Error: No such container" }
@@ -2,17 +2,22 @@ package k8s
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/agent/common/cgroups"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
 	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
@@ -22,6 +27,11 @@ import (
 const (
 	defaultMaxPollAttempts   = 5
 	defaultPollRetryInterval = time.Millisecond * 300
+	defaultPodListCacheTTL   = time.Second * 10
+	defaultKubeletSecurePort = 10250
+	defaultKubeletCAPath     = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	defaultTokenPath         = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	defaultNodeNameEnv       = "MY_NODE_NAME"
 )
 
 type containerLookup int
@@ -36,25 +46,66 @@ var k8sErr = errs.Class("k8s")
 
 type k8sPlugin struct {
 	kubeletReadOnlyPort int
+	kubeletURL          string
+	tokenPath           string
 	maxPollAttempts     int
 	pollRetryInterval   time.Duration
+	podListCacheTTL     time.Duration
 	httpClient          httpClient
 	fs                  cgroups.FileSystem
+	clock               clock.Clock
 	mtx                 *sync.RWMutex
+
+	// podList is the cached list of pods, fetched at podListTime. Each
+	// fetch increments podListGen.
+	podListMtx  sync.Mutex
+	podList     *podList
+	podListTime time.Time
+	podListGen  uint64
 }
 
 type k8sPluginConfig struct {
-	KubeletReadOnlyPort int    `hcl:"kubelet_read_only_port"`
-	MaxPollAttempts     int    `hcl:"max_poll_attempts"`
-	PollRetryInterval   string `hcl:"poll_retry_interval"`
+	// KubeletReadOnlyPort is the port of the kubelet read-only API. If set,
+	// the secure kubelet API is not used.
+	KubeletReadOnlyPort int `hcl:"kubelet_read_only_port"`
+	// KubeletSecurePort is the port of the authenticated kubelet API
+	// (default: 10250).
+	KubeletSecurePort int `hcl:"kubelet_secure_port"`
+	// KubeletCAPath is the path to the CA bundle used to verify the kubelet
+	// serving certificate (default: the service account CA bundle).
+	KubeletCAPath string `hcl:"kubelet_ca_path"`
+	// SkipKubeletVerification disables the verification of the kubelet
+	// serving certificate.
+	SkipKubeletVerification bool `hcl:"skip_kubelet_verification"`
+	// TokenPath is the path to the bearer token used to authenticate with the
+	// kubelet when no client certificate is configured (default: the service
+	// account token).
+	TokenPath string `hcl:"token_path"`
+	// CertificatePath and PrivateKeyPath are the paths to the client
+	// certificate and key used to authenticate with the kubelet.
+	CertificatePath string `hcl:"certificate_path"`
+	PrivateKeyPath  string `hcl:"private_key_path"`
+	// NodeName is the name of the node, which the kubelet serving certificate
+	// is verified against. If unset, it is read from the environment
+	// variable named by NodeNameEnv (default: "MY_NODE_NAME").
+	NodeName    string `hcl:"node_name"`
+	NodeNameEnv string `hcl:"node_name_env"`
+
+	MaxPollAttempts   int    `hcl:"max_poll_attempts"`
+	PollRetryInterval string `hcl:"poll_retry_interval"`
+	// PodListCacheTTL is how long the list of pods fetched from the kubelet
+	// is reused across attestations (default: "10s").
+	PodListCacheTTL string `hcl:"pod_list_cache_ttl"`
 }
 
 type podInfo struct {
 	// We only care about namespace, serviceAccountName and containerID
 	Metadata struct {
 		UID             string            `json:"uid"`
+		Name            string            `json:"name"`
 		Namespace       string            `json:"namespace"`
 		Labels          map[string]string `json:"labels"`
+		Annotations     map[string]string `json:"annotations"`
 		OwnerReferences []struct {
 			Kind string `json:"kind"`
 			UID  string `json:"uid"`
@@ -80,10 +131,12 @@ type podList struct {
 type containerStatus struct {
 	Name        string `json:"name"`
 	Image       string `json:"image"`
+	ImageID     string `json:"imageID"`
 	ContainerID string `json:"containerID"`
 }
 
 type podStatus struct {
+	HostIP                string            `json:"hostIP"`
 	InitContainerStatuses []containerStatus `json:"initContainerStatuses"`
 	ContainerStatuses     []containerStatus `json:"containerStatuses"`
 }
@@ -96,45 +149,32 @@ func (p *k8sPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequ
 	p.mtx.RLock()
 	defer p.mtx.RUnlock()
 
-	cgroups, err := cgroups.GetCgroups(req.Pid, p.fs)
+	cgroupList, err := cgroups.GetCgroups(req.Pid, p.fs)
 	if err != nil {
 		return nil, k8sErr.Wrap(err)
 	}
 
-	var containerID string
-	for _, cgroup := range cgroups {
-		// We are only interested in kube pods entries. Example entry:
-		// 11:hugetlb:/kubepods/burstable/pod2c48913c-b29f-11e7-9350-020968147796/9bca8d63d5fa610783847915bcff0ecac1273e5b4bed3f6fa1b07350e0135961
-		if len(cgroup.GroupPath) < 9 {
-			continue
-		}
-
-		substring := cgroup.GroupPath[:9]
-		if substring == "/kubepods" {
-			parts := strings.Split(cgroup.GroupPath, "/")
-
-			if len(parts) < 5 {
-				log.Printf("Kube pod entry found, but without container id: %v", substring)
-				continue
-			}
-			containerID = parts[4]
-			break
-		}
+	containerID, err := cgroups.GetContainerID(cgroupList)
+	if err != nil {
+		return nil, k8sErr.Wrap(err)
 	}
 
 	// Not a Kubernetes pod
-	if containerID == "" {
+	if containerID == "" || !isPodCgroup(cgroupList) {
 		return &workloadattestor.AttestResponse{}, nil
 	}
 
 	// Poll pod information and search for the pod with the container. If
 	// the pod is not found, and there are pods with containers that aren't
 	// fully initialized, delay for a little bit and try again.
-	for attempt := 1; ; attempt++ {
-		list, err := p.getPodListFromInsecureKubeletPort()
+	var minPodListGen uint64
+	for attempt := 1; ; {
+		list, listGen, cached, err := p.getPodList(minPodListGen)
 		if err != nil {
 			return nil, k8sErr.Wrap(err)
 		}
+		// any further lookup needs a list fetched after this one
+		minPodListGen = listGen
 
 		notAllContainersReady := false
 		for _, item := range list.Items {
@@ -150,6 +190,12 @@ func (p *k8sPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequ
 			}
 		}
 
+		// the cached list might predate the pod of the workload, so the
+		// search is repeated right away on a fresh list.
+		if cached {
+			continue
+		}
+
 		// if the container was not located and there were no pods with
 		// uninitialized containers, then the search is over.
 		if !notAllContainersReady || attempt >= p.maxPollAttempts {
@@ -165,11 +211,51 @@ func (p *k8sPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequ
 		case <-ctx.Done():
 			return nil, k8sErr.New("no selectors found: %v", ctx.Err())
 		}
+		attempt++
+	}
+}
+
+// getPodList returns the cached pod list if it is newer than the generation
+// minGen and has not expired, or fetches a new one from the kubelet otherwise.
+// Since a single fetch serves every concurrent caller, bursts of attestations
+// do not translate into bursts of requests to the kubelet. The returned flag
+// is true if the list was served from the cache without any requirement on
+// its generation (i.e. minGen is zero), in which case it might predate the
+// caller.
+func (p *k8sPlugin) getPodList(minGen uint64) (*podList, uint64, bool, error) {
+	p.podListMtx.Lock()
+	defer p.podListMtx.Unlock()
+
+	now := p.clock.Now()
+	if p.podList != nil && p.podListGen > minGen && now.Sub(p.podListTime) < p.podListCacheTTL {
+		return p.podList, p.podListGen, minGen == 0, nil
+	}
+
+	list, err := p.fetchPodList()
+	if err != nil {
+		return nil, 0, false, err
 	}
+	p.podList = list
+	p.podListTime = now
+	p.podListGen++
+	return list, p.podListGen, false, nil
 }
 
-func (p *k8sPlugin) getPodListFromInsecureKubeletPort() (out *podList, err error) {
-	httpResp, err := p.httpClient.Get(fmt.Sprintf("http://localhost:%d/pods", p.kubeletReadOnlyPort))
+func (p *k8sPlugin) fetchPodList() (out *podList, err error) {
+	req, err := http.NewRequest("GET", p.kubeletURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.tokenPath != "" {
+		// the token is read on every request since it can be rotated
+		token, err := ioutil.ReadFile(p.tokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpResp, err := p.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +273,20 @@ func (p *k8sPlugin) getPodListFromInsecureKubeletPort() (out *podList, err error
 	return out, nil
 }
 
+// isPodCgroup returns true if any of the cgroups is in the hierarchy the
+// kubelet creates for pods (e.g. "/kubepods/burstable/pod<uid>/<id>" or
+// "/kubepods.slice/kubepods-burstable.slice/...").
+func isPodCgroup(cgroupList []cgroups.Cgroup) bool {
+	for _, cgroup := range cgroupList {
+		for _, element := range strings.Split(cgroup.GroupPath, "/") {
+			if strings.HasPrefix(element, "kubepods") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func lookUpContainerInPod(containerID string, status podStatus) (*containerStatus, containerLookup) {
 	notReady := false
 	for _, status := range status.ContainerStatuses {
@@ -240,13 +340,28 @@ func getSelectorsFromPodInfo(info *podInfo, status *containerStatus) []*common.S
 		makeSelector("ns:%s", info.Metadata.Namespace),
 		makeSelector("node-name:%s", info.Spec.NodeName),
 		makeSelector("pod-uid:%s", info.Metadata.UID),
+		makeSelector("pod-name:%s", info.Metadata.Name),
 		makeSelector("container-name:%s", status.Name),
 		makeSelector("container-image:%s", status.Image),
+		makeSelector("container-init:%t", isInitContainer(info.Status, status.Name)),
 	}
 
+	if info.Status.HostIP != "" {
+		selectors = append(selectors, makeSelector("node-ip:%s", info.Status.HostIP))
+	}
+	if status.ImageID != "" {
+		selectors = append(selectors, makeSelector("container-image-id:%s", status.ImageID))
+		// image IDs look like "docker-pullable://localhost/spiffe/blog@sha256:..."
+		if i := strings.LastIndex(status.ImageID, "@"); i >= 0 && i < len(status.ImageID)-1 {
+			selectors = append(selectors, makeSelector("container-image-digest:%s", status.ImageID[i+1:]))
+		}
+	}
 	for k, v := range info.Metadata.Labels {
 		selectors = append(selectors, makeSelector("pod-label:%s:%s", k, v))
 	}
+	for k, v := range info.Metadata.Annotations {
+		selectors = append(selectors, makeSelector("pod-annotation:%s:%s", k, v))
+	}
 	for _, ownerReference := range info.Metadata.OwnerReferences {
 		selectors = append(selectors, makeSelector("pod-owner:%s:%s", ownerReference.Kind, ownerReference.Name))
 		selectors = append(selectors, makeSelector("pod-owner-uid:%s:%s", ownerReference.Kind, ownerReference.UID))
@@ -255,6 +370,18 @@ func getSelectorsFromPodInfo(info *podInfo, status *containerStatus) []*common.S
 	return selectors
 }
 
+// isInitContainer returns true if the container is an init container of the
+// pod. Container names are unique across the init and regular containers of a
+// pod.
+func isInitContainer(status podStatus, name string) bool {
+	for _, initStatus := range status.InitContainerStatuses {
+		if initStatus.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func makeSelector(format string, args ...interface{}) *common.Selector {
 	return &common.Selector{
 		Type:  selectorType,
@@ -289,13 +416,110 @@ func (p *k8sPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*
 		pollRetryInterval = defaultPollRetryInterval
 	}
 
+	var podListCacheTTL time.Duration
+	if config.PodListCacheTTL != "" {
+		podListCacheTTL, err = time.ParseDuration(config.PodListCacheTTL)
+		if err != nil {
+			return nil, k8sErr.Wrap(err)
+		}
+	}
+	if podListCacheTTL <= 0 {
+		podListCacheTTL = defaultPodListCacheTTL
+	}
+
+	var kubeletURL, tokenPath string
+	switch {
+	case config.KubeletReadOnlyPort != 0 && config.KubeletSecurePort != 0:
+		return nil, k8sErr.New("kubelet_read_only_port and kubelet_secure_port are mutually exclusive")
+	case config.KubeletReadOnlyPort != 0:
+		kubeletURL = fmt.Sprintf("http://localhost:%d/pods", config.KubeletReadOnlyPort)
+	default:
+		if config.KubeletSecurePort == 0 {
+			config.KubeletSecurePort = defaultKubeletSecurePort
+		}
+		kubeletURL = fmt.Sprintf("https://127.0.0.1:%d/pods", config.KubeletSecurePort)
+
+		tlsConfig, err := makeKubeletTLSConfig(config)
+		if err != nil {
+			return nil, k8sErr.Wrap(err)
+		}
+		if len(tlsConfig.Certificates) == 0 {
+			tokenPath = config.TokenPath
+			if tokenPath == "" {
+				tokenPath = defaultTokenPath
+			}
+		}
+		p.httpClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		}
+	}
+
 	// Set local vars from config struct
 	p.kubeletReadOnlyPort = config.KubeletReadOnlyPort
+	p.kubeletURL = kubeletURL
+	p.tokenPath = tokenPath
 	p.pollRetryInterval = pollRetryInterval
 	p.maxPollAttempts = config.MaxPollAttempts
+	p.podListCacheTTL = podListCacheTTL
+
+	// the cached pod list might come from another kubelet endpoint
+	p.podListMtx.Lock()
+	p.podList = nil
+	p.podListMtx.Unlock()
+
 	return &spi.ConfigureResponse{}, nil
 }
 
+// makeKubeletTLSConfig returns the TLS configuration used to reach the secure
+// kubelet port.
+func makeKubeletTLSConfig(config *k8sPluginConfig) (*tls.Config, error) {
+	tlsConfig := new(tls.Config)
+
+	if config.SkipKubeletVerification {
+		tlsConfig.InsecureSkipVerify = true
+	} else {
+		caPath := config.KubeletCAPath
+		if caPath == "" {
+			caPath = defaultKubeletCAPath
+		}
+		rootCAs, err := util.LoadCertPool(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kubelet CA: %v", err)
+		}
+		tlsConfig.RootCAs = rootCAs
+
+		// the kubelet is reached through the loopback interface, but its
+		// serving certificate is issued for the node name.
+		nodeName := config.NodeName
+		if nodeName == "" {
+			nodeNameEnv := config.NodeNameEnv
+			if nodeNameEnv == "" {
+				nodeNameEnv = defaultNodeNameEnv
+			}
+			nodeName = os.Getenv(nodeNameEnv)
+		}
+		if nodeName == "" {
+			return nil, fmt.Errorf("unable to determine the node name to verify the kubelet certificate; set node_name or skip_kubelet_verification")
+		}
+		tlsConfig.ServerName = nodeName
+	}
+
+	switch {
+	case config.CertificatePath != "" && config.PrivateKeyPath != "":
+		cert, err := tls.LoadX509KeyPair(config.CertificatePath, config.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case config.CertificatePath != "" || config.PrivateKeyPath != "":
+		return nil, fmt.Errorf("certificate_path and private_key_path must be set together")
+	}
+
+	return tlsConfig, nil
+}
+
 func (*k8sPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
 	return &spi.GetPluginInfoResponse{}, nil
 }
@@ -305,5 +529,6 @@ func New() *k8sPlugin {
 		mtx:        &sync.RWMutex{},
 		httpClient: &http.Client{},
 		fs:         cgroups.OSFileSystem{},
+		clock:      clock.New(),
 	}
 }
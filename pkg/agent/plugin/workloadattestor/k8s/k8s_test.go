@@ -3,13 +3,20 @@ package k8s
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/andres-erbsen/clock"
 	mock "github.com/golang/mock/gomock"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
@@ -26,9 +33,12 @@ const (
 	invalidConfig             = `{"kubelet_read_only_port":"invalid"}`
 	podListFilePath           = "../../../../../test/fixture/workloadattestor/k8s/pod_list.json"
 	podListNotRunningFilePath = "../../../../../test/fixture/workloadattestor/k8s/pod_list_not_running.json"
+	podListEmptyFilePath      = "../../../../../test/fixture/workloadattestor/k8s/pod_list_empty.json"
 	cgPidInPodFilePath        = "../../../../../test/fixture/workloadattestor/k8s/cgroups_pid_in_pod.txt"
 	cgInitPidInPodFilePath    = "../../../../../test/fixture/workloadattestor/k8s/cgroups_init_pid_in_pod.txt"
 	cgPidNotInPodFilePath     = "../../../../../test/fixture/workloadattestor/k8s/cgroups_pid_not_in_pod.txt"
+	cgPidInPodV2FilePath      = "../../../../../test/fixture/workloadattestor/k8s/cgroups_pid_in_pod_v2.txt"
+	cgPidInContainerFilePath  = "../../../../../test/fixture/workloadattestor/k8s/cgroups_pid_in_container_not_in_pod.txt"
 )
 
 var (
@@ -44,19 +54,24 @@ type K8sAttestorSuite struct {
 	suite.Suite
 
 	ctrl       *mock.Controller
+	dir        string
 	p          workloadattestor.Plugin
 	fs         *filesystem_mock.MockfileSystem
 	httpClient *http_client_mock.MockhttpClient
+	clock      *clock.Mock
 }
 
 func (s *K8sAttestorSuite) SetupTest() {
 	s.ctrl = mock.NewController(s.T())
+	s.dir = ""
 	s.fs = filesystem_mock.NewMockfileSystem(s.ctrl)
 	s.httpClient = http_client_mock.NewMockhttpClient(s.ctrl)
+	s.clock = clock.NewMock()
 
 	p := New()
 	p.fs = s.fs
 	p.httpClient = s.httpClient
+	p.clock = s.clock
 
 	s.p = workloadattestor.NewBuiltIn(p)
 	s.configure(time.Millisecond)
@@ -64,6 +79,9 @@ func (s *K8sAttestorSuite) SetupTest() {
 
 func (s *K8sAttestorSuite) TearDownTest() {
 	s.ctrl.Finish()
+	if s.dir != "" {
+		os.RemoveAll(s.dir)
+	}
 }
 
 func (s *K8sAttestorSuite) configure(pollRetryInterval time.Duration) {
@@ -86,7 +104,7 @@ func (s *K8sAttestorSuite) addPodListResponse(fixturePath string) {
 	s.Require().NoError(err)
 
 	podsURL := fmt.Sprintf("http://localhost:%d/pods", kubeletReadOnlyPort)
-	s.httpClient.EXPECT().Get(podsURL).Return(&http.Response{
+	s.httpClient.EXPECT().Do(requestURLMatcher(podsURL)).Return(&http.Response{
 		StatusCode: http.StatusOK,
 		Body:       ioutil.NopCloser(bytes.NewReader(podList)),
 	}, nil)
@@ -107,6 +125,17 @@ func (s *K8sAttestorSuite) TestAttestWithPidInPod() {
 	s.Require().NotEmpty(resp.Selectors)
 }
 
+func (s *K8sAttestorSuite) TestAttestWithPidInPodOnCgroupV2() {
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodV2FilePath)
+
+	resp, err := s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Selectors)
+}
+
 func (s *K8sAttestorSuite) TestAttestWithInitPidInPod() {
 	s.addPodListResponse(podListFilePath)
 	s.addCgroupsResponse(cgInitPidInPodFilePath)
@@ -116,6 +145,9 @@ func (s *K8sAttestorSuite) TestAttestWithInitPidInPod() {
 	})
 	s.Require().NoError(err)
 	s.Require().NotEmpty(resp.Selectors)
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "k8s", Value: "container-name:install-cni"})
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "k8s", Value: "container-init:true"})
+	s.Require().Contains(resp.Selectors, &common.Selector{Type: "k8s", Value: "container-image-digest:sha256:1b401bf0c30bada9a539389c3be652b58fe38463361edf488e6543c8761d4970"})
 }
 
 func (s *K8sAttestorSuite) TestAttestWithPidInPodAfterRetry() {
@@ -132,12 +164,20 @@ func (s *K8sAttestorSuite) TestAttestWithPidInPodAfterRetry() {
 	// assert the selectors (sorting for consistency)
 	util.SortSelectors(resp.Selectors)
 	s.Require().Equal([]*common.Selector{
+		{Type: "k8s", Value: "container-image-digest:sha256:0cfdaced91cb46dd7af48309799a3c351e4ca2d5e1ee9737ca0cbd932cb79898"},
+		{Type: "k8s", Value: "container-image-id:docker-pullable://localhost/spiffe/blog@sha256:0cfdaced91cb46dd7af48309799a3c351e4ca2d5e1ee9737ca0cbd932cb79898"},
 		{Type: "k8s", Value: "container-image:localhost/spiffe/blog:latest"},
+		{Type: "k8s", Value: "container-init:false"},
 		{Type: "k8s", Value: "container-name:blog"},
+		{Type: "k8s", Value: "node-ip:10.90.0.100"},
 		{Type: "k8s", Value: "node-name:k8s-node-1"},
 		{Type: "k8s", Value: "ns:default"},
+		{Type: "k8s", Value: "pod-annotation:kubernetes.io/config.seen:2017-10-16T23:24:09.173356571Z"},
+		{Type: "k8s", Value: "pod-annotation:kubernetes.io/config.source:api"},
+		{Type: "k8s", Value: "pod-annotation:kubernetes.io/created-by:{\"kind\":\"SerializedReference\",\"apiVersion\":\"v1\",\"reference\":{\"kind\":\"ReplicationController\",\"namespace\":\"default\",\"name\":\"blog\",\"uid\":\"2c401175-b29f-11e7-9350-020968147796\",\"apiVersion\":\"v1\",\"resourceVersion\":\"1406\"}}\n"},
 		{Type: "k8s", Value: "pod-label:k8s-app:blog"},
 		{Type: "k8s", Value: "pod-label:version:v0"},
+		{Type: "k8s", Value: "pod-name:blog-24ck7"},
 		{Type: "k8s", Value: "pod-owner-uid:ReplicationController:2c401175-b29f-11e7-9350-020968147796"},
 		{Type: "k8s", Value: "pod-owner:ReplicationController:blog"},
 		{Type: "k8s", Value: "pod-uid:2c48913c-b29f-11e7-9350-020968147796"},
@@ -186,6 +226,182 @@ func (s *K8sAttestorSuite) TestAttestWithPidNotInPod() {
 	s.Require().Empty(resp.Selectors)
 }
 
+func (s *K8sAttestorSuite) TestAttestWithPidInContainerNotInPod() {
+	s.addCgroupsResponse(cgPidInContainerFilePath)
+
+	resp, err := s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Selectors)
+}
+
+func (s *K8sAttestorSuite) TestAttestUsesPodListCache() {
+	// the pod list is fetched on the first attestation
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestSelectors()
+
+	// and served from the cache on the next ones
+	s.addCgroupsResponse(cgInitPidInPodFilePath)
+	s.requireAttestSelectors()
+
+	// until it expires
+	s.clock.Add(defaultPodListCacheTTL)
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestSelectors()
+}
+
+func (s *K8sAttestorSuite) TestAttestRefreshesPodListCacheOnMiss() {
+	// the workload pod is not in the list yet
+	s.addPodListResponse(podListEmptyFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	_, err := s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().EqualError(err, "k8s: no selectors found")
+
+	// the cached list is refreshed when the pod is not found in it
+	s.addPodListResponse(podListFilePath)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestSelectors()
+}
+
+func (s *K8sAttestorSuite) TestAttestWithSecurePortAndToken() {
+	dir := s.tempDir()
+	tokenPath := filepath.Join(dir, "token")
+	s.Require().NoError(ioutil.WriteFile(tokenPath, []byte("TOKEN\n"), 0600))
+
+	podList, err := ioutil.ReadFile(podListFilePath)
+	s.Require().NoError(err)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/pods" || req.Header.Get("Authorization") != "Bearer TOKEN" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write(podList)
+	}))
+	defer server.Close()
+
+	s.p = s.newSecurePortPlugin(server, fmt.Sprintf(`
+		token_path = %q
+		node_name = "example.com"
+	`, tokenPath))
+
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestSelectors()
+
+	// the token is reloaded for each request
+	s.Require().NoError(ioutil.WriteFile(tokenPath, []byte("ROTATED"), 0600))
+	s.clock.Add(defaultPodListCacheTTL)
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	_, err = s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().EqualError(err, "k8s: unexpected status code: 401")
+}
+
+func (s *K8sAttestorSuite) TestAttestWithSecurePortAndClientCertificate() {
+	podList, err := ioutil.ReadFile(podListFilePath)
+	s.Require().NoError(err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if len(req.TLS.PeerCertificates) == 0 || req.Header.Get("Authorization") != "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Write(podList)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// the server certificate doubles as the client certificate
+	dir := s.tempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	cert := server.TLS.Certificates[0]
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	s.Require().NoError(err)
+	s.Require().NoError(ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600))
+	s.Require().NoError(ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	s.p = s.newSecurePortPlugin(server, fmt.Sprintf(`
+		certificate_path = %q
+		private_key_path = %q
+		node_name = "example.com"
+	`, certPath, keyPath))
+
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	s.requireAttestSelectors()
+}
+
+func (s *K8sAttestorSuite) TestAttestWithSecurePortVerifiesNodeName() {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	s.p = s.newSecurePortPlugin(server, `
+		node_name = "not-the-node"
+		token_path = "/dev/null"
+	`)
+
+	s.addCgroupsResponse(cgPidInPodFilePath)
+	_, err := s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "x509: certificate is valid for")
+	s.Require().Contains(err.Error(), "not not-the-node")
+}
+
+func (s *K8sAttestorSuite) TestConfigureSecurePort() {
+	dir := s.tempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	server.Close()
+	s.Require().NoError(ioutil.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	configure := func(config string) (*k8sPlugin, error) {
+		p := New()
+		_, err := p.Configure(context.Background(), &spi.ConfigureRequest{
+			Configuration: config,
+		})
+		return p, err
+	}
+
+	// the secure port is used by default
+	p, err := configure(fmt.Sprintf(`kubelet_ca_path = %q
+		node_name = "example.com"`, caPath))
+	s.Require().NoError(err)
+	s.Equal("https://127.0.0.1:10250/pods", p.kubeletURL)
+	s.Equal(defaultTokenPath, p.tokenPath)
+
+	// the node name can be read from the environment
+	os.Setenv("TEST_NODE_NAME", "example.com")
+	defer os.Unsetenv("TEST_NODE_NAME")
+	p, err = configure(fmt.Sprintf(`kubelet_secure_port = 1234
+		kubelet_ca_path = %q
+		node_name_env = "TEST_NODE_NAME"`, caPath))
+	s.Require().NoError(err)
+	s.Equal("https://127.0.0.1:1234/pods", p.kubeletURL)
+
+	_, err = configure(fmt.Sprintf(`kubelet_ca_path = %q
+		node_name_env = "TEST_MISSING_NODE_NAME"`, caPath))
+	s.Require().EqualError(err, "k8s: unable to determine the node name to verify the kubelet certificate; set node_name or skip_kubelet_verification")
+
+	_, err = configure(`kubelet_ca_path = "/no/such/ca.pem"`)
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "k8s: unable to load kubelet CA")
+
+	_, err = configure(`skip_kubelet_verification = true
+		certificate_path = "cert.pem"`)
+	s.Require().EqualError(err, "k8s: certificate_path and private_key_path must be set together")
+
+	_, err = configure(`kubelet_read_only_port = 10255
+		kubelet_secure_port = 10250`)
+	s.Require().EqualError(err, "k8s: kubelet_read_only_port and kubelet_secure_port are mutually exclusive")
+}
+
 func (s *K8sAttestorSuite) TestConfigureValidConfig() {
 	p := New()
 	resp, err := p.Configure(context.Background(), &spi.ConfigureRequest{
@@ -196,6 +412,8 @@ func (s *K8sAttestorSuite) TestConfigureValidConfig() {
 	s.Equal(p.kubeletReadOnlyPort, 1)
 	s.Equal(p.maxPollAttempts, 2)
 	s.Equal(p.pollRetryInterval, 3*time.Second)
+	s.Equal(p.podListCacheTTL, defaultPodListCacheTTL)
+	s.Equal("", p.tokenPath)
 }
 
 func (s *K8sAttestorSuite) TestConfigureInvalidConfig() {
@@ -213,3 +431,53 @@ func (s *K8sAttestorSuite) TestGetPluginInfo() {
 	s.NoError(err)
 	s.Equal(&spi.GetPluginInfoResponse{}, resp)
 }
+
+func (s *K8sAttestorSuite) requireAttestSelectors() {
+	resp, err := s.p.Attest(context.Background(), &workloadattestor.AttestRequest{
+		Pid: int32(pid),
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Selectors)
+}
+
+func (s *K8sAttestorSuite) newSecurePortPlugin(server *httptest.Server, config string) workloadattestor.Plugin {
+	serverURL, err := url.Parse(server.URL)
+	s.Require().NoError(err)
+
+	caPath := filepath.Join(s.tempDir(), "ca.pem")
+	s.Require().NoError(ioutil.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	p := New()
+	p.fs = s.fs
+	p.clock = s.clock
+	_, err = p.Configure(context.Background(), &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			kubelet_secure_port = %s
+			kubelet_ca_path = %q
+		`, serverURL.Port(), caPath) + config,
+	})
+	s.Require().NoError(err)
+	return workloadattestor.NewBuiltIn(p)
+}
+
+// tempDir returns a directory for the files of the test, which is removed
+// after the test.
+func (s *K8sAttestorSuite) tempDir() string {
+	if s.dir == "" {
+		dir, err := ioutil.TempDir("", "k8s-workload-attestor-test-")
+		s.Require().NoError(err)
+		s.dir = dir
+	}
+	return s.dir
+}
+
+type requestURLMatcher string
+
+func (m requestURLMatcher) Matches(x interface{}) bool {
+	req, ok := x.(*http.Request)
+	return ok && req.URL.String() == string(m)
+}
+
+func (m requestURLMatcher) String() string {
+	return fmt.Sprintf("is a request for %s", string(m))
+}
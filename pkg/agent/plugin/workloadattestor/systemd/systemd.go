@@ -0,0 +1,165 @@
+package systemd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/agent/common/cgroups"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/common"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/zeebo/errs"
+)
+
+const (
+	selectorType = "systemd"
+)
+
+var (
+	systemdErr = errs.Class("systemd")
+
+	// unitSuffixes are the suffixes of the unit types that can contain
+	// processes. Slices only group other units, so they are not included.
+	unitSuffixes = []string{".service", ".scope"}
+)
+
+type Configuration struct{}
+
+type SystemdPlugin struct {
+	// hooks for tests
+	hooks struct {
+		getCgroups      func(pid int32) ([]cgroups.Cgroup, error)
+		getFragmentPath func(ctx context.Context, unit string) (string, error)
+	}
+}
+
+func New() *SystemdPlugin {
+	p := &SystemdPlugin{}
+	p.hooks.getCgroups = func(pid int32) ([]cgroups.Cgroup, error) {
+		return cgroups.GetCgroups(pid, cgroups.OSFileSystem{})
+	}
+	p.hooks.getFragmentPath = getFragmentPath
+	return p
+}
+
+func (p *SystemdPlugin) Attest(ctx context.Context, req *workloadattestor.AttestRequest) (*workloadattestor.AttestResponse, error) {
+	cgroupList, err := p.hooks.getCgroups(req.Pid)
+	if err != nil {
+		return nil, systemdErr.New("cgroups lookup: %v", err)
+	}
+
+	unit := getUnitFromCgroups(cgroupList)
+	if unit == "" {
+		// The workload does not belong to a systemd unit (e.g. systemd is not
+		// the init system). It is fine to return a response without any
+		// selectors.
+		return &workloadattestor.AttestResponse{}, nil
+	}
+
+	fragmentPath, err := p.hooks.getFragmentPath(ctx, unit)
+	if err != nil {
+		return nil, systemdErr.New("fragment path lookup: %v", err)
+	}
+
+	selectors := []*common.Selector{
+		makeSelector("id", unit),
+	}
+	// transient units (e.g. scopes) are not loaded from a unit file
+	if fragmentPath != "" {
+		selectors = append(selectors, makeSelector("fragment_path", fragmentPath))
+	}
+
+	return &workloadattestor.AttestResponse{
+		Selectors: selectors,
+	}, nil
+}
+
+func (p *SystemdPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(Configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, systemdErr.Wrap(err)
+	}
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *SystemdPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+// getUnitFromCgroups returns the unit the process belongs to, based on its
+// membership in the cgroup hierarchy managed by systemd. That is the
+// "name=systemd" hierarchy on cgroup v1 hosts and the unified hierarchy
+// otherwise.
+func getUnitFromCgroups(cgroupList []cgroups.Cgroup) string {
+	var unifiedPath string
+	for _, cgroup := range cgroupList {
+		switch {
+		case cgroup.ControllerList == "name=systemd":
+			return getUnitFromCgroupPath(cgroup.GroupPath)
+		case cgroup.IsUnified():
+			unifiedPath = cgroup.GroupPath
+		}
+	}
+	if unifiedPath == "" {
+		return ""
+	}
+	return getUnitFromCgroupPath(unifiedPath)
+}
+
+// getUnitFromCgroupPath returns the first unit in the cgroup path that is not
+// a slice. For example, the unit of "/system.slice/nginx.service" is
+// "nginx.service" and the unit of a process started by a user's service
+// manager, e.g. "/user.slice/user-1000.slice/user@1000.service/app.service",
+// is "user@1000.service".
+func getUnitFromCgroupPath(path string) string {
+	for _, name := range strings.Split(path, "/") {
+		if name == "" || strings.HasSuffix(name, ".slice") {
+			continue
+		}
+		for _, suffix := range unitSuffixes {
+			if len(name) > len(suffix) && strings.HasSuffix(name, suffix) {
+				return name
+			}
+		}
+		return ""
+	}
+	return ""
+}
+
+// getFragmentPath asks systemd for the path of the unit file the unit was
+// loaded from.
+func getFragmentPath(ctx context.Context, unit string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "systemctl", "show", "--property=FragmentPath", "--", unit)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("systemctl show %q: %v: %s", unit, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseFragmentPath(stdout.Bytes())
+}
+
+func parseFragmentPath(output []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if value := strings.TrimPrefix(scanner.Text(), "FragmentPath="); value != scanner.Text() {
+			return value, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("FragmentPath property missing from systemctl output")
+}
+
+func makeSelector(kind, value string) *common.Selector {
+	return &common.Selector{
+		Type:  selectorType,
+		Value: fmt.Sprintf("%s:%s", kind, value),
+	}
+}
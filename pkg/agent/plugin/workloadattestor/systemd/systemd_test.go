@@ -0,0 +1,163 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/agent/common/cgroups"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestAttest(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cgroups   []cgroups.Cgroup
+		err       string
+		selectors []string
+	}{
+		{
+			name: "service on cgroup v1",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "11", ControllerList: "hugetlb", GroupPath: "/"},
+				{HierarchyID: "1", ControllerList: "name=systemd", GroupPath: "/system.slice/nginx.service"},
+			},
+			selectors: []string{
+				"id:nginx.service",
+				"fragment_path:/lib/systemd/system/nginx.service",
+			},
+		},
+		{
+			name: "service on cgroup v2",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "0", ControllerList: "", GroupPath: "/system.slice/nginx.service"},
+			},
+			selectors: []string{
+				"id:nginx.service",
+				"fragment_path:/lib/systemd/system/nginx.service",
+			},
+		},
+		{
+			name: "service with delegated subgroups",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "0", ControllerList: "", GroupPath: "/system.slice/nginx.service/workers"},
+			},
+			selectors: []string{
+				"id:nginx.service",
+				"fragment_path:/lib/systemd/system/nginx.service",
+			},
+		},
+		{
+			name: "transient scope",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "0", ControllerList: "", GroupPath: "/user.slice/user-1000.slice/session-3.scope"},
+			},
+			selectors: []string{
+				"id:session-3.scope",
+			},
+		},
+		{
+			name: "not in a unit",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "10", ControllerList: "devices", GroupPath: "/user.slice"},
+			},
+		},
+		{
+			name: "fail to get fragment path",
+			cgroups: []cgroups.Cgroup{
+				{HierarchyID: "0", ControllerList: "", GroupPath: "/system.slice/broken.service"},
+			},
+			err: "systemd: fragment path lookup: oh no",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p := New()
+			p.hooks.getCgroups = func(pid int32) ([]cgroups.Cgroup, error) {
+				require.Equal(t, int32(123), pid)
+				return testCase.cgroups, nil
+			}
+			p.hooks.getFragmentPath = fakeGetFragmentPath
+
+			resp, err := p.Attest(ctx, &workloadattestor.AttestRequest{
+				Pid: 123,
+			})
+			if testCase.err != "" {
+				require.EqualError(t, err, testCase.err)
+				require.Nil(t, resp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			var selectors []string
+			for _, selector := range resp.Selectors {
+				require.Equal(t, "systemd", selector.Type)
+				selectors = append(selectors, selector.Value)
+			}
+			require.Equal(t, testCase.selectors, selectors)
+		})
+	}
+}
+
+func TestAttestFailsToGetCgroups(t *testing.T) {
+	p := New()
+	p.hooks.getCgroups = func(pid int32) ([]cgroups.Cgroup, error) {
+		return nil, errors.New("no such process")
+	}
+
+	_, err := p.Attest(ctx, &workloadattestor.AttestRequest{Pid: 123})
+	require.EqualError(t, err, "systemd: cgroups lookup: no such process")
+}
+
+func TestGetUnitFromCgroupPath(t *testing.T) {
+	require.Equal(t, "nginx.service", getUnitFromCgroupPath("/system.slice/nginx.service"))
+	require.Equal(t, "user@1000.service", getUnitFromCgroupPath("/user.slice/user-1000.slice/user@1000.service/app.slice/app.service"))
+	require.Equal(t, "init.scope", getUnitFromCgroupPath("/init.scope"))
+	require.Equal(t, "", getUnitFromCgroupPath("/"))
+	require.Equal(t, "", getUnitFromCgroupPath("/system.slice"))
+	require.Equal(t, "", getUnitFromCgroupPath("/docker/6469646e74"))
+}
+
+func TestParseFragmentPath(t *testing.T) {
+	path, err := parseFragmentPath([]byte("FragmentPath=/etc/systemd/system/app.service\n"))
+	require.NoError(t, err)
+	require.Equal(t, "/etc/systemd/system/app.service", path)
+
+	path, err = parseFragmentPath([]byte("FragmentPath=\n"))
+	require.NoError(t, err)
+	require.Equal(t, "", path)
+
+	_, err = parseFragmentPath([]byte("Id=app.service\n"))
+	require.EqualError(t, err, "FragmentPath property missing from systemctl output")
+}
+
+func TestConfigure(t *testing.T) {
+	resp, err := New().Configure(ctx, &spi.ConfigureRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.ConfigureResponse{}, resp)
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	resp, err := New().GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &spi.GetPluginInfoResponse{}, resp)
+}
+
+func fakeGetFragmentPath(ctx context.Context, unit string) (string, error) {
+	switch unit {
+	case "nginx.service":
+		return "/lib/systemd/system/nginx.service", nil
+	case "broken.service":
+		return "", errors.New("oh no")
+	default:
+		return "", nil
+	}
+}
@@ -1,6 +1,7 @@
 package unix
 
 import (
+	"bufio"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -8,6 +9,9 @@ import (
 	"io"
 	"os"
 	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/hcl"
@@ -29,6 +33,9 @@ var (
 type processInfo interface {
 	Uids() ([]int32, error)
 	Gids() ([]int32, error)
+	Groups() ([]int32, error)
+	Name() (string, error)
+	Ppid() (int32, error)
 	Exe() (string, error)
 }
 
@@ -46,14 +53,18 @@ type UnixPlugin struct {
 		newProcess      func(pid int32) (processInfo, error)
 		lookupUserById  func(id string) (*user.User, error)
 		lookupGroupById func(id string) (*user.Group, error)
+		hostPath        func(pid int32, path string) (string, error)
+		procRoot        func(pid int32) string
 	}
 }
 
 func New() *UnixPlugin {
 	p := &UnixPlugin{}
-	p.hooks.newProcess = func(pid int32) (processInfo, error) { return process.NewProcess(pid) }
+	p.hooks.newProcess = newOSProcess
 	p.hooks.lookupUserById = user.LookupId
 	p.hooks.lookupGroupById = user.LookupGroupId
+	p.hooks.hostPath = hostPath
+	p.hooks.procRoot = procRoot
 	return p
 }
 
@@ -88,20 +99,46 @@ func (p *UnixPlugin) Attest(ctx context.Context, req *workloadattestor.AttestReq
 		return nil, err
 	}
 
+	supplementaryGids, err := p.getSupplementaryGids(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.getName(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	ppid, err := p.getPpid(proc)
+	if err != nil {
+		return nil, err
+	}
+
 	// obtaining the workload process path and digest are behind a config flag
 	// since it requires the agent to have permissions that might not be
 	// available.
 	var processPath string
 	var sha256Digest string
+	var parentPath string
 	if config.DiscoverWorkloadPath {
-		processPath, err = p.getPath(proc)
+		workloadPath, err := p.getPath(proc)
 		if err != nil {
 			return nil, err
 		}
-		sha256Digest, err = getSHA256Digest(processPath, config.WorkloadSizeLimit)
+		// the path is relative to the root of the workload, which is not
+		// the root of the agent if the workload runs in a container. If the
+		// binary is not visible to the agent, the path is left out but the
+		// binary can still be read through the root of the workload.
+		digestPath := filepath.Join(p.hooks.procRoot(req.Pid), workloadPath)
+		if path, err := p.hooks.hostPath(req.Pid, workloadPath); err == nil {
+			processPath = path
+			digestPath = path
+		}
+		sha256Digest, err = getSHA256Digest(digestPath, config.WorkloadSizeLimit)
 		if err != nil {
 			return nil, err
 		}
+		parentPath = p.getParentPath(ppid)
 	}
 
 	selectors := []*common.Selector{
@@ -110,12 +147,28 @@ func (p *UnixPlugin) Attest(ctx context.Context, req *workloadattestor.AttestReq
 		makeSelector("gid", gid),
 		makeSelector("group", group),
 	}
+	for _, supplementaryGid := range supplementaryGids {
+		selectors = append(selectors, makeSelector("supplementary_gid", supplementaryGid))
+		// supplementary groups are not required to have a name on the host
+		// (e.g. groups only known inside of a container), so a missing name
+		// is not an error.
+		if supplementaryGroup, err := p.getGroupName(supplementaryGid); err == nil {
+			selectors = append(selectors, makeSelector("supplementary_group", supplementaryGroup))
+		}
+	}
+	selectors = append(selectors,
+		makeSelector("name", name),
+		makeSelector("ppid", fmt.Sprint(ppid)),
+	)
 	if processPath != "" {
 		selectors = append(selectors, makeSelector("path", processPath))
 	}
 	if sha256Digest != "" {
 		selectors = append(selectors, makeSelector("sha256", sha256Digest))
 	}
+	if parentPath != "" {
+		selectors = append(selectors, makeSelector("parent_path", parentPath))
+	}
 
 	return &workloadattestor.AttestResponse{
 		Selectors: selectors,
@@ -199,6 +252,60 @@ func (p *UnixPlugin) getGroupName(gid string) (string, error) {
 	return g.Name, nil
 }
 
+func (p *UnixPlugin) getSupplementaryGids(proc processInfo) ([]string, error) {
+	groups, err := proc.Groups()
+	if err != nil {
+		return nil, unixErr.New("supplementary GIDs lookup: %v", err)
+	}
+
+	var gids []string
+	for _, group := range groups {
+		gids = append(gids, fmt.Sprint(group))
+	}
+	return gids, nil
+}
+
+func (p *UnixPlugin) getName(proc processInfo) (string, error) {
+	name, err := proc.Name()
+	if err != nil {
+		return "", unixErr.New("name lookup: %v", err)
+	}
+	return name, nil
+}
+
+func (p *UnixPlugin) getPpid(proc processInfo) (int32, error) {
+	ppid, err := proc.Ppid()
+	if err != nil {
+		return 0, unixErr.New("PPID lookup: %v", err)
+	}
+	return ppid, nil
+}
+
+// getParentPath returns the host-visible path of the binary of the parent
+// process, or an empty string if it cannot be determined, e.g. because the
+// parent has already exited. Leaving the selector out only makes entries
+// that require it not match, so it does not fail the attestation.
+func (p *UnixPlugin) getParentPath(ppid int32) string {
+	// processes without a parent (e.g. the init process) have a PPID of 0
+	if ppid == 0 {
+		return ""
+	}
+
+	parent, err := p.hooks.newProcess(ppid)
+	if err != nil {
+		return ""
+	}
+	path, err := parent.Exe()
+	if err != nil {
+		return ""
+	}
+	path, err = p.hooks.hostPath(ppid, path)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
 func (p *UnixPlugin) getPath(proc processInfo) (string, error) {
 	path, err := proc.Exe()
 	if err != nil {
@@ -232,6 +339,202 @@ func getSHA256Digest(path string, limit int64) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
+// osProcess extends the gopsutil process with the lookup of the supplementary
+// groups, which gopsutil does not provide.
+type osProcess struct {
+	*process.Process
+}
+
+func newOSProcess(pid int32) (processInfo, error) {
+	proc, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, err
+	}
+	return osProcess{Process: proc}, nil
+}
+
+func (p osProcess) Groups() ([]int32, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/status", p.Pid))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "Groups:" {
+			continue
+		}
+		var groups []int32
+		for _, field := range fields[1:] {
+			group, err := strconv.ParseInt(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid group %q: %v", field, err)
+			}
+			groups = append(groups, int32(group))
+		}
+		return groups, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("no groups in status of PID %d", p.Pid)
+}
+
+// hostPath returns the path through which the agent can reach the file that
+// the process with the given PID sees at the given path. Processes in another
+// mount namespace than the agent (e.g. processes in containers) see another
+// file system hierarchy, so the path is translated through the mounts of both
+// namespaces. Returns an error if the file is not visible to the agent.
+func hostPath(pid int32, path string) (string, error) {
+	agentNS, err := os.Readlink("/proc/self/ns/mnt")
+	if err != nil {
+		return "", err
+	}
+	procNS, err := os.Readlink(fmt.Sprintf("/proc/%d/ns/mnt", pid))
+	if err != nil {
+		return "", err
+	}
+	if agentNS == procNS {
+		return path, nil
+	}
+
+	procMounts, err := readMountInfo(fmt.Sprintf("/proc/%d/mountinfo", pid))
+	if err != nil {
+		return "", err
+	}
+	agentMounts, err := readMountInfo("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+	translated, ok := translatePath(procMounts, agentMounts, path)
+	if !ok {
+		return "", fmt.Errorf("%s is not visible to the agent", path)
+	}
+
+	// mounts on the agent side might hide the file at the translated path
+	translatedInfo, err := os.Stat(translated)
+	if err != nil {
+		return "", err
+	}
+	procInfo, err := os.Stat(filepath.Join(procRoot(pid), path))
+	if err != nil {
+		return "", err
+	}
+	if !os.SameFile(translatedInfo, procInfo) {
+		return "", fmt.Errorf("%s is not visible to the agent", path)
+	}
+	return translated, nil
+}
+
+// procRoot returns the path through which the agent can reach the root of
+// the file system hierarchy of the process with the given PID.
+func procRoot(pid int32) string {
+	return fmt.Sprintf("/proc/%d/root", pid)
+}
+
+// mountInfo is a mount as listed in /proc/<pid>/mountinfo.
+type mountInfo struct {
+	// device is the major:minor device number of the file system
+	device string
+	// root is the path of the directory of the file system that is mounted
+	root string
+	// mountPoint is the path of the mount point, relative to the root of
+	// the process
+	mountPoint string
+}
+
+func readMountInfo(path string) ([]mountInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseMountInfo(f)
+}
+
+func parseMountInfo(r io.Reader) ([]mountInfo, error) {
+	var mounts []mountInfo
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("invalid mountinfo line %q", scanner.Text())
+		}
+		mounts = append(mounts, mountInfo{
+			device:     fields[2],
+			root:       unescapeMountPath(fields[3]),
+			mountPoint: unescapeMountPath(fields[4]),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return mounts, nil
+}
+
+// unescapeMountPath undoes the octal escaping of whitespace and backslashes
+// in mountinfo paths (e.g. "\040" for a space).
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, "\\") {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if c, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// translatePath translates a path seen by a process with the given mounts to
+// the path of the same file seen by the agent with the given mounts. The file
+// system holding the file must be mounted for the agent as well, e.g. as the
+// container root file system is usually mounted on the host.
+func translatePath(procMounts, agentMounts []mountInfo, path string) (string, bool) {
+	// the file is on the last mounted of the mounts closest to it, since
+	// that one hides the others
+	var procMount *mountInfo
+	for i := range procMounts {
+		mount := &procMounts[i]
+		if pathContains(mount.mountPoint, path) && (procMount == nil || len(mount.mountPoint) >= len(procMount.mountPoint)) {
+			procMount = mount
+		}
+	}
+	if procMount == nil {
+		return "", false
+	}
+	fsPath := filepath.Join(procMount.root, relPath(procMount.mountPoint, path))
+
+	var agentMount *mountInfo
+	for i := range agentMounts {
+		mount := &agentMounts[i]
+		if mount.device == procMount.device && pathContains(mount.root, fsPath) && (agentMount == nil || len(mount.root) > len(agentMount.root)) {
+			agentMount = mount
+		}
+	}
+	if agentMount == nil {
+		return "", false
+	}
+	return filepath.Join(agentMount.mountPoint, relPath(agentMount.root, fsPath)), true
+}
+
+// pathContains reports whether path is dir or within dir.
+func pathContains(dir, path string) bool {
+	return dir == "/" || path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+func relPath(dir, path string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(path, dir), "/")
+}
+
 func makeSelector(kind, value string) *common.Selector {
 	return &common.Selector{
 		Type:  selectorType,
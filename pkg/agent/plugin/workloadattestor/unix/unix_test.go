@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,12 @@ func (s *Suite) SetupTest() {
 	}
 	p.hooks.lookupUserById = fakeLookupUserById
 	p.hooks.lookupGroupById = fakeLookupGroupById
+	p.hooks.hostPath = func(pid int32, path string) (string, error) {
+		return fakeHostPath(pid, path, s.dir)
+	}
+	p.hooks.procRoot = func(pid int32) string {
+		return s.dir
+	}
 	s.p = workloadattestor.NewBuiltIn(p)
 	s.configure("")
 }
@@ -96,6 +103,8 @@ func (s *Suite) TestAttest() {
 				"user:u1000",
 				"gid:2000",
 				"group:g2000",
+				"name:proc7",
+				"ppid:1",
 			},
 		},
 		{
@@ -106,6 +115,8 @@ func (s *Suite) TestAttest() {
 				"user:u1100",
 				"gid:2100",
 				"group:g2100",
+				"name:proc8",
+				"ppid:1",
 			},
 		},
 		{
@@ -135,6 +146,102 @@ func (s *Suite) TestAttest() {
 				"user:u1000",
 				"gid:2000",
 				"group:g2000",
+				"name:proc12",
+				"ppid:1",
+				fmt.Sprintf("path:%s", filepath.Join(s.dir, "exe")),
+				"sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7",
+				"parent_path:/sbin/init",
+			},
+		},
+		{
+			name: "supplementary groups",
+			pid:  13,
+			selectors: []string{
+				"uid:1000",
+				"user:u1000",
+				"gid:2000",
+				"group:g2000",
+				"supplementary_gid:2000",
+				"supplementary_group:g2000",
+				"supplementary_gid:2100",
+				"supplementary_group:g2100",
+				"supplementary_gid:2999",
+				"name:proc13",
+				"ppid:1",
+			},
+		},
+		{
+			name: "fail to get supplementary gids",
+			pid:  14,
+			err:  "unix: supplementary GIDs lookup: unable to get groups for PID 14",
+		},
+		{
+			name: "fail to get name",
+			pid:  15,
+			err:  "unix: name lookup: unable to get name for PID 15",
+		},
+		{
+			name: "fail to get ppid",
+			pid:  16,
+			err:  "unix: PPID lookup: unable to get PPID for PID 16",
+		},
+		{
+			name:   "process in another mount namespace",
+			pid:    17,
+			config: "discover_workload_path = true",
+			selectors: []string{
+				"uid:1000",
+				"user:u1000",
+				"gid:2000",
+				"group:g2000",
+				"name:proc17",
+				"ppid:1",
+				fmt.Sprintf("path:%s", filepath.Join(s.dir, "exe")),
+				"sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7",
+				"parent_path:/sbin/init",
+			},
+		},
+		{
+			name:   "parent already exited",
+			pid:    18,
+			config: "discover_workload_path = true",
+			selectors: []string{
+				"uid:1000",
+				"user:u1000",
+				"gid:2000",
+				"group:g2000",
+				"name:proc18",
+				"ppid:9",
+				fmt.Sprintf("path:%s", filepath.Join(s.dir, "exe")),
+				"sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7",
+			},
+		},
+		{
+			name:   "binary not visible to the agent",
+			pid:    19,
+			config: "discover_workload_path = true",
+			selectors: []string{
+				"uid:1000",
+				"user:u1000",
+				"gid:2000",
+				"group:g2000",
+				"name:proc19",
+				"ppid:1",
+				"sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7",
+				"parent_path:/sbin/init",
+			},
+		},
+		{
+			name:   "process without a parent",
+			pid:    20,
+			config: "discover_workload_path = true",
+			selectors: []string{
+				"uid:1000",
+				"user:u1000",
+				"gid:2000",
+				"group:g2000",
+				"name:proc20",
+				"ppid:0",
 				fmt.Sprintf("path:%s", filepath.Join(s.dir, "exe")),
 				"sha256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7",
 			},
@@ -168,6 +275,63 @@ func (s *Suite) TestAttest() {
 	}
 }
 
+func (s *Suite) TestHostPath() {
+	// the agent shares the mount namespace with itself
+	path, err := hostPath(int32(os.Getpid()), "/usr/bin/nginx")
+	s.Require().NoError(err)
+	s.Require().Equal("/usr/bin/nginx", path)
+}
+
+func (s *Suite) TestTranslatePath() {
+	agentMounts, err := parseMountInfo(strings.NewReader(`22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+45 22 0:40 / /var/lib/docker/overlay2/abc/merged rw,relatime - overlay overlay rw
+46 22 8:1 /srv/my\040data /mnt/data rw,relatime - ext4 /dev/sda1 rw
+`))
+	s.Require().NoError(err)
+	procMounts, err := parseMountInfo(strings.NewReader(`100 90 0:40 / / rw,relatime - overlay overlay rw
+101 100 8:1 /srv/my\040data /data rw,relatime - ext4 /dev/sda1 rw
+102 100 8:1 /srv/my\040data/bin /usr/local/bin rw,relatime - ext4 /dev/sda1 rw
+103 100 0:50 / /tmp rw,relatime - tmpfs tmpfs rw
+`))
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		// on the container root file system
+		{path: "/usr/bin/nginx", expected: "/var/lib/docker/overlay2/abc/merged/usr/bin/nginx"},
+		// on a bind mount of a directory of the host, which is mounted for
+		// the agent as well
+		{path: "/data/bin/app", expected: "/mnt/data/bin/app"},
+		// the deepest mount wins
+		{path: "/usr/local/bin/app", expected: "/mnt/data/bin/app"},
+		// "/datafile" is not within "/data"
+		{path: "/datafile", expected: "/var/lib/docker/overlay2/abc/merged/datafile"},
+		// on a file system not mounted for the agent
+		{path: "/tmp/app", expected: ""},
+	}
+	for _, testCase := range testCases {
+		path, ok := translatePath(procMounts, agentMounts, testCase.path)
+		s.Require().Equal(testCase.expected != "", ok, testCase.path)
+		s.Require().Equal(testCase.expected, path, testCase.path)
+	}
+}
+
+func (s *Suite) TestOSProcessGroups() {
+	proc, err := newOSProcess(int32(os.Getpid()))
+	s.Require().NoError(err)
+	groups, err := proc.Groups()
+	s.Require().NoError(err)
+
+	expected, err := os.Getgroups()
+	s.Require().NoError(err)
+	s.Require().Len(groups, len(expected))
+	for i, group := range expected {
+		s.Require().Equal(int32(group), groups[i])
+	}
+}
+
 func (s *Suite) TestConfigure() {
 	resp, err := s.p.Configure(ctx, &spi.ConfigureRequest{})
 	s.NoError(err)
@@ -204,7 +368,7 @@ func (p fakeProcess) Uids() ([]int32, error) {
 		return nil, fmt.Errorf("unable to get UIDs for PID %d", p.pid)
 	case 3:
 		return []int32{1999}, nil
-	case 4, 5, 6, 7, 9, 10, 11, 12:
+	case 4, 5, 6, 7, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20:
 		return []int32{1000}, nil
 	case 8:
 		return []int32{1000, 1100}, nil
@@ -221,7 +385,7 @@ func (p fakeProcess) Gids() ([]int32, error) {
 		return nil, fmt.Errorf("unable to get GIDs for PID %d", p.pid)
 	case 6:
 		return []int32{2999}, nil
-	case 7, 9, 10, 11, 12:
+	case 7, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20:
 		return []int32{2000}, nil
 	case 8:
 		return []int32{2000, 2100}, nil
@@ -230,14 +394,51 @@ func (p fakeProcess) Gids() ([]int32, error) {
 	}
 }
 
+func (p fakeProcess) Groups() ([]int32, error) {
+	switch p.pid {
+	case 13:
+		return []int32{2000, 2100, 2999}, nil
+	case 14:
+		return nil, fmt.Errorf("unable to get groups for PID %d", p.pid)
+	default:
+		return nil, nil
+	}
+}
+
+func (p fakeProcess) Name() (string, error) {
+	switch p.pid {
+	case 15:
+		return "", fmt.Errorf("unable to get name for PID %d", p.pid)
+	default:
+		return fmt.Sprintf("proc%d", p.pid), nil
+	}
+}
+
+func (p fakeProcess) Ppid() (int32, error) {
+	switch p.pid {
+	case 16:
+		return 0, fmt.Errorf("unable to get PPID for PID %d", p.pid)
+	case 18:
+		return 9, nil
+	case 20:
+		return 0, nil
+	default:
+		return 1, nil
+	}
+}
+
 func (p fakeProcess) Exe() (string, error) {
 	switch p.pid {
+	case 1:
+		return "/sbin/init", nil
 	case 7, 8, 9:
 		return "", fmt.Errorf("unable to get EXE for PID %d", p.pid)
 	case 10:
 		return filepath.Join(p.dir, "unreadable-exe"), nil
-	case 11, 12:
+	case 11, 12, 18, 20:
 		return filepath.Join(p.dir, "exe"), nil
+	case 17, 19:
+		return "/exe", nil
 	default:
 		return "", fmt.Errorf("unhandled exe test case %d", p.pid)
 	}
@@ -247,6 +448,18 @@ func newFakeProcess(pid int32, dir string) processInfo {
 	return fakeProcess{pid: pid, dir: dir}
 }
 
+func fakeHostPath(pid int32, path, dir string) (string, error) {
+	switch pid {
+	case 17:
+		// the process root is the test directory
+		return filepath.Join(dir, path), nil
+	case 19:
+		return "", fmt.Errorf("%s is not visible to the agent", path)
+	default:
+		return path, nil
+	}
+}
+
 func fakeLookupUserById(uid string) (*user.User, error) {
 	switch uid {
 	case "1000":
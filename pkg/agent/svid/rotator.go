@@ -7,12 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	observer "github.com/imkira/go-observer"
+	"github.com/spiffe/go-spiffe/uri"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/andres-erbsen/clock"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/api/node"
 )
@@ -24,6 +27,21 @@ type Rotator interface {
 	Subscribe() observer.Stream
 }
 
+// Reattestor re-attests the agent against SPIRE server.
+type Reattestor interface {
+	// AttestationDataChanged returns true if the attestation data the node
+	// attestors produce differs from the data the agent last attested with.
+	AttestationDataChanged(ctx context.Context) (bool, error)
+
+	// Reattest performs node attestation again and returns a new agent SVID
+	// for the given key. The root CAs are used to authenticate the server.
+	Reattest(ctx context.Context, key *ecdsa.PrivateKey, rootCAs []*x509.Certificate) ([]*x509.Certificate, error)
+}
+
+// maxReattestBackoff is the longest the rotator waits before trying to
+// re-attest the agent again after re-attestation failed.
+const maxReattestBackoff = time.Hour
+
 type rotator struct {
 	c      *RotatorConfig
 	client client.Client
@@ -31,6 +49,11 @@ type rotator struct {
 	state observer.Property
 	clk   clock.Clock
 
+	// re-attestation is not attempted again before reattestAfter, which
+	// backs off for reattestBackoff after every failed attempt.
+	reattestAfter   time.Time
+	reattestBackoff time.Duration
+
 	// Mutex used to protect access to c.BundleStream.
 	bsm *sync.RWMutex
 }
@@ -53,6 +76,15 @@ func (r *rotator) Run(ctx context.Context) error {
 			r.client.Release()
 			return nil
 		case <-t.C:
+			if r.shouldReattest(ctx) {
+				err := r.tryReattest(ctx)
+				if err == nil {
+					continue
+				}
+				// fall back to rotating the SVID, which may still succeed
+				// while the attestation data is not accepted.
+				r.c.Log.Errorf("Could not re-attest agent; not trying again for %v: %v", r.reattestBackoff, err)
+			}
 			if r.shouldRotate() {
 				if err := r.rotateSVID(ctx); err != nil {
 					r.c.Log.Errorf("Could not rotate agent SVID: %v", err)
@@ -82,7 +114,40 @@ func (r *rotator) shouldRotate() bool {
 	ttl := s.SVID[0].NotAfter.Sub(r.clk.Now())
 	watermark := s.SVID[0].NotAfter.Sub(s.SVID[0].NotBefore) / 2
 
-	return ttl <= watermark
+	return ttl <= watermark || !r.chainsToBundle(s.SVID)
+}
+
+// shouldReattest returns a boolean informing the caller of whether or not the
+// agent should be re-attested because its node attestation data changed. The
+// attestation data is not checked while re-attestation is backing off.
+func (r *rotator) shouldReattest(ctx context.Context) bool {
+	if r.c.Reattestor == nil || r.clk.Now().Before(r.reattestAfter) {
+		return false
+	}
+
+	changed, err := r.c.Reattestor.AttestationDataChanged(ctx)
+	if err != nil {
+		r.c.Log.Warnf("Could not check node attestation data for changes: %v", err)
+		return false
+	}
+	return changed
+}
+
+// chainsToBundle returns true if the SVID chains to the roots of the trust
+// domain bundle. It is false when the CA that signed the SVID has been revoked.
+func (r *rotator) chainsToBundle(svid []*x509.Certificate) bool {
+	return x509util.ChainsToRoots(svid, r.rootCAs())
+}
+
+// rootCAs returns the roots of the trust domain bundle.
+func (r *rotator) rootCAs() []*x509.Certificate {
+	r.bsm.RLock()
+	defer r.bsm.RUnlock()
+
+	if bundle := r.c.BundleStream.Value()[r.c.TrustDomain.String()]; bundle != nil {
+		return bundle.RootCAs()
+	}
+	return nil
 }
 
 // rotateSVID asks SPIRE's server for a new agent's SVID.
@@ -139,6 +204,72 @@ func (r *rotator) rotateSVID(ctx context.Context) (err error) {
 	return nil
 }
 
+// tryReattest re-attests the agent. When re-attestation fails, e.g. because
+// the server does not allow the agent to attest again, further attempts are
+// backed off exponentially, starting at twice the rotation interval.
+func (r *rotator) tryReattest(ctx context.Context) error {
+	if err := r.reattest(ctx); err != nil {
+		r.reattestBackoff *= 2
+		if r.reattestBackoff < 2*r.c.Interval {
+			r.reattestBackoff = 2 * r.c.Interval
+		}
+		if r.reattestBackoff > maxReattestBackoff {
+			r.reattestBackoff = maxReattestBackoff
+		}
+		r.reattestAfter = r.clk.Now().Add(r.reattestBackoff)
+		return err
+	}
+
+	r.reattestBackoff = 0
+	r.reattestAfter = time.Time{}
+	return nil
+}
+
+// reattest performs node attestation again to obtain a new agent SVID.
+func (r *rotator) reattest(ctx context.Context) (err error) {
+	counter := telemetry.StartCall(r.c.Metrics, "agent_svid", "reattest")
+	defer counter.Done(&err)
+
+	counter.AddLabel("spiffe_id", r.c.SpiffeID)
+	r.c.Log.Info("Node attestation data changed; re-attesting agent")
+
+	key, err := r.newKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	certs, err := r.c.Reattestor.Reattest(ctx, key, r.rootCAs())
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return errors.New("no SVID received when re-attesting agent")
+	}
+
+	// the rotator, the client and the cache are all bound to the agent ID,
+	// so re-attestation must not change it.
+	uris, err := uri.GetURINamesFromCertificate(certs[0])
+	if err != nil {
+		return err
+	}
+	if len(uris) == 0 || uris[0] != r.c.SpiffeID {
+		return fmt.Errorf("re-attestation returned an SVID for %v instead of %q", uris, r.c.SpiffeID)
+	}
+
+	if err := r.storeKey(ctx, key); err != nil {
+		return err
+	}
+
+	// See rotateSVID.
+	r.client.Release()
+
+	r.state.Update(State{
+		SVID: certs,
+		Key:  key,
+	})
+	return nil
+}
+
 func (r *rotator) newKey(ctx context.Context) (*ecdsa.PrivateKey, error) {
 	mgrs := r.c.Catalog.KeyManagers()
 	if len(mgrs) > 1 {
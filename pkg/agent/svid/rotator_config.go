@@ -38,6 +38,10 @@ type RotatorConfig struct {
 
 	// Clk is the clock that the rotator will use to create a ticker
 	Clk clock.Clock
+
+	// Reattestor, if set, is used to re-attest the agent when the node
+	// attestation data changes, instead of rotating the SVID.
+	Reattestor Reattestor
 }
 
 func NewRotator(c *RotatorConfig) (*rotator, client.Client) {
@@ -2,7 +2,9 @@ package svid
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/x509"
+	"errors"
 	"net/url"
 	"testing"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/agent/keymanager"
@@ -180,6 +183,27 @@ func (s *RotatorTestSuite) TestShouldRotate() {
 	s.Assert().True(s.r.shouldRotate())
 }
 
+func (s *RotatorTestSuite) TestShouldRotateWhenCARevoked() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	otherCA, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{cert},
+	})
+
+	// the SVID chains to the bundle
+	s.setBundleRootCAs(cert, otherCA)
+	s.Assert().False(s.r.shouldRotate())
+
+	// the CA that signed the SVID has been removed from the bundle
+	s.setBundleRootCAs(otherCA)
+	s.Assert().True(s.r.shouldRotate())
+}
+
 func (s *RotatorTestSuite) TestRotateSVID() {
 	cert, _, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -203,6 +227,153 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().Equal(state.Key, storedKey)
 }
 
+func (s *RotatorTestSuite) TestRunWithReattestation() {
+	// Cert that's valid for 1hr, so it is not rotated
+	temp, err := util.NewSVIDTemplate(s.mockClock, s.r.c.SpiffeID)
+	s.Require().NoError(err)
+	oldCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	newCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.setBundleRootCAs(oldCert, newCert)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{oldCert},
+	})
+	reattestor := &fakeReattestor{changed: true, svid: []*x509.Certificate{newCert}}
+	s.r.c.Reattestor = reattestor
+	s.client.EXPECT().Release().MaxTimes(2)
+
+	stream := s.r.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := new(tomb.Tomb)
+	t.Go(func() error {
+		return s.r.Run(ctx)
+	})
+
+	s.mockClock.WaitForTicker(time.Second, "timed out waiting for rotator to create a ticker")
+	s.mockClock.Add(s.r.c.Interval)
+
+	select {
+	case <-time.After(time.Second):
+		s.T().Error("timed out while waiting for expected re-attestation")
+	case <-stream.Changes():
+		state := stream.Next().(State)
+		s.Require().Len(state.SVID, 1)
+		s.Assert().Equal(newCert, state.SVID[0])
+		s.Assert().Equal(reattestor.key, state.Key)
+	}
+
+	cancel()
+	s.Require().NoError(t.Wait())
+}
+
+func (s *RotatorTestSuite) TestShouldReattest() {
+	// no reattestor configured
+	s.Assert().False(s.r.shouldReattest(context.Background()))
+
+	reattestor := &fakeReattestor{}
+	s.r.c.Reattestor = reattestor
+	s.Assert().False(s.r.shouldReattest(context.Background()))
+
+	reattestor.changed = true
+	s.Assert().True(s.r.shouldReattest(context.Background()))
+
+	// failures to check the attestation data are not fatal
+	reattestor.err = errors.New("oh no")
+	s.Assert().False(s.r.shouldReattest(context.Background()))
+}
+
+func (s *RotatorTestSuite) TestReattest() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, s.r.c.SpiffeID)
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	reattestor := &fakeReattestor{svid: []*x509.Certificate{cert}}
+	s.r.c.Reattestor = reattestor
+	s.setBundleRootCAs(cert)
+	s.client.EXPECT().Release()
+
+	stream := s.r.Subscribe()
+	s.Require().NoError(s.r.reattest(context.Background()))
+	s.Require().True(stream.HasNext())
+
+	state := stream.Next().(State)
+	s.Require().Len(state.SVID, 1)
+	s.Assert().True(cert.Equal(state.SVID[0]))
+	s.Assert().Equal(reattestor.key, state.Key)
+	s.Assert().Equal([]*x509.Certificate{cert}, reattestor.rootCAs)
+
+	// keymanager data matches state
+	mgr := s.r.c.Catalog.KeyManagers()[0]
+	kresp, err := mgr.FetchPrivateKey(context.Background(), &keymanager.FetchPrivateKeyRequest{})
+	s.Require().NoError(err)
+	storedKey, err := x509.ParseECPrivateKey(kresp.PrivateKey)
+	s.Require().NoError(err)
+	s.Assert().Equal(state.Key, storedKey)
+}
+
+func (s *RotatorTestSuite) TestTryReattestBacksOff() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, s.r.c.SpiffeID)
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.setBundleRootCAs(cert)
+
+	reattestor := &fakeReattestor{
+		changed:     true,
+		svid:        []*x509.Certificate{cert},
+		reattestErr: errors.New("node has already attested"),
+	}
+	s.r.c.Reattestor = reattestor
+
+	s.Require().True(s.r.shouldReattest(context.Background()))
+	s.Require().EqualError(s.r.tryReattest(context.Background()), "node has already attested")
+	s.Require().Equal(2*s.r.c.Interval, s.r.reattestBackoff)
+
+	// the attestation data is not checked while backing off
+	s.mockClock.Add(s.r.c.Interval)
+	s.Assert().False(s.r.shouldReattest(context.Background()))
+	s.Assert().Equal(1, reattestor.checks)
+
+	// the back-off doubles after every failure
+	s.mockClock.Add(s.r.c.Interval)
+	s.Require().True(s.r.shouldReattest(context.Background()))
+	s.Require().Error(s.r.tryReattest(context.Background()))
+	s.Require().Equal(4*s.r.c.Interval, s.r.reattestBackoff)
+
+	// up to a maximum
+	s.r.reattestBackoff = maxReattestBackoff
+	s.Require().Error(s.r.tryReattest(context.Background()))
+	s.Require().Equal(maxReattestBackoff, s.r.reattestBackoff)
+
+	// and is reset once re-attestation succeeds
+	s.mockClock.Add(maxReattestBackoff)
+	reattestor.reattestErr = nil
+	s.client.EXPECT().Release()
+	s.Require().True(s.r.shouldReattest(context.Background()))
+	s.Require().NoError(s.r.tryReattest(context.Background()))
+	s.Require().Zero(s.r.reattestBackoff)
+	s.Assert().True(s.r.shouldReattest(context.Background()))
+}
+
+func (s *RotatorTestSuite) TestReattestWithDifferentAgentID() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/spire/agent/5678")
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.c.Reattestor = &fakeReattestor{svid: []*x509.Certificate{cert}}
+
+	stream := s.r.Subscribe()
+	err = s.r.reattest(context.Background())
+	s.Require().Error(err)
+	s.Assert().Contains(err.Error(), `instead of "spiffe://example.org/spire/agent/1234"`)
+	s.Assert().False(stream.HasNext())
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
@@ -217,3 +388,38 @@ func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
 		}, nil)
 	s.client.EXPECT().Release().MaxTimes(2)
 }
+
+func (s *RotatorTestSuite) setBundleRootCAs(rootCAs ...*x509.Certificate) {
+	bundle := bundleutil.New(s.r.c.TrustDomain.String())
+	for _, rootCA := range rootCAs {
+		bundle.AppendRootCA(rootCA)
+	}
+	s.r.c.BundleStream = cache.NewBundleStream(observer.NewProperty(map[string]*cache.Bundle{
+		s.r.c.TrustDomain.String(): bundle,
+	}).Observe())
+}
+
+type fakeReattestor struct {
+	changed     bool
+	err         error
+	svid        []*x509.Certificate
+	reattestErr error
+
+	checks  int
+	key     *ecdsa.PrivateKey
+	rootCAs []*x509.Certificate
+}
+
+func (r *fakeReattestor) AttestationDataChanged(ctx context.Context) (bool, error) {
+	r.checks++
+	return r.changed, r.err
+}
+
+func (r *fakeReattestor) Reattest(ctx context.Context, key *ecdsa.PrivateKey, rootCAs []*x509.Certificate) ([]*x509.Certificate, error) {
+	r.key = key
+	r.rootCAs = rootCAs
+	if r.reattestErr != nil {
+		return nil, r.reattestErr
+	}
+	return r.svid, nil
+}
@@ -0,0 +1,47 @@
+package cryptoutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/spiffe/spire/proto/common"
+)
+
+// ParseKeyType parses a key type as it is expressed in configuration (i.e.
+// "ec-p256", "ec-p384", "rsa-2048" or "rsa-4096").
+func ParseKeyType(s string) (common.KeyType, error) {
+	switch strings.ToLower(s) {
+	case "ec-p256":
+		return common.KeyType_EC_P256, nil
+	case "ec-p384":
+		return common.KeyType_EC_P384, nil
+	case "rsa-2048":
+		return common.KeyType_RSA_2048, nil
+	case "rsa-4096":
+		return common.KeyType_RSA_4096, nil
+	default:
+		return common.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("unsupported key type %q", s)
+	}
+}
+
+// GenerateSigner generates an in-memory private key of the given type. Only
+// the key types that can be configured (see ParseKeyType) are supported.
+func GenerateSigner(keyType common.KeyType) (crypto.Signer, error) {
+	switch keyType {
+	case common.KeyType_EC_P256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case common.KeyType_EC_P384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case common.KeyType_RSA_2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case common.KeyType_RSA_4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
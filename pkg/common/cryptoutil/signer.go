@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/keymanager"
 )
 
@@ -68,7 +69,7 @@ func (s *KeyManagerSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOp
 	return s.SignContext(context.Background(), digest, opts)
 }
 
-func GenerateKeyRaw(ctx context.Context, km keymanager.KeyManager, keyId string, keyType keymanager.KeyType) ([]byte, error) {
+func GenerateKeyRaw(ctx context.Context, km keymanager.KeyManager, keyId string, keyType common.KeyType) ([]byte, error) {
 	resp, err := km.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   keyId,
 		KeyType: keyType,
@@ -82,7 +83,7 @@ func GenerateKeyRaw(ctx context.Context, km keymanager.KeyManager, keyId string,
 	return resp.PublicKey.PkixData, nil
 }
 
-func GenerateKey(ctx context.Context, km keymanager.KeyManager, keyId string, keyType keymanager.KeyType) (crypto.PublicKey, error) {
+func GenerateKey(ctx context.Context, km keymanager.KeyManager, keyId string, keyType common.KeyType) (crypto.PublicKey, error) {
 	pkixData, err := GenerateKeyRaw(ctx, km, keyId, keyType)
 	if err != nil {
 		return nil, err
@@ -95,7 +96,7 @@ func GenerateKey(ctx context.Context, km keymanager.KeyManager, keyId string, ke
 	return publicKey, nil
 }
 
-func GenerateKeyAndSigner(ctx context.Context, km keymanager.KeyManager, keyId string, keyType keymanager.KeyType) (*KeyManagerSigner, error) {
+func GenerateKeyAndSigner(ctx context.Context, km keymanager.KeyManager, keyId string, keyType common.KeyType) (*KeyManagerSigner, error) {
 	publicKey, err := GenerateKey(ctx, km, keyId, keyType)
 	if err != nil {
 		return nil, err
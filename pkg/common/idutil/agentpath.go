@@ -0,0 +1,85 @@
+package idutil
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// AgentPath renders the path of an agent ID from an agent path template for
+// a node attestor plugin. Values taken from attestation data are passed to
+// the template as PathSegment values, which must render as a single
+// non-dot path segment, so that they cannot add segments to the path or
+// traverse out of the plugin's part of the agent ID namespace.
+type AgentPath struct {
+	pluginName string
+	invalid    []string
+}
+
+// NewAgentPath returns an AgentPath for the given plugin, whose agent IDs
+// must be below spiffe://<trust domain>/spire/agent/<plugin name>.
+func NewAgentPath(pluginName string) *AgentPath {
+	return &AgentPath{pluginName: pluginName}
+}
+
+// Segment returns the template value for the given string.
+func (p *AgentPath) Segment(s string) PathSegment {
+	return PathSegment{value: s, path: p}
+}
+
+// Segments returns the template values for the given strings.
+func (p *AgentPath) Segments(ss []string) []PathSegment {
+	segments := make([]PathSegment, 0, len(ss))
+	for _, s := range ss {
+		segments = append(segments, p.Segment(s))
+	}
+	return segments
+}
+
+// MakeURI executes the template with the given data and returns the agent
+// ID. It fails if a PathSegment rendered by the template is not a single
+// path segment, or if the rendered path is not below the plugin name.
+func (p *AgentPath) MakeURI(trustDomain string, agentPathTemplate *template.Template, data interface{}) (*url.URL, error) {
+	p.invalid = nil
+	var agentPath bytes.Buffer
+	if err := agentPathTemplate.Execute(&agentPath, data); err != nil {
+		return nil, err
+	}
+	if len(p.invalid) > 0 {
+		return nil, fmt.Errorf("agent path template value %q is not a single path segment", p.invalid[0])
+	}
+
+	segments := strings.Split(agentPath.String(), "/")
+	if len(segments) < 2 || segments[0] != p.pluginName {
+		return nil, fmt.Errorf("agent path %q is not below %q", agentPath.String(), p.pluginName)
+	}
+	for _, segment := range segments[1:] {
+		if !isPathSegment(segment) {
+			return nil, fmt.Errorf("agent path %q has an empty or dot segment", agentPath.String())
+		}
+	}
+
+	return AgentURI(trustDomain, agentPath.String()), nil
+}
+
+// PathSegment is a value rendered into an agent path by an agent path
+// template.
+type PathSegment struct {
+	value string
+	path  *AgentPath
+}
+
+// String returns the value, recording it with the AgentPath that created the
+// segment if it is not a single non-dot path segment.
+func (s PathSegment) String() string {
+	if s.path != nil && !isPathSegment(s.value) {
+		s.path.invalid = append(s.path.invalid, s.value)
+	}
+	return s.value
+}
+
+func isPathSegment(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.Contains(s, "/")
+}
@@ -0,0 +1,57 @@
+package idutil
+
+import (
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+type agentPathTestData struct {
+	PluginName string
+	Name       PathSegment
+	Names      []PathSegment
+}
+
+func TestAgentPath(t *testing.T) {
+	makeURI := func(text, name string, names ...string) (string, error) {
+		agentPath := NewAgentPath("plugin")
+		id, err := agentPath.MakeURI("example.org", template.Must(template.New("agent-path").Parse(text)), agentPathTestData{
+			PluginName: "plugin",
+			Name:       agentPath.Segment(name),
+			Names:      agentPath.Segments(names),
+		})
+		if err != nil {
+			return "", err
+		}
+		return id.String(), nil
+	}
+
+	id, err := makeURI("{{ .PluginName }}/{{ .Name }}/{{ index .Names 1 }}", "node1", "a", "b")
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/plugin/node1/b", id)
+
+	// values must be single non-dot segments
+	for _, name := range []string{"", ".", "..", "../other", "a/b", "/a"} {
+		_, err = makeURI("{{ .PluginName }}/x{{ .Name }}", name)
+		require.Error(t, err, name)
+		require.Contains(t, err.Error(), "is not a single path segment")
+	}
+	_, err = makeURI("{{ .PluginName }}/{{ index .Names 1 }}", "node1", "a", "..")
+	require.EqualError(t, err, `agent path template value ".." is not a single path segment`)
+
+	// values that are not rendered are not checked
+	id, err = makeURI("{{ .PluginName }}/{{ .Name }}", "node1", "..")
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/plugin/node1", id)
+
+	// the path must be below the plugin name
+	_, err = makeURI("{{ .Name }}", "node1")
+	require.EqualError(t, err, `agent path "node1" is not below "plugin"`)
+	_, err = makeURI("{{ .PluginName }}", "node1")
+	require.EqualError(t, err, `agent path "plugin" is not below "plugin"`)
+	_, err = makeURI("{{ .PluginName }}/../other/{{ .Name }}", "node1")
+	require.EqualError(t, err, `agent path "plugin/../other/node1" has an empty or dot segment`)
+	_, err = makeURI("{{ .PluginName }}//{{ .Name }}", "node1")
+	require.EqualError(t, err, `agent path "plugin//node1" has an empty or dot segment`)
+}
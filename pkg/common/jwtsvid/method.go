@@ -3,8 +3,11 @@ package jwtsvid
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"encoding/asn1"
+	"fmt"
 	"math/big"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -14,8 +17,42 @@ var (
 	signingMethodES256 = &signingMethodECDSA{
 		SigningMethodECDSA: jwt.SigningMethodES256,
 	}
+	signingMethodES384 = &signingMethodECDSA{
+		SigningMethodECDSA: jwt.SigningMethodES384,
+	}
+	signingMethodRS256 = &signingMethodRSA{
+		SigningMethodRSA: jwt.SigningMethodRS256,
+	}
+
+	// supportedAlgorithms are the JWT signature algorithms that can be used
+	// to sign and validate JWT-SVIDs.
+	supportedAlgorithms = map[string]bool{
+		signingMethodES256.Alg(): true,
+		signingMethodES384.Alg(): true,
+		signingMethodRS256.Alg(): true,
+	}
 )
 
+// signingMethodForKey returns the signing method appropriate for the given
+// public key (i.e. ES256 for P-256, ES384 for P-384 and RS256 for RSA keys).
+func signingMethodForKey(publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return signingMethodES256, nil
+		case elliptic.P384():
+			return signingMethodES384, nil
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", publicKey.Curve.Params().Name)
+		}
+	case *rsa.PublicKey:
+		return signingMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
 // signingMethodECDSA is a copy of the implementation of the JWT package
 // modified to accomodate both an *ecdsa.PrivateKey and a crypto.Signer based
 // key. It can be thrown away as soon as
@@ -85,3 +122,37 @@ func (m *signingMethodECDSA) Sign(signingString string, key interface{}) (string
 
 	return jwt.EncodeSegment(out), nil
 }
+
+// signingMethodRSA wraps the RSA implementation of the JWT package so that it
+// can be used with a crypto.Signer based key (e.g. one backed by a KeyManager).
+type signingMethodRSA struct {
+	*jwt.SigningMethodRSA
+}
+
+func (m *signingMethodRSA) Sign(signingString string, key interface{}) (string, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	// make sure the signer is for RSA
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	if !m.Hash.Available() {
+		return "", jwt.ErrHashUnavailable
+	}
+
+	hasher := m.Hash.New()
+	hasher.Write([]byte(signingString))
+
+	// passing the hash as the signer options results in a PKCS#1 v1.5
+	// signature, as required by RS256.
+	signatureBytes, err := signer.Sign(rand.Reader, hasher.Sum(nil), m.Hash)
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.EncodeSegment(signatureBytes), nil
+}
@@ -37,7 +37,12 @@ func SignToken(spiffeID string, audience []string, expires time.Time, signer cry
 		"iat": time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(signingMethodES256, claims)
+	signingMethod, err := signingMethodForKey(signer.Public())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(signingMethod, claims)
 	token.Header[keyIDHeader] = kid
 	signedToken, err := token.SignedString(signer)
 	if err != nil {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"testing"
@@ -83,6 +86,25 @@ func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
 	s.Require().NotEmpty(claims)
 }
 
+func (s *TokenSuite) TestSignAndValidateWithES384() {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	s.Require().NoError(err)
+	s.testSignAndValidateWithKey(key, "ES384")
+}
+
+func (s *TokenSuite) TestSignAndValidateWithRS256() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.testSignAndValidateWithKey(key, "RS256")
+}
+
+func (s *TokenSuite) TestSignWithUnsupportedCurve() {
+	key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	s.Require().NoError(err)
+	_, err = SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), key, "kid")
+	s.Require().EqualError(err, `unsupported EC curve "P-224"`)
+}
+
 func (s *TokenSuite) TestSignWithNoExpiration() {
 	_, err := SignToken(fakeSpiffeID, fakeAudience, time.Time{}, s.key, "kid")
 	s.Require().EqualError(err, "expiration is required")
@@ -216,6 +238,27 @@ func (s *TokenSuite) TestValidateKeyNotFound() {
 	s.Require().Nil(claims)
 }
 
+func (s *TokenSuite) testSignAndValidateWithKey(key crypto.Signer, expectedAlg string) {
+	token, err := SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), key, "kid")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(token)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	s.Require().NoError(err)
+	s.Require().Equal(expectedAlg, parsed.Method.Alg())
+
+	bundle := NewKeyStore(map[string]map[string]crypto.PublicKey{
+		"spiffe://example.org": {
+			"kid": key.Public(),
+		},
+	})
+
+	spiffeID, claims, err := ValidateToken(ctx, token, bundle, fakeAudience[0:1])
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+	s.Require().NotEmpty(claims)
+}
+
 func (s *TokenSuite) requireErrorContains(err error, contains string) {
 	s.Require().Error(err)
 	s.Require().Contains(err.Error(), contains)
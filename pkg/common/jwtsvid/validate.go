@@ -37,7 +37,7 @@ func (t *keyStore) FindPublicKey(ctx context.Context, trustDomainId, keyID strin
 }
 
 func getSigningKey(ctx context.Context, keyStore KeyStore, t *jwt.Token, claims jwt.MapClaims) (string, interface{}, error) {
-	if t.Method.Alg() != jwt.SigningMethodES256.Alg() {
+	if !supportedAlgorithms[t.Method.Alg()] {
 		return "", nil, fmt.Errorf("unexpected token signature algorithm: %s", t.Method.Alg())
 	}
 	keyID, _ := t.Header[keyIDHeader].(string)
@@ -4,8 +4,22 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+const (
+	PluginName = "aws_iid"
 )
 
+// DefaultAgentPathTemplate is the default text/template
+var DefaultAgentPathTemplate = template.Must(template.New("agent-path").Parse("{{ .PluginName }}/{{ .AccountID }}/{{ .Region }}/{{ .InstanceID }}"))
+
 type InstanceIdentityDocument struct {
 	InstanceId string `json:"instanceId" `
 	AccountId  string `json:"accountId"`
@@ -17,6 +31,14 @@ type IIDAttestationData struct {
 	Signature string `json:"signature"`
 }
 
+type agentPathTemplateData struct {
+	PluginName string
+	AccountID  idutil.PathSegment
+	Region     idutil.PathSegment
+	InstanceID idutil.PathSegment
+	Tags       map[string]idutil.PathSegment
+}
+
 func AttestationStepError(step string, cause error) error {
 	return fmt.Errorf("Attempted AWS IID attestation but an error occured %s: %s", step, cause)
 }
@@ -29,3 +51,43 @@ func IIDAgentID(trustDomain, accountID, region, instanceID string) string {
 	}
 	return id.String()
 }
+
+// MakeSpiffeID makes an agent spiffe ID. The ID always has a host value equal to the given trust domain,
+// the path is created using the given agentPathTemplate which is given access to the account ID, region
+// and instance ID from the instance identity document, as well as the instance tags. The values taken
+// from the document and tags must be single path segments, and the path must be below the plugin name.
+func MakeSpiffeID(trustDomain string, agentPathTemplate *template.Template, doc InstanceIdentityDocument, tags map[string]string) (*url.URL, error) {
+	agentPath := idutil.NewAgentPath(PluginName)
+	tagSegments := make(map[string]idutil.PathSegment, len(tags))
+	for key, value := range tags {
+		tagSegments[key] = agentPath.Segment(value)
+	}
+	return agentPath.MakeURI(trustDomain, agentPathTemplate, agentPathTemplateData{
+		PluginName: PluginName,
+		AccountID:  agentPath.Segment(doc.AccountId),
+		Region:     agentPath.Segment(doc.Region),
+		InstanceID: agentPath.Segment(doc.InstanceId),
+		Tags:       tagSegments,
+	})
+}
+
+// NewSession creates an AWS session for the given region. Static credentials
+// are used if provided, otherwise the default credential chain. If an IAM role
+// ARN is provided, the role is assumed using those credentials, which allows
+// calls against resources of other accounts.
+func NewSession(accessKeyID, secretAccessKey, region, assumeRoleARN string) (*session.Session, error) {
+	config := aws.NewConfig().WithRegion(region)
+	if accessKeyID != "" && secretAccessKey != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	if assumeRoleARN == "" {
+		return sess, nil
+	}
+
+	return session.NewSession(config.Copy().WithCredentials(stscreds.NewCredentials(sess, assumeRoleARN)))
+}
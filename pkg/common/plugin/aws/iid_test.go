@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeSpiffeID(t *testing.T) {
+	doc := InstanceIdentityDocument{
+		AccountId:  "123456789012",
+		Region:     "us-west-2",
+		InstanceId: "i-0123456789abcdef0",
+	}
+	tags := map[string]string{"Name": "node1"}
+
+	// the default template matches the IID agent ID
+	id, err := MakeSpiffeID("example.org", DefaultAgentPathTemplate, doc, tags)
+	require.NoError(t, err)
+	require.Equal(t, IIDAgentID("example.org", doc.AccountId, doc.Region, doc.InstanceId), id.String())
+
+	tmpl := template.Must(template.New("agent-path").Parse(`{{ .PluginName }}/{{ .Region }}/{{ index .Tags "Name" }}`))
+	id, err = MakeSpiffeID("example.org", tmpl, doc, tags)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/aws_iid/us-west-2/node1", id.String())
+
+	// tag values cannot traverse out of the plugin's agent IDs
+	for _, name := range []string{"..", "../x509pop/fingerprint", "node1/node2"} {
+		_, err = MakeSpiffeID("example.org", tmpl, doc, map[string]string{"Name": name})
+		require.EqualError(t, err, fmt.Sprintf("agent path template value %q is not a single path segment", name))
+	}
+
+	// neither can the template
+	tmpl = template.Must(template.New("agent-path").Parse(`{{ .Region }}/{{ .InstanceID }}`))
+	_, err = MakeSpiffeID("example.org", tmpl, doc, tags)
+	require.EqualError(t, err, `agent path "us-west-2/i-0123456789abcdef0" is not below "aws_iid"`)
+}
@@ -0,0 +1,30 @@
+package jointoken
+
+import (
+	"net/url"
+	"path"
+)
+
+const (
+	PluginName = "join_token"
+)
+
+// Challenge is sent by the server to an agent attesting with a join token
+// that assigns the agent a SPIFFE ID other than the one derived from the
+// token. The agent is expected to continue the attestation requesting the
+// assigned SPIFFE ID.
+type Challenge struct {
+	// SpiffeID is the SPIFFE ID assigned to the agent.
+	SpiffeID string `json:"spiffe_id"`
+}
+
+// SpiffeID returns the SPIFFE ID derived from the token, which is the
+// SPIFFE ID requested by agents attesting with it.
+func SpiffeID(trustDomain, token string) string {
+	u := url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   path.Join("spire", "agent", PluginName, token),
+	}
+	return u.String()
+}
@@ -0,0 +1,155 @@
+package sshpop
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	nonceLen = 32
+)
+
+type AttestationData struct {
+	// Certificate is the SSH host certificate in SSH wire format.
+	Certificate []byte `json:"certificate"`
+}
+
+type Challenge struct {
+	// Nonce is the nonce generated by the challenger.
+	Nonce []byte `json:"nonce"`
+}
+
+type Response struct {
+	// Nonce is the nonce generated by the responder.
+	Nonce []byte `json:"nonce"`
+
+	// SignatureFormat is the format of the signature (e.g. "ssh-ed25519").
+	SignatureFormat string `json:"signature_format"`
+
+	// Signature is the signature, by the host key, of the combined
+	// challenger and responder nonces.
+	Signature []byte `json:"signature"`
+}
+
+// ParseCertificate parses an SSH certificate in SSH wire format.
+func ParseCertificate(data []byte) (*ssh.Certificate, error) {
+	key, err := ssh.ParsePublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("expected certificate; got %s key", key.Type())
+	}
+	return cert, nil
+}
+
+func GenerateChallenge() (*Challenge, error) {
+	nonce, err := randBytes(nonceLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Challenge{
+		Nonce: nonce,
+	}, nil
+}
+
+func CalculateResponse(signer ssh.Signer, challenge *Challenge) (*Response, error) {
+	nonce, err := randBytes(nonceLen)
+	if err != nil {
+		return nil, err
+	}
+
+	combined, err := combineNonces(challenge.Nonce, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signer.Sign(rand.Reader, combined)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Nonce:           nonce,
+		SignatureFormat: signature.Format,
+		Signature:       signature.Blob,
+	}, nil
+}
+
+func VerifyChallengeResponse(publicKey ssh.PublicKey, challenge *Challenge, response *Response) error {
+	combined, err := combineNonces(challenge.Nonce, response.Nonce)
+	if err != nil {
+		return err
+	}
+
+	if err := publicKey.Verify(combined, &ssh.Signature{
+		Format: response.SignatureFormat,
+		Blob:   response.Signature,
+	}); err != nil {
+		return errors.New("signature verify failed")
+	}
+	return nil
+}
+
+// AgentName returns the name identifying the host of the certificate, which
+// is the key ID of the certificate or, if it has none, its first principal
+// (i.e. the hostname). The name must be a single path segment of the agent
+// ID.
+func AgentName(cert *ssh.Certificate) (string, error) {
+	var name string
+	switch {
+	case cert.KeyId != "":
+		name = cert.KeyId
+	case len(cert.ValidPrincipals) > 0:
+		name = cert.ValidPrincipals[0]
+	default:
+		return "", errors.New("certificate has neither a key ID nor principals")
+	}
+	if name == "." || name == ".." || strings.Contains(name, "/") {
+		return "", fmt.Errorf("invalid agent name %q", name)
+	}
+	return name, nil
+}
+
+func SpiffeID(trustDomain string, cert *ssh.Certificate) (string, error) {
+	name, err := AgentName(cert)
+	if err != nil {
+		return "", err
+	}
+	u := url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   path.Join("spire", "agent", "sshpop", name),
+	}
+	return u.String(), nil
+}
+
+func randBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func combineNonces(challenge, response []byte) ([]byte, error) {
+	if len(challenge) != nonceLen {
+		return nil, errors.New("invalid challenge nonce")
+	}
+	if len(response) != nonceLen {
+		return nil, errors.New("invalid response nonce")
+	}
+	h := sha256.New()
+	h.Write(challenge)
+	h.Write(response)
+	return h.Sum(nil), nil
+}
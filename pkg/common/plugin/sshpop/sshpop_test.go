@@ -0,0 +1,126 @@
+package sshpop
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestChallengeResponse(t *testing.T) {
+	require := require.New(t)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+
+	for _, key := range []interface{}{ecdsaKey, ed25519Key} {
+		signer, err := ssh.NewSignerFromKey(key)
+		require.NoError(err)
+
+		challenge, err := GenerateChallenge()
+		require.NoError(err)
+		response, err := CalculateResponse(signer, challenge)
+		require.NoError(err)
+		require.Equal(signer.PublicKey().Type(), response.SignatureFormat)
+		require.NoError(VerifyChallengeResponse(signer.PublicKey(), challenge, response))
+
+		// the response is bound to the challenge
+		otherChallenge, err := GenerateChallenge()
+		require.NoError(err)
+		require.EqualError(VerifyChallengeResponse(signer.PublicKey(), otherChallenge, response), "signature verify failed")
+
+		// and to the key
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(err)
+		otherSigner, err := ssh.NewSignerFromKey(otherKey)
+		require.NoError(err)
+		require.EqualError(VerifyChallengeResponse(otherSigner.PublicKey(), challenge, response), "signature verify failed")
+	}
+}
+
+func TestVerifyChallengeResponseWithBadNonces(t *testing.T) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(err)
+
+	_, err = CalculateResponse(signer, &Challenge{Nonce: []byte("short")})
+	require.EqualError(err, "invalid challenge nonce")
+
+	challenge, err := GenerateChallenge()
+	require.NoError(err)
+	err = VerifyChallengeResponse(signer.PublicKey(), challenge, &Response{Nonce: []byte("short")})
+	require.EqualError(err, "invalid response nonce")
+}
+
+func TestParseCertificate(t *testing.T) {
+	require := require.New(t)
+
+	cert := createCertificate(t, "", "node1.example.org")
+	parsed, err := ParseCertificate(cert.Marshal())
+	require.NoError(err)
+	require.Equal(cert.Marshal(), parsed.Marshal())
+
+	// not a certificate
+	_, err = ParseCertificate(cert.Key.Marshal())
+	require.EqualError(err, "expected certificate; got ecdsa-sha2-nistp256 key")
+
+	// malformed
+	_, err = ParseCertificate([]byte("malformed"))
+	require.Error(err)
+}
+
+func TestSpiffeID(t *testing.T) {
+	require := require.New(t)
+
+	// key ID takes precedence
+	spiffeID, err := SpiffeID("example.org", createCertificate(t, "node1", "node1.example.org"))
+	require.NoError(err)
+	require.Equal("spiffe://example.org/spire/agent/sshpop/node1", spiffeID)
+
+	// otherwise the first principal
+	spiffeID, err = SpiffeID("example.org", createCertificate(t, "", "node1.example.org", "node1"))
+	require.NoError(err)
+	require.Equal("spiffe://example.org/spire/agent/sshpop/node1.example.org", spiffeID)
+
+	// neither
+	_, err = SpiffeID("example.org", createCertificate(t, ""))
+	require.EqualError(err, "certificate has neither a key ID nor principals")
+
+	// names that are not a single path segment
+	for _, name := range []string{".", "..", "node1/../node2"} {
+		_, err = SpiffeID("example.org", createCertificate(t, name))
+		require.EqualError(err, `invalid agent name "`+name+`"`)
+	}
+}
+
+func createCertificate(t *testing.T, keyID string, principals ...string) *ssh.Certificate {
+	require := require.New(t)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(err)
+	hostKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	hostPublicKey, err := ssh.NewPublicKey(&hostKey.PublicKey)
+	require.NoError(err)
+
+	cert := &ssh.Certificate{
+		Key:             hostPublicKey,
+		CertType:        ssh.HostCert,
+		KeyId:           keyID,
+		ValidPrincipals: principals,
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(cert.SignCert(rand.Reader, caSigner))
+	return cert
+}
@@ -0,0 +1,53 @@
+package tpm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/credactivation"
+)
+
+// MakeCredential protects the secret so that it can only be recovered by
+// the TPM holding the private part of the endorsement key, and only if the
+// TPM also holds the key with the given public area (TPM2_MakeCredential).
+//
+// It returns the credential blob (the TPMS_ID_OBJECT) and the encrypted
+// seed to pass to TPM2_ActivateCredential.
+func MakeCredential(ek, key tpm2.Public, secret []byte) (credentialBlob, encryptedSecret []byte, err error) {
+	if err := checkStorageKey(ek); err != nil {
+		return nil, nil, err
+	}
+	ekKey, err := ek.Key()
+	if err != nil {
+		return nil, nil, err
+	}
+	name, err := key.Name()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blob, encrypted, err := credactivation.Generate(name.Digest, ekKey, int(ek.RSAParameters.Symmetric.KeyBits)/8, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	// both are returned as TPM2B structures, which TPM2_ActivateCredential
+	// takes the contents of.
+	return blob[2:], encrypted[2:], nil
+}
+
+func checkStorageKey(ek tpm2.Public) error {
+	if ek.Type != tpm2.AlgRSA || ek.RSAParameters == nil {
+		return fmt.Errorf("unsupported endorsement key type 0x%04x", ek.Type)
+	}
+	if ek.Attributes&(tpm2.FlagRestricted|tpm2.FlagDecrypt) != tpm2.FlagRestricted|tpm2.FlagDecrypt {
+		return errors.New("endorsement key is not a restricted decryption key")
+	}
+	if ek.NameAlg != tpm2.AlgSHA256 {
+		return fmt.Errorf("unsupported endorsement key name algorithm 0x%04x", ek.NameAlg)
+	}
+	if sym := ek.RSAParameters.Symmetric; sym == nil || sym.Alg != tpm2.AlgAES || sym.Mode != tpm2.AlgCFB {
+		return errors.New("unsupported endorsement key symmetric algorithm")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package tpm
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/go-tpm-tools/tpm2tools"
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+)
+
+const (
+	// EKCertificateIndex is the NV index holding the RSA 2048 endorsement
+	// key certificate provisioned by the TPM manufacturer.
+	EKCertificateIndex = tpmutil.Handle(0x01C00002)
+
+	// nvReadBlockSize is the amount of NV data read per command, which is
+	// below the NV buffer size of every TPM in the wild.
+	nvReadBlockSize = 512
+)
+
+// Device issues the TPM 2.0 commands needed for attestation to a TPM, like
+// the /dev/tpmrm0 resource manager device.
+type Device struct {
+	rw io.ReadWriter
+}
+
+func NewDevice(rw io.ReadWriter) *Device {
+	return &Device{rw: rw}
+}
+
+// CreateEK creates the endorsement key from the TCG default RSA template,
+// returning the handle of the loaded key and its public area (TPMT_PUBLIC).
+// The endorsement hierarchy must have an empty authorization value.
+func (d *Device) CreateEK() (tpmutil.Handle, []byte, error) {
+	return d.createPrimary(EKTemplate())
+}
+
+// CreateAK creates the attestation key, returning the handle of the loaded
+// key and its public area (TPMT_PUBLIC). The endorsement hierarchy must
+// have an empty authorization value.
+func (d *Device) CreateAK() (tpmutil.Handle, []byte, error) {
+	return d.createPrimary(AKTemplate())
+}
+
+// ReadEKCertificate reads the endorsement key certificate from NV using the
+// owner authorization, which must be empty.
+func (d *Device) ReadEKCertificate() ([]byte, error) {
+	return tpm2.NVReadEx(d.rw, EKCertificateIndex, tpm2.HandleOwner, "", nvReadBlockSize)
+}
+
+// ActivateCredential recovers the secret protected by the credential blob
+// and encrypted secret (see MakeCredential) for the key loaded at the
+// handle, using the endorsement key loaded at ekHandle. The endorsement key
+// must use the default EK policy and the endorsement hierarchy an empty
+// authorization value.
+func (d *Device) ActivateCredential(handle, ekHandle tpmutil.Handle, credentialBlob, encryptedSecret []byte) ([]byte, error) {
+	session, _, err := tpm2.StartAuthSession(d.rw, tpm2.HandleNull, tpm2.HandleNull,
+		make([]byte, 16), nil, tpm2.SessionPolicy, tpm2.AlgNull, tpm2.AlgSHA256)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start policy session: %v", err)
+	}
+	defer tpm2.FlushContext(d.rw, session)
+
+	// satisfy the EK policy, i.e. PolicySecret(TPM_RH_ENDORSEMENT)
+	if _, err := tpm2.PolicySecret(d.rw, tpm2.HandleEndorsement,
+		tpm2.AuthCommand{Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession},
+		session, nil, nil, nil, 0); err != nil {
+		return nil, fmt.Errorf("unable to satisfy endorsement key policy: %v", err)
+	}
+
+	return tpm2.ActivateCredentialUsingAuth(d.rw, []tpm2.AuthCommand{
+		{Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession},
+		{Session: session, Attributes: tpm2.AttrContinueSession},
+	}, handle, ekHandle, credentialBlob, encryptedSecret)
+}
+
+// Quote quotes the PCRs of the SHA256 bank with the attestation key loaded
+// at the handle, including the nonce in the quote. It returns the
+// TPMS_ATTEST structure and its signature (TPMT_SIGNATURE).
+func (d *Device) Quote(handle tpmutil.Handle, nonce []byte, pcrs []int) (quote, signature []byte, err error) {
+	quote, sig, err := tpm2.Quote(d.rw, handle, "", "", nonce, pcrSelection(pcrs), tpm2.AlgNull)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sig.RSA == nil {
+		return nil, nil, fmt.Errorf("unexpected quote signature algorithm 0x%04x", sig.Alg)
+	}
+	signature, err = tpmutil.Pack(sig.Alg, sig.RSA.HashAlg, sig.RSA.Signature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quote, signature, nil
+}
+
+// ReadPCRs returns the values of the PCRs of the SHA256 bank.
+func (d *Device) ReadPCRs(pcrs []int) (map[int][]byte, error) {
+	values := make(map[int][]byte)
+	remaining := append([]int(nil), pcrs...)
+	for len(remaining) > 0 {
+		// the TPM returns a limited number of values per command
+		read, err := tpm2.ReadPCRs(d.rw, pcrSelection(remaining))
+		if err != nil {
+			return nil, err
+		}
+		if len(read) == 0 {
+			return nil, fmt.Errorf("unable to read PCRs %v", remaining)
+		}
+
+		var next []int
+		for _, pcr := range remaining {
+			if value, ok := read[pcr]; ok {
+				values[pcr] = value
+			} else {
+				next = append(next, pcr)
+			}
+		}
+		remaining = next
+	}
+	return values, nil
+}
+
+// Flush unloads the object or session at the handle.
+func (d *Device) Flush(handle tpmutil.Handle) error {
+	return tpm2.FlushContext(d.rw, handle)
+}
+
+func (d *Device) createPrimary(template tpm2.Public) (tpmutil.Handle, []byte, error) {
+	handle, public, _, _, _, _, err := tpm2.CreatePrimaryEx(d.rw, tpm2.HandleEndorsement, tpm2.PCRSelection{}, "", "", template)
+	if err != nil {
+		return 0, nil, err
+	}
+	return handle, public, nil
+}
+
+// EKTemplate returns the TCG default template for the RSA 2048 endorsement
+// key (template L-1 of the TCG EK Credential Profile).
+func EKTemplate() tpm2.Public {
+	return tpm2tools.DefaultEKTemplateRSA()
+}
+
+// AKTemplate returns the template for the RSA 2048 attestation key, a
+// restricted signing key that can only sign structures generated by the
+// TPM, like quotes.
+func AKTemplate() tpm2.Public {
+	return tpm2tools.AIKTemplateRSA([256]byte{})
+}
+
+func pcrSelection(pcrs []int) tpm2.PCRSelection {
+	return tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: pcrs}
+}
@@ -0,0 +1,214 @@
+package tpm
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+
+	"github.com/google/go-tpm/tpm2"
+)
+
+const (
+	PluginName = "tpm"
+
+	// PCRCount is the number of PCRs required by the PC client platform
+	// specification, which can be selected.
+	PCRCount = 24
+
+	// attestMagic is the TPM_GENERATED_VALUE that prefixes every structure
+	// signed by the TPM.
+	attestMagic uint32 = 0xff544347
+)
+
+type AttestationData struct {
+	// EKCertificate is the DER encoded endorsement key certificate issued by
+	// the TPM manufacturer.
+	EKCertificate []byte `json:"ek_certificate"`
+
+	// EKPublic is the public area (TPMT_PUBLIC) of the endorsement key.
+	EKPublic []byte `json:"ek_public"`
+
+	// AKPublic is the public area (TPMT_PUBLIC) of the attestation key.
+	AKPublic []byte `json:"ak_public"`
+}
+
+type Challenge struct {
+	// CredentialBlob and EncryptedSecret protect the secret the TPM has to
+	// recover through credential activation (see MakeCredential).
+	CredentialBlob  []byte `json:"credential_blob"`
+	EncryptedSecret []byte `json:"encrypted_secret"`
+
+	// Nonce is the nonce the attestation key has to include in the quote.
+	Nonce []byte `json:"nonce"`
+}
+
+type Response struct {
+	// Secret is the secret recovered through credential activation.
+	Secret []byte `json:"secret"`
+
+	// Quote is the TPMS_ATTEST structure of the quote of the PCR values.
+	Quote []byte `json:"quote"`
+
+	// QuoteSignature is the signature (TPMT_SIGNATURE) of the quote by the
+	// attestation key.
+	QuoteSignature []byte `json:"quote_signature"`
+
+	// PCRValues holds the SHA256 bank values of the quoted PCRs.
+	PCRValues map[int][]byte `json:"pcr_values"`
+}
+
+// VerifyEKPublic verifies that the endorsement key public area matches the
+// key in the endorsement key certificate.
+func VerifyEKPublic(ek tpm2.Public, cert *x509.Certificate) error {
+	if err := checkStorageKey(ek); err != nil {
+		return err
+	}
+	key, err := ek.Key()
+	if err != nil {
+		return err
+	}
+	keyBytes, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return err
+	}
+	certKeyBytes, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(keyBytes, certKeyBytes) {
+		return errors.New("endorsement key does not match the certificate")
+	}
+	return nil
+}
+
+// VerifyAKPublic verifies that the attestation key is a restricted signing
+// key generated by, and bound to, the TPM.
+func VerifyAKPublic(ak tpm2.Public) error {
+	required := tpm2.FlagFixedTPM | tpm2.FlagFixedParent | tpm2.FlagSensitiveDataOrigin | tpm2.FlagRestricted | tpm2.FlagSign
+	if ak.Attributes&required != required {
+		return errors.New("attestation key is not a restricted signing key bound to the TPM")
+	}
+	if ak.Attributes&tpm2.FlagDecrypt != 0 {
+		return errors.New("attestation key is also a decryption key")
+	}
+	if ak.Type != tpm2.AlgRSA || ak.RSAParameters == nil || ak.RSAParameters.Sign == nil ||
+		ak.RSAParameters.Sign.Alg != tpm2.AlgRSASSA || ak.RSAParameters.Sign.Hash != tpm2.AlgSHA256 {
+		return errors.New("unsupported attestation key scheme")
+	}
+	return nil
+}
+
+// VerifyQuote verifies that the quote in the response was signed by the
+// attestation key, includes the nonce and covers the reported PCR values.
+func VerifyQuote(ak tpm2.Public, nonce []byte, response *Response) error {
+	key, err := ak.Key()
+	if err != nil {
+		return err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("unsupported attestation key type")
+	}
+	sig, err := tpm2.DecodeSignature(bytes.NewBuffer(response.QuoteSignature))
+	if err != nil {
+		return err
+	}
+	if sig.Alg != tpm2.AlgRSASSA || sig.RSA.HashAlg != tpm2.AlgSHA256 {
+		return fmt.Errorf("unsupported quote signature scheme 0x%04x", sig.Alg)
+	}
+	digest := sha256.Sum256(response.Quote)
+	if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], sig.RSA.Signature); err != nil {
+		return fmt.Errorf("invalid quote signature: %v", err)
+	}
+
+	quote, err := tpm2.DecodeAttestationData(response.Quote)
+	if err != nil {
+		return err
+	}
+	if quote.Magic != attestMagic || quote.Type != tpm2.TagAttestQuote {
+		return errors.New("signed data is not a quote")
+	}
+	if subtle.ConstantTimeCompare(quote.ExtraData, nonce) != 1 {
+		return errors.New("quote nonce mismatch")
+	}
+	quoteInfo := quote.AttestedQuoteInfo
+	if quoteInfo.PCRSelection.Hash != tpm2.AlgSHA256 {
+		return fmt.Errorf("unsupported PCR bank 0x%04x", quoteInfo.PCRSelection.Hash)
+	}
+	if len(quoteInfo.PCRSelection.PCRs) != len(response.PCRValues) {
+		return errors.New("quoted PCRs do not match the PCR values")
+	}
+	for _, pcr := range quoteInfo.PCRSelection.PCRs {
+		value, ok := response.PCRValues[pcr]
+		if !ok {
+			return fmt.Errorf("missing value of quoted PCR %d", pcr)
+		}
+		if len(value) != sha256.Size {
+			return fmt.Errorf("invalid value of PCR %d", pcr)
+		}
+	}
+	if !bytes.Equal(quoteInfo.PCRDigest, PCRDigest(response.PCRValues)) {
+		return errors.New("quoted PCR digest does not match the PCR values")
+	}
+	return nil
+}
+
+// ValidatePCRs verifies that the PCRs can be selected.
+func ValidatePCRs(pcrs []int) error {
+	for _, pcr := range pcrs {
+		if pcr < 0 || pcr >= PCRCount {
+			return fmt.Errorf("invalid PCR index %d", pcr)
+		}
+	}
+	return nil
+}
+
+// PCRDigest returns the digest of the PCR values as computed by the TPM
+// for a quote, i.e. the digest of the concatenation of the values in
+// ascending PCR order.
+func PCRDigest(values map[int][]byte) []byte {
+	h := sha256.New()
+	for _, pcr := range SortedPCRs(values) {
+		h.Write(values[pcr])
+	}
+	return h.Sum(nil)
+}
+
+// SortedPCRs returns the PCRs of the values in ascending order.
+func SortedPCRs(values map[int][]byte) []int {
+	pcrs := make([]int, 0, len(values))
+	for pcr := range values {
+		pcrs = append(pcrs, pcr)
+	}
+	sort.Ints(pcrs)
+	return pcrs
+}
+
+// PubKeyHash returns the hex encoded SHA256 hash of the ASN.1 DER encoding
+// of the public key.
+func PubKeyHash(key crypto.PublicKey) (string, error) {
+	keyBytes, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(keyBytes)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func SpiffeID(trustDomain string, ekPubHash string) string {
+	u := url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   path.Join("spire", "agent", PluginName, ekPubHash),
+	}
+	return u.String()
+}
@@ -0,0 +1,166 @@
+//go:build tpmsimulator
+// +build tpmsimulator
+
+package tpm
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTPMWithSimulator(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+type Suite struct {
+	suite.Suite
+
+	sim      *tpmsimulator.Simulator
+	device   *Device
+	ekHandle tpmutil.Handle
+	ek       tpm2.Public
+	akHandle tpmutil.Handle
+	ak       tpm2.Public
+}
+
+func (s *Suite) SetupTest() {
+	require := s.Require()
+
+	var err error
+	s.sim, err = tpmsimulator.New()
+	require.NoError(err)
+	s.device = NewDevice(s.sim)
+
+	var ekPublic, akPublic []byte
+	s.ekHandle, ekPublic, err = s.device.CreateEK()
+	require.NoError(err)
+	s.ek, err = tpm2.DecodePublic(ekPublic)
+	require.NoError(err)
+
+	s.akHandle, akPublic, err = s.device.CreateAK()
+	require.NoError(err)
+	s.ak, err = tpm2.DecodePublic(akPublic)
+	require.NoError(err)
+}
+
+func (s *Suite) TearDownTest() {
+	s.Require().NoError(s.sim.Close())
+}
+
+func (s *Suite) TestEKCertificate() {
+	ekCertificate, err := s.device.ReadEKCertificate()
+	s.Require().NoError(err)
+	s.Require().Equal(s.sim.EKCertificate(), ekCertificate)
+}
+
+func (s *Suite) TestCredential() {
+	require := s.Require()
+
+	blob, encryptedSecret, err := MakeCredential(s.ek, s.ak, []byte("secret"))
+	require.NoError(err)
+	secret, err := s.device.ActivateCredential(s.akHandle, s.ekHandle, blob, encryptedSecret)
+	require.NoError(err)
+	require.Equal([]byte("secret"), secret)
+
+	// the credential is bound to the key it was made for
+	blob, encryptedSecret, err = MakeCredential(s.ek, s.ek, []byte("secret"))
+	require.NoError(err)
+	_, err = s.device.ActivateCredential(s.akHandle, s.ekHandle, blob, encryptedSecret)
+	require.Error(err)
+
+	// every session was flushed
+	loaded, err := s.sim.LoadedHandles()
+	require.NoError(err)
+	require.Equal(2, loaded)
+
+	// the endorsement key must be a storage key
+	_, _, err = MakeCredential(s.ak, s.ak, []byte("secret"))
+	require.EqualError(err, "endorsement key is not a restricted decryption key")
+}
+
+func (s *Suite) TestVerifyAKPublic() {
+	require := s.Require()
+
+	require.NoError(VerifyAKPublic(s.ak))
+	require.EqualError(VerifyAKPublic(s.ek), "attestation key is not a restricted signing key bound to the TPM")
+}
+
+func (s *Suite) TestVerifyQuote() {
+	require := s.Require()
+
+	require.NoError(s.sim.ExtendPCR(7, []byte("secure boot")))
+
+	// more PCRs than the TPM returns in a single PCR_Read
+	pcrs := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 23}
+	nonce := []byte("nonce")
+	values, err := s.device.ReadPCRs(pcrs)
+	require.NoError(err)
+	require.Len(values, len(pcrs))
+	pcr7, err := s.sim.PCRValue(7)
+	require.NoError(err)
+	require.Equal(pcr7, values[7])
+
+	quote, signature, err := s.device.Quote(s.akHandle, nonce, pcrs)
+	require.NoError(err)
+	response := &Response{
+		Quote:          quote,
+		QuoteSignature: signature,
+		PCRValues:      values,
+	}
+	require.NoError(VerifyQuote(s.ak, nonce, response))
+
+	// wrong nonce
+	require.EqualError(VerifyQuote(s.ak, []byte("other"), response), "quote nonce mismatch")
+
+	// quote signed by another key
+	err = VerifyQuote(s.ek, nonce, response)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid quote signature")
+
+	// tampered quote
+	tampered := *response
+	tampered.Quote = append([]byte(nil), response.Quote...)
+	tampered.Quote[len(tampered.Quote)-1] ^= 0xFF
+	err = VerifyQuote(s.ak, nonce, &tampered)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid quote signature")
+
+	// forged PCR values
+	forged := copyValues(values)
+	forged[7] = make([]byte, sha256.Size)
+	require.EqualError(VerifyQuote(s.ak, nonce, &Response{
+		Quote:          quote,
+		QuoteSignature: signature,
+		PCRValues:      forged,
+	}), "quoted PCR digest does not match the PCR values")
+
+	// values of PCRs that were not quoted
+	extra := copyValues(values)
+	extra[10] = make([]byte, sha256.Size)
+	require.EqualError(VerifyQuote(s.ak, nonce, &Response{
+		Quote:          quote,
+		QuoteSignature: signature,
+		PCRValues:      extra,
+	}), "quoted PCRs do not match the PCR values")
+	missing := copyValues(values)
+	delete(missing, 23)
+	missing[10] = make([]byte, sha256.Size)
+	require.EqualError(VerifyQuote(s.ak, nonce, &Response{
+		Quote:          quote,
+		QuoteSignature: signature,
+		PCRValues:      missing,
+	}), "missing value of quoted PCR 23")
+}
+
+func copyValues(values map[int][]byte) map[int][]byte {
+	out := make(map[int][]byte)
+	for pcr, value := range values {
+		out[pcr] = value
+	}
+	return out
+}
@@ -0,0 +1,21 @@
+package tpm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The tests that talk to a TPM run against the TPM simulator, which needs
+// cgo and OpenSSL 1.x. They are in tpm_simulator_test.go and only built with
+// the tpmsimulator build tag.
+
+func TestValidatePCRs(t *testing.T) {
+	require.NoError(t, ValidatePCRs([]int{0, 7, 23}))
+	require.EqualError(t, ValidatePCRs([]int{0, 24}), "invalid PCR index 24")
+	require.EqualError(t, ValidatePCRs([]int{-1}), "invalid PCR index -1")
+}
+
+func TestSpiffeID(t *testing.T) {
+	require.Equal(t, "spiffe://example.org/spire/agent/tpm/abcd", SpiffeID("example.org", "abcd"))
+}
@@ -14,12 +14,20 @@ import (
 	"math/big"
 	"net/url"
 	"path"
+	"text/template"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
 )
 
 const (
+	PluginName = "x509pop"
+
 	nonceLen = 32
 )
 
+// DefaultAgentPathTemplate is the default text/template
+var DefaultAgentPathTemplate = template.Must(template.New("agent-path").Parse("{{ .PluginName }}/{{ .Fingerprint }}"))
+
 type AttestationData struct {
 	// DER encoded x509 certificate chain leading back to the trusted root. The
 	// leaf certificate comes first.
@@ -258,6 +266,48 @@ func SpiffeID(trustDomain string, cert *x509.Certificate) string {
 	return u.String()
 }
 
+type agentPathTemplateData struct {
+	PluginName      string
+	Fingerprint     string
+	SerialNumberHex string
+	Subject         agentPathSubject
+	DNSNames        []idutil.PathSegment
+}
+
+type agentPathSubject struct {
+	CommonName         idutil.PathSegment
+	SerialNumber       idutil.PathSegment
+	Country            []idutil.PathSegment
+	Organization       []idutil.PathSegment
+	OrganizationalUnit []idutil.PathSegment
+	Locality           []idutil.PathSegment
+	Province           []idutil.PathSegment
+}
+
+// MakeSpiffeID makes an agent spiffe ID. The ID always has a host value equal to the given trust domain,
+// the path is created using the given agentPathTemplate which is given access to the subject and DNS names
+// of the leaf certificate (e.g. .Subject.CommonName, .DNSNames), its SHA1 .Fingerprint and its
+// .SerialNumberHex. The values taken from the certificate must be single path segments, and the path
+// must be below the plugin name.
+func MakeSpiffeID(trustDomain string, agentPathTemplate *template.Template, cert *x509.Certificate) (*url.URL, error) {
+	agentPath := idutil.NewAgentPath(PluginName)
+	return agentPath.MakeURI(trustDomain, agentPathTemplate, agentPathTemplateData{
+		PluginName:      PluginName,
+		Fingerprint:     Fingerprint(cert),
+		SerialNumberHex: cert.SerialNumber.Text(16),
+		Subject: agentPathSubject{
+			CommonName:         agentPath.Segment(cert.Subject.CommonName),
+			SerialNumber:       agentPath.Segment(cert.Subject.SerialNumber),
+			Country:            agentPath.Segments(cert.Subject.Country),
+			Organization:       agentPath.Segments(cert.Subject.Organization),
+			OrganizationalUnit: agentPath.Segments(cert.Subject.OrganizationalUnit),
+			Locality:           agentPath.Segments(cert.Subject.Locality),
+			Province:           agentPath.Segments(cert.Subject.Province),
+		},
+		DNSNames: agentPath.Segments(cert.DNSNames),
+	})
+}
+
 func randBytes(n int) ([]byte, error) {
 	b := make([]byte, n)
 	if _, err := rand.Read(b); err != nil {
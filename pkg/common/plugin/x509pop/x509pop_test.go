@@ -2,12 +2,16 @@ package x509pop
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"fmt"
 	"math/big"
 	"testing"
+	"text/template"
 
 	"github.com/stretchr/testify/require"
 )
@@ -125,3 +129,43 @@ func createBadCertificate(privateKey, publicKey interface{}) (*x509.Certificate,
 	}
 	return x509.ParseCertificate(certBytes)
 }
+
+func TestMakeSpiffeID(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(0x1234),
+		Subject:      pkix.Name{CommonName: "node1"},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	// the default template produces the fingerprint based ID
+	id, err := MakeSpiffeID("example.org", DefaultAgentPathTemplate, cert)
+	require.NoError(t, err)
+	require.Equal(t, SpiffeID("example.org", cert), id.String())
+
+	agentPathTemplate := template.Must(template.New("agent-path").Parse("{{ .PluginName }}/{{ .Subject.CommonName }}/{{ .SerialNumberHex }}"))
+	id, err = MakeSpiffeID("example.org", agentPathTemplate, cert)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/spire/agent/x509pop/node1/1234", id.String())
+
+	// certificate values cannot traverse out of the plugin's agent IDs
+	agentPathTemplate = template.Must(template.New("agent-path").Parse("{{ .PluginName }}/{{ .Subject.CommonName }}"))
+	for _, commonName := range []string{"..", ".", "../aws_iid/123456789012/us-west-2/i-0123456789abcdef0", "node1/node2", ""} {
+		tmpl.Subject.CommonName = commonName
+		certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, privateKey.Public(), privateKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(certBytes)
+		require.NoError(t, err)
+		_, err = MakeSpiffeID("example.org", agentPathTemplate, cert)
+		require.EqualError(t, err, fmt.Sprintf("agent path template value %q is not a single path segment", commonName))
+	}
+
+	// the values are only checked if the template uses them
+	id, err = MakeSpiffeID("example.org", DefaultAgentPathTemplate, cert)
+	require.NoError(t, err)
+	require.Equal(t, SpiffeID("example.org", cert), id.String())
+}
@@ -20,8 +20,7 @@ func MakeCSR(privateKey interface{}, spiffeId string) (csr []byte, err error) {
 			Country:      []string{"US"},
 			Organization: []string{"SPIRE"},
 		},
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{uriSAN},
+		URIs: []*url.URL{uriSAN},
 	}
 
 	csr, err = x509.CreateCertificateRequest(rand.Reader, template, privateKey)
@@ -49,3 +49,20 @@ func DERFromCertificates(certs []*x509.Certificate) (derBytes []byte) {
 	}
 	return derBytes
 }
+
+// ChainsToRoots returns true if any certificate in the chain is one of the
+// roots or is signed by one of them. Validity periods and key usages are not
+// considered. An empty set of roots is treated as unknown and returns true.
+func ChainsToRoots(chain []*x509.Certificate, roots []*x509.Certificate) bool {
+	if len(roots) == 0 {
+		return true
+	}
+	for _, cert := range chain {
+		for _, root := range roots {
+			if cert.Equal(root) || cert.CheckSignatureFrom(root) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -37,8 +37,16 @@ type serverCAConfig struct {
 
 type ServerCA interface {
 	SignX509SVID(ctx context.Context, csrDER []byte, ttl time.Duration) ([]*x509.Certificate, error)
+	// SignAgentX509SVID signs an X509-SVID for the public key of the CSR
+	// with the given agent SPIFFE ID, which may differ from the SPIFFE ID
+	// requested in the CSR.
+	SignAgentX509SVID(ctx context.Context, csrDER []byte, agentID string) ([]*x509.Certificate, error)
 	SignX509CASVID(ctx context.Context, csrDER []byte, ttl time.Duration) ([]*x509.Certificate, error)
 	SignJWTSVID(ctx context.Context, jsr *node.JSR) (string, error)
+
+	// X509CA returns the X.509 CA certificate currently used for signing,
+	// or nil if there is none.
+	X509CA() *x509.Certificate
 }
 
 type serverCA struct {
@@ -73,7 +81,23 @@ func (ca *serverCA) getKeypairSet() *keypairSet {
 	return ca.kp
 }
 
+func (ca *serverCA) X509CA() *x509.Certificate {
+	kp := ca.getKeypairSet()
+	if kp == nil || kp.x509CA == nil {
+		return nil
+	}
+	return kp.x509CA.cert()
+}
+
 func (ca *serverCA) SignX509SVID(ctx context.Context, csrDER []byte, ttl time.Duration) ([]*x509.Certificate, error) {
+	return ca.signX509SVID(ctx, csrDER, "", ttl)
+}
+
+func (ca *serverCA) SignAgentX509SVID(ctx context.Context, csrDER []byte, agentID string) ([]*x509.Certificate, error) {
+	return ca.signX509SVID(ctx, csrDER, agentID, 0)
+}
+
+func (ca *serverCA) signX509SVID(ctx context.Context, csrDER []byte, agentID string, ttl time.Duration) ([]*x509.Certificate, error) {
 	kp := ca.getKeypairSet()
 	if kp == nil || kp.x509CA == nil || len(kp.x509CA.chain) < 1 {
 		return nil, errors.New("no X509-SVID keypair available")
@@ -91,7 +115,13 @@ func (ca *serverCA) SignX509SVID(ctx context.Context, csrDER []byte, ttl time.Du
 
 	serialNumber := big.NewInt(atomic.AddInt64(&ca.x509sn, 1))
 
-	template, err := CreateX509SVIDTemplate(csrDER, ca.c.TrustDomain.Host, notBefore, notAfter, serialNumber)
+	var template *x509.Certificate
+	var err error
+	if agentID != "" {
+		template, err = CreateAgentX509SVIDTemplate(csrDER, ca.c.TrustDomain.Host, agentID, notBefore, notAfter, serialNumber)
+	} else {
+		template, err = CreateX509SVIDTemplate(csrDER, ca.c.TrustDomain.Host, notBefore, notAfter, serialNumber)
+	}
 	if err != nil {
 		return nil, err
 	}
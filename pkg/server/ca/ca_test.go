@@ -18,7 +18,6 @@ import (
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
-	"github.com/spiffe/spire/proto/server/keymanager"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/stretchr/testify/suite"
 )
@@ -51,13 +50,13 @@ func (s *CATestSuite) SetupTest() {
 	s.now = time.Now().Truncate(time.Second).UTC()
 
 	km := memory.New()
-	x509CASigner, err := cryptoutil.GenerateKeyAndSigner(ctx, km, "x509-CA-FOO", keymanager.KeyType_EC_P256)
+	x509CASigner, err := cryptoutil.GenerateKeyAndSigner(ctx, km, "x509-CA-FOO", common.KeyType_EC_P256)
 	s.Require().NoError(err)
 
 	cert, err := SelfSignServerCACertificate(x509CASigner, "example.org", pkix.Name{}, s.now, s.now.Add(time.Minute*10))
 	s.Require().NoError(err)
 
-	jwtSigningKeyPKIX, err := cryptoutil.GenerateKeyRaw(ctx, km, "JWT-Signer-FOO", keymanager.KeyType_EC_P256)
+	jwtSigningKeyPKIX, err := cryptoutil.GenerateKeyRaw(ctx, km, "JWT-Signer-FOO", common.KeyType_EC_P256)
 	s.Require().NoError(err)
 
 	jwtSigningKey, err := caPublicKeyFromPublicKey(&common.PublicKey{
@@ -174,6 +173,29 @@ func (s *CATestSuite) TestSignX509SVIDIncrementsSerialNumber() {
 	s.Require().Equal(0, svid2[0].SerialNumber.Cmp(big.NewInt(2)))
 }
 
+func (s *CATestSuite) TestSignAgentX509SVID() {
+	svid, err := s.ca.SignAgentX509SVID(ctx, s.generateCSR("example.org"), "spiffe://example.org/spire/agent/foo")
+	s.Require().NoError(err)
+	s.Require().Len(svid, 2)
+	s.Require().Len(svid[0].URIs, 1)
+	s.Require().Equal("spiffe://example.org/spire/agent/foo", svid[0].URIs[0].String())
+	s.Require().Equal(s.now.Add(-backdate), svid[0].NotBefore)
+	s.Require().Equal(s.now.Add(time.Minute), svid[0].NotAfter)
+}
+
+func (s *CATestSuite) TestSignAgentX509SVIDValidatesAgentID() {
+	_, err := s.ca.SignAgentX509SVID(ctx, s.generateCSR("example.org"), "spiffe://example.org/foo")
+	s.Require().Error(err)
+
+	_, err = s.ca.SignAgentX509SVID(ctx, s.generateCSR("example.org"), "spiffe://foo.com/spire/agent/foo")
+	s.Require().Error(err)
+}
+
+func (s *CATestSuite) TestSignAgentX509SVIDValidatesCSR() {
+	_, err := s.ca.SignAgentX509SVID(ctx, s.generateCSR("foo.com"), "spiffe://example.org/spire/agent/foo")
+	s.Require().EqualError(err, `"spiffe://foo.com" does not belong to trust domain "example.org"`)
+}
+
 func (s *CATestSuite) TestNoJWTKeypairSet() {
 	ca := newServerCA(s.ca.c)
 	_, err := ca.SignJWTSVID(ctx, s.generateJSR("example.org", 0))
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,13 +16,14 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
-	"github.com/spiffe/spire/pkg/common/diskutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/common/x509util"
@@ -31,13 +33,40 @@ import (
 	"github.com/spiffe/spire/proto/server/keymanager"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	DefaultSVIDTTL  = time.Hour
-	DefaultCATTL    = 24 * time.Hour
-	backdate        = time.Second * 10
-	safetyThreshold = 24 * time.Hour
+	DefaultSVIDTTL    = time.Hour
+	DefaultCATTL      = 24 * time.Hour
+	DefaultCAKeyType  = common.KeyType_EC_P384
+	DefaultJWTKeyType = common.KeyType_EC_P256
+	backdate          = time.Second * 10
+	safetyThreshold   = 24 * time.Hour
+
+	// journalLeaseTTL is how long a server may hold the CA journal lease
+	// while preparing a keypair set before other servers consider it
+	// abandoned.
+	journalLeaseTTL = 5 * time.Minute
+
+	// keyHolderTTL is how long a server that holds the private keys for the
+	// keypair sets in the CA journal is considered alive after it last
+	// recorded itself as the key holder. Servers refresh the record halfway
+	// through.
+	keyHolderTTL = 5 * time.Minute
+
+	// journalPollInterval is how often Initialize checks the CA journal
+	// while waiting for another server to prepare the first keypair set.
+	journalPollInterval = 5 * time.Second
+
+	// upstreamBundleRetryInterval is how long to wait before reopening the
+	// upstream bundle update stream after it fails.
+	upstreamBundleRetryInterval = 30 * time.Second
+
+	// releaseKeyHolderTimeout bounds how long shutdown waits on the
+	// datastore to release the CA journal keys.
+	releaseKeyHolderTimeout = 5 * time.Second
 )
 
 type ManagerConfig struct {
@@ -47,9 +76,15 @@ type ManagerConfig struct {
 	SVIDTTL        time.Duration
 	CATTL          time.Duration
 	CASubject      pkix.Name
-	CertsPath      string
+	CAKeyType      common.KeyType
+	JWTKeyType     common.KeyType
 	Log            logrus.FieldLogger
 	Metrics        telemetry.Metrics
+
+	// CertsPath is the path of the legacy on-disk keypair journal. It is only
+	// read to import keypair sets into the datastore when the datastore does
+	// not hold a CA journal yet.
+	CertsPath string
 }
 
 type Manager interface {
@@ -62,6 +97,40 @@ type Manager interface {
 
 	// Returns the CA being managed
 	CA() ServerCA
+
+	// Returns the active keypair set, followed by the prepared keypair set,
+	// if any.
+	KeypairSets(ctx context.Context) ([]KeypairSetInfo, error)
+
+	// Prepares a new keypair set and adds it to the bundle. Fails if there
+	// is already a prepared keypair set.
+	PrepareKeypairSet(ctx context.Context) (*KeypairSetInfo, error)
+
+	// Activates the prepared keypair set immediately.
+	ActivateKeypairSet(ctx context.Context) (*KeypairSetInfo, error)
+
+	// Revokes an X.509 CA that is no longer active by removing it from the
+	// bundle. If it belongs to the prepared keypair set, the keypair set is
+	// discarded.
+	RevokeX509CA(ctx context.Context, keyID string) error
+
+	// Revokes a JWT signing key that is no longer active by removing it
+	// from the bundle. If it belongs to the prepared keypair set, the
+	// keypair set is discarded.
+	RevokeJWTSigningKey(ctx context.Context, kid string) error
+
+	// Publishes a JWT signing key of a downstream server to the trust
+	// domain bundle, forwarding it to the upstream trust domain when
+	// joining the upstream PKI. Returns the JWT signing keys of the bundle.
+	PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error)
+}
+
+// KeypairSetInfo describes a keypair set managed by the CA manager
+type KeypairSetInfo struct {
+	Slot          string
+	Active        bool
+	X509CA        *x509.Certificate
+	JWTSigningKey *common.PublicKey
 }
 
 type caX509CA struct {
@@ -111,11 +180,37 @@ type manager struct {
 	c  *ManagerConfig
 	ca *serverCA
 
+	// mu protects the keypair sets and journal state, which are modified
+	// both by the rotation loop and by administrative requests.
+	mu sync.Mutex
+
 	current *keypairSet
 	next    *keypairSet
 
+	// serverID identifies this server when holding the CA journal lease
+	serverID string
+
+	// journalVersion is the version of the CA journal last read from or
+	// written to the datastore. Zero if the journal has not been stored yet.
+	journalVersion int64
+
+	// lease is the CA journal lease, as last read from or written to the
+	// datastore. Only the lease holder prepares new keypair sets.
+	lease *journalLease
+
+	// keyHolder is the server that last recorded that it holds the private
+	// keys for the keypair sets in the CA journal. Servers that do not hold
+	// the keys never replace the keypair sets while it is alive.
+	keyHolder *journalLease
+
+	// upstreamBundle is the upstream bundle last merged into the trust
+	// bundle. Its root CAs and JWT signing keys are replaced by those of the
+	// next upstream bundle update.
+	upstreamBundle *common.Bundle
+
 	hooks struct {
-		now func() time.Time
+		now   func() time.Time
+		after func(time.Duration) <-chan time.Time
 	}
 }
 
@@ -126,6 +221,12 @@ func NewManager(c *ManagerConfig) *manager {
 	if c.CATTL <= 0 {
 		c.CATTL = DefaultCATTL
 	}
+	if c.CAKeyType == common.KeyType_UNSPECIFIED_KEY_TYPE {
+		c.CAKeyType = DefaultCAKeyType
+	}
+	if c.JWTKeyType == common.KeyType_UNSPECIFIED_KEY_TYPE {
+		c.JWTKeyType = DefaultJWTKeyType
+	}
 
 	m := &manager{
 		c: c,
@@ -143,33 +244,67 @@ func NewManager(c *ManagerConfig) *manager {
 		next: &keypairSet{
 			slot: "B",
 		},
+		serverID: newServerID(),
 	}
 	m.hooks.now = time.Now
+	m.hooks.after = time.After
 	return m
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
-	if err := m.loadKeypairSets(ctx); err != nil {
-		return err
-	}
-	if err := m.rotateCAs(ctx); err != nil {
+	m.mu.Lock()
+	_, err := m.loadJournal(ctx, false)
+	m.mu.Unlock()
+	if err != nil {
 		return err
 	}
 
-	return nil
+	for {
+		if err := m.rotateCAs(ctx); err != nil {
+			return err
+		}
+		if m.hasCurrentKeypairSet() {
+			return nil
+		}
+
+		// another server holds the journal lease and is preparing the
+		// first keypair set. wait for it to show up in the journal.
+		m.c.Log.Info("Waiting for another server to prepare the CA keypair set")
+		select {
+		case <-m.hooks.after(journalPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 func (m *manager) Run(ctx context.Context) error {
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
 			return m.rotateCAsEvery(ctx, 1*time.Minute)
 		},
 		func(ctx context.Context) error {
 			return m.pruneBundleEvery(ctx, 6*time.Hour)
+		},
+	}
+	// the upstream bundle is only part of the trust bundle when joining the
+	// upstream PKI.
+	if upstreamCAs := m.c.Catalog.UpstreamCAs(); len(upstreamCAs) > 0 && m.c.UpstreamBundle {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return m.streamUpstreamBundleUpdates(ctx, upstreamCAs[0])
 		})
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
+
+	// let a restarted server with a non-persistent key manager take over
+	// the CA journal right away.
+	releaseCtx, cancel := context.WithTimeout(context.Background(), releaseKeyHolderTimeout)
+	defer cancel()
+	m.releaseKeyHolder(releaseCtx)
 	return err
 }
 
@@ -195,6 +330,131 @@ func (m *manager) rotateCAsEvery(ctx context.Context, interval time.Duration) er
 }
 
 func (m *manager) rotateCAs(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// pick up keypair sets prepared or activated by other servers
+	if _, err := m.loadJournal(ctx, false); err != nil {
+		return err
+	}
+
+	if m.current.x509CA == nil || (m.next.x509CA == nil && m.shouldPrepare()) {
+		prepared, err := m.prepareKeypairSets(ctx)
+		if err != nil {
+			return err
+		}
+		if !prepared {
+			return nil
+		}
+	}
+
+	if m.shouldActivate() {
+		m.current.Reset()
+		m.current, m.next = m.next, m.current
+		committed, err := m.commitJournal(ctx)
+		if err != nil {
+			return err
+		}
+		// if another server updated the journal first, the keypair sets
+		// have been reloaded (and activated) from the journal instead.
+		if committed {
+			m.setKeypairSet()
+		}
+	}
+
+	if m.current.x509CA != nil && m.shouldRefreshKeyHolder() {
+		m.holdKeys()
+		if _, err := m.commitJournal(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// holdKeys records this server as the holder of the private keys for the
+// keypair sets. It is committed with the next journal write.
+func (m *manager) holdKeys() {
+	m.keyHolder = &journalLease{
+		ServerID:  m.serverID,
+		ExpiresAt: m.hooks.now().Add(keyHolderTTL).Unix(),
+	}
+}
+
+// shouldRefreshKeyHolder returns true if the key holder record is missing or
+// about to expire. Any server holding the keys can refresh it.
+func (m *manager) shouldRefreshKeyHolder() bool {
+	return m.keyHolder == nil || m.hooks.now().After(time.Unix(m.keyHolder.ExpiresAt, 0).Add(-keyHolderTTL/2))
+}
+
+// keyHolderAlive returns true if the given key holder is another server that
+// recorded itself recently enough.
+func (m *manager) keyHolderAlive(keyHolder *journalLease) bool {
+	return keyHolder != nil && keyHolder.ServerID != m.serverID &&
+		m.hooks.now().Before(time.Unix(keyHolder.ExpiresAt, 0))
+}
+
+// releaseKeyHolder removes the key holder record from the CA journal when
+// this server holds the keys. Other servers holding the keys record
+// themselves again on their next rotation.
+func (m *manager) releaseKeyHolder(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.keyHolder == nil || m.current.x509CA == nil {
+		return
+	}
+	m.keyHolder = nil
+	if _, err := m.commitJournal(ctx); err != nil {
+		m.c.Log.Warnf("Unable to release the CA journal keys: %v", err)
+	}
+}
+
+// prepareKeypairSets prepares the current keypair set if there is none and
+// the next keypair set if the current is within the preparation threshold.
+// Keypair sets are only prepared while holding the journal lease so that
+// servers sharing a datastore (and key manager) do not prepare competing
+// keypair sets. Returns false if another server holds the lease.
+func (m *manager) prepareKeypairSets(ctx context.Context) (bool, error) {
+	acquired, err := m.withLease(ctx, m.prepareKeypairSetsWithLease)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		m.c.Log.Debug("CA journal lease held by another server; skipping keypair set preparation")
+		return false, nil
+	}
+	return true, nil
+}
+
+// withLease runs fn while holding the journal lease. The lease is released
+// and the keypair sets are committed to the journal afterwards, even if fn
+// fails. Returns false if another server holds the lease.
+func (m *manager) withLease(ctx context.Context, fn func(context.Context) error) (bool, error) {
+	acquired, err := m.acquireLease(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	err = fn(ctx)
+
+	m.lease = nil
+	if m.current.x509CA != nil {
+		m.holdKeys()
+	}
+	if _, commitErr := m.commitJournal(ctx); commitErr != nil && err == nil {
+		err = commitErr
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *manager) prepareKeypairSetsWithLease(ctx context.Context) error {
 	// if there is no current keypair set, generate one
 	if m.current.x509CA == nil {
 		if err := m.prepareKeypairSet(ctx, m.current); err != nil {
@@ -211,16 +471,256 @@ func (m *manager) rotateCAs(ctx context.Context) error {
 		}
 	}
 
-	if m.shouldActivate() {
-		m.current.Reset()
-		m.current, m.next = m.next, m.current
-		m.writeKeypairSets()
-		m.setKeypairSet()
+	return nil
+}
+
+func (m *manager) acquireLease(ctx context.Context) (bool, error) {
+	now := m.hooks.now()
+	if m.lease != nil && m.lease.ServerID != m.serverID && now.Before(time.Unix(m.lease.ExpiresAt, 0)) {
+		return false, nil
 	}
 
+	m.lease = &journalLease{
+		ServerID:  m.serverID,
+		ExpiresAt: now.Add(journalLeaseTTL).Unix(),
+	}
+	return m.commitJournal(ctx)
+}
+
+func (m *manager) KeypairSets(ctx context.Context) ([]KeypairSetInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.loadJournal(ctx, false); err != nil {
+		return nil, err
+	}
+
+	var infos []KeypairSetInfo
+	for _, kps := range []*keypairSet{m.current, m.next} {
+		if kps.x509CA != nil {
+			infos = append(infos, m.keypairSetInfo(kps))
+		}
+	}
+	return infos, nil
+}
+
+func (m *manager) PrepareKeypairSet(ctx context.Context) (*KeypairSetInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.loadJournal(ctx, false); err != nil {
+		return nil, err
+	}
+	if m.current.x509CA == nil {
+		return nil, errors.New("no active keypair set")
+	}
+	if m.next.x509CA != nil {
+		return nil, errors.New("a keypair set is already prepared; activate or revoke it first")
+	}
+
+	acquired, err := m.withLease(ctx, func(ctx context.Context) error {
+		return m.prepareKeypairSet(ctx, m.next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, errors.New("another server is preparing a keypair set")
+	}
+	if m.next.x509CA == nil {
+		return nil, errors.New("keypair set was not prepared")
+	}
+
+	m.c.Log.Infof("Prepared keypair set %q on request", m.next.slot)
+	info := m.keypairSetInfo(m.next)
+	return &info, nil
+}
+
+func (m *manager) ActivateKeypairSet(ctx context.Context) (*KeypairSetInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.loadJournal(ctx, false); err != nil {
+		return nil, err
+	}
+	if m.next.x509CA == nil {
+		return nil, errors.New("no prepared keypair set to activate")
+	}
+
+	m.current.Reset()
+	m.current, m.next = m.next, m.current
+	committed, err := m.commitJournal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !committed {
+		return nil, errors.New("CA journal was updated by another server; try again")
+	}
+	m.setKeypairSet()
+
+	m.c.Log.Infof("Activated keypair set %q on request", m.current.slot)
+	info := m.keypairSetInfo(m.current)
+	return &info, nil
+}
+
+func (m *manager) RevokeX509CA(ctx context.Context, keyID string) error {
+	matchesKeypairSet := func(kps *keypairSet) bool {
+		return kps.x509CA != nil && X509CAKeyID(kps.x509CA.cert()) == keyID
+	}
+	what := fmt.Sprintf("X.509 CA %q", keyID)
+	if m.upstreamBundleEnabled() {
+		// X509-SVIDs chain to the upstream roots, so removing an X.509 CA
+		// from the bundle does not revoke it. Only the prepared keypair set
+		// can still be discarded.
+		return m.revokeKey(ctx, matchesKeypairSet, nil, what)
+	}
+	return m.revokeKey(ctx, matchesKeypairSet, func(bundle *common.Bundle) bool {
+		revoked := false
+		var rootCAs []*common.Certificate
+		for _, rootCA := range bundle.RootCas {
+			cert, err := x509.ParseCertificate(rootCA.DerBytes)
+			if err == nil && X509CAKeyID(cert) == keyID {
+				revoked = true
+				continue
+			}
+			rootCAs = append(rootCAs, rootCA)
+		}
+		bundle.RootCas = rootCAs
+		return revoked
+	}, what)
+}
+
+func (m *manager) RevokeJWTSigningKey(ctx context.Context, kid string) error {
+	return m.revokeKey(ctx, func(kps *keypairSet) bool {
+		return kps.jwtSigningKey != nil && kps.jwtSigningKey.Kid == kid
+	}, func(bundle *common.Bundle) bool {
+		revoked := false
+		var jwtSigningKeys []*common.PublicKey
+		for _, jwtSigningKey := range bundle.JwtSigningKeys {
+			if jwtSigningKey.Kid == kid {
+				revoked = true
+				continue
+			}
+			jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
+		}
+		bundle.JwtSigningKeys = jwtSigningKeys
+		return revoked
+	}, fmt.Sprintf("JWT signing key %q", kid))
+}
+
+// revokeKey removes a key from the bundle. matchesKeypairSet reports whether
+// a keypair set holds the key and removeFromBundle removes the key from the
+// bundle, reporting whether it was present. A nil removeFromBundle means the
+// key cannot be revoked through the bundle, so only a key of the prepared
+// keypair set can be revoked by discarding the set.
+func (m *manager) revokeKey(ctx context.Context, matchesKeypairSet func(*keypairSet) bool, removeFromBundle func(*common.Bundle) bool, what string) (err error) {
+	defer telemetry.CountCall(m.c.Metrics, "manager", "key", "revoke")(&err)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.loadJournal(ctx, false); err != nil {
+		return err
+	}
+	if matchesKeypairSet(m.current) {
+		return fmt.Errorf("cannot revoke %s: it belongs to the active keypair set", what)
+	}
+	if removeFromBundle == nil && !matchesKeypairSet(m.next) {
+		return fmt.Errorf("cannot revoke %s: the trust bundle holds the upstream root CAs, so it must be revoked in the upstream PKI", what)
+	}
+
+	ds := m.c.Catalog.DataStores()[0]
+	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if resp.Bundle == nil {
+		return fmt.Errorf("%s not found in bundle", what)
+	}
+	bundle := cloneBundle(resp.Bundle)
+	revoked := false
+	if removeFromBundle != nil {
+		revoked = removeFromBundle(bundle)
+	}
+
+	// revoking a key of the prepared keypair set discards the whole set,
+	// including its other key.
+	discardNext := matchesKeypairSet(m.next)
+	if discardNext {
+		m.removeKeypairSetFromBundle(bundle, m.next)
+		revoked = true
+	}
+
+	switch {
+	case !revoked:
+		return fmt.Errorf("%s not found in bundle", what)
+	case len(bundle.RootCas) == 0 || len(bundle.JwtSigningKeys) == 0:
+		return fmt.Errorf("cannot revoke %s: bundle would be left empty", what)
+	}
+
+	if discardNext {
+		m.c.Log.Infof("Discarding prepared keypair set %q", m.next.slot)
+		m.next.Reset()
+		committed, err := m.commitJournal(ctx)
+		if err != nil {
+			return err
+		}
+		if !committed {
+			return errors.New("CA journal was updated by another server; try again")
+		}
+	}
+
+	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+	}); err != nil {
+		return fmt.Errorf("write new bundle: %v", err)
+	}
+
+	m.c.Log.Infof("Revoked %s", what)
 	return nil
 }
 
+// upstreamBundleEnabled reports whether the server joins the upstream PKI,
+// i.e. whether the trust bundle holds the upstream root CAs.
+func (m *manager) upstreamBundleEnabled() bool {
+	return len(m.c.Catalog.UpstreamCAs()) > 0 && m.c.UpstreamBundle
+}
+
+func (m *manager) removeKeypairSetFromBundle(bundle *common.Bundle, kps *keypairSet) {
+	var rootCAs []*common.Certificate
+	for _, rootCA := range bundle.RootCas {
+		if !bytes.Equal(rootCA.DerBytes, kps.x509CA.cert().Raw) {
+			rootCAs = append(rootCAs, rootCA)
+		}
+	}
+	bundle.RootCas = rootCAs
+
+	var jwtSigningKeys []*common.PublicKey
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if jwtSigningKey.Kid != kps.jwtSigningKey.Kid {
+			jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
+		}
+	}
+	bundle.JwtSigningKeys = jwtSigningKeys
+}
+
+func (m *manager) keypairSetInfo(kps *keypairSet) KeypairSetInfo {
+	return KeypairSetInfo{
+		Slot:          kps.slot,
+		Active:        kps == m.current,
+		X509CA:        kps.x509CA.cert(),
+		JWTSigningKey: kps.jwtSigningKey.PublicKey,
+	}
+}
+
+func (m *manager) hasCurrentKeypairSet() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current.x509CA != nil
+}
+
 func (m *manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -310,7 +810,243 @@ pruneRootCA:
 	return nil
 }
 
-func (m *manager) appendBundle(ctx context.Context, caChain []*x509.Certificate, jwtSigningKey *common.PublicKey) error {
+// streamUpstreamBundleUpdates merges the upstream trust bundle into the local
+// bundle as it changes, so that rotation of the upstream CA is picked up
+// without waiting for the local CA to rotate. The stream is reopened if it
+// fails. Upstream CAs that do not support streaming are ignored.
+func (m *manager) streamUpstreamBundleUpdates(ctx context.Context, upstreamCA upstreamca.UpstreamCA) error {
+	for {
+		err := m.receiveUpstreamBundleUpdates(ctx, upstreamCA)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case status.Code(err) == codes.Unimplemented:
+			m.c.Log.Debug("Upstream CA does not support streaming bundle updates")
+			return nil
+		}
+		m.c.Log.Errorf("Upstream bundle update stream failed: %v", err)
+
+		select {
+		case <-m.hooks.after(upstreamBundleRetryInterval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *manager) receiveUpstreamBundleUpdates(ctx context.Context, upstreamCA upstreamca.UpstreamCA) error {
+	stream, err := upstreamCA.StreamBundleUpdates(ctx, &upstreamca.StreamBundleUpdatesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := m.appendUpstreamBundle(ctx, resp.UpstreamBundle); err != nil {
+			return err
+		}
+	}
+}
+
+// appendUpstreamBundle replaces the root CAs and JWT signing keys of the
+// previous upstream bundle update with those of the given upstream bundle, so
+// that roots and keys removed upstream are removed from the trust bundle as
+// well. Roots and keys minted locally are always kept. Roots and keys that
+// were removed upstream while the server was not running are left to be
+// pruned once they expire.
+func (m *manager) appendUpstreamBundle(ctx context.Context, upstreamBundle *common.Bundle) (err error) {
+	defer telemetry.CountCall(m.c.Metrics, "manager", "bundle", "upstream_update")(&err)
+
+	if upstreamBundle == nil {
+		return errors.New("upstream bundle update is missing the bundle")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ds := m.c.Catalog.DataStores()[0]
+	resp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	bundle := &common.Bundle{
+		TrustDomainId: m.c.TrustDomain.String(),
+	}
+	if resp.Bundle != nil {
+		bundle = cloneBundle(resp.Bundle)
+	}
+
+	removed := 0
+	if m.upstreamBundle != nil {
+		removed = m.removeStaleUpstreamEntries(bundle, upstreamBundle)
+	}
+	bundle, _ = bundleutil.MergeBundles(bundle, &common.Bundle{
+		RootCas:        upstreamBundle.RootCas,
+		JwtSigningKeys: upstreamBundle.JwtSigningKeys,
+	})
+
+	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+	}); err != nil {
+		return errs.Wrap(err)
+	}
+	m.upstreamBundle = cloneBundle(upstreamBundle)
+
+	m.c.Log.Debugf("Merged upstream bundle (%d root CAs, %d JWT signing keys, %d removed)", len(upstreamBundle.RootCas), len(upstreamBundle.JwtSigningKeys), removed)
+	return nil
+}
+
+// removeStaleUpstreamEntries removes the root CAs and JWT signing keys of the
+// previous upstream bundle update that are not in the new upstream bundle from
+// the bundle, except those of the keypair sets. Returns the number of entries
+// removed.
+func (m *manager) removeStaleUpstreamEntries(bundle, upstreamBundle *common.Bundle) int {
+	stale := make(map[string]bool)
+	for _, rootCA := range m.upstreamBundle.RootCas {
+		stale[rootCA.String()] = true
+	}
+	for _, jwtSigningKey := range m.upstreamBundle.JwtSigningKeys {
+		stale[jwtSigningKey.String()] = true
+	}
+	for _, rootCA := range upstreamBundle.RootCas {
+		delete(stale, rootCA.String())
+	}
+	for _, jwtSigningKey := range upstreamBundle.JwtSigningKeys {
+		delete(stale, jwtSigningKey.String())
+	}
+
+	isLocalRootCA := func(rootCA *common.Certificate) bool {
+		for _, kps := range []*keypairSet{m.current, m.next} {
+			if kps.x509CA != nil && bytes.Equal(rootCA.DerBytes, kps.x509CA.cert().Raw) {
+				return true
+			}
+		}
+		return false
+	}
+	isLocalJWTSigningKey := func(jwtSigningKey *common.PublicKey) bool {
+		for _, kps := range []*keypairSet{m.current, m.next} {
+			if kps.jwtSigningKey != nil && jwtSigningKey.Kid == kps.jwtSigningKey.Kid {
+				return true
+			}
+		}
+		return false
+	}
+
+	removed := 0
+	var rootCAs []*common.Certificate
+	for _, rootCA := range bundle.RootCas {
+		if stale[rootCA.String()] && !isLocalRootCA(rootCA) {
+			removed++
+			continue
+		}
+		rootCAs = append(rootCAs, rootCA)
+	}
+	bundle.RootCas = rootCAs
+
+	var jwtSigningKeys []*common.PublicKey
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if stale[jwtSigningKey.String()] && !isLocalJWTSigningKey(jwtSigningKey) {
+			removed++
+			continue
+		}
+		jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
+	}
+	bundle.JwtSigningKeys = jwtSigningKeys
+
+	return removed
+}
+
+func (m *manager) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) (_ []*common.PublicKey, err error) {
+	defer telemetry.CountCall(m.c.Metrics, "manager", "jwt_key", "publish")(&err)
+
+	m.mu.Lock()
+	x509CA := m.current.x509CA
+	m.mu.Unlock()
+	if x509CA == nil {
+		return nil, errors.New("no X.509 CA is active")
+	}
+
+	// the downstream server is vouched for by the active X.509 CA, so its
+	// JWT signing key cannot outlive it.
+	jwtKey = proto.Clone(jwtKey).(*common.PublicKey)
+	if notAfter := x509CA.cert().NotAfter.Unix(); jwtKey.NotAfter == 0 || jwtKey.NotAfter > notAfter {
+		jwtKey.NotAfter = notAfter
+	}
+
+	ds := m.c.Catalog.DataStores()[0]
+	bundleResp, err := ds.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if bundleResp.Bundle != nil {
+		for _, existing := range bundleResp.Bundle.JwtSigningKeys {
+			if existing.Kid == jwtKey.Kid && !bytes.Equal(existing.PkixBytes, jwtKey.PkixBytes) {
+				return nil, status.Errorf(codes.AlreadyExists, "a different JWT signing key with key ID %q is already published", jwtKey.Kid)
+			}
+		}
+	}
+
+	upstreamJWTKeys, err := m.publishJWTKeyUpstream(ctx, jwtKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  m.c.TrustDomain.String(),
+			JwtSigningKeys: withUpstreamJWTKeys(jwtKey, upstreamJWTKeys),
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	m.c.Log.Debugf("Published JWT signing key %q", jwtKey.Kid)
+	return resp.Bundle.JwtSigningKeys, nil
+}
+
+// publishJWTKeyUpstream publishes the JWT signing key to the upstream trust
+// domain when joining the upstream PKI and returns the upstream JWT signing
+// keys. Upstream CAs that do not support publishing JWT keys are ignored.
+func (m *manager) publishJWTKeyUpstream(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+	if !m.upstreamBundleEnabled() {
+		return nil, nil
+	}
+	upstreamCAs := m.c.Catalog.UpstreamCAs()
+
+	resp, err := upstreamCAs[0].PublishJWTKey(ctx, &upstreamca.PublishJWTKeyRequest{
+		JwtKey: jwtKey,
+	})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		m.c.Log.Debug("Upstream CA does not support publishing JWT keys")
+		return nil, nil
+	case err != nil:
+		return nil, errs.New("unable to publish JWT key upstream: %v", err)
+	}
+	return resp.UpstreamJwtKeys, nil
+}
+
+// withUpstreamJWTKeys returns the JWT signing key followed by the upstream JWT
+// signing keys, which usually include the JWT signing key itself.
+func withUpstreamJWTKeys(jwtKey *common.PublicKey, upstreamJWTKeys []*common.PublicKey) []*common.PublicKey {
+	jwtKeys := []*common.PublicKey{jwtKey}
+	for _, upstreamJWTKey := range upstreamJWTKeys {
+		if !proto.Equal(upstreamJWTKey, jwtKey) {
+			jwtKeys = append(jwtKeys, upstreamJWTKey)
+		}
+	}
+	return jwtKeys
+}
+
+func (m *manager) appendBundle(ctx context.Context, caChain []*x509.Certificate, jwtSigningKeys []*common.PublicKey) error {
 	// TODO: SPIRE 0.8 should only add the "root" to the bundle, instead of
 	// all the intermediates.
 	var rootCAs []*common.Certificate
@@ -323,11 +1059,9 @@ func (m *manager) appendBundle(ctx context.Context, caChain []*x509.Certificate,
 	ds := m.c.Catalog.DataStores()[0]
 	if _, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
 		Bundle: &common.Bundle{
-			TrustDomainId: m.c.TrustDomain.String(),
-			RootCas:       rootCAs,
-			JwtSigningKeys: []*common.PublicKey{
-				jwtSigningKey,
-			},
+			TrustDomainId:  m.c.TrustDomain.String(),
+			RootCas:        rootCAs,
+			JwtSigningKeys: jwtSigningKeys,
 		},
 	}); err != nil {
 		return err
@@ -346,7 +1080,7 @@ func (m *manager) prepareKeypairSet(ctx context.Context, kps *keypairSet) (err e
 	notAfter := now.Add(m.c.CATTL)
 
 	km := m.c.Catalog.KeyManagers()[0]
-	x509CASigner, err := cryptoutil.GenerateKeyAndSigner(ctx, km, kps.X509CAKeyID(), keymanager.KeyType_EC_P384)
+	x509CASigner, err := cryptoutil.GenerateKeyAndSigner(ctx, km, kps.X509CAKeyID(), m.c.CAKeyType)
 	if err != nil {
 		return err
 	}
@@ -385,7 +1119,7 @@ func (m *manager) prepareKeypairSet(ctx context.Context, kps *keypairSet) (err e
 		trustBundle = certChainWithRoot
 	}
 
-	jwtSigningKeyPKIX, err := cryptoutil.GenerateKeyRaw(ctx, km, kps.JWTSignerKeyID(), keymanager.KeyType_EC_P256)
+	jwtSigningKeyPKIX, err := cryptoutil.GenerateKeyRaw(ctx, km, kps.JWTSignerKeyID(), m.c.JWTKeyType)
 	if err != nil {
 		return err
 	}
@@ -404,7 +1138,14 @@ func (m *manager) prepareKeypairSet(ctx context.Context, kps *keypairSet) (err e
 		return err
 	}
 
-	if err := m.appendBundle(ctx, trustBundle, jwtSigningKey.PublicKey); err != nil {
+	// publish the JWT signing key upstream so JWT-SVIDs signed by this
+	// server can be validated throughout the hierarchy.
+	upstreamJWTKeys, err := m.publishJWTKeyUpstream(ctx, jwtSigningKey.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if err := m.appendBundle(ctx, trustBundle, withUpstreamJWTKeys(jwtSigningKey.PublicKey, upstreamJWTKeys)); err != nil {
 		return err
 	}
 
@@ -412,16 +1153,108 @@ func (m *manager) prepareKeypairSet(ctx context.Context, kps *keypairSet) (err e
 		chain: certChainWithRoot,
 	}
 	kps.jwtSigningKey = jwtSigningKey
-	m.writeKeypairSets()
 	m.c.Log.Debugf("Keypair set %q will expire %s", kps.slot, certChainWithRoot[0].NotAfter.Format(time.RFC3339))
 	return nil
 }
 
-func (m *manager) loadKeypairSets(ctx context.Context) error {
-	if m.c.CertsPath == "" {
-		return nil
+// loadJournal loads the keypair sets from the CA journal in the datastore if
+// it has changed since it was last loaded (or if forced). If the datastore
+// does not have a journal yet, the keypair sets are imported from the legacy
+// on-disk journal, if any. Returns true if the keypair sets were reloaded.
+func (m *manager) loadJournal(ctx context.Context, force bool) (bool, error) {
+	ds := m.c.Catalog.DataStores()[0]
+	resp, err := ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+	})
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+
+	var data *keypairData
+	var source string
+	switch {
+	case resp.Journal != nil:
+		if resp.Journal.Version == m.journalVersion && !force {
+			return false, nil
+		}
+		data, err = decodeKeypairData(resp.Journal.Data)
+		if err != nil {
+			return false, err
+		}
+		source = "CA journal"
+	case m.journalVersion == 0 && m.c.CertsPath != "":
+		data, err = readKeypairData(m.c.CertsPath)
+		if err != nil {
+			return false, err
+		}
+		if data == nil {
+			return false, nil
+		}
+		m.c.Log.Infof("Importing keypair sets from %s into the CA journal", m.c.CertsPath)
+		source = m.c.CertsPath
+	default:
+		return false, nil
 	}
 
+	if err := m.loadKeypairSets(ctx, data, source); err != nil {
+		return false, err
+	}
+	if resp.Journal != nil {
+		m.journalVersion = resp.Journal.Version
+	}
+	m.lease = data.Lease
+	m.keyHolder = data.KeyHolder
+
+	if resp.Journal == nil {
+		if err := m.writeJournal(ctx); err != nil {
+			m.c.Log.Warnf("Unable to store imported keypair sets in the CA journal: %v", err)
+		}
+	}
+	return true, nil
+}
+
+// commitJournal writes the keypair sets and lease to the CA journal. If the
+// write fails because another server updated the journal first, the journal
+// is reloaded and false is returned.
+func (m *manager) commitJournal(ctx context.Context) (bool, error) {
+	err := m.writeJournal(ctx)
+	if err == nil {
+		return true, nil
+	}
+
+	version := m.journalVersion
+	if _, loadErr := m.loadJournal(ctx, true); loadErr != nil {
+		return false, loadErr
+	}
+	if m.journalVersion != version {
+		m.c.Log.Debugf("CA journal updated by another server: %v", err)
+		return false, nil
+	}
+	return false, fmt.Errorf("unable to write CA journal: %v", err)
+}
+
+func (m *manager) writeJournal(ctx context.Context) error {
+	data, err := encodeKeypairData(m.current, m.next, m.lease, m.keyHolder)
+	if err != nil {
+		return err
+	}
+
+	ds := m.c.Catalog.DataStores()[0]
+	resp, err := ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{
+			TrustDomainId: m.c.TrustDomain.String(),
+			Data:          data,
+			Version:       m.journalVersion,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	m.journalVersion = resp.Journal.Version
+	return nil
+}
+
+func (m *manager) loadKeypairSets(ctx context.Context, data *keypairData, source string) error {
 	km := m.c.Catalog.KeyManagers()[0]
 	keys, err := loadKeyManagerKeys(ctx, km)
 	if err != nil {
@@ -444,11 +1277,22 @@ func (m *manager) loadKeypairSets(ctx context.Context) error {
 		}
 	}
 
-	x509CAs, publicKeys, err := m.loadKeypairData(m.c.CertsPath, rootCAs)
+	x509CAs, publicKeys, err := m.loadKeypairData(data, rootCAs)
 	if err != nil {
 		return err
 	}
 
+	// the journal is authoritative while the server holding its keys is
+	// alive: a server without the keys (i.e. one that does not share the
+	// key manager) must not replace the keypair sets with its own.
+	if m.keyHolderAlive(data.KeyHolder) {
+		for _, kps := range []*keypairSet{m.current, m.next} {
+			if !keypairSetKeysMatch(x509CAs[kps.X509CAKeyID()], publicKeys[kps.JWTSignerKeyID()], keys[kps.X509CAKeyID()], keys[kps.JWTSignerKeyID()]) {
+				return fmt.Errorf("private keys for keypair set %q in %s are not in the keymanager and are in use by another server; servers sharing a datastore must share a keymanager", kps.slot, source)
+			}
+		}
+	}
+
 	populateKeypairSet := func(kps *keypairSet) bool {
 		kps.Reset()
 
@@ -472,7 +1316,7 @@ func (m *manager) loadKeypairSets(ctx context.Context) error {
 			}
 			switch {
 			case x509CA == nil:
-				m.c.Log.Warnf("Keypair set %q unusable: corresponding x509 CA certificate not found in %s", kps.slot, m.c.CertsPath)
+				m.c.Log.Warnf("Keypair set %q unusable: corresponding x509 CA certificate not found in %s", kps.slot, source)
 			case x509CAKMKey == nil:
 				m.c.Log.Warnf("keypair set %q unusable: x509 CA private key not found in keymanager", kps.slot)
 			case !x509CAOK:
@@ -480,7 +1324,7 @@ func (m *manager) loadKeypairSets(ctx context.Context) error {
 			}
 			switch {
 			case jwtSigningKey == nil:
-				m.c.Log.Warnf("Keypair set %q unusable: corresponding JWT signing public key not found in %s", kps.slot, m.c.CertsPath)
+				m.c.Log.Warnf("Keypair set %q unusable: corresponding JWT signing public key not found in %s", kps.slot, source)
 			case jwtSigningKMKey == nil:
 				m.c.Log.Warnf("Keypair set %q unusable: JWT signing private key not found in keymanager", kps.slot)
 			case !jwtSigningKeyOK:
@@ -498,20 +1342,36 @@ func (m *manager) loadKeypairSets(ctx context.Context) error {
 	currentValid := populateKeypairSet(m.current)
 	nextValid := populateKeypairSet(m.next)
 
-	// if B (next) was loaded but A (current) was not, OR if B comes before
-	// A, then swap B into the current slot.
-	if nextValid && (!currentValid ||
-		m.current.x509CA.cert().NotBefore.After(m.next.x509CA.cert().NotBefore)) {
+	switch {
+	case data.ActiveSlot != "":
+		// the journal records which keypair set is active
+		if m.current.slot != data.ActiveSlot {
+			m.current, m.next = m.next, m.current
+		}
+	case nextValid && (!currentValid ||
+		m.current.x509CA.cert().NotBefore.After(m.next.x509CA.cert().NotBefore)):
+		// if B (next) was loaded but A (current) was not, OR if B comes
+		// before A, then swap B into the current slot.
 		m.current, m.next = m.next, m.current
 	}
 
 	m.c.Log.Debugf("Loaded keypair sets")
-	if m.current.x509CA != nil {
+	if m.current.x509CA != nil && !m.isKeypairSetActive(m.current) {
 		m.setKeypairSet()
 	}
 	return nil
 }
 
+// keypairSetKeysMatch returns true if the keypair set in the journal, if any,
+// matches the private keys in the keymanager.
+func keypairSetKeysMatch(x509CA *caX509CA, jwtSigningKey *caPublicKey, x509CAKMKey, jwtSigningKMKey crypto.PublicKey) bool {
+	if x509CA == nil && jwtSigningKey == nil {
+		return true
+	}
+	return x509CA != nil && x509CAKMKey != nil && certMatchesKey(x509CA.cert(), x509CAKMKey) &&
+		jwtSigningKey != nil && jwtSigningKMKey != nil && publicKeyEqual(jwtSigningKey.publicKey, jwtSigningKMKey)
+}
+
 func (m *manager) getCurrentKeypairSet() *keypairSet {
 	copy := *m.current
 	return &copy
@@ -522,35 +1382,20 @@ func (m *manager) getNextKeypairSet() *keypairSet {
 	return &copy
 }
 
+// isKeypairSetActive returns true if the given keypair set is the one used by
+// the server CA.
+func (m *manager) isKeypairSetActive(kps *keypairSet) bool {
+	active := m.ca.getKeypairSet()
+	return active != nil && active.x509CA != nil && kps.x509CA != nil &&
+		active.x509CA.cert().Equal(kps.x509CA.cert())
+}
+
 func (m *manager) setKeypairSet() {
 	m.c.Log.Debugf("Activating keypair set %q", m.current.slot)
 	m.c.Metrics.IncrCounter([]string{"manager", "keypair", "activate"}, 1)
 	m.ca.setKeypairSet(*m.current)
 }
 
-func (m *manager) writeKeypairSets() {
-	if m.c.CertsPath == "" {
-		return
-	}
-	x509CAs := make(map[string]*caX509CA)
-	publicKeys := make(map[string]*caPublicKey)
-	if m.current.x509CA != nil {
-		x509CAs[m.current.X509CAKeyID()] = m.current.x509CA
-	}
-	if m.current.jwtSigningKey != nil {
-		publicKeys[m.current.JWTSignerKeyID()] = m.current.jwtSigningKey
-	}
-	if m.next.x509CA != nil {
-		x509CAs[m.next.X509CAKeyID()] = m.next.x509CA
-	}
-	if m.next.jwtSigningKey != nil {
-		publicKeys[m.next.JWTSignerKeyID()] = m.next.jwtSigningKey
-	}
-	if err := writeKeypairData(m.c.CertsPath, x509CAs, publicKeys); err != nil {
-		m.c.Log.Errorf("unable to write keypair sets: %v", err)
-	}
-}
-
 func (m *manager) shouldPrepare() bool {
 	return m.current.x509CA == nil || m.hooks.now().After(preparationThreshold(m.current.x509CA.cert()))
 }
@@ -563,24 +1408,46 @@ type keypairData struct {
 	DEPRECATEDCerts map[string][]byte `json:"certs"`
 	CAs             map[string][]byte `json:"cas"`
 	PublicKeys      map[string][]byte `json:"public_keys"`
+
+	// ActiveSlot is the slot of the active keypair set. It is not set in
+	// the legacy on-disk journal.
+	ActiveSlot string `json:"active_slot,omitempty"`
+
+	// Lease is held by the server preparing a keypair set
+	Lease *journalLease `json:"lease,omitempty"`
+
+	// KeyHolder is the server that last recorded that it holds the private
+	// keys for the keypair sets
+	KeyHolder *journalLease `json:"key_holder,omitempty"`
 }
 
-func (m *manager) loadKeypairData(path string, bundleCerts []*x509.Certificate) (map[string]*caX509CA, map[string]*caPublicKey, error) {
-	x509CAs := make(map[string]*caX509CA)
-	publicKeys := make(map[string]*caPublicKey)
+type journalLease struct {
+	ServerID  string `json:"server_id"`
+	ExpiresAt int64  `json:"expires_at"`
+}
 
+func readKeypairData(path string) (*keypairData, error) {
 	jsonBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return x509CAs, publicKeys, nil
+			return nil, nil
 		}
-		return nil, nil, err
+		return nil, err
 	}
+	return decodeKeypairData(jsonBytes)
+}
 
+func decodeKeypairData(jsonBytes []byte) (*keypairData, error) {
 	data := new(keypairData)
 	if err := json.Unmarshal(jsonBytes, data); err != nil {
-		return nil, nil, fmt.Errorf("unable to decode certificate JSON: %v", err)
+		return nil, fmt.Errorf("unable to decode certificate JSON: %v", err)
 	}
+	return data, nil
+}
+
+func (m *manager) loadKeypairData(data *keypairData, bundleCerts []*x509.Certificate) (map[string]*caX509CA, map[string]*caPublicKey, error) {
+	x509CAs := make(map[string]*caX509CA)
+	publicKeys := make(map[string]*caPublicKey)
 
 	for id, caBytes := range data.CAs {
 		certs, err := x509.ParseCertificates(caBytes)
@@ -650,6 +1517,16 @@ func (m *manager) loadKeypairData(path string, bundleCerts []*x509.Certificate)
 	return x509CAs, publicKeys, nil
 }
 
+// X509CAKeyID returns the key ID used to identify an X.509 CA, which is the
+// hex encoded subject key ID of its public key.
+func X509CAKeyID(cert *x509.Certificate) string {
+	keyID, err := x509util.GetSubjectKeyId(cert.PublicKey)
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(keyID)
+}
+
 func certMatchesKey(certificate *x509.Certificate, publicKey crypto.PublicKey) bool {
 	matches, err := x509util.CertificateMatchesPublicKey(certificate, publicKey)
 	if err != nil {
@@ -672,10 +1549,11 @@ func GenerateServerCACSR(signer crypto.Signer, trustDomain string, subject pkix.
 		Host:   trustDomain,
 	}
 
+	// the signature algorithm is left unspecified so that it is chosen based
+	// on the type of the signer key.
 	template := x509.CertificateRequest{
-		Subject:            subject,
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{spiffeID},
+		Subject: subject,
+		URIs:    []*url.URL{spiffeID},
 	}
 
 	csr, err := x509.CreateCertificateRequest(rand.Reader, &template, signer)
@@ -785,33 +1663,39 @@ func loadKeyManagerKeys(ctx context.Context, km keymanager.KeyManager) (map[stri
 	return publicKeys, nil
 }
 
-func writeKeypairData(path string, x509CAs map[string]*caX509CA, publicKeys map[string]*caPublicKey) error {
+func encodeKeypairData(current, next *keypairSet, lease, keyHolder *journalLease) ([]byte, error) {
 	data := &keypairData{
 		CAs:        make(map[string][]byte),
 		PublicKeys: make(map[string][]byte),
+		Lease:      lease,
+		KeyHolder:  keyHolder,
 	}
-	for id, x509CA := range x509CAs {
-		var raw []byte
-		for _, cert := range x509CA.chain {
-			raw = append(raw, cert.Raw...)
-		}
-		data.CAs[id] = raw
+	if current.x509CA != nil {
+		data.ActiveSlot = current.slot
 	}
 
-	for id, publicKey := range publicKeys {
-		publicKeyBytes, err := proto.Marshal(publicKey.PublicKey)
-		if err != nil {
-			return errs.Wrap(err)
+	for _, kps := range []*keypairSet{current, next} {
+		if kps.x509CA != nil {
+			var raw []byte
+			for _, cert := range kps.x509CA.chain {
+				raw = append(raw, cert.Raw...)
+			}
+			data.CAs[kps.X509CAKeyID()] = raw
+		}
+		if kps.jwtSigningKey != nil {
+			publicKeyBytes, err := proto.Marshal(kps.jwtSigningKey.PublicKey)
+			if err != nil {
+				return nil, errs.Wrap(err)
+			}
+			data.PublicKeys[kps.JWTSignerKeyID()] = publicKeyBytes
 		}
-		data.PublicKeys[id] = publicKeyBytes
 	}
 
 	jsonBytes, err := json.MarshalIndent(data, "", "\t")
 	if err != nil {
-		return errs.Wrap(err)
+		return nil, errs.Wrap(err)
 	}
-
-	return diskutil.AtomicWriteFile(path, jsonBytes, 0644)
+	return jsonBytes, nil
 }
 
 func preparationThreshold(cert *x509.Certificate) time.Time {
@@ -854,6 +1738,17 @@ func keyIDFromBytes(choices []byte) string {
 	return buf.String()
 }
 
+func newServerID() string {
+	u, err := uuid.NewV4()
+	if err != nil {
+		// fall back to a random key ID; the server ID only needs to be
+		// unique among the servers sharing the CA journal.
+		id, _ := newKeyID()
+		return id
+	}
+	return u.String()
+}
+
 func newKeyID() (string, error) {
 	choices := make([]byte, 32)
 	_, err := rand.Read(choices)
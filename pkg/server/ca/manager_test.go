@@ -2,7 +2,12 @@ package ca
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -12,6 +17,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
@@ -21,6 +27,8 @@ import (
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/spiffe/spire/test/fakes/fakeupstreamca"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -66,11 +74,19 @@ func (m *ManagerTestSuite) TearDownTest() {
 }
 
 func (m *ManagerTestSuite) newManager() {
+	m.m = m.createManager()
+}
+
+func (m *ManagerTestSuite) createManager() *manager {
+	return m.createManagerWithCatalog(m.catalog)
+}
+
+func (m *ManagerTestSuite) createManagerWithCatalog(catalog *fakeservercatalog.Catalog) *manager {
 	logger, err := log.NewLogger("DEBUG", "")
 	m.NoError(err)
 
 	config := &ManagerConfig{
-		Catalog: m.catalog,
+		Catalog: catalog,
 		Log:     logger,
 		Metrics: telemetry.Blackhole{},
 		TrustDomain: url.URL{
@@ -81,8 +97,9 @@ func (m *ManagerTestSuite) newManager() {
 		CertsPath:      m.certsPath(),
 	}
 
-	m.m = NewManager(config)
-	m.m.hooks.now = m.nowHook
+	manager := NewManager(config)
+	manager.hooks.now = m.nowHook
+	return manager
 }
 
 func (m *ManagerTestSuite) certsPath() string {
@@ -107,8 +124,19 @@ func (m *ManagerTestSuite) advanceTime(d time.Duration) {
 	m.now = m.now.Add(d).Truncate(time.Second)
 }
 
+func (m *ManagerTestSuite) loadJournal() *keypairData {
+	resp, err := m.datastore.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: m.m.c.TrustDomain.String(),
+	})
+	m.Require().NoError(err)
+	m.Require().NotNil(resp.Journal)
+	data, err := decodeKeypairData(resp.Journal.Data)
+	m.Require().NoError(err)
+	return data
+}
+
 func (m *ManagerTestSuite) loadKeypairSets() (a, b *keypairSet) {
-	certs, publicKeys, err := m.m.loadKeypairData(m.certsPath(), nil)
+	certs, publicKeys, err := m.m.loadKeypairData(m.loadJournal(), nil)
 	m.Require().NoError(err)
 	xa := certs["x509-CA-A"]
 	ja := publicKeys["JWT-Signer-A"]
@@ -151,6 +179,7 @@ func (m *ManagerTestSuite) TestPersistence() {
 	m.requireEmptyKeypairSet(next2)
 
 	// drop the keys, "reload" the manager, and assert the keypairs are new
+	m.m.releaseKeyHolder(ctx)
 	m.catalog.SetKeyManagers(memory.New())
 	m.newManager()
 	m.Require().NoError(m.m.Initialize(ctx))
@@ -160,6 +189,7 @@ func (m *ManagerTestSuite) TestPersistence() {
 	m.requireEmptyKeypairSet(next3)
 
 	// load the old keys, "reload" the manager, and assert the keypairs are new
+	m.m.releaseKeyHolder(ctx)
 	m.catalog.SetKeyManagers(m.keymanager)
 	m.newManager()
 	m.Require().NoError(m.m.Initialize(ctx))
@@ -209,6 +239,20 @@ func (m *ManagerTestSuite) TestSelfSigning() {
 	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
 }
 
+func (m *ManagerTestSuite) TestSelfSigningWithRSAKeys() {
+	m.m.c.CAKeyType = common.KeyType_RSA_2048
+	m.m.c.JWTKeyType = common.KeyType_RSA_2048
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	m.Require().Equal(a.x509CA.cert().Subject, a.x509CA.cert().Issuer)
+	m.Require().IsType(&rsa.PublicKey{}, a.x509CA.cert().PublicKey)
+	m.Require().Equal(x509.SHA256WithRSA, a.x509CA.cert().SignatureAlgorithm)
+	m.Require().IsType(&rsa.PublicKey{}, a.jwtSigningKey.publicKey)
+	m.requireBundleRootCAs(a.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+}
+
 func (m *ManagerTestSuite) TestUpstreamSigning() {
 	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
 		TrustDomain: "example.org",
@@ -244,6 +288,181 @@ func (m *ManagerTestSuite) TestUpstreamSigningWithIntermediate() {
 	m.requireBundleRootCAs(upstreamRoot, a.x509CA.cert())
 }
 
+func (m *ManagerTestSuite) TestUpstreamBundleUpdates() {
+	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain: "example.org",
+	})
+	m.catalog.SetUpstreamCAs(upstreamCA)
+	upstreamRoot := upstreamCA.Root()
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- m.m.streamUpstreamBundleUpdates(ctx, upstreamCA)
+	}()
+	defer func() {
+		cancel()
+		m.Require().NoError(<-done)
+	}()
+
+	// the upstream CA rotates; its new root and a JWT signing key are
+	// merged into the bundle.
+	newUpstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain:     "example.org",
+		UseIntermediate: true,
+	})
+	upstreamJWTSigningKey := &common.PublicKey{
+		Kid:       "UPSTREAM",
+		PkixBytes: a.jwtSigningKey.PkixBytes,
+	}
+	upstreamCA.AppendRootCA(newUpstreamCA.Intermediate())
+	upstreamCA.AppendJWTSigningKey(upstreamJWTSigningKey)
+
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		bundle := m.fetchBundle()
+		if len(bundle.RootCas) == 3 && len(bundle.JwtSigningKeys) == 2 {
+			break
+		}
+		m.Require().True(time.Now().Before(deadline), "timed out waiting for the upstream bundle to be merged")
+	}
+	m.requireBundleRootCAs(upstreamRoot, a.x509CA.cert(), newUpstreamCA.Intermediate())
+	m.requirePublicKeysEqual([]*common.PublicKey{a.jwtSigningKey.PublicKey, upstreamJWTSigningKey}, m.fetchBundle().JwtSigningKeys)
+
+	// the upstream CA drops the new root, its JWT signing key and the one
+	// published by this server. The upstream entries are removed from the
+	// bundle but the locally minted JWT signing key is kept.
+	upstreamCA.RemoveRootCA(newUpstreamCA.Intermediate())
+	upstreamCA.RemoveJWTSigningKey(upstreamJWTSigningKey.Kid)
+	upstreamCA.RemoveJWTSigningKey(a.jwtSigningKey.Kid)
+
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		bundle := m.fetchBundle()
+		if len(bundle.RootCas) == 2 && len(bundle.JwtSigningKeys) == 1 {
+			break
+		}
+		m.Require().True(time.Now().Before(deadline), "timed out waiting for the upstream bundle to be replaced")
+	}
+	m.requireBundleRootCAs(upstreamRoot, a.x509CA.cert())
+	m.requirePublicKeysEqual([]*common.PublicKey{a.jwtSigningKey.PublicKey}, m.fetchBundle().JwtSigningKeys)
+}
+
+func (m *ManagerTestSuite) TestUpstreamJWTKeyPublishing() {
+	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain: "example.org",
+	})
+	upstreamJWTSigningKey := &common.PublicKey{
+		Kid: "UPSTREAM",
+	}
+	upstreamCA.AppendJWTSigningKey(upstreamJWTSigningKey)
+	m.catalog.SetUpstreamCAs(upstreamCA)
+
+	// the JWT signing key of the prepared keypair set is published upstream
+	// and the upstream JWT signing keys are merged into the bundle.
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	m.requirePublicKeysEqual([]*common.PublicKey{upstreamJWTSigningKey, a.jwtSigningKey.PublicKey}, upstreamCA.JWTSigningKeys())
+	m.requirePublicKeysEqual([]*common.PublicKey{a.jwtSigningKey.PublicKey, upstreamJWTSigningKey}, m.fetchBundle().JwtSigningKeys)
+}
+
+func (m *ManagerTestSuite) TestUpstreamJWTKeyPublishingDisabledWithoutUpstreamBundle() {
+	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain: "example.org",
+	})
+	m.catalog.SetUpstreamCAs(upstreamCA)
+	m.m.c.UpstreamBundle = false
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	m.Require().Empty(upstreamCA.JWTSigningKeys())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+}
+
+func (m *ManagerTestSuite) TestPublishJWTKey() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	downstreamJWTSigningKey := &common.PublicKey{
+		Kid:       "DOWNSTREAM",
+		PkixBytes: a.jwtSigningKey.PkixBytes,
+	}
+	jwtSigningKeys, err := m.m.PublishJWTKey(ctx, downstreamJWTSigningKey)
+	m.Require().NoError(err)
+
+	// the key cannot outlive the active X.509 CA
+	publishedJWTSigningKey := &common.PublicKey{
+		Kid:       "DOWNSTREAM",
+		PkixBytes: a.jwtSigningKey.PkixBytes,
+		NotAfter:  a.x509CA.cert().NotAfter.Unix(),
+	}
+	expected := []*common.PublicKey{a.jwtSigningKey.PublicKey, publishedJWTSigningKey}
+	m.requirePublicKeysEqual(expected, jwtSigningKeys)
+	m.requirePublicKeysEqual(expected, m.fetchBundle().JwtSigningKeys)
+}
+
+func (m *ManagerTestSuite) TestPublishJWTKeyKeepsEarlierExpiry() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	downstreamJWTSigningKey := &common.PublicKey{
+		Kid:       "DOWNSTREAM",
+		PkixBytes: a.jwtSigningKey.PkixBytes,
+		NotAfter:  a.x509CA.cert().NotAfter.Add(-time.Minute).Unix(),
+	}
+	jwtSigningKeys, err := m.m.PublishJWTKey(ctx, downstreamJWTSigningKey)
+	m.Require().NoError(err)
+	m.requirePublicKeysEqual([]*common.PublicKey{a.jwtSigningKey.PublicKey, downstreamJWTSigningKey}, jwtSigningKeys)
+}
+
+func (m *ManagerTestSuite) TestPublishJWTKeyRejectsKeyIDOfAnotherKey() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	m.Require().NoError(err)
+	pkixBytes, err := x509.MarshalPKIXPublicKey(otherKey.Public())
+	m.Require().NoError(err)
+
+	// a downstream server cannot replace the key of this server (or of
+	// another downstream server) by publishing under its key ID
+	_, err = m.m.PublishJWTKey(ctx, &common.PublicKey{
+		Kid:       a.jwtSigningKey.Kid,
+		PkixBytes: pkixBytes,
+	})
+	m.Require().Error(err)
+	m.Require().Equal(codes.AlreadyExists, status.Code(err))
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+
+	// publishing the same key again is fine
+	_, err = m.m.PublishJWTKey(ctx, a.jwtSigningKey.PublicKey)
+	m.Require().NoError(err)
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+}
+
+func (m *ManagerTestSuite) TestPublishJWTKeyForwardsUpstream() {
+	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain: "example.org",
+	})
+	m.catalog.SetUpstreamCAs(upstreamCA)
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	downstreamJWTSigningKey := &common.PublicKey{
+		Kid:       "DOWNSTREAM",
+		PkixBytes: a.jwtSigningKey.PkixBytes,
+	}
+	jwtSigningKeys, err := m.m.PublishJWTKey(ctx, downstreamJWTSigningKey)
+	m.Require().NoError(err)
+	downstreamJWTSigningKey.NotAfter = a.x509CA.cert().NotAfter.Unix()
+	expected := []*common.PublicKey{a.jwtSigningKey.PublicKey, downstreamJWTSigningKey}
+	m.requirePublicKeysEqual(expected, upstreamCA.JWTSigningKeys())
+	m.requirePublicKeysEqual(expected, jwtSigningKeys)
+	m.requirePublicKeysEqual(expected, m.fetchBundle().JwtSigningKeys)
+}
+
 func (m *ManagerTestSuite) TestDeprecatedUpstreamSigning() {
 	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
 		TrustDomain:         "example.org",
@@ -346,6 +565,279 @@ func (m *ManagerTestSuite) TestRotation() {
 	m.requireBundleJWTSigningKeys(a1.jwtSigningKey, b2.jwtSigningKey, a4.jwtSigningKey)
 }
 
+func (m *ManagerTestSuite) TestJournalActiveSlot() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	m.Require().Equal("A", m.loadJournal().ActiveSlot)
+
+	// prepare and activate B
+	m.setTime(activationThreshold(a.x509CA.cert()).Add(time.Second))
+	m.Require().NoError(m.m.rotateCAs(ctx))
+	m.requireKeypairSet("B", m.m.getCurrentKeypairSet())
+	m.Require().Equal("B", m.loadJournal().ActiveSlot)
+	m.Require().Nil(m.loadJournal().Lease)
+}
+
+func (m *ManagerTestSuite) TestJournalImportedFromDisk() {
+	// initialize a manager and copy its journal to disk, as an older server
+	// would have written it, and then remove it from the datastore.
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	data, err := encodeKeypairData(m.m.current, m.m.next, nil, nil)
+	m.Require().NoError(err)
+	m.Require().NoError(ioutil.WriteFile(m.certsPath(), data, 0644))
+	m.datastore = fakedatastore.New()
+	_, err = m.datastore.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: bundleutil.BundleProtoFromRootCA(m.m.c.TrustDomain.String(), a.x509CA.cert()),
+	})
+	m.Require().NoError(err)
+	m.catalog.SetDataStores(m.datastore)
+
+	// "reload" the manager and assert the keypair set was imported into
+	// the datastore journal
+	m.newManager()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.requireKeypairSetKeysEqual(a, m.m.getCurrentKeypairSet())
+	imported, _ := m.loadKeypairSets()
+	m.Require().NotNil(imported)
+	m.requireKeypairSetKeysEqual(a, imported)
+}
+
+func (m *ManagerTestSuite) TestHA() {
+	// two servers share the datastore and the key manager
+	m1 := m.m
+	m2 := m.createManager()
+
+	// the second server adopts the keypair set prepared by the first
+	m.Require().NoError(m1.Initialize(ctx))
+	m.Require().NoError(m2.Initialize(ctx))
+	a := m1.getCurrentKeypairSet()
+	m.requireKeypairSetKeysEqual(a, m2.getCurrentKeypairSet())
+	m.requireBundleRootCAs(a.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+
+	// past the preparation threshold, whichever server rotates first
+	// prepares the next keypair set and the other adopts it.
+	m.setTime(preparationThreshold(a.x509CA.cert()).Add(time.Second))
+	m.Require().NoError(m2.rotateCAs(ctx))
+	m.Require().NoError(m1.rotateCAs(ctx))
+	b := m2.getNextKeypairSet()
+	m.requireValidKeypairSet(b)
+	m.requireKeypairSetKeysEqual(b, m1.getNextKeypairSet())
+	m.requireBundleRootCAs(a.x509CA.cert(), b.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey, b.jwtSigningKey)
+
+	// past the activation threshold both servers activate B
+	m.setTime(activationThreshold(a.x509CA.cert()).Add(time.Second))
+	m.Require().NoError(m1.rotateCAs(ctx))
+	m.Require().NoError(m2.rotateCAs(ctx))
+	m.requireKeypairSetKeysEqual(b, m1.ca.getKeypairSet())
+	m.requireKeypairSetKeysEqual(b, m2.ca.getKeypairSet())
+	m.requireEmptyKeypairSet(m1.getNextKeypairSet())
+	m.requireEmptyKeypairSet(m2.getNextKeypairSet())
+	m.requireBundleRootCAs(a.x509CA.cert(), b.x509CA.cert())
+}
+
+func (m *ManagerTestSuite) TestHAWithSeparateKeyManagers() {
+	// two servers share the datastore but each has its own key manager
+	otherCatalog := fakeservercatalog.New()
+	otherCatalog.SetKeyManagers(memory.New())
+	otherCatalog.SetDataStores(m.datastore)
+	m1 := m.m
+	m2 := m.createManagerWithCatalog(otherCatalog)
+
+	// the second server refuses to replace the keypair set of the first
+	m.Require().NoError(m1.Initialize(ctx))
+	a := m1.getCurrentKeypairSet()
+	err := m2.Initialize(ctx)
+	m.Require().Error(err)
+	m.Require().Contains(err.Error(), `private keys for keypair set "A" in CA journal are not in the keymanager and are in use by another server`)
+
+	// neither rotation loop replaces the journal or adds roots
+	for i := 0; i < 3; i++ {
+		m.advanceTime(time.Minute)
+		m.Require().NoError(m1.rotateCAs(ctx))
+		m.Require().Error(m2.rotateCAs(ctx))
+	}
+	journalA, _ := m.loadKeypairSets()
+	m.requireKeypairSetKeysEqual(a, journalA)
+	m.requireKeypairSetKeysEqual(a, m1.ca.getKeypairSet())
+	m.requireBundleRootCAs(a.x509CA.cert())
+
+	// once the first server is gone for good, the second takes over
+	m.advanceTime(keyHolderTTL + time.Second)
+	m.Require().NoError(m2.rotateCAs(ctx))
+	b := m2.getCurrentKeypairSet()
+	m.requireValidKeypairSet(b)
+	m.requireKeypairSetKeysNotEqual(a, b)
+	m.requireBundleRootCAs(a.x509CA.cert(), b.x509CA.cert())
+
+	// and the first server, now without the keys, refuses in turn
+	m.Require().Error(m1.rotateCAs(ctx))
+	m.requireBundleRootCAs(a.x509CA.cert(), b.x509CA.cert())
+}
+
+func (m *ManagerTestSuite) TestKeyHolderReleasedOnShutdown() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().NotNil(m.loadJournal().KeyHolder)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	m.Require().NoError(m.m.Run(runCtx))
+	m.Require().Nil(m.loadJournal().KeyHolder)
+
+	// a restarted server with a non-persistent key manager can take over
+	// right away
+	m.catalog.SetKeyManagers(memory.New())
+	m.newManager()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.requireValidKeypairSet(m.m.getCurrentKeypairSet())
+}
+
+func (m *ManagerTestSuite) TestLeaseHeldByAnotherServer() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	// past the preparation threshold, another server takes the lease
+	m.setTime(preparationThreshold(a.x509CA.cert()).Add(time.Second))
+	other := m.createManager()
+	_, err := other.loadJournal(ctx, false)
+	m.Require().NoError(err)
+	acquired, err := other.acquireLease(ctx)
+	m.Require().NoError(err)
+	m.Require().True(acquired)
+
+	// nothing is prepared while the lease is held by the other server
+	m.Require().NoError(m.m.rotateCAs(ctx))
+	m.requireEmptyKeypairSet(m.m.getNextKeypairSet())
+	m.requireBundleRootCAs(a.x509CA.cert())
+
+	// once the lease expires, the next keypair set is prepared
+	m.advanceTime(journalLeaseTTL + time.Second)
+	m.Require().NoError(m.m.rotateCAs(ctx))
+	m.requireValidKeypairSet(m.m.getNextKeypairSet())
+}
+
+func (m *ManagerTestSuite) TestManualRotation() {
+	// nothing can be prepared before initialization
+	_, err := m.m.PrepareKeypairSet(ctx)
+	m.Require().EqualError(err, "no active keypair set")
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	// nothing to activate until a keypair set is prepared
+	_, err = m.m.ActivateKeypairSet(ctx)
+	m.Require().EqualError(err, "no prepared keypair set to activate")
+
+	// prepare B ahead of the preparation threshold
+	info, err := m.m.PrepareKeypairSet(ctx)
+	m.Require().NoError(err)
+	m.Require().Equal("B", info.Slot)
+	m.Require().False(info.Active)
+	b := m.m.getNextKeypairSet()
+	m.requireValidKeypairSet(b)
+	m.requireBundleRootCAs(a.x509CA.cert(), b.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey, b.jwtSigningKey)
+
+	// only one keypair set can be prepared at a time
+	_, err = m.m.PrepareKeypairSet(ctx)
+	m.Require().EqualError(err, "a keypair set is already prepared; activate or revoke it first")
+
+	infos, err := m.m.KeypairSets(ctx)
+	m.Require().NoError(err)
+	m.Require().Len(infos, 2)
+	m.Require().Equal("A", infos[0].Slot)
+	m.Require().True(infos[0].Active)
+	m.Require().Equal("B", infos[1].Slot)
+	m.Require().False(infos[1].Active)
+
+	// activate B ahead of the activation threshold
+	info, err = m.m.ActivateKeypairSet(ctx)
+	m.Require().NoError(err)
+	m.Require().Equal("B", info.Slot)
+	m.Require().True(info.Active)
+	m.requireKeypairSet("B", b)
+	m.requireEmptyKeypairSet(m.m.getNextKeypairSet())
+	m.Require().Equal("B", m.loadJournal().ActiveSlot)
+
+	// A can now be revoked, which removes it from the bundle
+	m.Require().NoError(m.m.RevokeX509CA(ctx, X509CAKeyID(a.x509CA.cert())))
+	m.Require().NoError(m.m.RevokeJWTSigningKey(ctx, a.jwtSigningKey.Kid))
+	m.requireBundleRootCAs(b.x509CA.cert())
+	m.requireBundleJWTSigningKeys(b.jwtSigningKey)
+
+	// revoking again fails since the keys are no longer in the bundle
+	m.Require().EqualError(m.m.RevokeJWTSigningKey(ctx, a.jwtSigningKey.Kid),
+		fmt.Sprintf("JWT signing key %q not found in bundle", a.jwtSigningKey.Kid))
+}
+
+func (m *ManagerTestSuite) TestRevokeActiveKeypairSet() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+
+	keyID := X509CAKeyID(a.x509CA.cert())
+	m.Require().EqualError(m.m.RevokeX509CA(ctx, keyID),
+		fmt.Sprintf("cannot revoke X.509 CA %q: it belongs to the active keypair set", keyID))
+	m.Require().EqualError(m.m.RevokeJWTSigningKey(ctx, a.jwtSigningKey.Kid),
+		fmt.Sprintf("cannot revoke JWT signing key %q: it belongs to the active keypair set", a.jwtSigningKey.Kid))
+	m.requireBundleRootCAs(a.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+}
+
+func (m *ManagerTestSuite) TestRevokePreparedKeypairSet() {
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	_, err := m.m.PrepareKeypairSet(ctx)
+	m.Require().NoError(err)
+	b := m.m.getNextKeypairSet()
+
+	// revoking either key of the prepared keypair set discards the set and
+	// removes both of its keys from the bundle
+	m.Require().NoError(m.m.RevokeJWTSigningKey(ctx, b.jwtSigningKey.Kid))
+	m.requireEmptyKeypairSet(m.m.getNextKeypairSet())
+	m.Require().NotContains(m.loadJournal().CAs, b.X509CAKeyID())
+	m.requireBundleRootCAs(a.x509CA.cert())
+	m.requireBundleJWTSigningKeys(a.jwtSigningKey)
+
+	// a new keypair set can be prepared in its place
+	_, err = m.m.PrepareKeypairSet(ctx)
+	m.Require().NoError(err)
+	m.requireKeypairSetKeysNotEqual(b, m.m.getNextKeypairSet())
+}
+
+func (m *ManagerTestSuite) TestRevokeX509CAWithUpstreamBundle() {
+	upstreamCA := fakeupstreamca.New(m.T(), fakeupstreamca.Config{
+		TrustDomain: "example.org",
+	})
+	m.catalog.SetUpstreamCAs(upstreamCA)
+	upstreamRoot := upstreamCA.Root()
+
+	m.Require().NoError(m.m.Initialize(ctx))
+	a := m.m.getCurrentKeypairSet()
+	_, err := m.m.PrepareKeypairSet(ctx)
+	m.Require().NoError(err)
+	_, err = m.m.ActivateKeypairSet(ctx)
+	m.Require().NoError(err)
+	b := m.m.getCurrentKeypairSet()
+
+	// X509-SVIDs chain to the upstream root, so the X.509 CA of an inactive
+	// keypair set cannot be revoked through the bundle
+	keyID := X509CAKeyID(a.x509CA.cert())
+	m.Require().EqualError(m.m.RevokeX509CA(ctx, keyID),
+		fmt.Sprintf("cannot revoke X.509 CA %q: the trust bundle holds the upstream root CAs, so it must be revoked in the upstream PKI", keyID))
+	m.requireBundleRootCAs(upstreamRoot, a.x509CA.cert(), b.x509CA.cert())
+
+	// the X.509 CA of a prepared keypair set can still be revoked by
+	// discarding the set
+	_, err = m.m.PrepareKeypairSet(ctx)
+	m.Require().NoError(err)
+	c := m.m.getNextKeypairSet()
+	m.Require().NoError(m.m.RevokeX509CA(ctx, X509CAKeyID(c.x509CA.cert())))
+	m.requireEmptyKeypairSet(m.m.getNextKeypairSet())
+	m.requireBundleRootCAs(upstreamRoot, a.x509CA.cert(), b.x509CA.cert())
+}
+
 func (m *ManagerTestSuite) TestPrune() {
 	// Initialize and prepare an extra keypair set
 	m.Require().NoError(m.m.Initialize(ctx))
@@ -387,6 +879,15 @@ func (m *ManagerTestSuite) TestPrune() {
 	m.requireBundleJWTSigningKeys(b.jwtSigningKey)
 }
 
+func (m *ManagerTestSuite) fetchBundle() *common.Bundle {
+	resp, err := m.datastore.FetchBundle(ctx, &datastore.FetchBundleRequest{
+		TrustDomainId: m.m.c.TrustDomain.String(),
+	})
+	m.Require().NoError(err)
+	m.Require().NotNil(resp.Bundle, "missing bundle for domain %q", m.m.c.TrustDomain.String())
+	return resp.Bundle
+}
+
 func (m *ManagerTestSuite) requireBundleRootCAs(expectedCerts ...*x509.Certificate) {
 	var expected []*common.Certificate
 	for _, expectedCert := range expectedCerts {
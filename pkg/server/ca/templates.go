@@ -4,6 +4,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
@@ -72,3 +73,20 @@ func CreateX509SVIDTemplate(csrDER []byte, trustDomain string, notBefore, notAft
 		PublicKey:             csr.PublicKey,
 	}, nil
 }
+
+// CreateAgentX509SVIDTemplate creates an X509-SVID template for the public
+// key of the CSR that carries the given agent SPIFFE ID instead of the one
+// requested in the CSR.
+func CreateAgentX509SVIDTemplate(csrDER []byte, trustDomain, agentID string, notBefore, notAfter time.Time, serialNumber *big.Int) (*x509.Certificate, error) {
+	id, err := idutil.ParseSpiffeID(agentID, idutil.AllowTrustDomainAgent(trustDomain))
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := CreateX509SVIDTemplate(csrDER, trustDomain, notBefore, notAfter, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	template.URIs = []*url.URL{id}
+	return template, nil
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/jointoken"
 	k8s_na_psat "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/k8s/psat"
 	k8s_na_sat "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/k8s/sat"
+	sshpop_na "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/sshpop"
+	tpm_na "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/tpm"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/x509pop"
 	aws_nr "github.com/spiffe/spire/pkg/server/plugin/noderesolver/aws"
 	azure_nr "github.com/spiffe/spire/pkg/server/plugin/noderesolver/azure"
+	gcp_nr "github.com/spiffe/spire/pkg/server/plugin/noderesolver/gcp"
 	"github.com/spiffe/spire/pkg/server/plugin/noderesolver/noop"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"github.com/spiffe/spire/proto/server/keymanager"
@@ -29,6 +32,7 @@ import (
 	keymanager_memory "github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
 	upstreamca_aws "github.com/spiffe/spire/pkg/server/plugin/upstreamca/awssecret"
 	upstreamca_disk "github.com/spiffe/spire/pkg/server/plugin/upstreamca/disk"
+	upstreamca_spire "github.com/spiffe/spire/pkg/server/plugin/upstreamca/spire"
 )
 
 const (
@@ -68,15 +72,19 @@ var (
 			"azure_msi":  nodeattestor.NewBuiltIn(azure_na.NewMSIAttestorPlugin()),
 			"k8s_sat":    nodeattestor.NewBuiltIn(k8s_na_sat.NewAttestorPlugin()),
 			"k8s_psat":   nodeattestor.NewBuiltIn(k8s_na_psat.NewAttestorPlugin()),
+			"tpm":        nodeattestor.NewBuiltIn(tpm_na.New()),
+			"sshpop":     nodeattestor.NewBuiltIn(sshpop_na.New()),
 		},
 		NodeResolverType: {
 			"noop":      noderesolver.NewBuiltIn(noop.New()),
 			"aws_iid":   noderesolver.NewBuiltIn(aws_nr.NewIIDResolverPlugin()),
 			"azure_msi": noderesolver.NewBuiltIn(azure_nr.NewMSIResolverPlugin()),
+			"gcp_iit":   noderesolver.NewBuiltIn(gcp_nr.NewIITResolverPlugin()),
 		},
 		UpstreamCAType: {
 			"disk":      upstreamca.NewBuiltIn(upstreamca_disk.New()),
 			"awssecret": upstreamca.NewBuiltIn(upstreamca_aws.New()),
+			"spire":     upstreamca.NewBuiltIn(upstreamca_spire.New()),
 		},
 		KeyManagerType: {
 			"disk":   keymanager.NewBuiltIn(keymanager_disk.New()),
@@ -34,6 +34,12 @@ type Config struct {
 	// Server CA for signing SVIDs
 	ServerCA ca.ServerCA
 
+	// CA manager, for administrative CA operations
+	CAManager ca.Manager
+
+	// Networks agents are allowed to attest from, by node attestor name
+	NodeAttestorCIDRs map[string][]*net.IPNet
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 }
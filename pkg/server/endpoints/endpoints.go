@@ -96,6 +96,9 @@ func (e *endpoints) registerNodeAPI(tcpServer *grpc.Server) {
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
 		ServerCA:    e.c.ServerCA,
+		CAManager:   e.c.CAManager,
+
+		NodeAttestorCIDRs: e.c.NodeAttestorCIDRs,
 	})
 	node_pb.RegisterNodeServer(tcpServer, n)
 }
@@ -108,6 +111,7 @@ func (e *endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) {
 		Metrics:     e.c.Metrics,
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
+		CAManager:   e.c.CAManager,
 	}
 
 	registration_pb.RegisterRegistrationServer(tcpServer, r)
@@ -1,10 +1,15 @@
 package node
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/url"
 	"path"
 	"strings"
@@ -15,6 +20,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/plugin/jointoken"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
@@ -31,12 +37,26 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// boundAgentSelectorType is the type of the node selector that binds the ID
+// derived by an additional node attestor to the agent that attested with it.
+const boundAgentSelectorType = "bound_agent_id"
+
+// lastSeenInterval is how old the recorded time an agent was last seen can
+// get before it is recorded again when the agent syncs from the same address.
+const lastSeenInterval = time.Minute
+
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
 	Metrics     telemetry.Metrics
 	Catalog     catalog.Catalog
 	ServerCA    ca.ServerCA
+	CAManager   ca.Manager
 	TrustDomain url.URL
+
+	// NodeAttestorCIDRs holds, by node attestor name, the networks agents
+	// are allowed to attest from. Node attestors without an entry are not
+	// restricted.
+	NodeAttestorCIDRs map[string][]*net.IPNet
 }
 
 type Handler struct {
@@ -89,6 +109,21 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return status.Error(codes.InvalidArgument, "request missing CSR")
 	}
 
+	// The network policy is enforced before anything else happens on
+	// behalf of the agent (e.g. using up a join token).
+	peerIP := getPeerIP(ctx)
+	if err := h.checkNodeAttestorCIDRs(peerIP, request); err != nil {
+		h.c.Log.Warn(err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	if request.AttestationData.Type == "join_token" {
+		request, err = h.assignJoinTokenID(ctx, stream, request)
+		if err != nil {
+			return err
+		}
+	}
+
 	agentID, err := getSpiffeIDFromCSR(request.Csr, idutil.AllowTrustDomainAgent(h.c.TrustDomain.Host))
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, "request CSR is invalid: %v", err)
@@ -101,53 +136,118 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return errors.New("failed to determine if agent has already attested")
 	}
 
+	// Capture the attestation data of the additional node attestors, since
+	// subsequent requests only carry challenge responses for them.
+	additionalAttestationData := request.AdditionalAttestationData
+	if err := validateAdditionalAttestationData(request.AttestationData.Type, additionalAttestationData); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// Pick the right node attestor
 	var attestStream nodeattestor.Attest_Stream
 	if request.AttestationData.Type != "join_token" {
-		var nodeAttestor nodeattestor.NodeAttestor
-		for _, a := range h.c.Catalog.NodeAttestors() {
-			if a.Config().PluginName == request.AttestationData.Type {
-				nodeAttestor = a
-				break
-			}
-		}
-		if nodeAttestor == nil {
-			return fmt.Errorf("could not find node attestor type %q", request.AttestationData.Type)
-		}
-
-		attestStream, err = nodeAttestor.Attest(ctx)
+		attestStream, err = h.openAttestStream(ctx, request.AttestationData.Type)
 		if err != nil {
-			return fmt.Errorf("unable to open attest stream: %v", err)
+			return err
 		}
 	}
 
-	attestResponse, err := h.doAttestChallengeResponse(ctx, stream, attestStream, request, attestedBefore)
+	attestResponse, err := h.doAttestChallengeResponse(ctx, stream, attestStream, request, 0, attestedBefore)
 	if err != nil {
 		return err
 	}
 
-	if attestStream != nil {
-		if err := attestStream.CloseSend(); err != nil {
-			return err
+	if err := h.closeAttestStream(attestStream); err != nil {
+		return err
+	}
+
+	if err := h.validateAttestation(attestResponse); err != nil {
+		h.c.Log.Error(err)
+		return errors.New("attestor returned unexpected response")
+	}
+
+	assignedID := attestResponse.BaseSPIFFEID != agentID
+	if assignedID {
+		// the attestor assigned an agent ID that differs from the one
+		// requested in the CSR (e.g. one derived from data only available
+		// to the server). Since the attestor was told whether or not the
+		// CSR ID had attested before, make sure that is also true for the
+		// assigned ID before honoring it.
+		var assignedAttestedBefore bool
+		assignedAttestedBefore, err = h.isAttested(ctx, attestResponse.BaseSPIFFEID)
+		if err != nil {
+			h.c.Log.Error(err)
+			return errors.New("failed to determine if agent has already attested")
 		}
-		if _, err := attestStream.Recv(); err != io.EOF {
-			h.c.Log.Warnf("expected EOF on attestation stream; got %v", err)
+		if assignedAttestedBefore && !attestedBefore {
+			h.c.Log.Errorf("Attestor assigned agent ID %q which has already attested", attestResponse.BaseSPIFFEID)
+			return errors.New("attestor returned unexpected response")
 		}
+
+		agentID = attestResponse.BaseSPIFFEID
+		attestedBefore = assignedAttestedBefore
+		counter.AddLabel("assigned_spiffe_id", agentID)
 	}
 
-	if err := h.validateAttestation(agentID, attestResponse); err != nil {
+	// The agent ID cannot be one that is bound to another agent by an
+	// additional node attestor.
+	if err := h.checkBoundAgent(ctx, agentID, agentID); err != nil {
 		h.c.Log.Error(err)
 		return errors.New("attestor returned unexpected response")
 	}
 
-	h.c.Log.Debugf("Signing CSR for Agent SVID %v", agentID)
-	svid, err := h.c.ServerCA.SignX509SVID(ctx, request.Csr, 0)
+	selectors, err := h.resolveNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type)
+	if err != nil {
+		h.c.Log.Error(err)
+		return errors.New("failed to update node selectors")
+	}
+
+	// The additional node attestors run once the agent ID is settled. They
+	// only contribute selectors to the agent, and the IDs they derive are
+	// bound to it.
+	var additionalIDs []string
+	for i, data := range additionalAttestationData {
+		additionalID, additionalSelectors, err := h.doAdditionalAttestation(ctx, stream, data, uint32(i+1), agentID)
+		if err != nil {
+			return err
+		}
+		additionalIDs = append(additionalIDs, additionalID)
+		selectors = append(selectors, additionalSelectors...)
+	}
+
+	// The binds are made before anything is issued to the agent. They are
+	// conditional in the datastore, so concurrent attestations replaying the
+	// same additional attestation data cannot all succeed.
+	for _, additionalID := range additionalIDs {
+		if err := h.bindAgent(ctx, additionalID, agentID); err != nil {
+			h.c.Log.Error(err)
+			return errors.New("failed to bind additional attestation to agent")
+		}
+	}
+
+	// The join token is only used up once every node attestor has
+	// succeeded.
+	if request.AttestationData.Type == "join_token" {
+		if err := h.useJoinToken(ctx, request.AttestationData); err != nil {
+			h.c.Log.Error(err)
+			return fmt.Errorf("failed to attest: %v", err)
+		}
+	}
+
+	var svid []*x509.Certificate
+	if assignedID {
+		h.c.Log.Debugf("Signing CSR for assigned Agent SVID %v", agentID)
+		svid, err = h.c.ServerCA.SignAgentX509SVID(ctx, request.Csr, agentID)
+	} else {
+		h.c.Log.Debugf("Signing CSR for Agent SVID %v", agentID)
+		svid, err = h.c.ServerCA.SignX509SVID(ctx, request.Csr, 0)
+	}
 	if err != nil {
 		h.c.Log.Error(err)
 		return errors.New("failed to to sign CSR")
 	}
 
-	if err := h.updateNodeSelectors(ctx, agentID, attestResponse, request.AttestationData.Type); err != nil {
+	if err := h.updateNodeSelectors(ctx, agentID, selectors); err != nil {
 		h.c.Log.Error(err)
 		return errors.New("failed to update node selectors")
 	}
@@ -172,6 +272,8 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		}
 	}
 
+	h.updateLastSeen(ctx, agentID, peerIP)
+
 	p, ok := peer.FromContext(ctx)
 	if ok {
 		h.c.Log.Infof("Node attestation request from %v completed using strategy %v", p.Addr, request.AttestationData.Type)
@@ -195,6 +297,7 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 	if !ok {
 		return errors.New("client SVID is required for this request")
 	}
+	peerIP := getPeerIP(server.Context())
 
 	for {
 		request, err := server.Recv()
@@ -218,6 +321,12 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			return err
 		}
 
+		// downstream workloads also sync through here but are not
+		// attested nodes
+		if idutil.ValidateSpiffeID(agentID, idutil.AllowTrustDomainAgent(h.c.TrustDomain.Host)) == nil {
+			h.refreshLastSeen(ctx, agentID, peerIP)
+		}
+
 		regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.DataStores()[0], agentID)
 		if err != nil {
 			h.c.Log.Error(err)
@@ -332,6 +441,47 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 	}, nil
 }
 
+// PushJWTKeyUpstream publishes the JWT signing key of a downstream server to
+// the trust domain bundle and returns the resulting JWT signing keys.
+func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUpstreamRequest) (resp *node.PushJWTKeyUpstreamResponse, err error) {
+	counter := telemetry.StartCall(h.c.Metrics, "node_api", "jwt_key", "push")
+	defer counter.Done(&err)
+
+	peerCert, ok := getPeerCertificate(ctx)
+	if !ok {
+		return nil, errors.New("client SVID is required for this request")
+	}
+
+	callerID, err := getSpiffeIDFromCert(peerCert)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, err
+	}
+	counter.AddLabel("spiffe_id", callerID)
+
+	// validate request parameters
+	switch {
+	case req.JwtKey == nil:
+		return nil, status.Error(codes.InvalidArgument, "request missing JWT key")
+	case req.JwtKey.Kid == "":
+		return nil, status.Error(codes.InvalidArgument, "JWT key is missing key ID")
+	}
+	if _, err := x509.ParsePKIXPublicKey(req.JwtKey.PkixBytes); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "JWT key is malformed: %v", err)
+	}
+
+	h.c.Log.Debugf("Publishing JWT signing key %q on request by %v", req.JwtKey.Kid, callerID)
+	jwtSigningKeys, err := h.c.CAManager.PublishJWTKey(ctx, req.JwtKey)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, err
+	}
+
+	return &node.PushJWTKeyUpstreamResponse{
+		JwtSigningKeys: jwtSigningKeys,
+	}, nil
+}
+
 func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context.Context, error) {
 	switch fullMethod {
 	// no authn/authz is required for attestation
@@ -353,6 +503,22 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 
 		ctx = withPeerCertificate(ctx, peerCert)
 
+	// peer certificate of a downstream workload required for publishing
+	// JWT signing keys
+	case "/spire.api.node.Node/PushJWTKeyUpstream":
+		peerCert, err := getPeerCertificateFromRequestContext(ctx)
+		if err != nil {
+			h.c.Log.Error(err)
+			return nil, status.Error(codes.PermissionDenied, "downstream SVID is required for this request")
+		}
+
+		if err := h.validateDownstreamSVID(ctx, peerCert); err != nil {
+			h.c.Log.Error(err)
+			return nil, status.Error(codes.PermissionDenied, "caller is not an authorized downstream workload")
+		}
+
+		ctx = withPeerCertificate(ctx, peerCert)
+
 	// method not handled
 	default:
 		return nil, status.Errorf(codes.PermissionDenied, "authorization not implemented for method %q", fullMethod)
@@ -416,10 +582,60 @@ func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate)
 	return nil
 }
 
+func (h *Handler) validateDownstreamSVID(ctx context.Context, cert *x509.Certificate) error {
+	callerID, err := getSpiffeIDFromCert(cert)
+	if err != nil {
+		return err
+	}
+
+	// see validateAgentSVID for why the expiration is checked here
+	if h.hooks.now().After(cert.NotAfter) {
+		return fmt.Errorf("downstream %q SVID has expired", callerID)
+	}
+
+	_, err = h.getDownstreamEntry(ctx, callerID)
+	return err
+}
+
+func (h *Handler) openAttestStream(ctx context.Context, attestationType string) (nodeattestor.Attest_Stream, error) {
+	var nodeAttestor nodeattestor.NodeAttestor
+	for _, a := range h.c.Catalog.NodeAttestors() {
+		if a.Config().PluginName == attestationType {
+			nodeAttestor = a
+			break
+		}
+	}
+	if nodeAttestor == nil {
+		return nil, fmt.Errorf("could not find node attestor type %q", attestationType)
+	}
+
+	attestStream, err := nodeAttestor.Attest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open attest stream: %v", err)
+	}
+	return attestStream, nil
+}
+
+func (h *Handler) closeAttestStream(attestStream nodeattestor.Attest_Stream) error {
+	if attestStream == nil {
+		return nil
+	}
+	if err := attestStream.CloseSend(); err != nil {
+		return err
+	}
+	if _, err := attestStream.Recv(); err != io.EOF {
+		h.c.Log.Warnf("expected EOF on attestation stream; got %v", err)
+	}
+	return nil
+}
+
+// doAttestChallengeResponse runs the challenge/response loop between the
+// agent and a node attestor. The attestor index is sent along with each
+// challenge so the agent can route it to the right node attestor.
 func (h *Handler) doAttestChallengeResponse(ctx context.Context,
 	nodeStream node.Node_AttestServer,
 	attestStream nodeattestor.Attest_Stream,
-	request *node.AttestRequest, attestedBefore bool) (*nodeattestor.AttestResponse, error) {
+	request *node.AttestRequest, attestorIndex uint32, attestedBefore bool) (*nodeattestor.AttestResponse, error) {
 	// challenge/response loop
 	for {
 		response, err := h.attest(ctx, attestStream, request, attestedBefore)
@@ -432,27 +648,139 @@ func (h *Handler) doAttestChallengeResponse(ctx context.Context,
 		}
 
 		challengeResponse := &node.AttestResponse{
-			Challenge: response.Challenge,
+			Challenge:              response.Challenge,
+			ChallengeAttestorIndex: attestorIndex,
 		}
 
 		if err := nodeStream.Send(challengeResponse); err != nil {
 			return nil, fmt.Errorf("failed to send challenge request: %v", err)
 		}
 
-		request, err = nodeStream.Recv()
+		next, err := nodeStream.Recv()
 		if err != nil {
 			return nil, fmt.Errorf("failed to receive challenge response: %v", err)
 		}
+		if attestorIndex > 0 {
+			// responses to the challenges of additional node attestors
+			// only carry the response.
+			next = &node.AttestRequest{
+				AttestationData: request.AttestationData,
+				Response:        next.Response,
+			}
+		}
+		request = next
 	}
 }
 
+// doAdditionalAttestation attests the agent with an additional node attestor
+// and returns the ID the attestor derived for the node, along with the
+// selectors for it.
+//
+// The derived ID is only known once the attestor is done, so instead of
+// telling the attestor whether it attested before, the handler rejects IDs
+// that are agent IDs or are bound to another agent. This way attestation data
+// that is only good for a single node (e.g. an AWS instance identity
+// document) cannot be replayed by other agents, while the agent it is bound
+// to can attest again.
+func (h *Handler) doAdditionalAttestation(ctx context.Context,
+	nodeStream node.Node_AttestServer,
+	data *common.AttestationData, attestorIndex uint32, agentID string) (string, []*common.Selector, error) {
+
+	attestStream, err := h.openAttestStream(ctx, data.Type)
+	if err != nil {
+		return "", nil, err
+	}
+
+	attestResponse, err := h.doAttestChallengeResponse(ctx, nodeStream, attestStream, &node.AttestRequest{
+		AttestationData: data,
+	}, attestorIndex, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := h.closeAttestStream(attestStream); err != nil {
+		return "", nil, err
+	}
+
+	if err := h.validateAttestation(attestResponse); err != nil {
+		h.c.Log.Errorf("Additional node attestor %q: %v", data.Type, err)
+		return "", nil, errors.New("attestor returned unexpected response")
+	}
+
+	additionalID := attestResponse.BaseSPIFFEID
+	attestedBefore, err := h.isAttested(ctx, additionalID)
+	if err != nil {
+		h.c.Log.Error(err)
+		return "", nil, errors.New("failed to determine if agent has already attested")
+	}
+	if attestedBefore {
+		h.c.Log.Errorf("Additional node attestor %q derived agent ID %q which has already attested", data.Type, additionalID)
+		return "", nil, errors.New("attestor returned unexpected response")
+	}
+	if err := h.checkBoundAgent(ctx, additionalID, agentID); err != nil {
+		h.c.Log.Errorf("Additional node attestor %q: %v", data.Type, err)
+		return "", nil, errors.New("attestor returned unexpected response")
+	}
+
+	// node resolvers recognize the SPIFFE ID the node attestor derived for
+	// the node, so selectors are resolved for it instead of the agent ID.
+	selectors, err := h.resolveNodeSelectors(ctx, additionalID, attestResponse, data.Type)
+	if err != nil {
+		h.c.Log.Error(err)
+		return "", nil, errors.New("failed to update node selectors")
+	}
+	return additionalID, selectors, nil
+}
+
+// checkBoundAgent fails if the ID derived by an additional node attestor is
+// bound to an agent other than the given one. Bindings to agents that are no
+// longer attested (e.g. evicted ones) are ignored.
+func (h *Handler) checkBoundAgent(ctx context.Context, id, agentID string) error {
+	dataStore := h.c.Catalog.DataStores()[0]
+	resp, err := dataStore.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: id,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Selectors == nil {
+		return nil
+	}
+
+	for _, selector := range resp.Selectors.Selectors {
+		if selector.Type != boundAgentSelectorType || selector.Value == agentID {
+			continue
+		}
+		attested, err := h.isAttested(ctx, selector.Value)
+		if err != nil {
+			return err
+		}
+		if attested {
+			return fmt.Errorf("%q is bound to agent %q", id, selector.Value)
+		}
+	}
+	return nil
+}
+
+// bindAgent binds the ID derived by an additional node attestor to the agent
+// that attested with it.
+func (h *Handler) bindAgent(ctx context.Context, id, agentID string) error {
+	dataStore := h.c.Catalog.DataStores()[0]
+	_, err := dataStore.BindNodeSelectors(ctx, &datastore.BindNodeSelectorsRequest{
+		SpiffeId:             id,
+		SelectorType:         boundAgentSelectorType,
+		AttestedNodeSpiffeId: agentID,
+	})
+	return err
+}
+
 func (h *Handler) attest(ctx context.Context,
 	attestStream nodeattestor.Attest_Stream,
 	nodeRequest *node.AttestRequest, attestedBefore bool) (
 	response *nodeattestor.AttestResponse, err error) {
 
 	if attestStream == nil {
-		return h.attestToken(ctx, nodeRequest.AttestationData, attestedBefore)
+		return h.attestToken(ctx, nodeRequest, attestedBefore)
 	}
 
 	attestRequest := &nodeattestor.AttestRequest{
@@ -467,15 +795,17 @@ func (h *Handler) attest(ctx context.Context,
 	return attestStream.Recv()
 }
 
+// attestToken validates the join token without using it up. The token is
+// used up by useJoinToken once every node attestor has succeeded.
 func (h *Handler) attestToken(ctx context.Context,
-	attestationData *common.AttestationData, attestedBefore bool) (
+	nodeRequest *node.AttestRequest, attestedBefore bool) (
 	response *nodeattestor.AttestResponse, err error) {
 
 	if attestedBefore {
 		return nil, errors.New("join token has already been used")
 	}
 
-	tokenValue := string(attestationData.Data)
+	tokenValue := string(nodeRequest.AttestationData.Data)
 
 	ds := h.c.Catalog.DataStores()[0]
 	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
@@ -493,38 +823,137 @@ func (h *Handler) attestToken(ctx context.Context,
 		return nil, errors.New("invalid join token")
 	}
 
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
+	if time.Unix(t.Expiry, 0).Before(h.hooks.now()) {
+		if _, err := ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+			Token: tokenValue,
+		}); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("join token expired")
+	}
+
+	// If we're here, the token is valid
+	agentID, err := h.joinTokenAgentID(t, nodeRequest.Csr)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeattestor.AttestResponse{
+		Valid:        true,
+		BaseSPIFFEID: agentID,
+		Selectors:    t.Selectors,
+	}, nil
+}
+
+// useJoinToken uses up the join token the agent attested with. The use is
+// conditional in the datastore, so concurrent attestations cannot use the
+// token more times than it allows.
+func (h *Handler) useJoinToken(ctx context.Context, attestationData *common.AttestationData) error {
+	ds := h.c.Catalog.DataStores()[0]
+	resp, err := ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token: string(attestationData.Data),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.JoinToken == nil {
+		return errors.New("no such token")
+	}
+	return nil
+}
+
+// assignJoinTokenID makes sure an agent attesting with a join token requests
+// the SPIFFE ID assigned by the token. If it doesn't, the agent is challenged
+// with the assigned SPIFFE ID and is expected to continue the attestation
+// with a CSR for it. Unknown and expired tokens are left for attestToken to
+// reject.
+func (h *Handler) assignJoinTokenID(ctx context.Context,
+	stream node.Node_AttestServer, request *node.AttestRequest) (
+	*node.AttestRequest, error) {
+
+	requestedID, err := getSpiffeIDFromCSR(request.Csr, idutil.AllowTrustDomainAgent(h.c.TrustDomain.Host))
+	if err != nil {
+		// the CSR is rejected by the caller
+		return request, nil
+	}
+
+	ds := h.c.Catalog.DataStores()[0]
+	resp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: string(request.AttestationData.Data),
+	})
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("failed to fetch join token")
+	}
+	if resp.JoinToken == nil || time.Unix(resp.JoinToken.Expiry, 0).Before(h.hooks.now()) {
+		return request, nil
+	}
+
+	assignedID, err := h.joinTokenAgentID(resp.JoinToken, request.Csr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "request CSR is invalid: %v", err)
+	}
+	if requestedID == assignedID {
+		return request, nil
+	}
+
+	challenge, err := json.Marshal(jointoken.Challenge{
+		SpiffeID: assignedID,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := stream.Send(&node.AttestResponse{
+		Challenge: challenge,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to send challenge request: %v", err)
+	}
 
-	if time.Unix(t.Expiry, 0).Before(h.hooks.now()) {
-		return nil, errors.New("join token expired")
+	next, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive challenge response: %v", err)
+	}
+	if next.AttestationData == nil || next.AttestationData.Type != request.AttestationData.Type ||
+		!bytes.Equal(next.AttestationData.Data, request.AttestationData.Data) {
+		return nil, status.Error(codes.InvalidArgument, "join token changed during attestation")
+	}
+	if len(next.Csr) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "request missing CSR")
+	}
+	return next, nil
+}
+
+// joinTokenAgentID returns the SPIFFE ID of an agent attesting with the join
+// token. Unless the token is bound to a SPIFFE ID, the SPIFFE ID is derived
+// from the token and, for tokens that can be used more than once, from the
+// public key of the agent so that every agent gets a distinct SPIFFE ID.
+func (h *Handler) joinTokenAgentID(t *datastore.JoinToken, csrBytes []byte) (string, error) {
+	if t.SpiffeId != "" {
+		return t.SpiffeId, nil
 	}
 
-	// If we're here, the token is valid
 	id := &url.URL{
 		Scheme: h.c.TrustDomain.Scheme,
 		Host:   h.c.TrustDomain.Host,
 		Path:   path.Join("spire", "agent", "join_token", t.Token),
 	}
-	return &nodeattestor.AttestResponse{
-		Valid:        true,
-		BaseSPIFFEID: id.String(),
-	}, nil
+	if t.MaxUses > 1 {
+		csr, err := x509.ParseCertificateRequest(csrBytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse CSR: %v", err)
+		}
+		sum := sha256.Sum256(csr.RawSubjectPublicKeyInfo)
+		id.Path = path.Join(id.Path, hex.EncodeToString(sum[:]))
+	}
+	return id.String(), nil
 }
 
-func (h *Handler) validateAttestation(
-	csrBaseSpiffeID string, attestResponse *nodeattestor.AttestResponse) error {
-
+func (h *Handler) validateAttestation(attestResponse *nodeattestor.AttestResponse) error {
 	if !attestResponse.Valid {
 		return errors.New("attestation is invalid")
 	}
-	//check if baseSPIFFEID in attest response matches with SPIFFEID in CSR
-	if attestResponse.BaseSPIFFEID != csrBaseSpiffeID {
-		return errors.New("attested SPIFFE ID does not match CSR")
+	//check if baseSPIFFEID in attest response is an agent ID in our trust domain
+	if _, err := idutil.ParseSpiffeID(attestResponse.BaseSPIFFEID, idutil.AllowTrustDomainAgent(h.c.TrustDomain.Host)); err != nil {
+		return fmt.Errorf("attested SPIFFE ID is invalid: %v", err)
 	}
 
 	return nil
@@ -550,13 +979,85 @@ func (h *Handler) updateAttestationEntry(ctx context.Context, cert *x509.Certifi
 	return nil
 }
 
+// updateLastSeen records the address and time the agent was last seen.
+// Failing to do so is not fatal to the call the agent is making.
+func (h *Handler) updateLastSeen(ctx context.Context, agentID string, ip net.IP) {
+	ds := h.c.Catalog.DataStores()[0]
+
+	req := &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   agentID,
+		LastSeenAt: h.hooks.now().Unix(),
+	}
+	if ip != nil {
+		req.LastSeenIp = ip.String()
+	}
+	if _, err := ds.UpdateAttestedNodeLastSeen(ctx, req); err != nil {
+		h.c.Log.Warnf("Unable to record that agent %q was last seen: %v", agentID, err)
+	}
+}
+
+// refreshLastSeen records the address and time the agent was last seen if
+// the address changed or the recorded time is older than lastSeenInterval.
+// Agents sync often, so this keeps syncing from writing to the datastore
+// every time.
+func (h *Handler) refreshLastSeen(ctx context.Context, agentID string, ip net.IP) {
+	ds := h.c.Catalog.DataStores()[0]
+
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		h.c.Log.Warnf("Unable to fetch when agent %q was last seen: %v", agentID, err)
+		return
+	}
+	if resp.Node == nil {
+		return
+	}
+
+	var lastSeenIP string
+	if ip != nil {
+		lastSeenIP = ip.String()
+	}
+	lastSeenAt := time.Unix(resp.Node.LastSeenAt, 0)
+	if resp.Node.LastSeenIp == lastSeenIP && h.hooks.now().Sub(lastSeenAt) < lastSeenInterval {
+		return
+	}
+
+	h.updateLastSeen(ctx, agentID, ip)
+}
+
+// checkNodeAttestorCIDRs makes sure the agent is allowed to attest from its
+// address with each of the node attestors in the request.
+func (h *Handler) checkNodeAttestorCIDRs(ip net.IP, request *node.AttestRequest) error {
+	attestationTypes := []string{request.AttestationData.Type}
+	for _, data := range request.AdditionalAttestationData {
+		if data != nil {
+			attestationTypes = append(attestationTypes, data.Type)
+		}
+	}
+
+	for _, attestationType := range attestationTypes {
+		cidrs, ok := h.c.NodeAttestorCIDRs[attestationType]
+		if !ok {
+			continue
+		}
+		if ip == nil {
+			return fmt.Errorf("unable to determine client address to attest with %q", attestationType)
+		}
+		if !cidrsContainIP(cidrs, ip) {
+			return fmt.Errorf("client address %s is not allowed to attest with %q", ip, attestationType)
+		}
+	}
+	return nil
+}
+
 func (h *Handler) createAttestationEntry(ctx context.Context, cert *x509.Certificate, attestationType string) error {
 	ds := h.c.Catalog.DataStores()[0]
 	return createAttestationEntry(ctx, ds, cert, attestationType)
 }
 
-func (h *Handler) updateNodeSelectors(ctx context.Context,
-	baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) error {
+func (h *Handler) resolveNodeSelectors(ctx context.Context,
+	baseSpiffeID string, attestResponse *nodeattestor.AttestResponse, attestationType string) ([]*common.Selector, error) {
 
 	// Select node resolver based on request attestation type
 	var nodeResolver noderesolver.NodeResolver
@@ -577,7 +1078,7 @@ func (h *Handler) updateNodeSelectors(ctx context.Context,
 			BaseSpiffeIdList: []string{baseSpiffeID},
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if resolved := response.Map[baseSpiffeID]; resolved != nil {
@@ -586,11 +1087,16 @@ func (h *Handler) updateNodeSelectors(ctx context.Context,
 	}
 
 	selectors = append(selectors, attestResponse.Selectors...)
+	return selectors, nil
+}
+
+func (h *Handler) updateNodeSelectors(ctx context.Context,
+	agentID string, selectors []*common.Selector) error {
 
 	dataStore := h.c.Catalog.DataStores()[0]
 	_, err := dataStore.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
 		Selectors: &datastore.NodeSelectors{
-			SpiffeId:  baseSpiffeID,
+			SpiffeId:  agentID,
 			Selectors: selectors,
 		},
 	})
@@ -831,6 +1337,32 @@ func getPeerCertificateFromRequestContext(ctx context.Context) (cert *x509.Certi
 	return chain[0], nil
 }
 
+// getPeerIP returns the IP address of the peer, or nil if it cannot be
+// determined.
+func getPeerIP(ctx context.Context) net.IP {
+	ctxPeer, ok := peer.FromContext(ctx)
+	if !ok || ctxPeer.Addr == nil {
+		return nil
+	}
+	if tcpAddr, ok := ctxPeer.Addr.(*net.TCPAddr); ok {
+		return tcpAddr.IP
+	}
+	host, _, err := net.SplitHostPort(ctxPeer.Addr.String())
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
+
+func cidrsContainIP(cidrs []*net.IPNet, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func createAttestationEntry(ctx context.Context, ds datastore.DataStore, cert *x509.Certificate, attestationType string) error {
 	spiffeID, err := getSpiffeIDFromCert(cert)
 	if err != nil {
@@ -865,6 +1397,27 @@ func makeDeprecatedBundles(bs map[string]*common.Bundle) map[string]*node.Bundle
 	return out
 }
 
+// validateAdditionalAttestationData makes sure every additional node attestor
+// is distinct from each other and from the one deriving the agent ID. Join
+// tokens cannot be used by an additional node attestor.
+func validateAdditionalAttestationData(attestationType string, additional []*common.AttestationData) error {
+	seen := map[string]bool{
+		attestationType: true,
+	}
+	for _, data := range additional {
+		switch {
+		case data == nil || data.Type == "":
+			return errors.New("request missing additional attestation data type")
+		case data.Type == "join_token":
+			return errors.New("join token cannot be used as additional attestation data")
+		case seen[data.Type]:
+			return fmt.Errorf("request has duplicate attestation data type %q", data.Type)
+		}
+		seen[data.Type] = true
+	}
+	return nil
+}
+
 func getSpiffeIDFromCSR(csrBytes []byte, mode idutil.ValidationMode) (string, error) {
 	csr, err := x509.ParseCertificateRequest(csrBytes)
 	if err != nil {
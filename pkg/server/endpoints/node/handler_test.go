@@ -2,8 +2,11 @@ package node
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -18,8 +21,10 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/plugin/jointoken"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/datastore"
@@ -121,6 +126,7 @@ func (s *HandlerSuite) SetupTest() {
 		Metrics:     telemetry.Blackhole{},
 		Catalog:     s.catalog,
 		ServerCA:    s.serverCA,
+		CAManager:   &fakeCAManager{ds: s.ds},
 		TrustDomain: *trustDomainURL,
 	})
 	handler.hooks.now = func() time.Time {
@@ -265,17 +271,56 @@ func (s *HandlerSuite) TestAttestWithUnknownAttestor() {
 	}, codes.Unknown, `could not find node attestor type "test"`)
 }
 
-func (s *HandlerSuite) TestAttestWithMismatchedAgentID() {
+func (s *HandlerSuite) TestAttestWithInvalidAssignedAgentID() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		TrustDomain: "otherdomain.test",
+		Data:        map[string]string{"data": "id"},
+	})
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+	}, codes.Unknown, "attestor returned unexpected response")
+
+	s.assertLastLogMessageContains("attested SPIFFE ID is invalid")
+}
+
+func (s *HandlerSuite) TestAttestWithAssignedAgentID() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+	})
+
+	upd := s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/test/other"),
+	})
+
+	// the SVID is issued for the ID assigned by the attestor
+	svidChain := s.assertSVIDsInUpdate(upd, agentID)[0]
+	s.Require().Len(svidChain[0].URIs, 1)
+	s.Equal(agentID, svidChain[0].URIs[0].String())
+
+	attestedNode := s.fetchAttestedNode(agentID)
+	s.Require().NotNil(attestedNode)
+	s.Equal(svidChain[0].SerialNumber.String(), attestedNode.CertSerialNumber)
+	s.Nil(s.fetchAttestedNode("spiffe://example.org/spire/agent/test/other"))
+}
+
+func (s *HandlerSuite) TestAttestWithAssignedAgentIDAlreadyAttested() {
 	s.addAttestor("test", fakeservernodeattestor.Config{
 		Data: map[string]string{"data": "id"},
 	})
 
+	s.createAttestedNode(&common.AttestedNode{
+		SpiffeId: agentID,
+	})
+
 	s.requireAttestFailure(&node.AttestRequest{
 		AttestationData: makeAttestationData("test", "data"),
 		Csr:             s.makeCSR("spiffe://example.org/spire/agent/test/other"),
 	}, codes.Unknown, "attestor returned unexpected response")
 
-	s.assertLastLogMessage("attested SPIFFE ID does not match CSR")
+	s.assertLastLogMessage(`Attestor assigned agent ID "spiffe://example.org/spire/agent/test/id" which has already attested`)
 }
 
 func (s *HandlerSuite) TestAttestSuccess() {
@@ -310,6 +355,7 @@ func (s *HandlerSuite) TestAttestSuccess() {
 	s.Equal(agentID, attestedNode.SpiffeId)
 	s.Equal(svidChain[0].SerialNumber.String(), attestedNode.CertSerialNumber)
 	s.WithinDuration(svidChain[0].NotAfter, time.Unix(attestedNode.CertNotAfter, 0), 0)
+	s.assertLastSeen(attestedNode)
 
 	// No selectors were returned and no resolvers were available, so the node
 	// selectors should be empty.
@@ -402,6 +448,111 @@ func (s *HandlerSuite) TestAttestWithValidJoinToken() {
 	s.Nil(s.fetchJoinToken("TOKEN"))
 }
 
+func (s *HandlerSuite) TestAttestWithBoundJoinToken() {
+	s.storeJoinToken(&datastore.JoinToken{
+		Token:     "TOKEN",
+		Expiry:    s.now.Add(time.Second).Unix(),
+		SpiffeId:  agentID,
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	stream, err := s.unattestedClient.Attest(ctx)
+	s.Require().NoError(err)
+
+	// the agent is challenged with the SPIFFE ID bound to the token
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/join_token/TOKEN"),
+	}))
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().Nil(resp.SvidUpdate)
+	challenge := new(jointoken.Challenge)
+	s.Require().NoError(json.Unmarshal(resp.Challenge, challenge))
+	s.Require().Equal(agentID, challenge.SpiffeID)
+
+	// and continues with a CSR for it
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR(challenge.SpiffeID),
+	}))
+	stream.CloseSend()
+	resp, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.SvidUpdate)
+	s.assertSVIDsInUpdate(resp.SvidUpdate, agentID)
+
+	// the token selectors are stored as node selectors
+	s.Equal([]*common.Selector{{Type: "A", Value: "a"}}, s.getNodeSelectors(agentID))
+	s.Nil(s.fetchJoinToken("TOKEN"))
+}
+
+func (s *HandlerSuite) TestAttestWithBoundJoinTokenChangedDuringAttestation() {
+	s.storeJoinToken(&datastore.JoinToken{
+		Token:    "TOKEN",
+		Expiry:   s.now.Add(time.Second).Unix(),
+		SpiffeId: agentID,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	stream, err := s.unattestedClient.Attest(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/join_token/TOKEN"),
+	}))
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.Challenge)
+
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "OTHER"),
+		Csr:             s.makeCSR(agentID),
+	}))
+	stream.CloseSend()
+	resp, err = stream.Recv()
+	s.requireErrorContains(err, "join token changed during attestation")
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// the token was not used
+	s.NotNil(s.fetchJoinToken("TOKEN"))
+}
+
+func (s *HandlerSuite) TestAttestWithMultiUseJoinToken() {
+	s.storeJoinToken(&datastore.JoinToken{
+		Token:   "TOKEN",
+		Expiry:  s.now.Add(time.Second).Unix(),
+		MaxUses: 2,
+	})
+
+	// agents attesting with a multi-use token get an ID derived from their key
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	s.Require().NoError(err)
+	sum := sha256.Sum256(pkixBytes)
+	tokenAgentID := "spiffe://example.org/spire/agent/join_token/TOKEN/" + hex.EncodeToString(sum[:])
+
+	upd := s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR(tokenAgentID),
+	})
+	s.assertSVIDsInUpdate(upd, tokenAgentID)
+
+	// the token can be used again
+	token := s.fetchJoinToken("TOKEN")
+	s.Require().NotNil(token)
+	s.Equal(int32(1), token.Uses)
+
+	// but not by the same agent
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR(tokenAgentID),
+	}, codes.Unknown, "failed to attest: join token has already been used")
+}
+
 func (s *HandlerSuite) TestAttestWithOnlyAttestorSelectors() {
 	// configure the attestor to return selectors
 	s.addAttestor("test", fakeservernodeattestor.Config{
@@ -477,6 +628,388 @@ func (s *HandlerSuite) TestAttestWithBothAttestorAndResolverSelectors() {
 	}, s.getNodeSelectors("spiffe://example.org/spire/agent/test/id"))
 }
 
+func (s *HandlerSuite) TestAttestWithAdditionalAttestationData() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+		Challenges: map[string][]string{
+			"id": {"one"},
+		},
+		Selectors: map[string][]string{
+			"id": {"test-attestor-value"},
+		},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+		Challenges: map[string][]string{
+			"other-id": {"two", "three"},
+		},
+		Selectors: map[string][]string{
+			"other-id": {"other-attestor-value"},
+		},
+	})
+
+	// the resolver for the additional attestor resolves the ID derived by
+	// that attestor
+	s.addResolver("other", fakenoderesolver.Config{
+		Selectors: map[string][]string{
+			"spiffe://example.org/spire/agent/other/other-id": {"other-resolver-value"},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	stream, err := s.unattestedClient.Attest(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}))
+
+	// challenges are tagged with the index of the attestor
+	for _, challenge := range []struct {
+		value string
+		index uint32
+	}{
+		{value: "one", index: 0},
+		{value: "two", index: 1},
+		{value: "three", index: 1},
+	} {
+		resp, err := stream.Recv()
+		s.Require().NoError(err)
+		s.Require().Equal(challenge.value, string(resp.Challenge))
+		s.Require().Equal(challenge.index, resp.ChallengeAttestorIndex)
+		s.Require().NoError(stream.Send(&node.AttestRequest{
+			Response: resp.Challenge,
+		}))
+	}
+	stream.CloseSend()
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.SvidUpdate)
+
+	// the agent ID comes from the primary attestor
+	s.assertSVIDsInUpdate(resp.SvidUpdate, agentID)
+	attestedNode := s.fetchAttestedNode(agentID)
+	s.Require().NotNil(attestedNode)
+	s.Equal("test", attestedNode.AttestationDataType)
+	s.Nil(s.fetchAttestedNode("spiffe://example.org/spire/agent/other/other-id"))
+
+	// selectors from all attestors are combined
+	s.Equal([]*common.Selector{
+		{Type: "test", Value: "test-attestor-value"},
+		{Type: "other", Value: "other-resolver-value"},
+		{Type: "other", Value: "other-attestor-value"},
+	}, s.getNodeSelectors(agentID))
+
+	// the ID derived by the additional attestor is bound to the agent
+	s.Equal([]*common.Selector{
+		{Type: "bound_agent_id", Value: agentID},
+	}, s.getNodeSelectors("spiffe://example.org/spire/agent/other/other-id"))
+}
+
+func (s *HandlerSuite) TestAttestWithReplayedAdditionalAttestationData() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{
+			"data":       "id",
+			"other-data": "other-id",
+		},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	})
+
+	// another agent cannot attest with the same additional attestation data
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "other-data"),
+		Csr:                       s.makeCSR("spiffe://example.org/spire/agent/test/other-id"),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.Unknown, "attestor returned unexpected response")
+	s.Nil(s.fetchAttestedNode("spiffe://example.org/spire/agent/test/other-id"))
+
+	// nor can an agent attest with it as its primary attestation data
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("other", "other-data"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/other/other-id"),
+	}, codes.Unknown, "attestor returned unexpected response")
+	s.Nil(s.fetchAttestedNode("spiffe://example.org/spire/agent/other/other-id"))
+}
+
+func (s *HandlerSuite) TestAttestWithConcurrentlyReplayedAdditionalAttestationData() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{
+			"data":       "id",
+			"other-data": "other-id",
+		},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+	s.addAttestor("third", fakeservernodeattestor.Config{
+		Data: map[string]string{"third-data": "third-id"},
+		Challenges: map[string][]string{
+			"third-id": {"challenge"},
+		},
+	})
+
+	// the first agent is held up by the challenge of the last additional
+	// attestor, after the additional attestation data has been checked
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	stream, err := s.unattestedClient.Attest(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{
+			makeAttestationData("other", "other-data"),
+			makeAttestationData("third", "third-data"),
+		},
+	}))
+	resp, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().Equal("challenge", string(resp.Challenge))
+
+	// meanwhile, another agent attests with the same additional attestation
+	// data
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "other-data"),
+		Csr:                       s.makeCSR("spiffe://example.org/spire/agent/test/other-id"),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	})
+
+	// the first agent cannot bind the additional attestation data anymore
+	s.Require().NoError(stream.Send(&node.AttestRequest{
+		Response: resp.Challenge,
+	}))
+	stream.CloseSend()
+	_, err = stream.Recv()
+	s.requireErrorContains(err, "failed to bind additional attestation to agent")
+	s.Nil(s.fetchAttestedNode(agentID))
+	s.Equal([]*common.Selector{
+		{Type: "bound_agent_id", Value: "spiffe://example.org/spire/agent/test/other-id"},
+	}, s.getNodeSelectors("spiffe://example.org/spire/agent/other/other-id"))
+}
+
+func (s *HandlerSuite) TestAttestWithAdditionalAttestationDataOfAttestedAgent() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("other", "other-data"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/other/other-id"),
+	})
+
+	// the ID derived by the additional attestor belongs to another agent
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.Unknown, "attestor returned unexpected response")
+	s.Nil(s.fetchAttestedNode(agentID))
+}
+
+func (s *HandlerSuite) TestReattestWithAdditionalAttestationData() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data:        map[string]string{"data": "id"},
+		CanReattest: true,
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+
+	req := &node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}
+	s.requireAttestSuccess(req)
+
+	// the agent the additional attestation data is bound to can attest again
+	s.requireAttestSuccess(req)
+}
+
+func (s *HandlerSuite) TestAttestWithAdditionalAttestationDataOfEvictedAgent() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{
+			"data":       "id",
+			"other-data": "other-id",
+		},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	})
+	s.deleteAttestedNode(agentID)
+
+	// the binding to an evicted agent does not prevent another agent from
+	// attesting with the additional attestation data
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "other-data"),
+		Csr:                       s.makeCSR("spiffe://example.org/spire/agent/test/other-id"),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	})
+	s.Equal([]*common.Selector{
+		{Type: "bound_agent_id", Value: "spiffe://example.org/spire/agent/test/other-id"},
+	}, s.getNodeSelectors("spiffe://example.org/spire/agent/other/other-id"))
+}
+
+func (s *HandlerSuite) TestAttestWithFailingAdditionalAttestor() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{})
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.Unknown, `failed to attest: no ID configured for attestation data "other-data"`)
+
+	s.Nil(s.fetchAttestedNode(agentID))
+}
+
+func (s *HandlerSuite) TestAttestWithJoinTokenAndFailingAdditionalAttestor() {
+	s.addAttestor("other", fakeservernodeattestor.Config{})
+	s.createJoinToken("TOKEN", s.now.Add(time.Second))
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("join_token", "TOKEN"),
+		Csr:                       s.makeCSR("spiffe://example.org/spire/agent/join_token/TOKEN"),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.Unknown, `failed to attest: no ID configured for attestation data "other-data"`)
+
+	// the join token is not used up by the failed attestation
+	s.NotNil(s.fetchJoinToken("TOKEN"))
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/join_token/TOKEN"),
+	})
+	s.Nil(s.fetchJoinToken("TOKEN"))
+}
+
+func (s *HandlerSuite) TestAttestWithUnknownAdditionalAttestor() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+	})
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.Unknown, `could not find node attestor type "other"`)
+}
+
+func (s *HandlerSuite) TestAttestWithMalformedAdditionalAttestationData() {
+	for _, tt := range []struct {
+		data          []*common.AttestationData
+		errorContains string
+	}{
+		{
+			data:          []*common.AttestationData{{}},
+			errorContains: "request missing additional attestation data type",
+		},
+		{
+			data:          []*common.AttestationData{makeAttestationData("join_token", "TOKEN")},
+			errorContains: "join token cannot be used as additional attestation data",
+		},
+		{
+			data:          []*common.AttestationData{makeAttestationData("test", "data")},
+			errorContains: `request has duplicate attestation data type "test"`,
+		},
+		{
+			data: []*common.AttestationData{
+				makeAttestationData("other", "other-data"),
+				makeAttestationData("other", "other-data"),
+			},
+			errorContains: `request has duplicate attestation data type "other"`,
+		},
+	} {
+		s.requireAttestFailure(&node.AttestRequest{
+			AttestationData:           makeAttestationData("test", "data"),
+			Csr:                       s.makeCSR(agentID),
+			AdditionalAttestationData: tt.data,
+		}, codes.InvalidArgument, tt.errorContains)
+	}
+}
+
+func (s *HandlerSuite) TestAttestWithNodeAttestorCIDRs() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		CanReattest: true,
+		Data:        map[string]string{"data": "id"},
+	})
+	s.addAttestor("other", fakeservernodeattestor.Config{
+		Data: map[string]string{"other-data": "other-id"},
+	})
+	s.createJoinToken("TOKEN", s.now.Add(time.Second))
+
+	loopback := mustParseCIDRs("127.0.0.0/8", "::1/128")
+	other := mustParseCIDRs("10.0.0.0/8")
+
+	// denied by the primary node attestor policy
+	s.handler.c.NodeAttestorCIDRs = map[string][]*net.IPNet{"test": other}
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+	}, codes.PermissionDenied, `is not allowed to attest with "test"`)
+
+	// denied by the additional node attestor policy
+	s.handler.c.NodeAttestorCIDRs = map[string][]*net.IPNet{"test": loopback, "other": other}
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	}, codes.PermissionDenied, `is not allowed to attest with "other"`)
+	s.Nil(s.fetchAttestedNode(agentID))
+
+	// denied join tokens are not used up
+	s.handler.c.NodeAttestorCIDRs = map[string][]*net.IPNet{"join_token": other}
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR("spiffe://example.org/spire/agent/join_token/TOKEN"),
+	}, codes.PermissionDenied, `is not allowed to attest with "join_token"`)
+	s.NotNil(s.fetchJoinToken("TOKEN"))
+
+	// allowed by every policy
+	s.handler.c.NodeAttestorCIDRs = map[string][]*net.IPNet{"test": loopback, "other": loopback, "join_token": other}
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData:           makeAttestationData("test", "data"),
+		Csr:                       s.makeCSR(agentID),
+		AdditionalAttestationData: []*common.AttestationData{makeAttestationData("other", "other-data")},
+	})
+	s.assertLastSeen(s.fetchAttestedNode(agentID))
+}
+
+func (s *HandlerSuite) TestCheckNodeAttestorCIDRsWithUnknownAddress() {
+	s.handler.c.NodeAttestorCIDRs = map[string][]*net.IPNet{"other": mustParseCIDRs("10.0.0.0/8")}
+
+	// unrestricted node attestor
+	s.Require().NoError(s.handler.checkNodeAttestorCIDRs(nil, &node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+	}))
+
+	// restricted node attestor
+	err := s.handler.checkNodeAttestorCIDRs(nil, &node.AttestRequest{
+		AttestationData: makeAttestationData("other", "data"),
+	})
+	s.Require().EqualError(err, `unable to determine client address to attest with "other"`)
+}
+
 func (s *HandlerSuite) TestFetchX509SVIDWithUnattestedAgent() {
 	s.requireFetchX509SVIDAuthFailure()
 }
@@ -555,6 +1088,31 @@ func (s *HandlerSuite) TestFetchX509SVIDWithAgentCSR() {
 	s.Equal(agentID, attestedNode.SpiffeId)
 	s.Equal(svidChain[0].SerialNumber.String(), attestedNode.CertSerialNumber)
 	s.WithinDuration(svidChain[0].NotAfter, time.Unix(attestedNode.CertNotAfter, 0), 0)
+	s.assertLastSeen(attestedNode)
+}
+
+func (s *HandlerSuite) TestFetchX509SVIDRecordsLastSeen() {
+	s.attestAgent()
+	req := &node.FetchX509SVIDRequest{}
+
+	s.requireFetchX509SVIDSuccess(req)
+	s.assertLastSeen(s.fetchAttestedNode(agentID))
+	ip := s.fetchAttestedNode(agentID).LastSeenIp
+
+	// not recorded again from the same address within the interval
+	s.setLastSeen(ip, s.now.Add(-lastSeenInterval+time.Second))
+	s.requireFetchX509SVIDSuccess(req)
+	s.Equal(s.now.Add(-lastSeenInterval+time.Second).Unix(), s.fetchAttestedNode(agentID).LastSeenAt)
+
+	// recorded again once the interval passed
+	s.setLastSeen(ip, s.now.Add(-lastSeenInterval))
+	s.requireFetchX509SVIDSuccess(req)
+	s.assertLastSeen(s.fetchAttestedNode(agentID))
+
+	// recorded again within the interval when the address changed
+	s.setLastSeen("192.0.2.1", s.now)
+	s.requireFetchX509SVIDSuccess(req)
+	s.assertLastSeen(s.fetchAttestedNode(agentID))
 }
 
 func (s *HandlerSuite) TestFetchX509SVIDWithStaleAgent() {
@@ -699,6 +1257,58 @@ func (s *HandlerSuite) TestFetchJWTSVIDWithWorkloadID() {
 	s.Equal(s.now.Add(s.serverCA.DefaultTTL()).Unix(), svid.ExpiresAt)
 }
 
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithUnauthorizedCaller() {
+	s.attestAgent()
+
+	s.requirePushJWTKeyUpstreamFailure(&node.PushJWTKeyUpstreamRequest{
+		JwtKey: s.makeJWTKey("KID"),
+	}, codes.PermissionDenied, "caller is not an authorized downstream workload")
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithMissingKey() {
+	s.createDownstreamEntry()
+
+	s.requirePushJWTKeyUpstreamFailure(&node.PushJWTKeyUpstreamRequest{},
+		codes.InvalidArgument, "request missing JWT key")
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithMissingKeyID() {
+	s.createDownstreamEntry()
+
+	s.requirePushJWTKeyUpstreamFailure(&node.PushJWTKeyUpstreamRequest{
+		JwtKey: s.makeJWTKey(""),
+	}, codes.InvalidArgument, "JWT key is missing key ID")
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithMalformedKey() {
+	s.createDownstreamEntry()
+
+	s.requirePushJWTKeyUpstreamFailure(&node.PushJWTKeyUpstreamRequest{
+		JwtKey: &common.PublicKey{
+			Kid:       "KID",
+			PkixBytes: []byte("MALFORMED"),
+		},
+	}, codes.InvalidArgument, "JWT key is malformed")
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstream() {
+	s.createDownstreamEntry()
+
+	jwtKey := s.makeJWTKey("KID")
+	jwtSigningKeys := s.requirePushJWTKeyUpstreamSuccess(&node.PushJWTKeyUpstreamRequest{
+		JwtKey: jwtKey,
+	})
+	s.Require().Len(jwtSigningKeys, 1)
+	s.Require().True(proto.Equal(jwtKey, jwtSigningKeys[0]))
+
+	resp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Bundle.JwtSigningKeys, 1)
+	s.Require().True(proto.Equal(jwtKey, resp.Bundle.JwtSigningKeys[0]))
+}
+
 func (s *HandlerSuite) TestAuthorizeCallUnhandledMethod() {
 	ctx, err := s.handler.AuthorizeCall(context.Background(), "/spire.api.node.Node/Foo")
 	s.Require().Error(err)
@@ -722,6 +1332,46 @@ func (s *HandlerSuite) TestAuthorizeCallForFetchJWTSVID() {
 	s.testAuthorizeCallRequiringAgentSVID("FetchJWTSVID")
 }
 
+func (s *HandlerSuite) TestAuthorizeCallForPushJWTKeyUpstream() {
+	peerCert := s.agentSVID[0]
+	peerCtx := withPeerCert(context.Background(), s.agentSVID)
+
+	fullMethod := "/spire.api.node.Node/PushJWTKeyUpstream"
+
+	// no peer context
+	ctx, err := s.handler.AuthorizeCall(context.Background(), fullMethod)
+	s.Require().Error(err)
+	s.Equal("downstream SVID is required for this request", status.Convert(err).Message())
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage("no peer information")
+
+	// no downstream entry for the caller
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.Require().Error(err)
+	s.Equal("caller is not an authorized downstream workload", status.Convert(err).Message())
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage(`"spiffe://example.org/spire/agent/test/id" is not an authorized downstream workload`)
+
+	// good certificate
+	s.createDownstreamEntry()
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.Require().NoError(err)
+	actualCert, ok := getPeerCertificate(ctx)
+	s.Require().True(ok, "context has peer certificate")
+	s.Require().True(peerCert.Equal(actualCert), "peer certificate matches")
+
+	// expired certificate
+	s.now = peerCert.NotAfter.Add(time.Second)
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.Require().Error(err)
+	s.Equal("caller is not an authorized downstream workload", status.Convert(err).Message())
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage(`downstream "spiffe://example.org/spire/agent/test/id" SVID has expired`)
+}
+
 func (s *HandlerSuite) testAuthorizeCallRequiringAgentSVID(method string) {
 	peerCert := s.agentSVID[0]
 	peerCtx := withPeerCert(context.Background(), s.agentSVID)
@@ -808,11 +1458,15 @@ func (s *HandlerSuite) createBundle(bundle *common.Bundle) {
 }
 
 func (s *HandlerSuite) createJoinToken(token string, expiresAt time.Time) {
+	s.storeJoinToken(&datastore.JoinToken{
+		Token:  token,
+		Expiry: expiresAt.Unix(),
+	})
+}
+
+func (s *HandlerSuite) storeJoinToken(token *datastore.JoinToken) {
 	_, err := s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
-		JoinToken: &datastore.JoinToken{
-			Token:  token,
-			Expiry: expiresAt.Unix(),
-		},
+		JoinToken: token,
 	})
 	s.Require().NoError(err)
 }
@@ -845,6 +1499,13 @@ func (s *HandlerSuite) updateAttestedNode(spiffeID, serialNumber string, notAfte
 	s.Require().NoError(err)
 }
 
+func (s *HandlerSuite) deleteAttestedNode(spiffeID string) {
+	_, err := s.ds.DeleteAttestedNode(context.Background(), &datastore.DeleteAttestedNodeRequest{
+		SpiffeId: spiffeID,
+	})
+	s.Require().NoError(err)
+}
+
 func (s *HandlerSuite) fetchAttestedNode(spiffeID string) *common.AttestedNode {
 	resp, err := s.ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
 		SpiffeId: spiffeID,
@@ -854,6 +1515,21 @@ func (s *HandlerSuite) fetchAttestedNode(spiffeID string) *common.AttestedNode {
 	return resp.Node
 }
 
+func (s *HandlerSuite) setLastSeen(ip string, at time.Time) {
+	_, err := s.ds.UpdateAttestedNodeLastSeen(context.Background(), &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   agentID,
+		LastSeenIp: ip,
+		LastSeenAt: at.Unix(),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *HandlerSuite) assertLastSeen(attestedNode *common.AttestedNode) {
+	s.Require().NotNil(attestedNode)
+	s.True(net.ParseIP(attestedNode.LastSeenIp).IsLoopback(), "expected a loopback address; got %q", attestedNode.LastSeenIp)
+	s.Equal(s.now.Unix(), attestedNode.LastSeenAt)
+}
+
 func (s *HandlerSuite) getNodeSelectors(spiffeID string) []*common.Selector {
 	resp, err := s.ds.GetNodeSelectors(context.Background(), &datastore.GetNodeSelectorsRequest{
 		SpiffeId: spiffeID,
@@ -874,6 +1550,14 @@ func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry)
 	return resp.Entry
 }
 
+func (s *HandlerSuite) createDownstreamEntry() {
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:   trustDomainID,
+		SpiffeId:   agentID,
+		Downstream: true,
+	})
+}
+
 func (s *HandlerSuite) requireAttestSuccess(req *node.AttestRequest, responses ...string) *node.X509SVIDUpdate {
 	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
 	defer cancel()
@@ -981,6 +1665,24 @@ func (s *HandlerSuite) requireFetchJWTSVIDFailure(req *node.FetchJWTSVIDRequest,
 	s.Require().Nil(resp)
 }
 
+func (s *HandlerSuite) requirePushJWTKeyUpstreamSuccess(req *node.PushJWTKeyUpstreamRequest) []*common.PublicKey {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	resp, err := s.attestedClient.PushJWTKeyUpstream(ctx, req)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp.JwtSigningKeys
+}
+
+func (s *HandlerSuite) requirePushJWTKeyUpstreamFailure(req *node.PushJWTKeyUpstreamRequest, errorCode codes.Code, errorContains string) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	resp, err := s.attestedClient.PushJWTKeyUpstream(ctx, req)
+	s.Require().Contains(status.Convert(err).Message(), errorContains)
+	s.Require().Equal(errorCode, status.Code(err))
+	s.Require().Nil(resp)
+}
+
 func (s *HandlerSuite) assertBundlesInUpdate(upd *node.X509SVIDUpdate, federatedBundles ...*common.Bundle) {
 	// DEPRECATEDBundle field should contain the trust domain bundle certs
 	s.Equal(upd.DEPRECATEDBundle, s.bundle.RootCas[0].DerBytes)
@@ -1069,6 +1771,15 @@ func (s *HandlerSuite) makeSVID(spiffeID string) []*x509.Certificate {
 	return svid
 }
 
+func (s *HandlerSuite) makeJWTKey(kid string) *common.PublicKey {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	s.Require().NoError(err)
+	return &common.PublicKey{
+		Kid:       kid,
+		PkixBytes: pkixBytes,
+	}
+}
+
 func (s *HandlerSuite) makeCSR(spiffeID string) []byte {
 	csr, err := util.MakeCSR(testKey, spiffeID)
 	s.Require().NoError(err)
@@ -1137,6 +1848,37 @@ func (fl *fakeLimiter) callsFor(msgType int) int {
 	return 0
 }
 
+type fakeCAManager struct {
+	ca.Manager
+
+	ds datastore.DataStore
+}
+
+func (m *fakeCAManager) PublishJWTKey(ctx context.Context, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+	resp, err := m.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  trustDomainID,
+			JwtSigningKeys: []*common.PublicKey{jwtKey},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Bundle.JwtSigningKeys, nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var ipNets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
+
 func makeAttestationData(typ, data string) *common.AttestationData {
 	return &common.AttestationData{Type: typ, Data: []byte(data)}
 }
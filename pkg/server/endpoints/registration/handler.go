@@ -16,6 +16,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/proto/api/registration"
 	"github.com/spiffe/spire/proto/common"
@@ -34,6 +35,7 @@ type Handler struct {
 	Metrics     telemetry.Metrics
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
+	CAManager   ca.Manager
 }
 
 //Creates an entry in the Registration table,
@@ -481,6 +483,25 @@ func (h *Handler) CreateJoinToken(
 	if request.Ttl < 1 {
 		return nil, errors.New("Ttl is required, you must provide one")
 	}
+	if request.MaxUses < 0 {
+		return nil, errors.New("max uses cannot be negative")
+	}
+	if request.SpiffeId != "" {
+		request.SpiffeId, err = idutil.NormalizeSpiffeID(request.SpiffeId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+		if err != nil {
+			return nil, err
+		}
+		// the first agent to attest with the token takes the bound ID, so
+		// later uses would be rejected as an attempt to attest again
+		if request.MaxUses > 1 {
+			return nil, status.Error(codes.InvalidArgument, "a token bound to an agent SPIFFE ID can only be used once")
+		}
+	}
+	for _, s := range request.Selectors {
+		if s.Type == "" || s.Value == "" {
+			return nil, fmt.Errorf("invalid selector %q", s.Type+":"+s.Value)
+		}
+	}
 
 	// Generate a token if one wasn't specified
 	if request.Token == "" {
@@ -496,8 +517,11 @@ func (h *Handler) CreateJoinToken(
 
 	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
 		JoinToken: &datastore.JoinToken{
-			Token:  request.Token,
-			Expiry: expiry,
+			Token:     request.Token,
+			Expiry:    expiry,
+			SpiffeId:  request.SpiffeId,
+			Selectors: request.Selectors,
+			MaxUses:   request.MaxUses,
 		},
 	})
 	if err != nil {
@@ -505,9 +529,68 @@ func (h *Handler) CreateJoinToken(
 		return nil, errors.New("Failed to register token")
 	}
 
+	request.ExpiresAt = expiry
 	return request, nil
 }
 
+// ListJoinTokens lists the outstanding join tokens
+func (h *Handler) ListJoinTokens(ctx context.Context, request *registration.ListJoinTokensRequest) (response *registration.ListJoinTokensResponse, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "join_token", "list")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	resp, err := h.getDataStore().ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to list join tokens: %v", err)
+	}
+
+	response = &registration.ListJoinTokensResponse{}
+	for _, token := range resp.JoinTokens {
+		response.JoinTokens = append(response.JoinTokens, joinTokenFromDatastore(token))
+	}
+	return response, nil
+}
+
+// RevokeJoinToken revokes a join token so it can no longer be used
+func (h *Handler) RevokeJoinToken(ctx context.Context, request *registration.RevokeJoinTokenRequest) (response *registration.RevokeJoinTokenResponse, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "join_token", "revoke")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	if request.Token == "" {
+		return nil, errors.New("token is required")
+	}
+
+	ds := h.getDataStore()
+	fetchResp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: request.Token,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to fetch join token: %v", err)
+	}
+	if fetchResp.JoinToken == nil {
+		return nil, status.Error(codes.NotFound, "no such join token")
+	}
+
+	deleteResp, err := ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: request.Token,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to revoke join token: %v", err)
+	}
+
+	return &registration.RevokeJoinTokenResponse{
+		JoinToken: joinTokenFromDatastore(deleteResp.JoinToken),
+	}, nil
+}
+
 // FetchBundle retrieves the CA bundle.
 func (h *Handler) FetchBundle(
 	ctx context.Context, request *common.Empty) (
@@ -562,6 +645,85 @@ func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgen
 	return &registration.ListAgentsResponse{Nodes: resp.Nodes}, nil
 }
 
+// ListCAKeypairSets lists the active and prepared CA keypair sets
+func (h *Handler) ListCAKeypairSets(ctx context.Context, request *common.Empty) (response *registration.ListCAKeypairSetsResponse, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "ca", "list")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	infos, err := h.CAManager.KeypairSets(ctx)
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to list CA keypair sets: %v", err)
+	}
+
+	response = &registration.ListCAKeypairSetsResponse{}
+	for _, info := range infos {
+		response.KeypairSets = append(response.KeypairSets, caKeypairSetFromInfo(info))
+	}
+	return response, nil
+}
+
+// PrepareCAKeypairSet prepares a new CA keypair set, to be activated later
+func (h *Handler) PrepareCAKeypairSet(ctx context.Context, request *common.Empty) (response *registration.CAKeypairSet, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "ca", "prepare")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	info, err := h.CAManager.PrepareKeypairSet(ctx)
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to prepare CA keypair set: %v", err)
+	}
+	return caKeypairSetFromInfo(*info), nil
+}
+
+// ActivateCAKeypairSet activates the prepared CA keypair set immediately
+func (h *Handler) ActivateCAKeypairSet(ctx context.Context, request *common.Empty) (response *registration.CAKeypairSet, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "ca", "activate")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	info, err := h.CAManager.ActivateKeypairSet(ctx)
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to activate CA keypair set: %v", err)
+	}
+	return caKeypairSetFromInfo(*info), nil
+}
+
+// RevokeCAKey revokes a CA key that is no longer active, removing it from
+// the bundle
+func (h *Handler) RevokeCAKey(ctx context.Context, request *registration.RevokeCAKeyRequest) (response *common.Empty, err error) {
+	counter, err := h.startCall(ctx, "registration_api", "ca", "revoke")
+	if err != nil {
+		return nil, err
+	}
+	defer counter.Done(&err)
+
+	switch {
+	case request.X509CaKeyId != "" && request.JwtKeyId != "":
+		return nil, errors.New("only one of the X.509 CA key ID or JWT key ID can be set")
+	case request.X509CaKeyId != "":
+		err = h.CAManager.RevokeX509CA(ctx, request.X509CaKeyId)
+	case request.JwtKeyId != "":
+		err = h.CAManager.RevokeJWTSigningKey(ctx, request.JwtKeyId)
+	default:
+		return nil, errors.New("either the X.509 CA key ID or JWT key ID must be set")
+	}
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("error trying to revoke CA key: %v", err)
+	}
+	return &common.Empty{}, nil
+}
+
 func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
 	if agentID == "" {
 		return nil, errors.New("empty agent ID")
@@ -652,6 +814,27 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 	return ctx, nil
 }
 
+func caKeypairSetFromInfo(info ca.KeypairSetInfo) *registration.CAKeypairSet {
+	return &registration.CAKeypairSet{
+		Slot:          info.Slot,
+		Active:        info.Active,
+		X509Ca:        info.X509CA.Raw,
+		X509CaKeyId:   ca.X509CAKeyID(info.X509CA),
+		JwtSigningKey: info.JWTSigningKey,
+	}
+}
+
+func joinTokenFromDatastore(token *datastore.JoinToken) *registration.JoinToken {
+	return &registration.JoinToken{
+		Token:     token.Token,
+		SpiffeId:  token.SpiffeId,
+		Selectors: token.Selectors,
+		MaxUses:   token.MaxUses,
+		Uses:      token.Uses,
+		ExpiresAt: token.Expiry,
+	}
+}
+
 func cloneRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	return proto.Clone(entry).(*common.RegistrationEntry)
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"net"
 	"net/url"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/proto/api/registration"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/datastore"
@@ -64,8 +66,9 @@ type HandlerSuite struct {
 	peer   *peer.Peer
 	server *grpc.Server
 
-	ds      *fakedatastore.DataStore
-	handler registration.RegistrationClient
+	ds        *fakedatastore.DataStore
+	caManager *fakeCAManager
+	handler   registration.RegistrationClient
 }
 
 func (s *HandlerSuite) SetupTest() {
@@ -76,11 +79,14 @@ func (s *HandlerSuite) SetupTest() {
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStores(s.ds)
 
+	s.caManager = &fakeCAManager{}
+
 	handler := &Handler{
 		Log:         log,
 		Metrics:     telemetry.Blackhole{},
 		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
 		Catalog:     catalog,
+		CAManager:   s.caManager,
 	}
 
 	// we need to test a streaming API. without doing the same codegen we
@@ -753,12 +759,123 @@ func (s *HandlerSuite) TestCreateJoinToken() {
 	// Token specified
 	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo", Ttl: 1})
 	s.Require().NoError(err)
-	s.Require().Equal(resp, &registration.JoinToken{Token: "foo", Ttl: 1})
+	s.Require().NotZero(resp.ExpiresAt)
+	s.Require().Equal(resp, &registration.JoinToken{Token: "foo", Ttl: 1, ExpiresAt: resp.ExpiresAt})
 
 	// Already exists
 	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo", Ttl: 1})
 	s.requireErrorContains(err, "Failed to register token")
 	s.Require().Nil(resp)
+
+	// Bound to an agent ID, with multiple uses
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token:    "bar",
+		Ttl:      1,
+		SpiffeId: "spiffe://example.org/spire/agent/bar",
+		MaxUses:  2,
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+	s.requireErrorContains(err, "a token bound to an agent SPIFFE ID can only be used once")
+	s.Require().Nil(resp)
+
+	// Bound to an agent ID and selectors
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token:     "bar",
+		Ttl:       1,
+		SpiffeId:  "spiffe://example.org/spire/agent/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		MaxUses:   1,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/spire/agent/bar", resp.SpiffeId)
+	fetchResp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+		Token: "bar",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.JoinToken{
+		Token:     "bar",
+		Expiry:    resp.ExpiresAt,
+		SpiffeId:  "spiffe://example.org/spire/agent/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		MaxUses:   1,
+	}, fetchResp.JoinToken)
+
+	// Negative max uses
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Ttl: 1, MaxUses: -1})
+	s.requireErrorContains(err, "max uses cannot be negative")
+	s.Require().Nil(resp)
+
+	// Not an agent ID
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Ttl: 1, SpiffeId: "spiffe://example.org/foo"})
+	s.requireErrorContains(err, `"spiffe://example.org/foo" is not a valid agent SPIFFE ID`)
+	s.Require().Nil(resp)
+
+	// Invalid selector
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Ttl:       1,
+		Selectors: []*common.Selector{{Type: "A"}},
+	})
+	s.requireErrorContains(err, `invalid selector "A:"`)
+	s.Require().Nil(resp)
+}
+
+func (s *HandlerSuite) TestListJoinTokens() {
+	// No tokens
+	resp, err := s.handler.ListJoinTokens(context.Background(), &registration.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.JoinTokens)
+
+	s.createJoinToken(&datastore.JoinToken{Token: "foo", Expiry: 1000})
+	s.createJoinToken(&datastore.JoinToken{
+		Token:     "bar",
+		Expiry:    2000,
+		SpiffeId:  "spiffe://example.org/spire/agent/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		MaxUses:   3,
+		Uses:      1,
+	})
+
+	resp, err = s.handler.ListJoinTokens(context.Background(), &registration.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal([]*registration.JoinToken{
+		{
+			Token:     "bar",
+			ExpiresAt: 2000,
+			SpiffeId:  "spiffe://example.org/spire/agent/bar",
+			Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+			MaxUses:   3,
+			Uses:      1,
+		},
+		{
+			Token:     "foo",
+			ExpiresAt: 1000,
+		},
+	}, resp.JoinTokens)
+}
+
+func (s *HandlerSuite) TestRevokeJoinToken() {
+	s.createJoinToken(&datastore.JoinToken{Token: "foo", Expiry: 1000})
+
+	// No token
+	resp, err := s.handler.RevokeJoinToken(context.Background(), &registration.RevokeJoinTokenRequest{})
+	s.requireErrorContains(err, "token is required")
+	s.Require().Nil(resp)
+
+	// Unknown token
+	resp, err = s.handler.RevokeJoinToken(context.Background(), &registration.RevokeJoinTokenRequest{Token: "bar"})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+	s.Require().Nil(resp)
+
+	// Success
+	resp, err = s.handler.RevokeJoinToken(context.Background(), &registration.RevokeJoinTokenRequest{Token: "foo"})
+	s.Require().NoError(err)
+	s.Require().Equal(&registration.JoinToken{Token: "foo", ExpiresAt: 1000}, resp.JoinToken)
+
+	fetchResp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+		Token: "foo",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fetchResp.JoinToken)
 }
 
 func (s *HandlerSuite) TestFetchBundle() {
@@ -838,6 +955,74 @@ func (s *HandlerSuite) TestListWithNoAgents() {
 	s.Len(listResponse.Nodes, 0)
 }
 
+func (s *HandlerSuite) TestListCAKeypairSets() {
+	rootCA1, err := x509.ParseCertificate(rootCA1DER)
+	s.Require().NoError(err)
+	rootCA2, err := x509.ParseCertificate(rootCA2DER)
+	s.Require().NoError(err)
+	s.caManager.keypairSets = []ca.KeypairSetInfo{
+		{Slot: "A", Active: true, X509CA: rootCA1, JWTSigningKey: &common.PublicKey{Kid: "KIDA"}},
+		{Slot: "B", X509CA: rootCA2, JWTSigningKey: &common.PublicKey{Kid: "KIDB"}},
+	}
+
+	resp, err := s.handler.ListCAKeypairSets(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.KeypairSets, 2)
+	s.Require().True(proto.Equal(&registration.CAKeypairSet{
+		Slot:          "A",
+		Active:        true,
+		X509Ca:        rootCA1DER,
+		X509CaKeyId:   ca.X509CAKeyID(rootCA1),
+		JwtSigningKey: &common.PublicKey{Kid: "KIDA"},
+	}, resp.KeypairSets[0]))
+	s.Require().Equal("B", resp.KeypairSets[1].Slot)
+	s.Require().False(resp.KeypairSets[1].Active)
+}
+
+func (s *HandlerSuite) TestPrepareAndActivateCAKeypairSet() {
+	rootCA1, err := x509.ParseCertificate(rootCA1DER)
+	s.Require().NoError(err)
+	s.caManager.keypairSet = &ca.KeypairSetInfo{Slot: "B", X509CA: rootCA1, JWTSigningKey: &common.PublicKey{Kid: "KIDB"}}
+
+	resp, err := s.handler.PrepareCAKeypairSet(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Equal("B", resp.Slot)
+	s.Require().Equal(1, s.caManager.prepared)
+
+	s.caManager.keypairSet.Active = true
+	resp, err = s.handler.ActivateCAKeypairSet(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().True(resp.Active)
+	s.Require().Equal(1, s.caManager.activated)
+
+	s.caManager.err = errors.New("oh no")
+	_, err = s.handler.ActivateCAKeypairSet(context.Background(), &common.Empty{})
+	s.requireErrorContains(err, "error trying to activate CA keypair set: oh no")
+}
+
+func (s *HandlerSuite) TestRevokeCAKey() {
+	_, err := s.handler.RevokeCAKey(context.Background(), &registration.RevokeCAKeyRequest{})
+	s.requireErrorContains(err, "either the X.509 CA key ID or JWT key ID must be set")
+
+	_, err = s.handler.RevokeCAKey(context.Background(), &registration.RevokeCAKeyRequest{
+		X509CaKeyId: "X509",
+		JwtKeyId:    "KID",
+	})
+	s.requireErrorContains(err, "only one of the X.509 CA key ID or JWT key ID can be set")
+
+	_, err = s.handler.RevokeCAKey(context.Background(), &registration.RevokeCAKeyRequest{
+		X509CaKeyId: "X509",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"X509"}, s.caManager.revokedX509CAs)
+
+	_, err = s.handler.RevokeCAKey(context.Background(), &registration.RevokeCAKeyRequest{
+		JwtKeyId: "KID",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"KID"}, s.caManager.revokedJWTSigningKeys)
+}
+
 func (s *HandlerSuite) createAttestedNode(spiffeID string) *common.AttestedNode {
 	createResponse, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
 		Node: &common.AttestedNode{
@@ -951,6 +1136,13 @@ func (s *HandlerSuite) createBundle(bundle *datastore.Bundle) {
 	s.Require().NoError(err)
 }
 
+func (s *HandlerSuite) createJoinToken(token *datastore.JoinToken) {
+	_, err := s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+		JoinToken: token,
+	})
+	s.Require().NoError(err)
+}
+
 func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	resp, err := s.ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
 		Entry: entry,
@@ -993,3 +1185,45 @@ func requireNotGRPCStatusCode(t *testing.T, err error, code codes.Code) {
 	s := status.Convert(err)
 	require.NotEqual(t, code, s.Code(), "GRPC status code should not be %v", code)
 }
+
+type fakeCAManager struct {
+	ca.Manager
+
+	keypairSets           []ca.KeypairSetInfo
+	keypairSet            *ca.KeypairSetInfo
+	prepared              int
+	activated             int
+	revokedX509CAs        []string
+	revokedJWTSigningKeys []string
+	err                   error
+}
+
+func (m *fakeCAManager) KeypairSets(ctx context.Context) ([]ca.KeypairSetInfo, error) {
+	return m.keypairSets, m.err
+}
+
+func (m *fakeCAManager) PrepareKeypairSet(ctx context.Context) (*ca.KeypairSetInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.prepared++
+	return m.keypairSet, nil
+}
+
+func (m *fakeCAManager) ActivateKeypairSet(ctx context.Context) (*ca.KeypairSetInfo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.activated++
+	return m.keypairSet, nil
+}
+
+func (m *fakeCAManager) RevokeX509CA(ctx context.Context, keyID string) error {
+	m.revokedX509CAs = append(m.revokedX509CAs, keyID)
+	return m.err
+}
+
+func (m *fakeCAManager) RevokeJWTSigningKey(ctx context.Context, kid string) error {
+	m.revokedJWTSigningKeys = append(m.revokedJWTSigningKeys, kid)
+	return m.err
+}
@@ -13,7 +13,7 @@ import (
 
 const (
 	// version of the database in the code
-	codeVersion = 6
+	codeVersion = 9
 )
 
 func migrateDB(db *gorm.DB) (err error) {
@@ -75,7 +75,7 @@ func initDB(db *gorm.DB) (err error) {
 
 	if err := tx.AutoMigrate(&Bundle{}, &AttestedNode{},
 		&NodeSelector{}, &RegisteredEntry{}, &JoinToken{},
-		&Selector{}, &Migration{}).Error; err != nil {
+		&Selector{}, &CAJournal{}, &Migration{}).Error; err != nil {
 		tx.Rollback()
 		return sqlError.Wrap(err)
 	}
@@ -112,6 +112,12 @@ func migrateVersion(tx *gorm.DB, version int) (versionOut int, err error) {
 		err = migrateToV5(tx)
 	case 5:
 		err = migrateToV6(tx)
+	case 6:
+		err = migrateToV7(tx)
+	case 7:
+		err = migrateToV8(tx)
+	case 8:
+		err = migrateToV9(tx)
 	default:
 		err = sqlError.New("no migration support for version %d", version)
 	}
@@ -178,7 +184,7 @@ func migrateToV3(tx *gorm.DB) (err error) {
 		}
 	}
 
-	var attestedNodes []*AttestedNode
+	var attestedNodes []*V3AttestedNode
 	if err := tx.Find(&attestedNodes).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
@@ -286,6 +292,35 @@ func migrateToV6(tx *gorm.DB) error {
 	return nil
 }
 
+func migrateToV7(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&CAJournal{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV8(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&JoinToken{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	// existing tokens are single-use; the use count must not be NULL since
+	// uses are conditioned on it
+	if err := tx.Model(&JoinToken{}).Updates(map[string]interface{}{
+		"max_uses": 0,
+		"uses":     0,
+	}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV9(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 // V3Bundle holds a version 3 trust bundle
 type V3Bundle struct {
 	Model
@@ -316,6 +351,21 @@ func (V3CACert) TableName() string {
 	return "ca_certs"
 }
 
+// V3AttestedNode holds a version 3 attested node
+type V3AttestedNode struct {
+	Model
+
+	SpiffeID     string `gorm:"unique_index"`
+	DataType     string
+	SerialNumber string
+	ExpiresAt    time.Time
+}
+
+// TableName gets table name for v3 attested node
+func (V3AttestedNode) TableName() string {
+	return "attested_node_entries"
+}
+
 // V4RegisteredEntry holds a version 4 registered entry
 type V4RegisteredEntry struct {
 	Model
@@ -29,6 +29,8 @@ type AttestedNode struct {
 	DataType     string
 	SerialNumber string
 	ExpiresAt    time.Time
+	LastSeenIP   string
+	LastSeenAt   *time.Time
 }
 
 // TableName gets table name of AttestedNode
@@ -70,6 +72,13 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+
+	SpiffeID string
+	// Selectors holds the selectors assigned to the agents attesting with
+	// the token, as a marshaled common.Selectors message
+	Selectors []byte
+	MaxUses   int32
+	Uses      int32
 }
 
 // Selector holds a selector by registered entry ID
@@ -81,6 +90,15 @@ type Selector struct {
 	Value             string `gorm:"unique_index:idx_selector_entry"`
 }
 
+// CAJournal holds the CA journal for a trust domain
+type CAJournal struct {
+	Model
+
+	TrustDomain string `gorm:"not null;unique_index"`
+	Data        []byte
+	Version     int64
+}
+
 // Migration holds version information
 type Migration struct {
 	Model
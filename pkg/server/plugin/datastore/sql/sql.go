@@ -194,6 +194,20 @@ func (ds *sqlPlugin) UpdateAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// UpdateAttestedNodeLastSeen records the address and time the given node
+// was last seen.
+func (ds *sqlPlugin) UpdateAttestedNodeLastSeen(ctx context.Context,
+	req *datastore.UpdateAttestedNodeLastSeenRequest) (resp *datastore.UpdateAttestedNodeLastSeenResponse, err error) {
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = updateAttestedNodeLastSeen(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteAttestedNode deletes the given attested node
 func (ds *sqlPlugin) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (resp *datastore.DeleteAttestedNodeResponse, err error) {
@@ -222,6 +236,23 @@ func (ds *sqlPlugin) SetNodeSelectors(ctx context.Context, req *datastore.SetNod
 	return resp, nil
 }
 
+// BindNodeSelectors binds a SPIFFE ID to an attested node by recording the
+// attested node in a node selector of the SPIFFE ID. It fails if the SPIFFE
+// ID is bound to another attested node that still exists.
+func (ds *sqlPlugin) BindNodeSelectors(ctx context.Context, req *datastore.BindNodeSelectorsRequest) (resp *datastore.BindNodeSelectorsResponse, err error) {
+	if req.SpiffeId == "" || req.SelectorType == "" || req.AttestedNodeSpiffeId == "" {
+		return nil, errors.New("invalid request: missing SPIFFE ID, selector type or attested node SPIFFE ID")
+	}
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = bindNodeSelectors(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNodeSelectors gets node (agent) selectors by SPIFFE ID
 func (ds *sqlPlugin) GetNodeSelectors(ctx context.Context,
 	req *datastore.GetNodeSelectorsRequest) (resp *datastore.GetNodeSelectorsResponse, err error) {
@@ -334,6 +365,17 @@ func (ds *sqlPlugin) FetchJoinToken(ctx context.Context, req *datastore.FetchJoi
 	return resp, nil
 }
 
+// ListJoinTokens lists all join tokens
+func (ds *sqlPlugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteJoinToken deletes the given join token
 func (ds *sqlPlugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (resp *datastore.DeleteJoinTokenResponse, err error) {
 	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -345,6 +387,18 @@ func (ds *sqlPlugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJ
 	return resp, nil
 }
 
+// UseJoinToken records a use of the given join token, deleting it once it
+// has been used the maximum number of times
+func (ds *sqlPlugin) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (resp *datastore.UseJoinTokenResponse, err error) {
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = useJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneJoinTokens takes a Token message, and deletes all tokens which have expired
 // before the date in the message
 func (ds *sqlPlugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (resp *datastore.PruneJoinTokensResponse, err error) {
@@ -357,6 +411,33 @@ func (ds *sqlPlugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJo
 	return resp, nil
 }
 
+// FetchCAJournal fetches the CA journal for the given trust domain
+func (ds *sqlPlugin) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (resp *datastore.FetchCAJournalResponse, err error) {
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetCAJournal sets the CA journal for the given trust domain. The request
+// fails if the journal version does not match the stored version.
+func (ds *sqlPlugin) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (resp *datastore.SetCAJournalResponse, err error) {
+	if req.Journal == nil || req.Journal.TrustDomainId == "" {
+		return nil, errors.New("journal with trust domain is required")
+	}
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = setCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *sqlPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -651,6 +732,11 @@ func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (
 		DataType:     req.Node.AttestationDataType,
 		SerialNumber: req.Node.CertSerialNumber,
 		ExpiresAt:    time.Unix(req.Node.CertNotAfter, 0),
+		LastSeenIP:   req.Node.LastSeenIp,
+	}
+	if req.Node.LastSeenAt != 0 {
+		lastSeenAt := time.Unix(req.Node.LastSeenAt, 0)
+		model.LastSeenAt = &lastSeenAt
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
@@ -732,6 +818,27 @@ func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (
 	}, nil
 }
 
+func updateAttestedNodeLastSeen(tx *gorm.DB, req *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+	var model AttestedNode
+	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	lastSeenAt := time.Unix(req.LastSeenAt, 0)
+	updates := AttestedNode{
+		LastSeenIP: req.LastSeenIp,
+		LastSeenAt: &lastSeenAt,
+	}
+
+	if err := tx.Model(&model).Updates(updates).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.UpdateAttestedNodeLastSeenResponse{
+		Node: modelToAttestedNode(model),
+	}, nil
+}
+
 func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	var model AttestedNode
 	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
@@ -766,6 +873,58 @@ func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*dat
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
 
+func bindNodeSelectors(tx *gorm.DB, req *datastore.BindNodeSelectorsRequest) (*datastore.BindNodeSelectorsResponse, error) {
+	// concurrent binds of the same SPIFFE ID must not both succeed. sqlite3
+	// only has one writer at a time. Other databases must not let concurrent
+	// transactions both find the SPIFFE ID unbound.
+	query := tx
+	switch tx.Dialect().GetName() {
+	case "postgres":
+		if err := tx.Exec("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE").Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	case "mysql":
+		query = tx.Set("gorm:query_option", "FOR UPDATE")
+	}
+
+	var models []NodeSelector
+	if err := query.Find(&models, "spiffe_id = ? AND type = ?", req.SpiffeId, req.SelectorType).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	bound := false
+	for _, model := range models {
+		if model.Value == req.AttestedNodeSpiffeId {
+			bound = true
+			continue
+		}
+		var count int
+		if err := tx.Model(&AttestedNode{}).Where("spiffe_id = ?", model.Value).Count(&count).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if count > 0 {
+			return nil, sqlError.New("%q is bound to attested node %q", req.SpiffeId, model.Value)
+		}
+		// the attested node no longer exists
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	if !bound {
+		model := &NodeSelector{
+			SpiffeID: req.SpiffeId,
+			Type:     req.SelectorType,
+			Value:    req.AttestedNodeSpiffeId,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	return &datastore.BindNodeSelectorsResponse{}, nil
+}
+
 func getNodeSelectors(tx *gorm.DB, req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
 	var models []NodeSelector
 	if err := tx.Where("spiffe_id = ?", req.SpiffeId).Find(&models).Error; err != nil {
@@ -1094,8 +1253,20 @@ func deleteRegistrationEntry(tx *gorm.DB,
 
 func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	t := JoinToken{
-		Token:  req.JoinToken.Token,
-		Expiry: req.JoinToken.Expiry,
+		Token:    req.JoinToken.Token,
+		Expiry:   req.JoinToken.Expiry,
+		SpiffeID: req.JoinToken.SpiffeId,
+		MaxUses:  req.JoinToken.MaxUses,
+	}
+
+	if len(req.JoinToken.Selectors) > 0 {
+		selectors, err := proto.Marshal(&common.Selectors{
+			Entries: req.JoinToken.Selectors,
+		})
+		if err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		t.Selectors = selectors
 	}
 
 	if err := tx.Create(&t).Error; err != nil {
@@ -1116,11 +1287,33 @@ func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datasto
 		return nil, sqlError.Wrap(err)
 	}
 
+	token, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.FetchJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
+		JoinToken: token,
 	}, nil
 }
 
+func listJoinTokens(tx *gorm.DB, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	var models []JoinToken
+	if err := tx.Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListJoinTokensResponse)
+	for _, model := range models {
+		token, err := modelToJoinToken(model)
+		if err != nil {
+			return nil, err
+		}
+		resp.JoinTokens = append(resp.JoinTokens, token)
+	}
+	return resp, nil
+}
+
 func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
 	var model JoinToken
 	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
@@ -1131,8 +1324,49 @@ func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datas
 		return nil, sqlError.Wrap(err)
 	}
 
+	token, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.DeleteJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
+		JoinToken: token,
+	}, nil
+}
+
+func useJoinToken(tx *gorm.DB, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	var model JoinToken
+	err := tx.Find(&model, "token = ?", req.Token).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.UseJoinTokenResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// the update is conditioned on the number of uses so concurrent uses
+	// of the last remaining use cannot both succeed.
+	query := tx.Where("id = ? AND uses = ?", model.ID, model.Uses)
+	var result *gorm.DB
+	if model.Uses+1 >= model.MaxUses {
+		result = query.Delete(&JoinToken{})
+	} else {
+		result = query.Model(&JoinToken{}).Update("uses", model.Uses+1)
+	}
+	if result.Error != nil {
+		return nil, sqlError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, sqlError.New("join token was used concurrently")
+	}
+	model.Uses++
+
+	token, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: token,
 	}, nil
 }
 
@@ -1144,6 +1378,82 @@ func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datas
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func fetchCAJournal(tx *gorm.DB, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var model CAJournal
+	err = tx.Find(&model, "trust_domain = ?", trustDomainID).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.FetchCAJournalResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		Journal: modelToCAJournal(model),
+	}, nil
+}
+
+func setCAJournal(tx *gorm.DB, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	trustDomainID, err := idutil.NormalizeSpiffeID(req.Journal.TrustDomainId, idutil.AllowAnyTrustDomain())
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	var model CAJournal
+	result := tx.Find(&model, "trust_domain = ?", trustDomainID)
+	switch {
+	case result.RecordNotFound():
+		model.TrustDomain = trustDomainID
+	case result.Error != nil:
+		return nil, sqlError.Wrap(result.Error)
+	}
+
+	if model.Version != req.Journal.Version {
+		return nil, sqlError.New("CA journal version mismatch (current=%d, requested=%d)", model.Version, req.Journal.Version)
+	}
+
+	// the update is conditioned on the version so concurrent writers
+	// operating on the same version cannot both succeed.
+	if model.ID == 0 {
+		model.Data = req.Journal.Data
+		model.Version = req.Journal.Version + 1
+		if err := tx.Create(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	} else {
+		result := tx.Model(&CAJournal{}).
+			Where("id = ? AND version = ?", model.ID, req.Journal.Version).
+			Updates(map[string]interface{}{
+				"data":    req.Journal.Data,
+				"version": req.Journal.Version + 1,
+			})
+		if result.Error != nil {
+			return nil, sqlError.Wrap(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil, sqlError.New("CA journal version mismatch (requested=%d)", req.Journal.Version)
+		}
+		model.Data = req.Journal.Data
+		model.Version = req.Journal.Version + 1
+	}
+
+	return &datastore.SetCAJournalResponse{
+		Journal: modelToCAJournal(model),
+	}, nil
+}
+
+func modelToCAJournal(model CAJournal) *datastore.CAJournal {
+	return &datastore.CAJournal{
+		TrustDomainId: model.TrustDomain,
+		Data:          model.Data,
+		Version:       model.Version,
+	}
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*datastore.Bundle, error) {
@@ -1262,19 +1572,35 @@ func newRegistrationEntryID() (string, error) {
 }
 
 func modelToAttestedNode(model AttestedNode) *datastore.AttestedNode {
-	return &datastore.AttestedNode{
+	node := &datastore.AttestedNode{
 		SpiffeId:            model.SpiffeID,
 		AttestationDataType: model.DataType,
 		CertSerialNumber:    model.SerialNumber,
 		CertNotAfter:        model.ExpiresAt.Unix(),
+		LastSeenIp:          model.LastSeenIP,
+	}
+	if model.LastSeenAt != nil {
+		node.LastSeenAt = model.LastSeenAt.Unix()
 	}
+	return node
 }
 
-func modelToJoinToken(model JoinToken) *datastore.JoinToken {
-	return &datastore.JoinToken{
-		Token:  model.Token,
-		Expiry: model.Expiry,
+func modelToJoinToken(model JoinToken) (*datastore.JoinToken, error) {
+	token := &datastore.JoinToken{
+		Token:    model.Token,
+		Expiry:   model.Expiry,
+		SpiffeId: model.SpiffeID,
+		MaxUses:  model.MaxUses,
+		Uses:     model.Uses,
+	}
+	if len(model.Selectors) > 0 {
+		selectors := new(common.Selectors)
+		if err := proto.Unmarshal(model.Selectors, selectors); err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		token.Selectors = selectors.Entries
 	}
+	return token, nil
 }
 
 func makeFederatesWith(tx *gorm.DB, ids []string) ([]*Bundle, error) {
@@ -467,6 +467,54 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.Equal(uexpires, fnode.CertNotAfter)
 }
 
+func (s *PluginSuite) TestUpdateAttestedNodeLastSeen() {
+	node := &datastore.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+
+	// not seen yet
+	fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.Require().Equal(node, fresp.Node)
+
+	lastSeenAt := time.Now().Unix()
+	uresp, err := s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   node.SpiffeId,
+		LastSeenIp: "10.1.2.3",
+		LastSeenAt: lastSeenAt,
+	})
+	s.Require().NoError(err)
+
+	// the cert serial and expiration are left untouched
+	expected := &datastore.AttestedNode{
+		SpiffeId:            node.SpiffeId,
+		AttestationDataType: node.AttestationDataType,
+		CertSerialNumber:    node.CertSerialNumber,
+		CertNotAfter:        node.CertNotAfter,
+		LastSeenIp:          "10.1.2.3",
+		LastSeenAt:          lastSeenAt,
+	}
+	s.Require().Equal(expected, uresp.Node)
+
+	fresp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+	s.Require().NoError(err)
+	s.Require().Equal(expected, fresp.Node)
+
+	// unknown node
+	_, err = s.ds.UpdateAttestedNodeLastSeen(ctx, &datastore.UpdateAttestedNodeLastSeenRequest{
+		SpiffeId:   "missing",
+		LastSeenIp: "10.1.2.3",
+		LastSeenAt: lastSeenAt,
+	})
+	s.Require().Error(err)
+}
+
 func (s *PluginSuite) TestDeleteAttestedNode() {
 	entry := &datastore.AttestedNode{
 		SpiffeId:            "foo",
@@ -525,6 +573,51 @@ func (s *PluginSuite) TestNodeSelectors() {
 	s.Require().Equal(bar, selectors)
 }
 
+func (s *PluginSuite) TestBindNodeSelectors() {
+	bind := func(agentID string) error {
+		_, err := s.ds.BindNodeSelectors(ctx, &datastore.BindNodeSelectorsRequest{
+			SpiffeId:             "foo",
+			SelectorType:         "BOUND",
+			AttestedNodeSpiffeId: agentID,
+		})
+		return err
+	}
+	other := []*common.Selector{
+		{Type: "OTHER", Value: "1"},
+	}
+
+	// bind foo to agent1, keeping selectors of other types
+	s.setNodeSelectors("foo", other)
+	s.Require().NoError(bind("agent1"))
+	s.Require().ElementsMatch([]*common.Selector{
+		{Type: "OTHER", Value: "1"},
+		{Type: "BOUND", Value: "agent1"},
+	}, s.getNodeSelectors("foo"))
+
+	// binding again to the same agent is a no-op
+	s.Require().NoError(bind("agent1"))
+	s.Require().Len(s.getNodeSelectors("foo"), 2)
+
+	// once agent1 has attested, foo cannot be bound to another agent
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &datastore.AttestedNode{
+			SpiffeId:     "agent1",
+			CertNotAfter: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().EqualError(bind("agent2"), `datastore-sql: "foo" is bound to attested node "agent1"`)
+
+	// once agent1 is gone, foo can be bound to another agent
+	_, err = s.ds.DeleteAttestedNode(ctx, &datastore.DeleteAttestedNodeRequest{SpiffeId: "agent1"})
+	s.Require().NoError(err)
+	s.Require().NoError(bind("agent2"))
+	s.Require().ElementsMatch([]*common.Selector{
+		{Type: "OTHER", Value: "1"},
+		{Type: "BOUND", Value: "agent2"},
+	}, s.getNodeSelectors("foo"))
+}
+
 func (s *PluginSuite) TestCreateRegistrationEntry() {
 	var validRegistrationEntries []*common.RegistrationEntry
 	s.getTestDataFromJSONFile(filepath.Join("testdata", "valid_registration_entries.json"), &validRegistrationEntries)
@@ -1174,6 +1267,74 @@ func (s *PluginSuite) TestDeleteJoinToken() {
 	s.Equal(joinToken2, resp.JoinToken)
 }
 
+func (s *PluginSuite) TestListJoinTokens() {
+	resp, err := s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Empty(resp.JoinTokens)
+
+	joinToken1 := &datastore.JoinToken{
+		Token:  "foobar",
+		Expiry: 1000,
+	}
+	joinToken2 := &datastore.JoinToken{
+		Token:    "batbaz",
+		Expiry:   2000,
+		SpiffeId: "spiffe://example.org/spire/agent/batbaz",
+		Selectors: []*common.Selector{
+			{Type: "a", Value: "1"},
+			{Type: "b", Value: "2"},
+		},
+		MaxUses: 3,
+	}
+	for _, joinToken := range []*datastore.JoinToken{joinToken1, joinToken2} {
+		_, err = s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: joinToken,
+		})
+		s.Require().NoError(err)
+	}
+
+	resp, err = s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.JoinTokens, 2)
+	s.True(proto.Equal(joinToken1, resp.JoinTokens[0]))
+	s.True(proto.Equal(joinToken2, resp.JoinTokens[1]))
+}
+
+func (s *PluginSuite) TestUseJoinToken() {
+	joinToken := &datastore.JoinToken{
+		Token:   "foobar",
+		Expiry:  1000,
+		MaxUses: 2,
+	}
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: joinToken,
+	})
+	s.Require().NoError(err)
+
+	// first use increments the use count
+	resp, err := s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.Equal(int32(1), resp.JoinToken.Uses)
+	s.Equal(int32(1), s.fetchJoinToken(joinToken.Token).Uses)
+
+	// last use deletes the token
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.Equal(int32(2), resp.JoinToken.Uses)
+	s.Nil(s.fetchJoinToken(joinToken.Token))
+
+	// unknown tokens cannot be used
+	resp, err = s.ds.UseJoinToken(ctx, &datastore.UseJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.Nil(resp.JoinToken)
+}
+
 func (s *PluginSuite) TestPruneJoinTokens() {
 	now := time.Now().Unix()
 	joinToken := &datastore.JoinToken{
@@ -1209,6 +1370,72 @@ func (s *PluginSuite) TestPruneJoinTokens() {
 	s.Nil(resp.JoinToken)
 }
 
+func (s *PluginSuite) TestCAJournal() {
+	// no journal yet
+	fresp, err := s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Journal)
+
+	// create the journal
+	sresp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{
+			TrustDomainId: "spiffe://example.org",
+			Data:          []byte("one"),
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.CAJournal{
+		TrustDomainId: "spiffe://example.org",
+		Data:          []byte("one"),
+		Version:       1,
+	}, sresp.Journal)
+
+	// creating it again fails since the version is stale
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{
+			TrustDomainId: "spiffe://example.org",
+			Data:          []byte("two"),
+		},
+	})
+	s.Require().EqualError(err, "datastore-sql: CA journal version mismatch (current=1, requested=0)")
+
+	// update with the right version
+	sresp, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{
+			TrustDomainId: "spiffe://example.org",
+			Data:          []byte("two"),
+			Version:       1,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(2), sresp.Journal.Version)
+
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.CAJournal{
+		TrustDomainId: "spiffe://example.org",
+		Data:          []byte("two"),
+		Version:       2,
+	}, fresp.Journal)
+
+	// journals are kept per trust domain
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Journal)
+
+	// trust domain is required
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{},
+	})
+	s.Require().EqualError(err, "journal with trust domain is required")
+}
+
 func (s *PluginSuite) TestGetPluginInfo() {
 	resp, err := s.ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.Require().NoError(err)
@@ -1324,6 +1551,41 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().NoError(err)
 			s.Require().Len(resp.Entries, 1)
 			s.Require().True(resp.Entries[0].Downstream)
+		case 6:
+			// the CA journal table should have been created
+			_, err := s.ds.SetCAJournal(context.Background(), &datastore.SetCAJournalRequest{
+				Journal: &datastore.CAJournal{
+					TrustDomainId: "spiffe://example.org",
+					Data:          []byte("DATA"),
+				},
+			})
+			s.Require().NoError(err)
+		case 7:
+			// existing join tokens can be used once
+			resp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: "TOKEN",
+			})
+			s.Require().NoError(err)
+			s.Require().Equal(&datastore.JoinToken{Token: "TOKEN", Expiry: 1545255999}, resp.JoinToken)
+
+			useResp, err := s.ds.UseJoinToken(context.Background(), &datastore.UseJoinTokenRequest{
+				Token: "TOKEN",
+			})
+			s.Require().NoError(err)
+			s.Require().Equal(int32(1), useResp.JoinToken.Uses)
+			s.Require().Nil(s.fetchJoinToken("TOKEN"))
+		case 8:
+			// attested nodes should gain the last seen columns
+			nodeID := "spiffe://example.org/spire/agent/x509pop/e81aef2e9178db3db836a1a85d362ca5b2241631"
+			resp, err := s.ds.UpdateAttestedNodeLastSeen(context.Background(), &datastore.UpdateAttestedNodeLastSeenRequest{
+				SpiffeId:   nodeID,
+				LastSeenIp: "10.1.2.3",
+				LastSeenAt: 1547486000,
+			})
+			s.Require().NoError(err)
+			s.Require().Equal("x509pop", resp.Node.AttestationDataType)
+			s.Require().Equal("10.1.2.3", resp.Node.LastSeenIp)
+			s.Require().Equal(int64(1547486000), resp.Node.LastSeenAt)
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -1392,6 +1654,15 @@ func (s *PluginSuite) fetchRegistrationEntry(entryID string) *common.Registratio
 	return resp.Entry
 }
 
+func (s *PluginSuite) fetchJoinToken(token string) *datastore.JoinToken {
+	resp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: token,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp.JoinToken
+}
+
 func makeFederatedRegistrationEntry() *common.RegistrationEntry {
 	return &common.RegistrationEntry{
 		Selectors: []*common.Selector{
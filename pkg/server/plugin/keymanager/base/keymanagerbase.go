@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/keymanager"
 )
 
@@ -53,7 +54,7 @@ func (m *Base) GenerateKey(ctx context.Context, req *keymanager.GenerateKeyReque
 	if req.KeyId == "" {
 		return nil, m.newError("key id is required")
 	}
-	if req.KeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+	if req.KeyType == common.KeyType_UNSPECIFIED_KEY_TYPE {
 		return nil, m.newError("key type is required")
 	}
 
@@ -173,19 +174,19 @@ func (m *Base) getPrivateKey(id string) crypto.PrivateKey {
 	return nil
 }
 
-func (m *Base) generateKeyEntry(keyId string, keyType keymanager.KeyType) (e *KeyEntry, err error) {
+func (m *Base) generateKeyEntry(keyId string, keyType common.KeyType) (e *KeyEntry, err error) {
 	var privateKey crypto.PrivateKey
 	var publicKey crypto.PublicKey
 	switch keyType {
-	case keymanager.KeyType_EC_P256:
+	case common.KeyType_EC_P256:
 		privateKey, publicKey, err = generateECKey(elliptic.P256())
-	case keymanager.KeyType_EC_P384:
+	case common.KeyType_EC_P384:
 		privateKey, publicKey, err = generateECKey(elliptic.P384())
-	case keymanager.KeyType_RSA_1024:
+	case common.KeyType_RSA_1024:
 		privateKey, publicKey, err = generateRSAKey(1024)
-	case keymanager.KeyType_RSA_2048:
+	case common.KeyType_RSA_2048:
 		privateKey, publicKey, err = generateRSAKey(2048)
-	case keymanager.KeyType_RSA_4096:
+	case common.KeyType_RSA_4096:
 		privateKey, publicKey, err = generateRSAKey(4096)
 	default:
 		return nil, m.newError("unknown key type %q", keyType)
@@ -206,7 +207,7 @@ func (m *Base) newError(format string, args ...interface{}) error {
 	return m.impl.ErrorFn(format, args...)
 }
 
-func makeKeyEntry(keyId string, keyType keymanager.KeyType, privateKey crypto.PrivateKey, publicKey crypto.PublicKey) (*KeyEntry, error) {
+func makeKeyEntry(keyId string, keyType common.KeyType, privateKey crypto.PrivateKey, publicKey crypto.PublicKey) (*KeyEntry, error) {
 	pkixData, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
 		return nil, err
@@ -241,28 +242,28 @@ func MakeKeyEntryFromKey(id string, privateKey crypto.PrivateKey) (*KeyEntry, er
 	}
 }
 
-func rsaKeyType(privateKey *rsa.PrivateKey) (keymanager.KeyType, error) {
+func rsaKeyType(privateKey *rsa.PrivateKey) (common.KeyType, error) {
 	bits := privateKey.N.BitLen()
 	switch bits {
 	case 1024:
-		return keymanager.KeyType_RSA_1024, nil
+		return common.KeyType_RSA_1024, nil
 	case 2048:
-		return keymanager.KeyType_RSA_2048, nil
+		return common.KeyType_RSA_2048, nil
 	case 4096:
-		return keymanager.KeyType_RSA_4096, nil
+		return common.KeyType_RSA_4096, nil
 	default:
-		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no RSA key type for key bit length: %d", bits)
+		return common.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no RSA key type for key bit length: %d", bits)
 	}
 }
 
-func ecdsaKeyType(privateKey *ecdsa.PrivateKey) (keymanager.KeyType, error) {
+func ecdsaKeyType(privateKey *ecdsa.PrivateKey) (common.KeyType, error) {
 	switch {
 	case privateKey.Curve == elliptic.P256():
-		return keymanager.KeyType_EC_P256, nil
+		return common.KeyType_EC_P256, nil
 	case privateKey.Curve == elliptic.P384():
-		return keymanager.KeyType_EC_P384, nil
+		return common.KeyType_EC_P384, nil
 	default:
-		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no EC key type for EC curve: %s",
+		return common.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no EC key type for EC curve: %s",
 			privateKey.Curve.Params().Name)
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/base"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/test"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/keymanager"
 	"github.com/stretchr/testify/require"
@@ -88,7 +89,7 @@ func (s *Suite) TestGenerateKeyBeforeConfigure() {
 	m := New()
 	resp, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().EqualError(err, "keymanager(disk): not configured")
 	s.Require().Nil(resp)
@@ -98,7 +99,7 @@ func (s *Suite) TestGenerateKeyPersistenceFailure() {
 	s.Require().NoError(os.Remove(s.keysDir()))
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().Error(err)
 	s.Require().Contains(err.Error(), "keymanager(disk): unable to write entries")
@@ -117,7 +118,7 @@ func (s *Suite) TestGenerateKeyPersistenceFailure() {
 	// generate and persist the key
 	resp, err = s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().NoError(err)
 
@@ -126,7 +127,7 @@ func (s *Suite) TestGenerateKeyPersistenceFailure() {
 	s.Require().NoError(os.RemoveAll(s.keysDir()))
 	_, err = s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().Error(err)
 
@@ -140,13 +141,13 @@ func (s *Suite) TestGenerateKeyPersistenceFailure() {
 func (s *Suite) TestGenerateKeyPersistence() {
 	resp1, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY1",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().NoError(err)
 
 	resp2, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY2",
-		KeyType: keymanager.KeyType_EC_P384,
+		KeyType: common.KeyType_EC_P384,
 	})
 	s.Require().NoError(err)
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/keymanager"
 	"github.com/stretchr/testify/suite"
 )
@@ -41,7 +42,7 @@ func (s *baseSuite) SetupTest() {
 func (s *baseSuite) TestGenerateKeyMissingKeyId() {
 	// missing key id
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().Error(err)
 	s.Require().Nil(resp)
@@ -59,13 +60,13 @@ func (s *baseSuite) TestGenerateKeyMissingKeyType() {
 func (s *baseSuite) TestGenerateKeyECP256() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P256,
+		KeyType: common.KeyType_EC_P256,
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 	s.Require().NotNil(resp.PublicKey)
 	s.Require().Equal(resp.PublicKey.Id, "KEY")
-	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_EC_P256)
+	s.Require().Equal(resp.PublicKey.Type, common.KeyType_EC_P256)
 	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
 	s.Require().NoError(err)
 	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
@@ -76,13 +77,13 @@ func (s *baseSuite) TestGenerateKeyECP256() {
 func (s *baseSuite) TestGenerateKeyECP384() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P384,
+		KeyType: common.KeyType_EC_P384,
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 	s.Require().NotNil(resp.PublicKey)
 	s.Require().Equal(resp.PublicKey.Id, "KEY")
-	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_EC_P384)
+	s.Require().Equal(resp.PublicKey.Type, common.KeyType_EC_P384)
 	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
 	s.Require().NoError(err)
 	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
@@ -93,13 +94,13 @@ func (s *baseSuite) TestGenerateKeyECP384() {
 func (s *baseSuite) TestGenerateKeyRSA1024() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_RSA_1024,
+		KeyType: common.KeyType_RSA_1024,
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 	s.Require().NotNil(resp.PublicKey)
 	s.Require().Equal(resp.PublicKey.Id, "KEY")
-	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_RSA_1024)
+	s.Require().Equal(resp.PublicKey.Type, common.KeyType_RSA_1024)
 	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
 	s.Require().NoError(err)
 	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
@@ -110,13 +111,13 @@ func (s *baseSuite) TestGenerateKeyRSA1024() {
 func (s *baseSuite) TestGenerateKeyRSA2048() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_RSA_2048,
+		KeyType: common.KeyType_RSA_2048,
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 	s.Require().NotNil(resp.PublicKey)
 	s.Require().Equal(resp.PublicKey.Id, "KEY")
-	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_RSA_2048)
+	s.Require().Equal(resp.PublicKey.Type, common.KeyType_RSA_2048)
 	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
 	s.Require().NoError(err)
 	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
@@ -127,13 +128,13 @@ func (s *baseSuite) TestGenerateKeyRSA2048() {
 func (s *baseSuite) TestGenerateKeyRSA4096() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_RSA_4096,
+		KeyType: common.KeyType_RSA_4096,
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(resp)
 	s.Require().NotNil(resp.PublicKey)
 	s.Require().Equal(resp.PublicKey.Id, "KEY")
-	s.Require().Equal(resp.PublicKey.Type, keymanager.KeyType_RSA_4096)
+	s.Require().Equal(resp.PublicKey.Type, common.KeyType_RSA_4096)
 	publicKey, err := x509.ParsePKIXPublicKey(resp.PublicKey.PkixData)
 	s.Require().NoError(err)
 	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
@@ -159,7 +160,7 @@ func (s *baseSuite) TestGetPublicKeyNoKey() {
 func (s *baseSuite) TestGetPublicKey() {
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
-		KeyType: keymanager.KeyType_EC_P384,
+		KeyType: common.KeyType_EC_P384,
 	})
 	s.Require().NoError(err)
 
@@ -182,13 +183,13 @@ func (s *baseSuite) TestGetPublicKeysNoKeys() {
 func (s *baseSuite) TestGetPublicKeys() {
 	z, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "Z",
-		KeyType: keymanager.KeyType_EC_P384,
+		KeyType: common.KeyType_EC_P384,
 	})
 	s.Require().NoError(err)
 
 	a, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "A",
-		KeyType: keymanager.KeyType_EC_P384,
+		KeyType: common.KeyType_EC_P384,
 	})
 	s.Require().NoError(err)
 
@@ -199,18 +200,18 @@ func (s *baseSuite) TestGetPublicKeys() {
 }
 
 func (s *baseSuite) TestSignDataECDSA() {
-	s.testSignData(keymanager.KeyType_EC_P256, x509.ECDSAWithSHA256)
+	s.testSignData(common.KeyType_EC_P256, x509.ECDSAWithSHA256)
 }
 
 func (s *baseSuite) TestSignDataRSAPKCS1v15() {
-	s.testSignData(keymanager.KeyType_RSA_1024, x509.SHA256WithRSA)
+	s.testSignData(common.KeyType_RSA_1024, x509.SHA256WithRSA)
 }
 
 func (s *baseSuite) TestSignDataRSAPSS() {
-	s.testSignData(keymanager.KeyType_RSA_1024, x509.SHA256WithRSAPSS)
+	s.testSignData(common.KeyType_RSA_1024, x509.SHA256WithRSAPSS)
 }
 
-func (s *baseSuite) testSignData(keyType keymanager.KeyType, signatureAlgorithm x509.SignatureAlgorithm) {
+func (s *baseSuite) testSignData(keyType common.KeyType, signatureAlgorithm x509.SignatureAlgorithm) {
 	// create a new key
 	generateResp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
@@ -9,14 +9,15 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"sync"
+	"text/template"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/proto/server/nodeattestor"
@@ -54,20 +55,42 @@ C1haGgSI/A1uZUKs/Zfnph0oEI0/hu1IIJ/SKBDtN5lvmZ/IzbOPIJWirlsllQIQ
 7zvWbGd9c9+Rm3p04oTvhup99la7kZqevJK0QRdD/6NpCKsqP/0=
 -----END CERTIFICATE-----`
 
-type IIDAttestorConfig struct {
-	AccessKeyID     string `hcl:"access_key_id"`
-	SecretAccessKey string `hcl:"secret_access_key"`
-	SkipBlockDevice bool   `hcl:"skip_block_device"`
+type ec2Client interface {
+	DescribeInstancesWithContext(aws.Context, *ec2.DescribeInstancesInput, ...request.Option) (*ec2.DescribeInstancesOutput, error)
 }
 
-type IIDAttestorPlugin struct {
-	trustDomain string
+type IIDAttestorConfig struct {
+	AccessKeyID       string            `hcl:"access_key_id"`
+	SecretAccessKey   string            `hcl:"secret_access_key"`
+	SkipBlockDevice   bool              `hcl:"skip_block_device"`
+	AgentPathTemplate string            `hcl:"agent_path_template"`
+	AccountAllowlist  []string          `hcl:"account_allowlist"`
+	AssumeRoleARNs    map[string]string `hcl:"assume_role_arns"`
+}
 
+type iidAttestorConfig struct {
+	trustDomain        string
 	awsCaCertPublicKey *rsa.PublicKey
 	accessKeyId        string
 	secretAccessKey    string
 	skipBlockDevice    bool
-	mtx                *sync.Mutex
+	pathTemplate       *template.Template
+	accountAllowlist   map[string]bool
+	assumeRoleARNs     map[string]string
+}
+
+type IIDAttestorPlugin struct {
+	config *iidAttestorConfig
+	mtx    *sync.Mutex
+
+	// clients are keyed by account and region, since the role assumed (if
+	// any) depends on the account. Clients are reused across attestations
+	// so that assumed role credentials are cached until they expire.
+	clients map[string]ec2Client
+
+	hooks struct {
+		newClient func(accessKeyID, secretAccessKey, region, assumeRoleARN string) (ec2Client, error)
+	}
 }
 
 func (p *IIDAttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
@@ -92,6 +115,11 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) erro
 		return caws.AttestationStepError("validating the IID", fmt.Errorf("the IID has been used and is no longer valid"))
 	}
 
+	c, err := p.getConfig()
+	if err != nil {
+		return err
+	}
+
 	docHash := sha256.Sum256([]byte(attestationData.Document))
 
 	sigBytes, err := base64.StdEncoding.DecodeString(attestationData.Signature)
@@ -99,34 +127,33 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) erro
 		return caws.AttestationStepError("base64 decoding the IID signature", err)
 	}
 
-	p.mtx.Lock()
-	defer p.mtx.Unlock()
-
-	err = rsa.VerifyPKCS1v15(p.awsCaCertPublicKey, crypto.SHA256, docHash[:], sigBytes)
+	err = rsa.VerifyPKCS1v15(c.awsCaCertPublicKey, crypto.SHA256, docHash[:], sigBytes)
 	if err != nil {
 		return caws.AttestationStepError("verifying the cryptographic signature", err)
 	}
 
-	var awsSession *session.Session
-
-	if p.secretAccessKey != "" && p.accessKeyId != "" {
-		creds := credentials.NewStaticCredentials(p.accessKeyId, p.secretAccessKey, "")
-		awsSession = session.Must(session.NewSession(&aws.Config{Credentials: creds, Region: &doc.Region}))
-	} else {
-		awsSession = session.Must(session.NewSession(&aws.Config{Region: &doc.Region}))
+	if len(c.accountAllowlist) > 0 && !c.accountAllowlist[doc.AccountId] {
+		return caws.AttestationStepError("validating the account ID", fmt.Errorf("account ID %q is not in the allowlist", doc.AccountId))
 	}
 
-	ec2Client := ec2.New(awsSession)
+	client, err := p.getClient(c, doc.AccountId, doc.Region)
+	if err != nil {
+		return caws.AttestationStepError("creating the AWS client", err)
+	}
 
 	query := &ec2.DescribeInstancesInput{
 		InstanceIds: []*string{&doc.InstanceId},
 	}
 
-	result, err := ec2Client.DescribeInstances(query)
+	result, err := client.DescribeInstancesWithContext(stream.Context(), query)
 	if err != nil {
 		return caws.AttestationStepError("querying AWS via describe-instances", err)
 	}
 
+	if len(result.Reservations) == 0 || len(result.Reservations[0].Instances) == 0 {
+		return caws.AttestationStepError("querying AWS via describe-instances", fmt.Errorf("instance %q not found", doc.InstanceId))
+	}
+
 	instance := result.Reservations[0].Instances[0]
 
 	ifaceZeroDeviceIndex := *instance.NetworkInterfaces[0].Attachment.DeviceIndex
@@ -139,7 +166,7 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) erro
 	ifaceZeroAttachTime := instance.NetworkInterfaces[0].Attachment.AttachTime
 
 	// skip anti-tampering mechanism when RootDeviceType is instance-store
-	if *instance.RootDeviceType != ec2.DeviceTypeInstanceStore && p.skipBlockDevice != true {
+	if *instance.RootDeviceType != ec2.DeviceTypeInstanceStore && c.skipBlockDevice != true {
 		rootDeviceIndex := -1
 		for i, bdm := range instance.BlockDeviceMappings {
 			if *bdm.DeviceName == *instance.RootDeviceName {
@@ -163,9 +190,21 @@ func (p *IIDAttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) erro
 		}
 	}
 
+	tags := make(map[string]string)
+	for _, tag := range instance.Tags {
+		if tag.Key != nil && tag.Value != nil {
+			tags[*tag.Key] = *tag.Value
+		}
+	}
+
+	spiffeID, err := caws.MakeSpiffeID(c.trustDomain, c.pathTemplate, doc, tags)
+	if err != nil {
+		return caws.AttestationStepError("creating the agent SPIFFE ID", err)
+	}
+
 	resp := &nodeattestor.AttestResponse{
 		Valid:        true,
-		BaseSPIFFEID: caws.IIDAgentID(p.trustDomain, doc.AccountId, doc.Region, doc.InstanceId),
+		BaseSPIFFEID: spiffeID.String(),
 	}
 
 	return stream.Send(resp)
@@ -218,24 +257,96 @@ func (p *IIDAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		return resp, err
 	}
 
+	accountAllowlist := make(map[string]bool)
+	for _, accountID := range config.AccountAllowlist {
+		accountAllowlist[accountID] = true
+	}
+
+	for accountID, roleARN := range config.AssumeRoleARNs {
+		if roleARN == "" {
+			err := fmt.Errorf("Error in the AWS IID Attestor configuration: missing role ARN to assume for account %q", accountID)
+			return resp, err
+		}
+	}
+
+	pathTemplate := caws.DefaultAgentPathTemplate
+	if len(config.AgentPathTemplate) > 0 {
+		tmpl, err := template.New("agent-path").Parse(config.AgentPathTemplate)
+		if err != nil {
+			err := fmt.Errorf("Error parsing the agent path template in the AWS IID Attestor: %q", config.AgentPathTemplate)
+			return resp, err
+		}
+		pathTemplate = tmpl
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	p.trustDomain = req.GlobalConfig.TrustDomain
-	p.awsCaCertPublicKey = awsCaCertPublicKey
-	p.accessKeyId = config.AccessKeyID
-	p.secretAccessKey = config.SecretAccessKey
-	p.skipBlockDevice = config.SkipBlockDevice
+	p.config = &iidAttestorConfig{
+		trustDomain:        req.GlobalConfig.TrustDomain,
+		awsCaCertPublicKey: awsCaCertPublicKey,
+		accessKeyId:        config.AccessKeyID,
+		secretAccessKey:    config.SecretAccessKey,
+		skipBlockDevice:    config.SkipBlockDevice,
+		pathTemplate:       pathTemplate,
+		accountAllowlist:   accountAllowlist,
+		assumeRoleARNs:     config.AssumeRoleARNs,
+	}
+	// the clients depend on the credentials and roles
+	p.clients = make(map[string]ec2Client)
 
 	return &spi.ConfigureResponse{}, nil
 }
 
+func (p *IIDAttestorPlugin) getConfig() (*iidAttestorConfig, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.config == nil {
+		return nil, caws.AttestationStepError("loading the configuration", errors.New("not configured"))
+	}
+	return p.config, nil
+}
+
+// getClient returns the client for the account and region. Creating a client
+// does not call AWS, so it is safe to do while holding the lock.
+func (p *IIDAttestorPlugin) getClient(c *iidAttestorConfig, accountID, region string) (ec2Client, error) {
+	key := accountID + "/" + region
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	// the configuration may have changed since it was loaded, in which case
+	// the client is created but not cached.
+	if client, ok := p.clients[key]; ok && c == p.config {
+		return client, nil
+	}
+
+	client, err := p.hooks.newClient(c.accessKeyId, c.secretAccessKey, region, c.assumeRoleARNs[accountID])
+	if err != nil {
+		return nil, err
+	}
+	if c == p.config {
+		p.clients[key] = client
+	}
+	return client, nil
+}
+
 func (*IIDAttestorPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
 func NewIID() nodeattestor.Plugin {
-	return &IIDAttestorPlugin{
+	p := &IIDAttestorPlugin{
 		mtx: &sync.Mutex{},
 	}
+	p.hooks.newClient = newEC2Client
+	return p
+}
+
+func newEC2Client(accessKeyID, secretAccessKey, region, assumeRoleARN string) (ec2Client, error) {
+	sess, err := caws.NewSession(accessKeyID, secretAccessKey, region, assumeRoleARN)
+	if err != nil {
+		return nil, err
+	}
+	return ec2.New(sess), nil
 }
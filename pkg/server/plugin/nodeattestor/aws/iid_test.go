@@ -0,0 +1,284 @@
+package aws
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	caws "github.com/spiffe/spire/pkg/common/plugin/aws"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestIIDAttestor(t *testing.T) {
+	suite.Run(t, new(IIDAttestorSuite))
+}
+
+type IIDAttestorSuite struct {
+	suite.Suite
+
+	key      *rsa.PrivateKey
+	client   *fakeEC2Client
+	roleARNs []string
+	plugin   *IIDAttestorPlugin
+	attestor *nodeattestor.BuiltIn
+}
+
+func (s *IIDAttestorSuite) SetupSuite() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+}
+
+func (s *IIDAttestorSuite) SetupTest() {
+	s.client = &fakeEC2Client{
+		instance: &ec2.Instance{
+			RootDeviceType: aws.String(ec2.DeviceTypeInstanceStore),
+			NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+				{
+					Attachment: &ec2.InstanceNetworkInterfaceAttachment{
+						DeviceIndex: aws.Int64(0),
+						AttachTime:  aws.Time(time.Now()),
+					},
+				},
+			},
+		},
+	}
+	s.roleARNs = nil
+
+	s.plugin = NewIID().(*IIDAttestorPlugin)
+	s.plugin.hooks.newClient = func(accessKeyID, secretAccessKey, region, assumeRoleARN string) (ec2Client, error) {
+		s.Require().Equal("us-west-2", region)
+		s.roleARNs = append(s.roleARNs, assumeRoleARN)
+		return s.client, nil
+	}
+	s.attestor = nodeattestor.NewBuiltIn(s.plugin)
+	s.configure("")
+}
+
+func (s *IIDAttestorSuite) TestAttestSuccess() {
+	resp, err := s.attest("123456789012", false)
+	s.Require().NoError(err)
+	s.Require().True(resp.Valid)
+	s.Require().Equal("spiffe://example.org/spire/agent/aws_iid/123456789012/us-west-2/i-0123456789abcdef0", resp.BaseSPIFFEID)
+	s.Require().Equal([]string{""}, s.roleARNs)
+}
+
+func (s *IIDAttestorSuite) TestAttestFailsWhenAttestedBefore() {
+	_, err := s.attest("123456789012", true)
+	s.requireErrorContains(err, "the IID has been used and is no longer valid")
+}
+
+func (s *IIDAttestorSuite) TestAttestFailsWithBadSignature() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	s.key = key
+	_, err = s.attest("123456789012", false)
+	s.requireErrorContains(err, "verifying the cryptographic signature")
+}
+
+func (s *IIDAttestorSuite) TestAttestWithAccountAllowlist() {
+	s.configure(`account_allowlist = ["123456789012"]`)
+
+	// allowed account
+	_, err := s.attest("123456789012", false)
+	s.Require().NoError(err)
+
+	// account not in the allowlist. AWS must not be queried.
+	s.roleARNs = nil
+	_, err = s.attest("210987654321", false)
+	s.requireErrorContains(err, `account ID "210987654321" is not in the allowlist`)
+	s.Require().Empty(s.roleARNs)
+}
+
+func (s *IIDAttestorSuite) TestAttestAssumesAccountRole() {
+	s.configure(`assume_role_arns = {
+		"123456789012" = "arn:aws:iam::123456789012:role/spire-server"
+	}`)
+
+	_, err := s.attest("123456789012", false)
+	s.Require().NoError(err)
+	_, err = s.attest("210987654321", false)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"arn:aws:iam::123456789012:role/spire-server", ""}, s.roleARNs)
+
+	// the clients, and with them the assumed role credentials, are reused
+	_, err = s.attest("123456789012", false)
+	s.Require().NoError(err)
+	_, err = s.attest("210987654321", false)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"arn:aws:iam::123456789012:role/spire-server", ""}, s.roleARNs)
+
+	// until the plugin is reconfigured
+	s.configure(`assume_role_arns = {
+		"123456789012" = "arn:aws:iam::123456789012:role/other"
+	}`)
+	s.roleARNs = nil
+	_, err = s.attest("123456789012", false)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"arn:aws:iam::123456789012:role/other"}, s.roleARNs)
+}
+
+func (s *IIDAttestorSuite) TestAttestDoesNotBlockOnAWS() {
+	// the first attestation blocks in describe-instances
+	blocking := &fakeEC2Client{
+		instance: s.client.instance,
+		block:    make(chan struct{}),
+		called:   make(chan struct{}),
+	}
+	s.plugin.hooks.newClient = func(accessKeyID, secretAccessKey, region, assumeRoleARN string) (ec2Client, error) {
+		if assumeRoleARN != "" {
+			return blocking, nil
+		}
+		return s.client, nil
+	}
+	s.configure(`assume_role_arns = {
+		"123456789012" = "arn:aws:iam::123456789012:role/spire-server"
+	}`)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := s.attest("123456789012", false)
+		errCh <- err
+	}()
+	select {
+	case <-blocking.called:
+	case <-time.After(time.Minute):
+		s.FailNow("timed out waiting for describe-instances")
+	}
+
+	// attestations of other accounts go through in the meantime
+	_, err := s.attest("210987654321", false)
+	s.Require().NoError(err)
+
+	close(blocking.block)
+	s.Require().NoError(<-errCh)
+}
+
+func (s *IIDAttestorSuite) TestAttestWithAgentPathTemplate() {
+	s.configure(`agent_path_template = "{{ .PluginName }}/{{ .Region }}/{{ index .Tags \"Name\" }}"`)
+	s.client.instance.Tags = []*ec2.Tag{
+		{Key: aws.String("Name"), Value: aws.String("node1")},
+	}
+
+	resp, err := s.attest("123456789012", false)
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/spire/agent/aws_iid/us-west-2/node1", resp.BaseSPIFFEID)
+}
+
+func (s *IIDAttestorSuite) TestAttestFailsWhenInstanceNotFound() {
+	s.client.instance = nil
+	_, err := s.attest("123456789012", false)
+	s.requireErrorContains(err, `instance "i-0123456789abcdef0" not found`)
+}
+
+func (s *IIDAttestorSuite) TestAttestFailsWhenDescribeInstancesFails() {
+	s.client.err = errors.New("oh no")
+	_, err := s.attest("123456789012", false)
+	s.requireErrorContains(err, "querying AWS via describe-instances: oh no")
+}
+
+func (s *IIDAttestorSuite) TestConfigure() {
+	// missing role ARN for an account
+	_, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `assume_role_arns = {
+			"123456789012" = ""
+		}`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.requireErrorContains(err, `missing role ARN to assume for account "123456789012"`)
+
+	// missing trust domain
+	_, err = s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{},
+	})
+	s.Require().EqualError(err, "trust_domain is required")
+}
+
+func (s *IIDAttestorSuite) configure(config string) {
+	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: config,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&plugin.ConfigureResponse{}, resp)
+
+	// verify instance identity documents signed by the test key
+	s.plugin.config.awsCaCertPublicKey = &s.key.PublicKey
+}
+
+func (s *IIDAttestorSuite) attest(accountID string, attestedBefore bool) (*nodeattestor.AttestResponse, error) {
+	doc, err := json.Marshal(caws.InstanceIdentityDocument{
+		AccountId:  accountID,
+		Region:     "us-west-2",
+		InstanceId: "i-0123456789abcdef0",
+	})
+	s.Require().NoError(err)
+
+	docHash := sha256.Sum256(doc)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, docHash[:])
+	s.Require().NoError(err)
+
+	data, err := json.Marshal(caws.IIDAttestationData{
+		Document:  string(doc),
+		Signature: base64.StdEncoding.EncodeToString(signature),
+	})
+	s.Require().NoError(err)
+
+	stream, err := s.attestor.Attest(context.Background())
+	s.Require().NoError(err)
+	defer stream.CloseSend()
+
+	s.Require().NoError(stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "aws_iid",
+			Data: data,
+		},
+		AttestedBefore: attestedBefore,
+	}))
+	return stream.Recv()
+}
+
+func (s *IIDAttestorSuite) requireErrorContains(err error, contains string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), contains)
+}
+
+type fakeEC2Client struct {
+	instance *ec2.Instance
+	err      error
+
+	// if set, describe-instances signals called and blocks until block is
+	// closed
+	block  chan struct{}
+	called chan struct{}
+}
+
+func (c *fakeEC2Client) DescribeInstancesWithContext(_ aws.Context, input *ec2.DescribeInstancesInput, _ ...request.Option) (*ec2.DescribeInstancesOutput, error) {
+	if c.block != nil {
+		close(c.called)
+		<-c.block
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	output := &ec2.DescribeInstancesOutput{}
+	if c.instance != nil && len(input.InstanceIds) == 1 && aws.StringValue(input.InstanceIds[0]) == "i-0123456789abcdef0" {
+		output.Reservations = []*ec2.Reservation{
+			{Instances: []*ec2.Instance{c.instance}},
+		}
+	}
+	return output, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/plugin/k8s"
 	"github.com/spiffe/spire/pkg/common/plugin/k8s/client"
 	"github.com/spiffe/spire/proto/common"
@@ -71,6 +72,12 @@ type ClusterConfig struct {
 // AttestorConfig contains a map of clusters that uses cluster name as key
 type AttestorConfig struct {
 	Clusters map[string]*ClusterConfig `hcl:"clusters"`
+
+	// If true, agents that have already attested can attest again with a
+	// fresh token, e.g. after the token they attested with was rotated.
+	// Tokens are bearer tokens, so anyone who gets hold of a token for the
+	// agent pod before it expires can then attest as the agent.
+	AllowReattestation bool `hcl:"allow_reattestation"`
 }
 
 type clusterConfig struct {
@@ -83,8 +90,9 @@ type clusterConfig struct {
 }
 
 type attestorConfig struct {
-	trustDomain string
-	clusters    map[string]*clusterConfig
+	trustDomain        string
+	clusters           map[string]*clusterConfig
+	allowReattestation bool
 }
 
 func (p *AttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
@@ -98,7 +106,7 @@ func (p *AttestorPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
 		return psatError.Wrap(err)
 	}
 
-	if req.AttestedBefore {
+	if req.AttestedBefore && !config.allowReattestation {
 		return psatError.New("node has already attested")
 	}
 
@@ -201,8 +209,12 @@ func (p *AttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReques
 	}
 
 	config := &attestorConfig{
-		trustDomain: req.GlobalConfig.TrustDomain,
-		clusters:    make(map[string]*clusterConfig),
+		trustDomain:        req.GlobalConfig.TrustDomain,
+		clusters:           make(map[string]*clusterConfig),
+		allowReattestation: hclConfig.AllowReattestation,
+	}
+	if config.allowReattestation {
+		logrus.Warn("k8s_psat re-attestation is allowed; anyone holding a valid token for an agent pod can attest as that agent")
 	}
 
 	for name, cluster := range hclConfig.Clusters {
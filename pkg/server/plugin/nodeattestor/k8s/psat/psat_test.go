@@ -324,6 +324,43 @@ func (s *AttestorSuite) TestAttestSuccess() {
 	}, resp.Selectors)
 }
 
+func (s *AttestorSuite) TestAttestWhenAttestedBeforeWithReattestationAllowed() {
+	attestor := NewAttestorPlugin()
+	_, err := attestor.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		allow_reattestation = true
+		clusters = {
+			"BAR" = {
+				service_account_key_file = %q
+				service_account_whitelist = ["NS2:SA2"]
+			}
+		}
+		`, s.barCertPath()),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+
+	req := s.signAttestRequest(s.barSigner, "BAR", TokenData{
+		namespace:          "NS2",
+		serviceAccountName: "SA2",
+		podName:            "podname-2",
+		podUID:             "poduid-2",
+		issuer:             "api",
+		audience:           []string{"spire-server"},
+	})
+	req.AttestedBefore = true
+	resp, err := s.doAttestOnAttestor(nodeattestor.NewBuiltIn(attestor), req)
+	s.Require().NoError(err)
+	s.Require().True(resp.Valid)
+	s.Require().Equal("spiffe://example.org/spire/agent/k8s_psat/BAR/poduid-2", resp.BaseSPIFFEID)
+
+	// the token is still validated
+	req = makeAttestRequest("BAR", "blah")
+	req.AttestedBefore = true
+	_, err = s.doAttestOnAttestor(nodeattestor.NewBuiltIn(attestor), req)
+	s.requireErrorContains(err, "k8s-psat: unable to parse token")
+}
+
 func (s *AttestorSuite) TestConfigure() {
 	// malformed configuration
 	resp, err := s.attestor.Configure(context.Background(), &plugin.ConfigureRequest{
@@ -0,0 +1,285 @@
+package sshpop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sync"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/plugin/sshpop"
+	"github.com/spiffe/spire/proto/common"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pluginName = "sshpop"
+)
+
+type configuration struct {
+	trustDomain       string
+	certAuthorities   []ssh.PublicKey
+	allowedPrincipals []string
+}
+
+type SSHPoPConfig struct {
+	// CertAuthorities holds the public keys of the trusted SSH CAs, in
+	// authorized_keys format.
+	CertAuthorities []string `hcl:"cert_authorities"`
+	// CertAuthoritiesPath is the path to a file holding the public keys of
+	// the trusted SSH CAs, one per line in authorized_keys format.
+	CertAuthoritiesPath string `hcl:"cert_authorities_path"`
+	// AllowedPrincipals, if set, restricts the certificates to those valid
+	// for a principal matching one of the patterns.
+	AllowedPrincipals []string `hcl:"allowed_principals"`
+}
+
+type SSHPoPPlugin struct {
+	m sync.Mutex
+	c *configuration
+}
+
+func New() *SSHPoPPlugin {
+	return &SSHPoPPlugin{}
+}
+
+func (p *SSHPoPPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	c := p.getConfiguration()
+	if c == nil {
+		return newError("not configured")
+	}
+
+	if dataType := req.AttestationData.Type; dataType != pluginName {
+		return newError("unexpected attestation data type %q", dataType)
+	}
+
+	attestationData := new(sshpop.AttestationData)
+	if err := json.Unmarshal(req.AttestationData.Data, attestationData); err != nil {
+		return newError("failed to unmarshal data: %v", err)
+	}
+
+	if len(attestationData.Certificate) == 0 {
+		return newError("no certificate to attest")
+	}
+	cert, err := sshpop.ParseCertificate(attestationData.Certificate)
+	if err != nil {
+		return newError("unable to parse certificate: %v", err)
+	}
+
+	// verify the certificate was issued by a trusted CA
+	if err := c.verifyCertificate(cert); err != nil {
+		return newError("certificate verification failed: %v", err)
+	}
+	spiffeID, err := sshpop.SpiffeID(c.trustDomain, cert)
+	if err != nil {
+		return newError("%v", err)
+	}
+
+	// now that the certificate is trusted, issue a challenge to the node
+	// to prove possession of the host key.
+	challenge, err := sshpop.GenerateChallenge()
+	if err != nil {
+		return fmt.Errorf("unable to generate challenge: %v", err)
+	}
+
+	challengeBytes, err := json.Marshal(challenge)
+	if err != nil {
+		return fmt.Errorf("unable to marshal challenge: %v", err)
+	}
+
+	if err := stream.Send(&nodeattestor.AttestResponse{
+		Challenge: challengeBytes,
+	}); err != nil {
+		return err
+	}
+
+	// receive and validate the challenge response
+	responseReq, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	response := new(sshpop.Response)
+	if err := json.Unmarshal(responseReq.Response, response); err != nil {
+		return newError("unable to unmarshal challenge response: %v", err)
+	}
+
+	if err := sshpop.VerifyChallengeResponse(cert.Key, challenge, response); err != nil {
+		return newError("challenge response verification failed: %v", err)
+	}
+
+	resp := &nodeattestor.AttestResponse{
+		Valid:        true,
+		BaseSPIFFEID: spiffeID,
+		Selectors:    buildSelectors(cert),
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *SSHPoPPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(SSHPoPConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, newError("unable to decode configuration: %v", err)
+	}
+
+	if req.GlobalConfig == nil {
+		return nil, newError("global configuration is required")
+	}
+
+	if req.GlobalConfig.TrustDomain == "" {
+		return nil, newError("trust_domain is required")
+	}
+
+	if len(config.CertAuthorities) == 0 && config.CertAuthoritiesPath == "" {
+		return nil, newError("cert_authorities or cert_authorities_path is required")
+	}
+
+	var certAuthorities []ssh.PublicKey
+	for _, line := range config.CertAuthorities {
+		keys, err := parseAuthorizedKeys([]byte(line))
+		if err != nil {
+			return nil, newError("unable to parse cert authority: %v", err)
+		}
+		certAuthorities = append(certAuthorities, keys...)
+	}
+	if config.CertAuthoritiesPath != "" {
+		data, err := ioutil.ReadFile(config.CertAuthoritiesPath)
+		if err != nil {
+			return nil, newError("unable to read cert authorities: %v", err)
+		}
+		keys, err := parseAuthorizedKeys(data)
+		if err != nil {
+			return nil, newError("unable to parse cert authorities: %v", err)
+		}
+		certAuthorities = append(certAuthorities, keys...)
+	}
+
+	for _, pattern := range config.AllowedPrincipals {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, newError("invalid allowed principal %q: %v", pattern, err)
+		}
+	}
+
+	p.setConfiguration(&configuration{
+		trustDomain:       req.GlobalConfig.TrustDomain,
+		certAuthorities:   certAuthorities,
+		allowedPrincipals: config.AllowedPrincipals,
+	})
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*SSHPoPPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *SSHPoPPlugin) getConfiguration() *configuration {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
+
+func (p *SSHPoPPlugin) setConfiguration(c *configuration) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+}
+
+// verifyCertificate verifies that the certificate is a valid host
+// certificate signed by one of the trusted CAs, for one of the allowed
+// principals if any.
+func (c *configuration) verifyCertificate(cert *ssh.Certificate) error {
+	if cert.CertType != ssh.HostCert {
+		return fmt.Errorf("expected host certificate; got type %d", cert.CertType)
+	}
+	if !c.isCertAuthority(cert.SignatureKey) {
+		return fmt.Errorf("certificate signed by unrecognized authority %s", ssh.FingerprintSHA256(cert.SignatureKey))
+	}
+
+	// the principal is only checked against the principals in the
+	// certificate, so check the one that is allowed, if any.
+	var principal string
+	if len(c.allowedPrincipals) > 0 {
+		principal = c.allowedPrincipal(cert)
+		if principal == "" {
+			return fmt.Errorf("no allowed principal in %q", cert.ValidPrincipals)
+		}
+	} else if len(cert.ValidPrincipals) > 0 {
+		principal = cert.ValidPrincipals[0]
+	}
+
+	checker := &ssh.CertChecker{}
+	return checker.CheckCert(principal, cert)
+}
+
+func (c *configuration) isCertAuthority(key ssh.PublicKey) bool {
+	keyBytes := key.Marshal()
+	for _, certAuthority := range c.certAuthorities {
+		if bytes.Equal(certAuthority.Marshal(), keyBytes) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *configuration) allowedPrincipal(cert *ssh.Certificate) string {
+	for _, principal := range cert.ValidPrincipals {
+		for _, pattern := range c.allowedPrincipals {
+			if ok, _ := path.Match(pattern, principal); ok {
+				return principal
+			}
+		}
+	}
+	return ""
+}
+
+func newError(format string, args ...interface{}) error {
+	return fmt.Errorf("sshpop: "+format, args...)
+}
+
+func buildSelectors(cert *ssh.Certificate) []*common.Selector {
+	selectors := []*common.Selector{}
+	for _, principal := range cert.ValidPrincipals {
+		selectors = append(selectors, &common.Selector{
+			Type: pluginName, Value: "principal:" + principal,
+		})
+	}
+	return selectors
+}
+
+// parseAuthorizedKeys parses the public keys in authorized_keys format,
+// one per line, skipping empty lines and comments.
+func parseAuthorizedKeys(data []byte) ([]ssh.PublicKey, error) {
+	var keys []ssh.PublicKey
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no public key found")
+	}
+	return keys, nil
+}
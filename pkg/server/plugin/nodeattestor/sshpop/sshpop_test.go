@@ -0,0 +1,352 @@
+package sshpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/plugin/sshpop"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHPoP(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+type Suite struct {
+	suite.Suite
+
+	dir      string
+	caSigner ssh.Signer
+	p        *nodeattestor.BuiltIn
+}
+
+func (s *Suite) SetupTest() {
+	require := s.Require()
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "server-sshpop-test")
+	require.NoError(err)
+
+	s.caSigner = s.newSigner()
+	caPath := filepath.Join(s.dir, "cas")
+	require.NoError(ioutil.WriteFile(caPath, []byte(fmt.Sprintf(`# trusted host CAs
+
+%s`, ssh.MarshalAuthorizedKey(s.caSigner.PublicKey()))), 0644))
+
+	s.p = nodeattestor.NewBuiltIn(New())
+	s.configure(fmt.Sprintf(`
+		cert_authorities_path = %q
+		allowed_principals = ["*.example.org"]`, caPath))
+}
+
+func (s *Suite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *Suite) TestAttestSuccess() {
+	signer, cert := s.newCertificate(nil)
+
+	resp := s.requireAttestSucceeds(signer, cert)
+	s.Require().Equal("spiffe://example.org/spire/agent/sshpop/node1", resp.BaseSPIFFEID)
+	s.Require().Equal([]*common.Selector{
+		{Type: "sshpop", Value: "principal:node1"},
+		{Type: "sshpop", Value: "principal:node1.example.org"},
+	}, resp.Selectors)
+}
+
+func (s *Suite) TestAttestSuccessWithCertAuthorities() {
+	otherCASigner := s.newSigner()
+	s.configure(fmt.Sprintf(`cert_authorities = [%q, %q]`,
+		ssh.MarshalAuthorizedKey(s.caSigner.PublicKey()),
+		ssh.MarshalAuthorizedKey(otherCASigner.PublicKey())))
+
+	// any principal is allowed and the first one names the agent
+	signer, cert := s.newCertificate(func(cert *ssh.Certificate) {
+		cert.KeyId = ""
+		cert.ValidPrincipals = []string{"node2"}
+	})
+	resp := s.requireAttestSucceeds(signer, cert)
+	s.Require().Equal("spiffe://example.org/spire/agent/sshpop/node2", resp.BaseSPIFFEID)
+
+	// certificates signed by either CA are trusted
+	s.caSigner = otherCASigner
+	signer, cert = s.newCertificate(nil)
+	s.requireAttestSucceeds(signer, cert)
+}
+
+func (s *Suite) TestAttestFailure() {
+	require := s.Require()
+
+	makeData := func(attestationData sshpop.AttestationData) *common.AttestationData {
+		return &common.AttestationData{
+			Type: "sshpop",
+			Data: s.marshal(attestationData),
+		}
+	}
+
+	attestFails := func(attestationData *common.AttestationData, expected string) {
+		stream, done := s.attest()
+		defer done()
+
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			AttestationData: attestationData,
+		}))
+
+		resp, err := stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	certFails := func(modify func(*ssh.Certificate), expected string) {
+		_, cert := s.newCertificate(modify)
+		attestFails(makeData(sshpop.AttestationData{
+			Certificate: cert.Marshal(),
+		}), expected)
+	}
+
+	challengeResponseFails := func(response string, expected string) {
+		stream, done := s.attest()
+		defer done()
+
+		_, cert := s.newCertificate(nil)
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			AttestationData: makeData(sshpop.AttestationData{
+				Certificate: cert.Marshal(),
+			}),
+		}))
+
+		resp, err := stream.Recv()
+		require.NoError(err)
+		require.NotNil(resp)
+
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			Response: []byte(response),
+		}))
+
+		resp, err = stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	// not configured yet
+	stream, err := nodeattestor.NewBuiltIn(New()).Attest(context.Background())
+	require.NoError(err)
+	defer stream.CloseSend()
+	require.NoError(stream.Send(&nodeattestor.AttestRequest{}))
+	_, err = stream.Recv()
+	require.EqualError(err, "sshpop: not configured")
+
+	// unexpected data type
+	attestFails(&common.AttestationData{Type: "foo"}, `sshpop: unexpected attestation data type "foo"`)
+
+	// malformed data
+	attestFails(&common.AttestationData{Type: "sshpop"}, "sshpop: failed to unmarshal data")
+
+	// no certificate
+	attestFails(makeData(sshpop.AttestationData{}), "sshpop: no certificate to attest")
+
+	// malformed certificate
+	attestFails(makeData(sshpop.AttestationData{Certificate: []byte("malformed")}), "sshpop: unable to parse certificate")
+
+	// user certificate
+	certFails(func(cert *ssh.Certificate) {
+		cert.CertType = ssh.UserCert
+	}, "sshpop: certificate verification failed: expected host certificate; got type 1")
+
+	// untrusted CA
+	caSigner := s.caSigner
+	s.caSigner = s.newSigner()
+	certFails(nil, "sshpop: certificate verification failed: certificate signed by unrecognized authority SHA256:")
+	s.caSigner = caSigner
+
+	// no allowed principal
+	certFails(func(cert *ssh.Certificate) {
+		cert.ValidPrincipals = []string{"node1", "node1.example.com"}
+	}, `sshpop: certificate verification failed: no allowed principal in ["node1" "node1.example.com"]`)
+
+	// expired
+	certFails(func(cert *ssh.Certificate) {
+		cert.ValidBefore = uint64(time.Now().Add(-time.Hour).Unix())
+	}, "sshpop: certificate verification failed: ssh: cert has expired")
+
+	// invalid agent name
+	certFails(func(cert *ssh.Certificate) {
+		cert.KeyId = ".."
+	}, `sshpop: invalid agent name ".."`)
+
+	// malformed challenge response
+	challengeResponseFails("", "sshpop: unable to unmarshal challenge response")
+
+	// invalid challenge response
+	challengeResponseFails("{}", "sshpop: challenge response verification failed")
+}
+
+func (s *Suite) TestConfigure() {
+	require := s.Require()
+
+	configureFails := func(config, expected string) {
+		resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+			Configuration: config,
+			GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+		})
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	// malformed
+	configureFails(`bad juju`, "sshpop: unable to decode configuration")
+
+	// missing global configuration
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{})
+	require.EqualError(err, "sshpop: global configuration is required")
+	require.Nil(resp)
+
+	// missing trust_domain
+	resp, err = s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{},
+	})
+	require.EqualError(err, "sshpop: trust_domain is required")
+	require.Nil(resp)
+
+	// missing cert authorities
+	configureFails(``, "sshpop: cert_authorities or cert_authorities_path is required")
+
+	// malformed cert authority
+	configureFails(`cert_authorities = ["ssh-ed25519 malformed"]`, "sshpop: unable to parse cert authority")
+	configureFails(`cert_authorities = [""]`, "sshpop: unable to parse cert authority: no public key found")
+
+	// missing cert authorities file
+	configureFails(`cert_authorities_path = "missing"`, "sshpop: unable to read cert authorities")
+
+	// malformed cert authorities file
+	path := filepath.Join(s.dir, "malformed")
+	require.NoError(ioutil.WriteFile(path, []byte("malformed"), 0644))
+	configureFails(fmt.Sprintf(`cert_authorities_path = %q`, path), "sshpop: unable to parse cert authorities")
+
+	// invalid allowed principal
+	configureFails(fmt.Sprintf(`
+		cert_authorities = [%q]
+		allowed_principals = ["["]`, ssh.MarshalAuthorizedKey(s.caSigner.PublicKey())),
+		`sshpop: invalid allowed principal "["`)
+}
+
+func (s *Suite) TestGetPluginInfo() {
+	require := s.Require()
+	resp, err := s.p.GetPluginInfo(context.Background(), &plugin.GetPluginInfoRequest{})
+	require.NoError(err)
+	require.Equal(resp, &plugin.GetPluginInfoResponse{})
+}
+
+func (s *Suite) requireAttestSucceeds(signer ssh.Signer, cert *ssh.Certificate) *nodeattestor.AttestResponse {
+	require := s.Require()
+
+	stream, done := s.attest()
+	defer done()
+
+	// send down good attestation data
+	err := stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "sshpop",
+			Data: s.marshal(sshpop.AttestationData{
+				Certificate: cert.Marshal(),
+			}),
+		},
+	})
+	require.NoError(err)
+
+	// receive and parse challenge
+	resp, err := stream.Recv()
+	require.NoError(err)
+	s.Equal("", resp.BaseSPIFFEID)
+	s.False(resp.Valid)
+	s.NotEmpty(resp.Challenge)
+
+	challenge := new(sshpop.Challenge)
+	s.unmarshal(resp.Challenge, challenge)
+
+	// calculate and send the response
+	response, err := sshpop.CalculateResponse(signer, challenge)
+	require.NoError(err)
+	err = stream.Send(&nodeattestor.AttestRequest{
+		Response: s.marshal(response),
+	})
+	require.NoError(err)
+
+	// receive the attestation result
+	resp, err = stream.Recv()
+	require.NoError(err)
+	require.True(resp.Valid)
+	require.Nil(resp.Challenge)
+	return resp
+}
+
+// newCertificate returns a host key and a host certificate for it, signed by
+// the current CA and optionally modified before signing.
+func (s *Suite) newCertificate(modify func(*ssh.Certificate)) (ssh.Signer, *ssh.Certificate) {
+	signer := s.newSigner()
+	cert := &ssh.Certificate{
+		Key:             signer.PublicKey(),
+		CertType:        ssh.HostCert,
+		KeyId:           "node1",
+		ValidPrincipals: []string{"node1", "node1.example.org"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if modify != nil {
+		modify(cert)
+	}
+	s.Require().NoError(cert.SignCert(rand.Reader, s.caSigner))
+	return signer, cert
+}
+
+func (s *Suite) newSigner() ssh.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	signer, err := ssh.NewSignerFromKey(key)
+	s.Require().NoError(err)
+	return signer
+}
+
+func (s *Suite) configure(config string) {
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: config,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *Suite) attest() (nodeattestor.Attest_Stream, func()) {
+	stream, err := s.p.Attest(context.Background())
+	s.Require().NoError(err)
+	return stream, func() {
+		s.Require().NoError(stream.CloseSend())
+	}
+}
+
+func (s *Suite) marshal(obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *Suite) unmarshal(data []byte, obj interface{}) {
+	s.Require().NoError(json.Unmarshal(data, obj))
+}
+
+func (s *Suite) errorContains(err error, substring string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), substring)
+}
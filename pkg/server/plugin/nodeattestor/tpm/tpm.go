@@ -0,0 +1,254 @@
+package tpm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/plugin/tpm"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/common"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+)
+
+const (
+	secretLen = 32
+	nonceLen  = 32
+)
+
+var (
+	// oidSubjectAltName is the OID of the subject alternative name
+	// extension. EK certificates carry the TPM manufacturer, model and
+	// version as a critical directory name, which is not handled by the
+	// x509 package.
+	oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+)
+
+type configuration struct {
+	trustDomain string
+	trustBundle *x509.CertPool
+}
+
+type TPMConfig struct {
+	CABundlePath string `hcl:"ca_bundle_path"`
+}
+
+type TPMPlugin struct {
+	m sync.Mutex
+	c *configuration
+}
+
+func New() *TPMPlugin {
+	return &TPMPlugin{}
+}
+
+func (p *TPMPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	c := p.getConfiguration()
+	if c == nil {
+		return newError("not configured")
+	}
+
+	if dataType := req.AttestationData.Type; dataType != tpm.PluginName {
+		return newError("unexpected attestation data type %q", dataType)
+	}
+
+	attestationData := new(tpm.AttestationData)
+	if err := json.Unmarshal(req.AttestationData.Data, attestationData); err != nil {
+		return newError("failed to unmarshal data: %v", err)
+	}
+
+	// verify that the endorsement key belongs to a TPM from a trusted
+	// manufacturer
+	if len(attestationData.EKCertificate) == 0 {
+		return newError("no endorsement key certificate to attest")
+	}
+	ekCert, err := x509.ParseCertificate(attestationData.EKCertificate)
+	if err != nil {
+		return newError("unable to parse endorsement key certificate: %v", err)
+	}
+	ignoreSubjectAltName(ekCert)
+	if _, err := ekCert.Verify(x509.VerifyOptions{
+		Roots:     c.trustBundle,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return newError("endorsement key certificate verification failed: %v", err)
+	}
+
+	ekPublic, err := tpm2.DecodePublic(attestationData.EKPublic)
+	if err != nil {
+		return newError("unable to decode endorsement key: %v", err)
+	}
+	if err := tpm.VerifyEKPublic(ekPublic, ekCert); err != nil {
+		return newError("invalid endorsement key: %v", err)
+	}
+
+	akPublic, err := tpm2.DecodePublic(attestationData.AKPublic)
+	if err != nil {
+		return newError("unable to decode attestation key: %v", err)
+	}
+	if err := tpm.VerifyAKPublic(akPublic); err != nil {
+		return newError("invalid attestation key: %v", err)
+	}
+
+	// now that the endorsement key is trusted, challenge the node to
+	// activate a credential bound to the attestation key, which only the
+	// TPM holding both keys can do, and to quote its PCRs with the
+	// attestation key.
+	secret, err := randBytes(secretLen)
+	if err != nil {
+		return fmt.Errorf("unable to generate challenge: %v", err)
+	}
+	nonce, err := randBytes(nonceLen)
+	if err != nil {
+		return fmt.Errorf("unable to generate challenge: %v", err)
+	}
+	credentialBlob, encryptedSecret, err := tpm.MakeCredential(ekPublic, akPublic, secret)
+	if err != nil {
+		return fmt.Errorf("unable to generate challenge: %v", err)
+	}
+
+	challengeBytes, err := json.Marshal(tpm.Challenge{
+		CredentialBlob:  credentialBlob,
+		EncryptedSecret: encryptedSecret,
+		Nonce:           nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal challenge: %v", err)
+	}
+
+	if err := stream.Send(&nodeattestor.AttestResponse{
+		Challenge: challengeBytes,
+	}); err != nil {
+		return err
+	}
+
+	// receive and validate the challenge response
+	responseReq, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	response := new(tpm.Response)
+	if err := json.Unmarshal(responseReq.Response, response); err != nil {
+		return newError("unable to unmarshal challenge response: %v", err)
+	}
+
+	if subtle.ConstantTimeCompare(response.Secret, secret) != 1 {
+		return newError("challenge response verification failed: credential activation failed")
+	}
+	if err := tpm.VerifyQuote(akPublic, nonce, response); err != nil {
+		return newError("challenge response verification failed: %v", err)
+	}
+
+	ekPubHash, err := tpm.PubKeyHash(ekCert.PublicKey)
+	if err != nil {
+		return newError("unable to hash endorsement key: %v", err)
+	}
+
+	resp := &nodeattestor.AttestResponse{
+		Valid:        true,
+		BaseSPIFFEID: tpm.SpiffeID(c.trustDomain, ekPubHash),
+		Selectors:    buildSelectors(ekPubHash, response.PCRValues),
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *TPMPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(TPMConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, newError("unable to decode configuration: %v", err)
+	}
+
+	if req.GlobalConfig == nil {
+		return nil, newError("global configuration is required")
+	}
+
+	if req.GlobalConfig.TrustDomain == "" {
+		return nil, newError("trust_domain is required")
+	}
+
+	if config.CABundlePath == "" {
+		return nil, newError("ca_bundle_path is required")
+	}
+
+	trustBundle, err := util.LoadCertPool(config.CABundlePath)
+	if err != nil {
+		return nil, newError("unable to load trust bundle: %v", err)
+	}
+
+	p.setConfiguration(&configuration{
+		trustDomain: req.GlobalConfig.TrustDomain,
+		trustBundle: trustBundle,
+	})
+
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*TPMPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *TPMPlugin) getConfiguration() *configuration {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.c
+}
+
+func (p *TPMPlugin) setConfiguration(c *configuration) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	p.c = c
+}
+
+func newError(format string, args ...interface{}) error {
+	return fmt.Errorf("tpm: "+format, args...)
+}
+
+func buildSelectors(ekPubHash string, pcrValues map[int][]byte) []*common.Selector {
+	selectors := []*common.Selector{
+		{Type: tpm.PluginName, Value: "ek_pubhash:" + ekPubHash},
+	}
+	for _, pcr := range tpm.SortedPCRs(pcrValues) {
+		selectors = append(selectors, &common.Selector{
+			Type: tpm.PluginName, Value: fmt.Sprintf("pcr:%d:%s", pcr, hex.EncodeToString(pcrValues[pcr])),
+		})
+	}
+	return selectors
+}
+
+func ignoreSubjectAltName(cert *x509.Certificate) {
+	var unhandled []asn1.ObjectIdentifier
+	for _, oid := range cert.UnhandledCriticalExtensions {
+		if !oid.Equal(oidSubjectAltName) {
+			unhandled = append(unhandled, oid)
+		}
+	}
+	cert.UnhandledCriticalExtensions = unhandled
+}
+
+func randBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,375 @@
+//go:build tpmsimulator
+// +build tpmsimulator
+
+package tpm
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+	"github.com/spiffe/spire/pkg/common/plugin/tpm"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+	"github.com/spiffe/spire/test/tpmsimulator"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTPMWithSimulator(t *testing.T) {
+	suite.Run(t, new(Suite))
+}
+
+type Suite struct {
+	suite.Suite
+
+	dir string
+	sim *tpmsimulator.Simulator
+	p   *nodeattestor.BuiltIn
+}
+
+func (s *Suite) SetupTest() {
+	require := s.Require()
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "server-tpm-test")
+	require.NoError(err)
+	s.sim, err = tpmsimulator.New()
+	require.NoError(err)
+
+	caPath := filepath.Join(s.dir, "manufacturer-ca.pem")
+	require.NoError(ioutil.WriteFile(caPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.sim.ManufacturerCA().Raw,
+	}), 0644))
+
+	s.p = nodeattestor.NewBuiltIn(New())
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+ca_bundle_path = %q`, caPath),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.NoError(err)
+	require.Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *Suite) TearDownTest() {
+	s.Require().NoError(s.sim.Close())
+	os.RemoveAll(s.dir)
+}
+
+func (s *Suite) TestAttestSuccess() {
+	require := s.Require()
+
+	require.NoError(s.sim.ExtendPCR(7, []byte("secure boot")))
+	agent := s.newAgent()
+	defer agent.close()
+
+	stream, done := s.attest()
+	defer done()
+
+	// send down good attestation data
+	err := stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: s.makeData(agent.data),
+	})
+	require.NoError(err)
+
+	// receive and parse challenge
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.Equal("", resp.BaseSPIFFEID)
+	s.False(resp.Valid)
+	s.NotEmpty(resp.Challenge)
+
+	challenge := new(tpm.Challenge)
+	s.unmarshal(resp.Challenge, challenge)
+
+	// calculate and send the response
+	err = stream.Send(&nodeattestor.AttestRequest{
+		Response: s.marshal(agent.respond(challenge, []int{0, 7})),
+	})
+	require.NoError(err)
+
+	// receive the attestation result
+	resp, err = stream.Recv()
+	require.NoError(err)
+	s.True(resp.Valid)
+	ekPubHash, err := tpm.PubKeyHash(s.sim.EKPublicKey())
+	require.NoError(err)
+	require.Equal("spiffe://example.org/spire/agent/tpm/"+ekPubHash, resp.BaseSPIFFEID)
+	require.Nil(resp.Challenge)
+	require.EqualValues([]*common.Selector{
+		{Type: "tpm", Value: "ek_pubhash:" + ekPubHash},
+		{Type: "tpm", Value: "pcr:0:" + hex.EncodeToString(s.pcrValue(0))},
+		{Type: "tpm", Value: "pcr:7:" + hex.EncodeToString(s.pcrValue(7))},
+	}, resp.Selectors)
+}
+
+func (s *Suite) TestAttestFailure() {
+	require := s.Require()
+
+	agent := s.newAgent()
+	defer agent.close()
+
+	attestFails := func(attestationData *common.AttestationData, expected string) {
+		stream, done := s.attest()
+		defer done()
+
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			AttestationData: attestationData,
+		}))
+
+		resp, err := stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	challengeResponseFails := func(respond func(*tpm.Challenge) []byte, expected string) {
+		stream, done := s.attest()
+		defer done()
+
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			AttestationData: s.makeData(agent.data),
+		}))
+
+		resp, err := stream.Recv()
+		require.NoError(err)
+		s.NotNil(resp)
+		challenge := new(tpm.Challenge)
+		s.unmarshal(resp.Challenge, challenge)
+
+		require.NoError(stream.Send(&nodeattestor.AttestRequest{
+			Response: respond(challenge),
+		}))
+
+		resp, err = stream.Recv()
+		s.errorContains(err, expected)
+		require.Nil(resp)
+	}
+
+	// unexpected data type
+	attestFails(&common.AttestationData{Type: "foo"},
+		"tpm: unexpected attestation data type \"foo\"")
+
+	// malformed data
+	attestFails(&common.AttestationData{Type: "tpm"},
+		"tpm: failed to unmarshal data")
+
+	// no EK certificate
+	attestFails(s.makeData(&tpm.AttestationData{}),
+		"tpm: no endorsement key certificate to attest")
+
+	// malformed EK certificate
+	attestFails(s.makeData(&tpm.AttestationData{EKCertificate: []byte{0x00}}),
+		"tpm: unable to parse endorsement key certificate")
+
+	// untrusted manufacturer
+	attestFails(s.makeData(&tpm.AttestationData{
+		EKCertificate: s.issueEKCertificate(s.sim.EKPublicKey()),
+		EKPublic:      agent.data.EKPublic,
+		AKPublic:      agent.data.AKPublic,
+	}), "tpm: endorsement key certificate verification failed")
+
+	// malformed EK
+	attestFails(s.makeData(&tpm.AttestationData{
+		EKCertificate: agent.data.EKCertificate,
+	}), "tpm: unable to decode endorsement key")
+
+	// EK that does not match the certificate
+	attestFails(s.makeData(&tpm.AttestationData{
+		EKCertificate: agent.data.EKCertificate,
+		EKPublic:      s.otherEKPublic(agent.data.EKPublic),
+	}), "tpm: invalid endorsement key: endorsement key does not match the certificate")
+
+	// malformed AK
+	attestFails(s.makeData(&tpm.AttestationData{
+		EKCertificate: agent.data.EKCertificate,
+		EKPublic:      agent.data.EKPublic,
+	}), "tpm: unable to decode attestation key")
+
+	// AK that is not a restricted signing key
+	attestFails(s.makeData(&tpm.AttestationData{
+		EKCertificate: agent.data.EKCertificate,
+		EKPublic:      agent.data.EKPublic,
+		AKPublic:      agent.data.EKPublic,
+	}), "tpm: invalid attestation key: attestation key is not a restricted signing key bound to the TPM")
+
+	// malformed challenge response
+	challengeResponseFails(func(*tpm.Challenge) []byte {
+		return nil
+	}, "tpm: unable to unmarshal challenge response")
+
+	// credential not activated
+	challengeResponseFails(func(challenge *tpm.Challenge) []byte {
+		response := agent.respond(challenge, []int{0})
+		response.Secret = []byte("guess")
+		return s.marshal(response)
+	}, "tpm: challenge response verification failed: credential activation failed")
+
+	// quote of another nonce
+	challengeResponseFails(func(challenge *tpm.Challenge) []byte {
+		challenge.Nonce = []byte("other")
+		return s.marshal(agent.respond(challenge, []int{0}))
+	}, "tpm: challenge response verification failed: quote nonce mismatch")
+
+	// forged PCR values
+	challengeResponseFails(func(challenge *tpm.Challenge) []byte {
+		response := agent.respond(challenge, []int{0})
+		response.PCRValues[0] = make([]byte, 32)
+		response.PCRValues[0][0] = 1
+		return s.marshal(response)
+	}, "tpm: challenge response verification failed: quoted PCR digest does not match the PCR values")
+}
+
+// agent plays the part of the agent plugin against the simulated TPM.
+type agent struct {
+	s        *Suite
+	device   *tpm.Device
+	ekHandle tpmutil.Handle
+	akHandle tpmutil.Handle
+	data     *tpm.AttestationData
+}
+
+func (s *Suite) newAgent() *agent {
+	require := s.Require()
+
+	device := tpm.NewDevice(s.sim)
+	ekHandle, ekPublic, err := device.CreateEK()
+	require.NoError(err)
+	akHandle, akPublic, err := device.CreateAK()
+	require.NoError(err)
+	ekCertificate, err := device.ReadEKCertificate()
+	require.NoError(err)
+
+	return &agent{
+		s:        s,
+		device:   device,
+		ekHandle: ekHandle,
+		akHandle: akHandle,
+		data: &tpm.AttestationData{
+			EKCertificate: ekCertificate,
+			EKPublic:      ekPublic,
+			AKPublic:      akPublic,
+		},
+	}
+}
+
+func (a *agent) respond(challenge *tpm.Challenge, pcrs []int) *tpm.Response {
+	require := a.s.Require()
+
+	secret, err := a.device.ActivateCredential(a.akHandle, a.ekHandle, challenge.CredentialBlob, challenge.EncryptedSecret)
+	require.NoError(err)
+	pcrValues, err := a.device.ReadPCRs(pcrs)
+	require.NoError(err)
+	quote, quoteSignature, err := a.device.Quote(a.akHandle, challenge.Nonce, pcrs)
+	require.NoError(err)
+	return &tpm.Response{
+		Secret:         secret,
+		Quote:          quote,
+		QuoteSignature: quoteSignature,
+		PCRValues:      pcrValues,
+	}
+}
+
+func (a *agent) close() {
+	a.device.Flush(a.akHandle)
+	a.device.Flush(a.ekHandle)
+}
+
+func (s *Suite) pcrValue(pcr int) []byte {
+	value, err := s.sim.PCRValue(pcr)
+	s.Require().NoError(err)
+	return value
+}
+
+// issueEKCertificate issues an endorsement key certificate from another
+// manufacturer than the one of the simulated TPM.
+func (s *Suite) issueEKCertificate(ekKey crypto.PublicKey) []byte {
+	require := s.Require()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Other Manufacturer CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(err)
+	ekDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}, ca, ekKey, caKey)
+	require.NoError(err)
+	return ekDER
+}
+
+// otherEKPublic returns the public area of the endorsement key with the key
+// replaced by another one.
+func (s *Suite) otherEKPublic(ekPublicBytes []byte) []byte {
+	require := s.Require()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	ekPublic, err := tpm2.DecodePublic(ekPublicBytes)
+	require.NoError(err)
+	ekPublic.RSAParameters.ModulusRaw = key.N.Bytes()
+	other, err := ekPublic.Encode()
+	require.NoError(err)
+	return other
+}
+
+func (s *Suite) attest() (nodeattestor.Attest_Stream, func()) {
+	stream, err := s.p.Attest(context.Background())
+	s.Require().NoError(err)
+	return stream, func() {
+		s.Require().NoError(stream.CloseSend())
+	}
+}
+
+func (s *Suite) makeData(attestationData *tpm.AttestationData) *common.AttestationData {
+	return &common.AttestationData{
+		Type: "tpm",
+		Data: s.marshal(attestationData),
+	}
+}
+
+func (s *Suite) marshal(obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *Suite) unmarshal(data []byte, obj interface{}) {
+	s.Require().NoError(json.Unmarshal(data, obj))
+}
+
+func (s *Suite) errorContains(err error, substring string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), substring)
+}
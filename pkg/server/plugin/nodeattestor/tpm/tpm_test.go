@@ -0,0 +1,82 @@
+package tpm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/nodeattestor"
+	"github.com/stretchr/testify/require"
+)
+
+// The attestation tests run against the TPM simulator, which needs cgo and
+// OpenSSL 1.x. They are in tpm_simulator_test.go and only built with the
+// tpmsimulator build tag.
+
+func TestAttestWithoutConfiguration(t *testing.T) {
+	stream, err := nodeattestor.NewBuiltIn(New()).Attest(context.Background())
+	require.NoError(t, err)
+	defer stream.CloseSend()
+	require.NoError(t, stream.Send(&nodeattestor.AttestRequest{}))
+	_, err = stream.Recv()
+	require.EqualError(t, err, "tpm: not configured")
+}
+
+func TestConfigure(t *testing.T) {
+	p := New()
+
+	// malformed
+	resp, err := p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `bad juju`,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tpm: unable to decode configuration")
+	require.Nil(t, resp)
+
+	// missing global configuration
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		ca_bundle_path = "blah"
+		`,
+	})
+	require.EqualError(t, err, "tpm: global configuration is required")
+	require.Nil(t, resp)
+
+	// missing trust_domain
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		ca_bundle_path = "blah"
+		`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{},
+	})
+	require.EqualError(t, err, "tpm: trust_domain is required")
+	require.Nil(t, resp)
+
+	// missing ca_bundle_path
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: ``,
+		GlobalConfig:  &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.EqualError(t, err, "tpm: ca_bundle_path is required")
+	require.Nil(t, resp)
+
+	// bad trust bundle
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		ca_bundle_path = "blah"
+		`,
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tpm: unable to load trust bundle")
+	require.Nil(t, resp)
+}
+
+func TestGetPluginInfo(t *testing.T) {
+	p := New()
+
+	resp, err := p.GetPluginInfo(context.Background(), &plugin.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, resp, &plugin.GetPluginInfoResponse{})
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"text/template"
 
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/pkg/common/plugin/x509pop"
@@ -20,12 +21,14 @@ const (
 )
 
 type configuration struct {
-	trustDomain string
-	trustBundle *x509.CertPool
+	trustDomain  string
+	trustBundle  *x509.CertPool
+	pathTemplate *template.Template
 }
 
 type X509PoPConfig struct {
-	CABundlePath string `hcl:"ca_bundle_path"`
+	CABundlePath      string `hcl:"ca_bundle_path"`
+	AgentPathTemplate string `hcl:"agent_path_template"`
 }
 
 type X509PoPPlugin struct {
@@ -117,9 +120,14 @@ func (p *X509PoPPlugin) Attest(stream nodeattestor.Attest_PluginStream) error {
 		return newError("challenge response verification failed: %v", err)
 	}
 
+	spiffeID, err := x509pop.MakeSpiffeID(c.trustDomain, c.pathTemplate, leaf)
+	if err != nil {
+		return newError("failed to create spiffe ID: %v", err)
+	}
+
 	resp := &nodeattestor.AttestResponse{
 		Valid:        true,
-		BaseSPIFFEID: x509pop.SpiffeID(c.trustDomain, leaf),
+		BaseSPIFFEID: spiffeID.String(),
 		Selectors:    buildSelectors(leaf, chains),
 	}
 
@@ -153,9 +161,19 @@ func (p *X509PoPPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest
 		return nil, newError("unable to load trust bundle: %v", err)
 	}
 
+	pathTemplate := x509pop.DefaultAgentPathTemplate
+	if len(config.AgentPathTemplate) > 0 {
+		tmpl, err := template.New("agent-path").Parse(config.AgentPathTemplate)
+		if err != nil {
+			return nil, newError("failed to parse agent path template: %q", config.AgentPathTemplate)
+		}
+		pathTemplate = tmpl
+	}
+
 	p.setConfiguration(&configuration{
-		trustDomain: req.GlobalConfig.TrustDomain,
-		trustBundle: trustBundle,
+		trustDomain:  req.GlobalConfig.TrustDomain,
+		trustBundle:  trustBundle,
+		pathTemplate: pathTemplate,
 	})
 
 	return &spi.ConfigureResponse{}, nil
@@ -41,13 +41,7 @@ func (s *Suite) SetupTest() {
 	leafKeyPath := fixture.Join("nodeattestor", "x509pop", "leaf-key.pem")
 
 	s.p = nodeattestor.NewBuiltIn(New())
-	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
-		Configuration: fmt.Sprintf(`
-ca_bundle_path = %q`, rootCertPath),
-		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
-	})
-	require.NoError(err)
-	require.Equal(resp, &plugin.ConfigureResponse{})
+	s.configure("")
 
 	kp, err := tls.LoadX509KeyPair(leafCertPath, leafKeyPath)
 	require.NoError(err)
@@ -64,43 +58,7 @@ ca_bundle_path = %q`, rootCertPath),
 func (s *Suite) TestAttestSuccess() {
 	require := s.Require()
 
-	stream, done := s.attest()
-	defer done()
-
-	// send down good attestation data
-	attestationData := &x509pop.AttestationData{
-		Certificates: s.leafBundle,
-	}
-	err := stream.Send(&nodeattestor.AttestRequest{
-		AttestationData: &common.AttestationData{
-			Type: "x509pop",
-			Data: s.marshal(attestationData),
-		},
-	})
-	require.NoError(err)
-
-	// receive and parse challenge
-	resp, err := stream.Recv()
-	require.NoError(err)
-	require.Equal("", resp.BaseSPIFFEID)
-	s.False(resp.Valid)
-	s.NotEmpty(resp.Challenge)
-
-	challenge := new(x509pop.Challenge)
-	s.unmarshal(resp.Challenge, challenge)
-
-	// calculate and send the response
-	response, err := x509pop.CalculateResponse(s.leafKey, challenge)
-	require.NoError(err)
-	err = stream.Send(&nodeattestor.AttestRequest{
-		Response: s.marshal(response),
-	})
-	require.NoError(err)
-
-	// receive the attestation result
-	resp, err = stream.Recv()
-	require.NoError(err)
-	s.True(resp.Valid)
+	resp := s.requireAttestSuccess()
 	require.Equal("spiffe://example.org/spire/agent/x509pop/"+x509pop.Fingerprint(s.leafCert), resp.BaseSPIFFEID)
 	require.Nil(resp.Challenge)
 	require.Len(resp.Selectors, 3)
@@ -111,6 +69,25 @@ func (s *Suite) TestAttestSuccess() {
 	}, resp.Selectors)
 }
 
+func (s *Suite) TestAttestSuccessWithAgentPathTemplate() {
+	require := s.Require()
+
+	s.configure(`agent_path_template = "{{ .PluginName }}/cn/{{ .Subject.CommonName }}/{{ .SerialNumberHex }}"`)
+
+	resp := s.requireAttestSuccess()
+	require.Equal(fmt.Sprintf("spiffe://example.org/spire/agent/x509pop/cn/some%%20common%%20name/%x", s.leafCert.SerialNumber), resp.BaseSPIFFEID)
+}
+
+func (s *Suite) TestAttestFailureWithTraversingAgentPathTemplate() {
+	require := s.Require()
+
+	s.configure(`agent_path_template = "{{ .PluginName }}/../aws_iid/{{ .Subject.CommonName }}"`)
+
+	resp, err := s.attestWithChallengeResponse()
+	s.errorContains(err, `x509pop: failed to create spiffe ID: agent path "x509pop/../aws_iid/some common name" has an empty or dot segment`)
+	require.Nil(resp)
+}
+
 func (s *Suite) TestAttestFailure() {
 	require := s.Require()
 
@@ -246,6 +223,17 @@ func (s *Suite) TestConfigure() {
 	s.errorContains(err, "x509pop: unable to load trust bundle")
 	require.Nil(resp)
 
+	// bad agent path template
+	resp, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+		ca_bundle_path = %q
+		agent_path_template = "{{ .Fingerprint "
+		`, fixture.Join("nodeattestor", "x509pop", "root-crt.pem")),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.errorContains(err, "x509pop: failed to parse agent path template")
+	require.Nil(resp)
+
 }
 
 func (s *Suite) TestGetPluginInfo() {
@@ -258,6 +246,65 @@ func (s *Suite) TestGetPluginInfo() {
 	require.Equal(resp, &plugin.GetPluginInfoResponse{})
 }
 
+func (s *Suite) configure(extraConfig string) {
+	rootCertPath := fixture.Join("nodeattestor", "x509pop", "root-crt.pem")
+	resp, err := s.p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+ca_bundle_path = %q
+%s`, rootCertPath, extraConfig),
+		GlobalConfig: &plugin.ConfigureRequest_GlobalConfig{TrustDomain: "example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *Suite) requireAttestSuccess() *nodeattestor.AttestResponse {
+	resp, err := s.attestWithChallengeResponse()
+	s.Require().NoError(err)
+	s.True(resp.Valid)
+	return resp
+}
+
+func (s *Suite) attestWithChallengeResponse() (*nodeattestor.AttestResponse, error) {
+	require := s.Require()
+
+	stream, done := s.attest()
+	defer done()
+
+	// send down good attestation data
+	attestationData := &x509pop.AttestationData{
+		Certificates: s.leafBundle,
+	}
+	err := stream.Send(&nodeattestor.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "x509pop",
+			Data: s.marshal(attestationData),
+		},
+	})
+	require.NoError(err)
+
+	// receive and parse challenge
+	resp, err := stream.Recv()
+	require.NoError(err)
+	require.Equal("", resp.BaseSPIFFEID)
+	s.False(resp.Valid)
+	s.NotEmpty(resp.Challenge)
+
+	challenge := new(x509pop.Challenge)
+	s.unmarshal(resp.Challenge, challenge)
+
+	// calculate and send the response
+	response, err := x509pop.CalculateResponse(s.leafKey, challenge)
+	require.NoError(err)
+	err = stream.Send(&nodeattestor.AttestRequest{
+		Response: s.marshal(response),
+	})
+	require.NoError(err)
+
+	// receive the attestation result
+	return stream.Recv()
+}
+
 func (s *Suite) attest() (nodeattestor.Attest_Stream, func()) {
 	stream, err := s.p.Attest(context.Background())
 	s.Require().NoError(err)
@@ -8,14 +8,13 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/hashicorp/hcl"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	caws "github.com/spiffe/spire/pkg/common/plugin/aws"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
@@ -50,8 +49,9 @@ type awsClient interface {
 }
 
 type IIDResolverConfig struct {
-	AccessKeyID     string `hcl:"access_key_id"`
-	SecretAccessKey string `hcl:"secret_access_key"`
+	AccessKeyID     string            `hcl:"access_key_id"`
+	SecretAccessKey string            `hcl:"secret_access_key"`
+	AssumeRoleARNs  map[string]string `hcl:"assume_role_arns"`
 }
 
 type IIDResolverPlugin struct {
@@ -61,7 +61,7 @@ type IIDResolverPlugin struct {
 
 	hooks struct {
 		getenv    func(string) string
-		newClient func(config *IIDResolverConfig, region string) (awsClient, error)
+		newClient func(config *IIDResolverConfig, accountID, region string) (awsClient, error)
 	}
 }
 
@@ -99,6 +99,12 @@ func (p *IIDResolverPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		return nil, iidError.New("configuration missing both access key id and secret access key")
 	}
 
+	for accountID, roleARN := range config.AssumeRoleARNs {
+		if roleARN == "" {
+			return nil, iidError.New("configuration missing role ARN to assume for account %q", accountID)
+		}
+	}
+
 	// set the AWS configuration and reset clients
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -126,13 +132,13 @@ func (p *IIDResolverPlugin) Resolve(ctx context.Context, req *noderesolver.Resol
 }
 
 func (p *IIDResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string) (*common.Selectors, error) {
-	_, region, instanceID, err := parseAgentID(spiffeID)
+	accountID, region, instanceID, err := parseAgentID(spiffeID)
 	if err != nil {
 		logrus.Warnf("unrecognized Agent ID: %s: %v", spiffeID, err)
 		return nil, nil
 	}
 
-	client, err := p.getRegionClient(region)
+	client, err := p.getClient(accountID, region)
 	if err != nil {
 		return nil, err
 	}
@@ -181,10 +187,14 @@ func (p *IIDResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 	return selectors, nil
 }
 
-func (p *IIDResolverPlugin) getRegionClient(region string) (awsClient, error) {
-	// do an initial check to see if p client for this region already exists
+func (p *IIDResolverPlugin) getClient(accountID, region string) (awsClient, error) {
+	// clients are keyed by account and region since the role assumed (if
+	// any) depends on the account.
+	key := accountID + "/" + region
+
+	// do an initial check to see if p client for this key already exists
 	p.mu.RLock()
-	client, ok := p.clients[region]
+	client, ok := p.clients[key]
 	p.mu.RUnlock()
 	if ok {
 		return client, nil
@@ -197,7 +207,7 @@ func (p *IIDResolverPlugin) getRegionClient(region string) (awsClient, error) {
 	// more than one thread could be racing to create p client (since we had
 	// to drop the read lock to take the write lock), so double check somebody
 	// hasn't beat us to it.
-	client, ok = p.clients[region]
+	client, ok = p.clients[key]
 	if ok {
 		return client, nil
 	}
@@ -206,12 +216,12 @@ func (p *IIDResolverPlugin) getRegionClient(region string) (awsClient, error) {
 		return nil, iidError.New("not configured")
 	}
 
-	client, err := p.hooks.newClient(p.config, region)
+	client, err := p.hooks.newClient(p.config, accountID, region)
 	if err != nil {
 		return nil, err
 	}
 
-	p.clients[region] = client
+	p.clients[key] = client
 	return client, nil
 }
 
@@ -263,12 +273,8 @@ func parseAgentID(spiffeID string) (accountID, region, instanceId string, err er
 	return m[1], m[2], m[3], nil
 }
 
-func newAWSClient(config *IIDResolverConfig, region string) (awsClient, error) {
-	conf := aws.NewConfig()
-	conf.Credentials = credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, "")
-	conf.Region = aws.String(region)
-
-	sess, err := session.NewSession(conf)
+func newAWSClient(config *IIDResolverConfig, accountID, region string) (awsClient, error) {
+	sess, err := caws.NewSession(config.AccessKeyID, config.SecretAccessKey, region, config.AssumeRoleARNs[accountID])
 	if err != nil {
 		return nil, iidError.Wrap(err)
 	}
@@ -178,6 +178,30 @@ func (s *IIDResolverSuite) TestConfigure() {
 	delete(s.env, "AWS_ACCESS_KEY_ID")
 	delete(s.env, "AWS_SECRET_ACCESS_KEY")
 
+	// fails with an empty role to assume
+	resp, err = s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		access_key_id = "ACCESSKEYID"
+		secret_access_key = "SECRETACCESSKEY"
+		assume_role_arns = {
+			"ACCOUNT" = ""
+		}
+		`})
+	s.Require().EqualError(err, `aws-iid: configuration missing role ARN to assume for account "ACCOUNT"`)
+	s.Require().Nil(resp)
+
+	// success with roles to assume
+	resp, err = s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		access_key_id = "ACCESSKEYID"
+		secret_access_key = "SECRETACCESSKEY"
+		assume_role_arns = {
+			"ACCOUNT" = "arn:aws:iam::ACCOUNT:role/spire-server"
+		}
+		`})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+
 	// success with access id/secret credentials
 	s.configureResolver()
 }
@@ -193,8 +217,9 @@ func (s *IIDResolverSuite) newResolver() {
 	resolver.hooks.getenv = func(key string) string {
 		return s.env[key]
 	}
-	resolver.hooks.newClient = func(config *IIDResolverConfig, region string) (awsClient, error) {
-		// assert that the right region is specified
+	resolver.hooks.newClient = func(config *IIDResolverConfig, accountID, region string) (awsClient, error) {
+		// assert that the right account and region are specified
+		s.Require().Equal("ACCOUNT", accountID)
 		s.Require().Equal("REGION", region)
 
 		// assert that the credentials are populated correctly
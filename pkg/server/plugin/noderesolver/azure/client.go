@@ -3,20 +3,28 @@ package azure
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/zeebo/errs"
 )
 
+// virtualMachineScaleSetAPIVersion is the first compute API version that
+// returns the scale set a virtual machine belongs to. The compute SDK in use
+// predates it, so the scale set is fetched with a request of its own.
+const virtualMachineScaleSetAPIVersion = "2019-03-01"
+
 // apiClient is an interface representing all of the API methods the resolver
 // needs to do its job.
 type apiClient interface {
 	SubscriptionID() string
 	GetVirtualMachineResourceID(ctx context.Context, principalID string) (string, error)
 	GetVirtualMachine(ctx context.Context, resourceGroup string, name string) (*compute.VirtualMachine, error)
+	GetVirtualMachineScaleSetID(ctx context.Context, resourceGroup string, name string) (string, error)
 	GetNetworkInterface(ctx context.Context, resourceGroup string, name string) (*network.Interface, error)
 }
 
@@ -50,8 +58,11 @@ func (c *azureClient) SubscriptionID() string {
 	return c.subscriptionID
 }
 
+// GetVirtualMachineResourceID returns the ID of the virtual machine, or of the
+// virtual machine scale set, the principal belongs to. Scale sets are looked up
+// so that agents using their shared identity can be told apart.
 func (c *azureClient) GetVirtualMachineResourceID(ctx context.Context, principalID string) (string, error) {
-	filter := fmt.Sprintf("resourceType eq 'Microsoft.Compute/virtualMachines' and identity/principalId eq '%s'", principalID)
+	filter := fmt.Sprintf("(resourceType eq 'Microsoft.Compute/virtualMachines' or resourceType eq 'Microsoft.Compute/virtualMachineScaleSets') and identity/principalId eq '%s'", principalID)
 	result, err := c.r.List(ctx, filter, "", nil)
 	if err != nil {
 		return "", errs.Wrap(err)
@@ -80,6 +91,52 @@ func (c *azureClient) GetVirtualMachine(ctx context.Context, resourceGroup strin
 	return &vm, nil
 }
 
+// GetVirtualMachineScaleSetID returns the ID of the scale set the virtual
+// machine belongs to, or an empty string if it does not belong to one.
+func (c *azureClient) GetVirtualMachineScaleSetID(ctx context.Context, resourceGroup string, name string) (string, error) {
+	pathParameters := map[string]interface{}{
+		"resourceGroupName": autorest.Encode("path", resourceGroup),
+		"subscriptionId":    autorest.Encode("path", c.subscriptionID),
+		"vmName":            autorest.Encode("path", name),
+	}
+	queryParameters := map[string]interface{}{
+		"api-version": virtualMachineScaleSetAPIVersion,
+	}
+	req, err := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(c.v.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Compute/virtualMachines/{vmName}", pathParameters),
+		autorest.WithQueryParameters(queryParameters)).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+
+	resp, err := autorest.SendWithSender(c.v, req,
+		autorest.DoRetryForStatusCodes(c.v.RetryAttempts, c.v.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+
+	var vm struct {
+		Properties struct {
+			VirtualMachineScaleSet *compute.SubResource `json:"virtualMachineScaleSet"`
+		} `json:"properties"`
+	}
+	if err := autorest.Respond(
+		resp,
+		c.v.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&vm),
+		autorest.ByClosing()); err != nil {
+		return "", errs.Wrap(err)
+	}
+
+	if vm.Properties.VirtualMachineScaleSet == nil || vm.Properties.VirtualMachineScaleSet.ID == nil {
+		return "", nil
+	}
+	return *vm.Properties.VirtualMachineScaleSet.ID, nil
+}
+
 func (c *azureClient) GetNetworkInterface(ctx context.Context, resourceGroup string, name string) (*network.Interface, error) {
 	ni, err := c.n.Get(ctx, resourceGroup, name, "")
 	if err != nil {
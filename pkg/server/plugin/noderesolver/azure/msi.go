@@ -31,11 +31,12 @@ const (
 var (
 	msiError = errs.Class("azure-msi")
 
-	reAgentIDPath            = regexp.MustCompile(`^/spire/agent/azure_msi/([^/]+)/([^/]+)`)
-	reVirtualMachineID       = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Compute/virtualMachines/([^/]+)$`)
-	reNetworkSecurityGroupID = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkSecurityGroups/([^/]+)$`)
-	reNetworkInterfaceID     = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkInterfaces/([^/]+)$`)
-	reVirtualNetworkSubnetID = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/virtualNetworks/([^/]+)/subnets/([^/]+)$`)
+	reAgentIDPath              = regexp.MustCompile(`^/spire/agent/azure_msi/([^/]+)/([^/]+)`)
+	reVirtualMachineID         = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Compute/virtualMachines/([^/]+)$`)
+	reVirtualMachineScaleSetID = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Compute/virtualMachineScaleSets/([^/]+)$`)
+	reNetworkSecurityGroupID   = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkSecurityGroups/([^/]+)$`)
+	reNetworkInterfaceID       = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/networkInterfaces/([^/]+)$`)
+	reVirtualNetworkSubnetID   = regexp.MustCompile(`^/subscriptions/[^/]+/resourceGroups/([^/]+)/providers/Microsoft.Network/virtualNetworks/([^/]+)/subnets/([^/]+)$`)
 )
 
 type TenantConfig struct {
@@ -45,14 +46,16 @@ type TenantConfig struct {
 }
 
 type MSIResolverConfig struct {
-	UseMSI  bool                    `hcl:"use_msi" json:"use_msi"`
-	Tenants map[string]TenantConfig `hcl:"tenants" json:"tenants"`
+	UseMSI       bool                    `hcl:"use_msi" json:"use_msi"`
+	Tenants      map[string]TenantConfig `hcl:"tenants" json:"tenants"`
+	TagAllowlist []string                `hcl:"tag_allowlist" json:"tag_allowlist"`
 }
 
 type MSIResolverPlugin struct {
 	mu            sync.RWMutex
 	msiClient     apiClient
 	tenantClients map[string]apiClient
+	tagAllowlist  map[string]bool
 
 	hooks struct {
 		newClient             func(string, autorest.Authorizer) apiClient
@@ -131,7 +134,15 @@ func (p *MSIResolverPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		}
 	}
 
-	p.setClients(msiClient, tenantClients)
+	tagAllowlist := make(map[string]bool)
+	for _, key := range config.TagAllowlist {
+		if key == "" {
+			return nil, msiError.New("tag allowlist cannot contain an empty key")
+		}
+		tagAllowlist[key] = true
+	}
+
+	p.setConfig(msiClient, tenantClients, tagAllowlist)
 	return &spi.ConfigureResponse{}, nil
 }
 
@@ -139,29 +150,30 @@ func (p *MSIResolverPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoReq
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
-func (p *MSIResolverPlugin) getClient(tenantID string) (apiClient, error) {
+func (p *MSIResolverPlugin) getClient(tenantID string) (apiClient, map[string]bool, error) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
 	switch {
 	case p.msiClient != nil:
-		return p.msiClient, nil
+		return p.msiClient, p.tagAllowlist, nil
 	case p.tenantClients != nil:
 		client, ok := p.tenantClients[tenantID]
 		if !ok {
-			return nil, msiError.New("not configured for tenant %q", tenantID)
+			return nil, nil, msiError.New("not configured for tenant %q", tenantID)
 		}
-		return client, nil
+		return client, p.tagAllowlist, nil
 	default:
-		return nil, msiError.New("not configured")
+		return nil, nil, msiError.New("not configured")
 	}
 }
 
-func (p *MSIResolverPlugin) setClients(msiClient apiClient, tenantClients map[string]apiClient) {
+func (p *MSIResolverPlugin) setConfig(msiClient apiClient, tenantClients map[string]apiClient, tagAllowlist map[string]bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.msiClient = msiClient
 	p.tenantClients = tenantClients
+	p.tagAllowlist = tagAllowlist
 }
 
 func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string) (*common.Selectors, error) {
@@ -177,28 +189,21 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 		return nil, nil
 	}
 
-	client, err := p.getClient(tenantID)
+	client, tagAllowlist, err := p.getClient(tenantID)
 	if err != nil {
 		return nil, err
 	}
 
 	// Retrieve the resource belonging to the principal id.
-	vmResourceID, err := client.GetVirtualMachineResourceID(ctx, principalID)
+	resourceID, err := client.GetVirtualMachineResourceID(ctx, principalID)
 	if err != nil {
 		return nil, msiError.New("unable to get resource for principal %q: %v", principalID, err)
 	}
 
-	// parse out the resource group and vm name from the resource ID
-	vmResourceGroup, vmName, err := parseVirtualMachineID(vmResourceID)
-	if err != nil {
-		return nil, err
-	}
-
 	// build up a unique map of selectors. this is easier than deduping
 	// individual selectors (e.g. the virtual network for each interface)
 	selectorMap := map[string]bool{
 		selectorValue("subscription-id", client.SubscriptionID()): true,
-		selectorValue("vm-name", vmResourceGroup, vmName):         true,
 	}
 	addSelectors := func(values []string) {
 		for _, value := range values {
@@ -206,18 +211,19 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 		}
 	}
 
-	// pull the VM information and gather selectors
-	vm, err := client.GetVirtualMachine(ctx, vmResourceGroup, vmName)
-	if err != nil {
-		return nil, msiError.New("unable to get virtual machine %q: %v", resourceGroupName(vmResourceGroup, vmName), err)
+	// the system assigned identity of a virtual machine scale set is shared by
+	// all of its instances, so the agent ID derived from it does not identify
+	// a single node. Only the first instance could ever attest, and it would
+	// be indistinguishable from the others, so such agents are rejected.
+	if vmssResourceGroup, vmssName, err := parseVirtualMachineScaleSetID(resourceID); err == nil {
+		return nil, msiError.New("principal %q is the identity of virtual machine scale set %q, which is shared by all of its instances", principalID, resourceGroupName(vmssResourceGroup, vmssName))
 	}
-	if vm.NetworkProfile != nil {
-		networkProfileSelectors, err := getNetworkProfileSelectors(ctx, client, vm.NetworkProfile)
-		if err != nil {
-			return nil, err
-		}
-		addSelectors(networkProfileSelectors)
+
+	vmSelectors, err := getVirtualMachineSelectors(ctx, client, resourceID, tagAllowlist)
+	if err != nil {
+		return nil, err
 	}
+	addSelectors(vmSelectors)
 
 	// sort and return selectors
 	selectorValues := make([]string, 0, len(selectorMap))
@@ -237,6 +243,66 @@ func (p *MSIResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string
 	return selectors, nil
 }
 
+func getVirtualMachineSelectors(ctx context.Context, client apiClient, resourceID string, tagAllowlist map[string]bool) ([]string, error) {
+	// parse out the resource group and vm name from the resource ID
+	vmResourceGroup, vmName, err := parseVirtualMachineID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// pull the VM information and gather selectors
+	vm, err := client.GetVirtualMachine(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return nil, msiError.New("unable to get virtual machine %q: %v", resourceGroupName(vmResourceGroup, vmName), err)
+	}
+
+	selectors := []string{
+		selectorValue("resource-group", vmResourceGroup),
+		selectorValue("vm-name", vmResourceGroup, vmName),
+	}
+	selectors = append(selectors, getTagSelectors(vm.Tags, tagAllowlist)...)
+
+	// virtual machines in a scale set with their own identity can be told
+	// apart, and are selected by the scale set they belong to.
+	vmssID, err := client.GetVirtualMachineScaleSetID(ctx, vmResourceGroup, vmName)
+	if err != nil {
+		return nil, msiError.New("unable to get scale set of virtual machine %q: %v", resourceGroupName(vmResourceGroup, vmName), err)
+	}
+	if vmssID != "" {
+		vmssResourceGroup, vmssName, err := parseVirtualMachineScaleSetID(vmssID)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selectorValue("vm-scale-set", vmssResourceGroup, vmssName))
+	}
+	if vm.VirtualMachineProperties != nil && vm.NetworkProfile != nil {
+		networkProfileSelectors, err := getNetworkProfileSelectors(ctx, client, vm.NetworkProfile)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, networkProfileSelectors...)
+	}
+	return selectors, nil
+}
+
+// getTagSelectors returns selectors for the resource tags whose keys are in
+// the allowlist. Tags are opt-in since they can be changed by anyone able to
+// manage the resource.
+func getTagSelectors(tags map[string]*string, tagAllowlist map[string]bool) []string {
+	var selectors []string
+	for key, value := range tags {
+		if !tagAllowlist[key] {
+			continue
+		}
+		tagValue := ""
+		if value != nil {
+			tagValue = *value
+		}
+		selectors = append(selectors, selectorValue("tag", key, tagValue))
+	}
+	return selectors
+}
+
 func getNetworkProfileSelectors(ctx context.Context, client apiClient, networkProfile *compute.NetworkProfile) ([]string, error) {
 	if networkProfile.NetworkInterfaces == nil {
 		return nil, nil
@@ -311,6 +377,14 @@ func parseVirtualMachineID(id string) (resourceGroup, name string, err error) {
 	return m[1], m[2], nil
 }
 
+func parseVirtualMachineScaleSetID(id string) (resourceGroup, name string, err error) {
+	m := reVirtualMachineScaleSetID.FindStringSubmatch(id)
+	if m == nil {
+		return "", "", msiError.New("malformed virtual machine scale set ID %q", id)
+	}
+	return m[1], m[2], nil
+}
+
 func parseNetworkSecurityGroupID(id string) (resourceGroup, name string, err error) {
 	m := reNetworkSecurityGroupID.FindStringSubmatch(id)
 	if m == nil {
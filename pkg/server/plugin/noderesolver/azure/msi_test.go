@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
 	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/spiffe/spire/pkg/common/plugin/azure"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/common/plugin"
@@ -17,8 +18,9 @@ import (
 )
 
 const (
-	azureAgentID = "spiffe://example.org/spire/agent/azure_msi/TENANT/PRINCIPAL"
-	vmResourceID = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachines/VIRTUALMACHINE"
+	azureAgentID   = "spiffe://example.org/spire/agent/azure_msi/TENANT/PRINCIPAL"
+	vmResourceID   = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachines/VIRTUALMACHINE"
+	vmssResourceID = "/subscriptions/SUBSCRIPTIONID/resourceGroups/RESOURCEGROUP/providers/Microsoft.Compute/virtualMachineScaleSets/SCALESET"
 )
 
 var (
@@ -30,6 +32,7 @@ var (
 
 	// these are expected selectors
 	vmSelectors = []string{
+		"resource-group:RESOURCEGROUP",
 		"subscription-id:SUBSCRIPTION",
 		"vm-name:RESOURCEGROUP:VIRTUALMACHINE",
 	}
@@ -184,6 +187,58 @@ func (s *MSIResolverSuite) TestResolveVirtualMachine() {
 	s.assertResolveSuccess(vmSelectors, niSelectors)
 }
 
+func (s *MSIResolverSuite) TestResolveVirtualMachineTags() {
+	s.setVirtualMachine(&compute.VirtualMachine{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+		Tags: map[string]*string{
+			"env":   to.StringPtr("prod"),
+			"owner": to.StringPtr("alice"),
+			"role":  nil,
+		},
+	})
+
+	// tags are not resolved unless allowed
+	s.assertResolveSuccess(vmSelectors)
+
+	s.configureResolver(`
+		use_msi = true
+		tag_allowlist = ["env", "role", "unused"]
+	`)
+	s.assertResolveSuccess(vmSelectors, []string{
+		"tag:env:prod",
+		"tag:role:",
+	})
+}
+
+func (s *MSIResolverSuite) TestResolveVirtualMachineInScaleSet() {
+	s.setVirtualMachine(&compute.VirtualMachine{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{},
+	})
+
+	// malformed scale set ID
+	s.api.SetVirtualMachineScaleSetID("RESOURCEGROUP", "VIRTUALMACHINE", malformedResourceID)
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: malformed virtual machine scale set ID "MALFORMEDRESOURCEID"`)
+
+	// the virtual machine is selected by its scale set
+	s.api.SetVirtualMachineScaleSetID("RESOURCEGROUP", "VIRTUALMACHINE", vmssResourceID)
+	s.assertResolveSuccess(vmSelectors, []string{
+		"vm-scale-set:RESOURCEGROUP:SCALESET",
+	})
+
+	// failing to get the scale set fails resolution
+	s.api.vmScaleSetErr = errors.New("oh no")
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: unable to get scale set of virtual machine "RESOURCEGROUP:VIRTUALMACHINE": oh no`)
+}
+
+func (s *MSIResolverSuite) TestResolveVirtualMachineScaleSet() {
+	// the identity of a scale set is shared by all of its instances
+	s.api.SetVirtualMachineResourceID("PRINCIPAL", vmssResourceID)
+	s.assertResolveFailure(azureAgentID,
+		`azure-msi: principal "PRINCIPAL" is the identity of virtual machine scale set "RESOURCEGROUP:SCALESET", which is shared by all of its instances`)
+}
+
 func (s *MSIResolverSuite) TestConfigure() {
 	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
 		Configuration: "blah",
@@ -244,6 +299,15 @@ func (s *MSIResolverSuite) TestConfigure() {
 
 	// success using MSI configuration
 	s.configureResolverWithMSI()
+
+	// empty tag key
+	resp, err = s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+		use_msi = true
+		tag_allowlist = [""]
+		`})
+	s.Require().EqualError(err, "azure-msi: tag allowlist cannot contain an empty key")
+	s.Require().Nil(resp)
 }
 
 func (s *MSIResolverSuite) TestGetPluginInfo() {
@@ -266,8 +330,12 @@ func (s *MSIResolverSuite) configureResolverWithTenant() {
 }
 
 func (s *MSIResolverSuite) configureResolverWithMSI() {
+	s.configureResolver(`use_msi = true`)
+}
+
+func (s *MSIResolverSuite) configureResolver(config string) {
 	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
-		Configuration: `use_msi = true`,
+		Configuration: config,
 	})
 	s.Require().NoError(err)
 	s.Require().Equal(resp, &plugin.ConfigureResponse{})
@@ -344,6 +412,8 @@ type fakeAPIClient struct {
 
 	vmResourceIDs     map[string]string
 	virtualMachines   map[string]*compute.VirtualMachine
+	vmScaleSetIDs     map[string]string
+	vmScaleSetErr     error
 	networkInterfaces map[string]*network.Interface
 }
 
@@ -352,6 +422,7 @@ func newFakeAPIClient(t testing.TB) *fakeAPIClient {
 		t:                 t,
 		vmResourceIDs:     make(map[string]string),
 		virtualMachines:   make(map[string]*compute.VirtualMachine),
+		vmScaleSetIDs:     make(map[string]string),
 		networkInterfaces: make(map[string]*network.Interface),
 	}
 }
@@ -384,6 +455,17 @@ func (c *fakeAPIClient) GetVirtualMachine(ctx context.Context, resourceGroup str
 	return vm, nil
 }
 
+func (c *fakeAPIClient) SetVirtualMachineScaleSetID(resourceGroup string, name string, vmssID string) {
+	c.vmScaleSetIDs[resourceGroupName(resourceGroup, name)] = vmssID
+}
+
+func (c *fakeAPIClient) GetVirtualMachineScaleSetID(ctx context.Context, resourceGroup string, name string) (string, error) {
+	if c.vmScaleSetErr != nil {
+		return "", c.vmScaleSetErr
+	}
+	return c.vmScaleSetIDs[resourceGroupName(resourceGroup, name)], nil
+}
+
 func (c *fakeAPIClient) SetNetworkInterface(resourceGroup string, name string, ni *network.Interface) {
 	c.networkInterfaces[resourceGroupName(resourceGroup, name)] = ni
 }
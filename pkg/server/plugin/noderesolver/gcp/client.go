@@ -0,0 +1,226 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	computeEndpoint  = "https://www.googleapis.com/compute/v1/"
+	computeScope     = "https://www.googleapis.com/auth/compute.readonly"
+	metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// computeInstance holds the subset of the Compute Engine instance resource
+// the resolver needs to do its job.
+type computeInstance struct {
+	ID                string             `json:"id"`
+	Name              string             `json:"name"`
+	Zone              string             `json:"zone"`
+	Labels            map[string]string  `json:"labels"`
+	Tags              instanceTags       `json:"tags"`
+	NetworkInterfaces []networkInterface `json:"networkInterfaces"`
+	ServiceAccounts   []serviceAccount   `json:"serviceAccounts"`
+}
+
+type instanceTags struct {
+	Items []string `json:"items"`
+}
+
+type networkInterface struct {
+	Network    string `json:"network"`
+	Subnetwork string `json:"subnetwork"`
+}
+
+type serviceAccount struct {
+	Email string `json:"email"`
+}
+
+// instanceRef identifies an instance within a project, either by ID or, when
+// the agent ID does not contain it, by name and optionally zone.
+type instanceRef struct {
+	ProjectID string
+	ID        string
+	Name      string
+	Zone      string
+}
+
+func (r instanceRef) matches(instance *computeInstance) bool {
+	if r.ID != "" {
+		return instance.ID == r.ID
+	}
+	return instance.Name == r.Name && (r.Zone == "" || path.Base(instance.Zone) == r.Zone)
+}
+
+func (r instanceRef) String() string {
+	if r.ID != "" {
+		return r.ID
+	}
+	if r.Zone != "" {
+		return path.Join(r.Zone, r.Name)
+	}
+	return r.Name
+}
+
+// apiClient is an interface representing all of the API methods the resolver
+// needs to do its job.
+type apiClient interface {
+	GetInstance(ctx context.Context, ref instanceRef) (*computeInstance, error)
+}
+
+// computeClient implements apiClient against the Compute Engine REST API
+type computeClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newComputeClient(endpoint string, httpClient *http.Client) apiClient {
+	return &computeClient{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}
+}
+
+// GetInstance looks up the instance across all zones of the project, since
+// the zone is not necessarily part of the agent ID.
+func (c *computeClient) GetInstance(ctx context.Context, ref instanceRef) (*computeInstance, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	u.Path = path.Join(u.Path, "projects", ref.ProjectID, "aggregated", "instances")
+	filter := fmt.Sprintf("id = %s", ref.ID)
+	if ref.ID == "" {
+		filter = fmt.Sprintf("name = %s", ref.Name)
+	}
+	u.RawQuery = url.Values{
+		"filter": []string{filter},
+	}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, errs.New("unexpected status code %d looking up instance: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	list := new(struct {
+		Items map[string]struct {
+			Instances []*computeInstance `json:"instances"`
+		} `json:"items"`
+	})
+	if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+		return nil, errs.New("unable to decode instance list: %v", err)
+	}
+
+	var found *computeInstance
+	for _, scope := range list.Items {
+		for _, instance := range scope.Instances {
+			if !ref.matches(instance) {
+				continue
+			}
+			if found != nil {
+				// instance names are only unique within a zone
+				return nil, errs.New("more than one instance %q found in project %q", ref, ref.ProjectID)
+			}
+			found = instance
+		}
+	}
+	if found == nil {
+		return nil, errs.New("instance %q not found in project %q", ref, ref.ProjectID)
+	}
+	return found, nil
+}
+
+// newHTTPClient returns an HTTP client authenticated for the Compute Engine
+// API, either with the given service account key file, or with the default
+// service account of the instance the server runs on.
+func newHTTPClient(ctx context.Context, serviceAccountFile string) (*http.Client, error) {
+	if serviceAccountFile == "" {
+		ts := oauth2.ReuseTokenSource(nil, metadataTokenSource{})
+		return oauth2.NewClient(ctx, ts), nil
+	}
+
+	data, err := ioutil.ReadFile(serviceAccountFile)
+	if err != nil {
+		return nil, errs.New("unable to read service account file: %v", err)
+	}
+
+	key := new(struct {
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	})
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, errs.New("unable to parse service account file: %v", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" || key.TokenURI == "" {
+		return nil, errs.New("service account file is missing client_email, private_key or token_uri")
+	}
+
+	config := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		TokenURL:     key.TokenURI,
+		Scopes:       []string{computeScope},
+	}
+	return config.Client(ctx), nil
+}
+
+// metadataTokenSource fetches access tokens for the default service account
+// from the GCE metadata server.
+type metadataTokenSource struct{}
+
+func (metadataTokenSource) Token() (*oauth2.Token, error) {
+	req, err := http.NewRequest("GET", metadataTokenURL, nil)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errs.New("unable to fetch token from metadata server: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errs.New("unexpected status code %d fetching token from metadata server", resp.StatusCode)
+	}
+
+	token := new(struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		TokenType   string `json:"token_type"`
+	})
+	if err := json.NewDecoder(resp.Body).Decode(token); err != nil {
+		return nil, errs.New("unable to decode token from metadata server: %v", err)
+	}
+
+	return &oauth2.Token{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
@@ -0,0 +1,290 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/hashicorp/hcl"
+	"github.com/sirupsen/logrus"
+	"github.com/zeebo/errs"
+
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/plugin/gcp"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/common"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/noderesolver"
+)
+
+var (
+	iitError = errs.Class("gcp-iit")
+)
+
+type IITResolverConfig struct {
+	ServiceAccountFile string `hcl:"service_account_file"`
+	AgentPathTemplate  string `hcl:"agent_path_template"`
+}
+
+type IITResolverPlugin struct {
+	mu      sync.RWMutex
+	client  apiClient
+	matcher *agentIDMatcher
+
+	hooks struct {
+		computeEndpoint string
+		newHTTPClient   func(ctx context.Context, serviceAccountFile string) (*http.Client, error)
+	}
+}
+
+var _ noderesolver.Plugin = (*IITResolverPlugin)(nil)
+
+func NewIITResolverPlugin() *IITResolverPlugin {
+	p := &IITResolverPlugin{}
+	p.hooks.computeEndpoint = computeEndpoint
+	p.hooks.newHTTPClient = newHTTPClient
+	return p
+}
+
+func (p *IITResolverPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	config := new(IITResolverConfig)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, iitError.New("unable to decode configuration: %v", err)
+	}
+
+	// the template must match the one of the gcp_iit node attestor, so the
+	// instance can be found from the agent ID.
+	tmpl := gcp.DefaultAgentPathTemplate
+	if len(config.AgentPathTemplate) > 0 {
+		var err error
+		tmpl, err = template.New("agent-path").Parse(config.AgentPathTemplate)
+		if err != nil {
+			return nil, iitError.New("failed to parse agent path template: %q", config.AgentPathTemplate)
+		}
+	}
+	matcher, err := newAgentIDMatcher(tmpl)
+	if err != nil {
+		return nil, iitError.Wrap(err)
+	}
+
+	// the client outlives the configure call, so it must not be bound to
+	// the request context.
+	httpClient, err := p.hooks.newHTTPClient(context.Background(), config.ServiceAccountFile)
+	if err != nil {
+		return nil, iitError.Wrap(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.client = newComputeClient(p.hooks.computeEndpoint, httpClient)
+	p.matcher = matcher
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (p *IITResolverPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *IITResolverPlugin) Resolve(ctx context.Context, req *noderesolver.ResolveRequest) (*noderesolver.ResolveResponse, error) {
+	resp := &noderesolver.ResolveResponse{
+		Map: make(map[string]*common.Selectors),
+	}
+	for _, spiffeID := range req.BaseSpiffeIdList {
+		selectors, err := p.resolveSpiffeID(ctx, spiffeID)
+		if err != nil {
+			return nil, err
+		}
+		if selectors != nil {
+			resp.Map[spiffeID] = selectors
+		}
+	}
+	return resp, nil
+}
+
+func (p *IITResolverPlugin) resolveSpiffeID(ctx context.Context, spiffeID string) (*common.Selectors, error) {
+	client, matcher, err := p.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := matcher.match(spiffeID)
+	if err != nil {
+		logrus.Warnf("unrecognized Agent ID: %s: %v", spiffeID, err)
+		return nil, nil
+	}
+
+	instance, err := client.GetInstance(ctx, ref)
+	if err != nil {
+		return nil, iitError.Wrap(err)
+	}
+
+	selectorSet := map[string]bool{}
+	addSelectors := func(values []string) {
+		for _, value := range values {
+			selectorSet[value] = true
+		}
+	}
+
+	addSelectors(resolveZone(instance.Zone))
+	addSelectors(resolveLabels(instance.Labels))
+	addSelectors(resolveTags(instance.Tags.Items))
+	for _, networkInterface := range instance.NetworkInterfaces {
+		addSelectors(resolveNetwork(networkInterface.Network, networkInterface.Subnetwork))
+	}
+	for _, serviceAccount := range instance.ServiceAccounts {
+		addSelectors(resolveServiceAccount(serviceAccount.Email))
+	}
+
+	// build and sort selectors
+	selectors := new(common.Selectors)
+	for value := range selectorSet {
+		selectors.Entries = append(selectors.Entries, &common.Selector{
+			Type:  gcp.PluginName,
+			Value: value,
+		})
+	}
+	util.SortSelectors(selectors.Entries)
+
+	return selectors, nil
+}
+
+func (p *IITResolverPlugin) getClient() (apiClient, *agentIDMatcher, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.client == nil {
+		return nil, nil, iitError.New("not configured")
+	}
+	return p.client, p.matcher, nil
+}
+
+func resolveZone(zone string) []string {
+	if zone == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("zone:%s", path.Base(zone))}
+}
+
+func resolveLabels(labels map[string]string) []string {
+	values := make([]string, 0, len(labels))
+	for key, value := range labels {
+		values = append(values, fmt.Sprintf("label:%s:%s", key, value))
+	}
+	return values
+}
+
+func resolveTags(tags []string) []string {
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, fmt.Sprintf("tag:%s", tag))
+	}
+	return values
+}
+
+func resolveNetwork(network, subnetwork string) []string {
+	var values []string
+	if network != "" {
+		values = append(values, fmt.Sprintf("network:%s", path.Base(network)))
+	}
+	if subnetwork != "" {
+		values = append(values, fmt.Sprintf("subnetwork:%s", path.Base(subnetwork)))
+	}
+	return values
+}
+
+func resolveServiceAccount(email string) []string {
+	if email == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("sa:%s", email)}
+}
+
+// agentIDMatcher extracts the instance from the agent IDs produced by an agent
+// path template. The template is rendered with a placeholder for each field of
+// the instance, which are then turned into the groups of a regular expression.
+type agentIDMatcher struct {
+	re *regexp.Regexp
+}
+
+// agentIDPlaceholders hold the placeholders for the instance fields. The
+// numeric fields use sentinel values since they are not used to look up the
+// instance.
+var agentIDPlaceholders = gcp.ComputeEngine{
+	ProjectID:                 "{project_id}",
+	ProjectNumber:             987654321012345678,
+	Zone:                      "{zone}",
+	InstanceID:                "{instance_id}",
+	InstanceName:              "{instance_name}",
+	InstanceCreationTimestamp: 876543210123456789,
+}
+
+func newAgentIDMatcher(tmpl *template.Template) (*agentIDMatcher, error) {
+	u, err := gcp.MakeSpiffeID("example.org", tmpl, agentIDPlaceholders)
+	if err != nil {
+		return nil, errs.New("failed to execute agent path template: %v", err)
+	}
+
+	expr := regexp.QuoteMeta("/" + strings.TrimPrefix(u.Path, "/"))
+	for _, number := range []int64{agentIDPlaceholders.ProjectNumber, agentIDPlaceholders.InstanceCreationTimestamp} {
+		expr = strings.Replace(expr, strconv.FormatInt(number, 10), `[0-9]+`, -1)
+	}
+	for _, placeholder := range []string{
+		agentIDPlaceholders.ProjectID,
+		agentIDPlaceholders.Zone,
+		agentIDPlaceholders.InstanceID,
+		agentIDPlaceholders.InstanceName,
+	} {
+		quoted := regexp.QuoteMeta(placeholder)
+		name := strings.Trim(placeholder, "{}")
+		// only the first occurrence of a field is captured
+		expr = strings.Replace(expr, quoted, fmt.Sprintf(`(?P<%s>[^/]+)`, name), 1)
+		expr = strings.Replace(expr, quoted, `[^/]+`, -1)
+	}
+
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, errs.New("unable to match agent IDs with the agent path template: %v", err)
+	}
+	m := &agentIDMatcher{re: re}
+	if m.group("project_id") < 0 || (m.group("instance_id") < 0 && m.group("instance_name") < 0) {
+		return nil, errs.New("agent path template must contain the project ID and either the instance ID or name")
+	}
+	return m, nil
+}
+
+func (m *agentIDMatcher) match(spiffeID string) (instanceRef, error) {
+	u, err := idutil.ParseSpiffeID(spiffeID, idutil.AllowAnyTrustDomainAgent())
+	if err != nil {
+		return instanceRef{}, errs.New("unable to parse agent id %q: %v", spiffeID, err)
+	}
+	values := m.re.FindStringSubmatch(u.Path)
+	if values == nil {
+		return instanceRef{}, errs.New("malformed agent id %q", spiffeID)
+	}
+	value := func(name string) string {
+		if i := m.group(name); i >= 0 {
+			return values[i]
+		}
+		return ""
+	}
+	return instanceRef{
+		ProjectID: value("project_id"),
+		ID:        value("instance_id"),
+		Name:      value("instance_name"),
+		Zone:      value("zone"),
+	}, nil
+}
+
+func (m *agentIDMatcher) group(name string) int {
+	for i, subexpName := range m.re.SubexpNames() {
+		if subexpName == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,331 @@
+package gcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/noderesolver"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	gcpAgentID = "spiffe://example.org/spire/agent/gcp_iit/PROJECT/1234567890"
+)
+
+func TestIITResolver(t *testing.T) {
+	suite.Run(t, new(IITResolverSuite))
+}
+
+type IITResolverSuite struct {
+	suite.Suite
+
+	dir      string
+	server   *httptest.Server
+	status   int
+	filter   string
+	instance *computeInstance
+	resolver *noderesolver.BuiltIn
+}
+
+func (s *IITResolverSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "gcp-iit-resolver-")
+	s.Require().NoError(err)
+	s.dir = dir
+
+	s.status = http.StatusOK
+	s.filter = "id = 1234567890"
+	s.instance = nil
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveCompute))
+	s.newResolver()
+	s.configureResolver()
+}
+
+func (s *IITResolverSuite) TearDownTest() {
+	s.server.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *IITResolverSuite) TestResolveWhenNotConfigured() {
+	s.newResolver()
+	s.assertResolveFailure(gcpAgentID, "gcp-iit: not configured")
+}
+
+func (s *IITResolverSuite) TestResolve() {
+	// nothing to resolve
+	resp, err := s.resolver.Resolve(context.Background(), &noderesolver.ResolveRequest{})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Empty(resp.Map)
+
+	// not an agent ID
+	resp, err = s.doResolve("spiffe://example.org/spire/server")
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Empty(resp.Map)
+
+	// not an IIT-based agent ID
+	resp, err = s.doResolve("spiffe://example.org/spire/agent/whatever")
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Empty(resp.Map)
+
+	// instance w/o any metadata
+	s.instance = &computeInstance{ID: "1234567890"}
+	s.assertResolveSuccess()
+
+	// instance with zone, labels, tags, network and service accounts
+	s.instance = &computeInstance{
+		ID:     "1234567890",
+		Zone:   "https://www.googleapis.com/compute/v1/projects/PROJECT/zones/us-west1-a",
+		Labels: map[string]string{"env": "prod", "app": "blog"},
+		Tags: instanceTags{
+			Items: []string{"http-server", "https-server"},
+		},
+		NetworkInterfaces: []networkInterface{
+			{
+				Network:    "https://www.googleapis.com/compute/v1/projects/PROJECT/global/networks/default",
+				Subnetwork: "https://www.googleapis.com/compute/v1/projects/PROJECT/regions/us-west1/subnetworks/default",
+			},
+		},
+		ServiceAccounts: []serviceAccount{
+			{Email: "blog@PROJECT.iam.gserviceaccount.com"},
+		},
+	}
+	s.assertResolveSuccess(
+		"zone:us-west1-a",
+		"label:app:blog",
+		"label:env:prod",
+		"tag:http-server",
+		"tag:https-server",
+		"network:default",
+		"subnetwork:default",
+		"sa:blog@PROJECT.iam.gserviceaccount.com",
+	)
+}
+
+func (s *IITResolverSuite) TestResolveWithAgentPathTemplate() {
+	s.configureResolverWithTemplate("{{ .PluginName }}/{{ .ProjectID }}/{{ .InstanceName }}")
+	s.filter = "name = blog"
+	agentID := "spiffe://example.org/spire/agent/gcp_iit/PROJECT/blog"
+
+	// agent IDs that do not match the template are not recognized
+	resp, err := s.doResolve("spiffe://example.org/spire/agent/gcp_iit/PROJECT/us-west1-a/blog")
+	s.Require().NoError(err)
+	s.Require().Empty(resp.Map)
+
+	// the instance is looked up by name
+	s.instance = &computeInstance{
+		ID:   "1234567890",
+		Name: "blog",
+		Zone: "https://www.googleapis.com/compute/v1/projects/PROJECT/zones/us-west1-a",
+	}
+	resp, err = s.doResolve(agentID)
+	s.Require().NoError(err)
+	s.Require().Equal(&noderesolver.ResolveResponse{
+		Map: map[string]*common.Selectors{
+			agentID: {
+				Entries: []*common.Selector{{Type: "gcp_iit", Value: "zone:us-west1-a"}},
+			},
+		},
+	}, resp)
+
+	// the zone narrows down the instance when the template contains it
+	s.configureResolverWithTemplate("{{ .ProjectID }}/{{ .Zone }}/{{ .InstanceName }}")
+	resp, err = s.doResolve("spiffe://example.org/spire/agent/PROJECT/us-west1-b/blog")
+	s.requireErrorContains(err, `gcp-iit: instance "us-west1-b/blog" not found in project "PROJECT"`)
+	s.Require().Nil(resp)
+	resp, err = s.doResolve("spiffe://example.org/spire/agent/PROJECT/us-west1-a/blog")
+	s.Require().NoError(err)
+	s.Require().Len(resp.Map, 1)
+}
+
+func (s *IITResolverSuite) TestResolveInstanceNotFound() {
+	s.instance = &computeInstance{ID: "0987654321"}
+	s.assertResolveFailure(gcpAgentID, `gcp-iit: instance "1234567890" not found in project "PROJECT"`)
+}
+
+func (s *IITResolverSuite) TestResolveComputeFailure() {
+	s.status = http.StatusForbidden
+	s.assertResolveFailure(gcpAgentID, "gcp-iit: unexpected status code 403 looking up instance")
+}
+
+func (s *IITResolverSuite) TestConfigure() {
+	// malformed configuration
+	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: "blah",
+	})
+	s.requireErrorContains(err, "gcp-iit: unable to decode configuration")
+	s.Require().Nil(resp)
+
+	// malformed agent path template
+	resp, err = s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `agent_path_template = "{{ .ProjectID "`,
+	})
+	s.requireErrorContains(err, "gcp-iit: failed to parse agent path template")
+	s.Require().Nil(resp)
+
+	// agent path template the instance cannot be found with
+	resp, err = s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `agent_path_template = "{{ .ProjectID }}/{{ .Zone }}"`,
+	})
+	s.requireErrorContains(err, "gcp-iit: agent path template must contain the project ID and either the instance ID or name")
+	s.Require().Nil(resp)
+
+	// success with default credentials
+	s.configureResolver()
+}
+
+func (s *IITResolverSuite) TestNewHTTPClient() {
+	// missing service account file
+	_, err := newHTTPClient(context.Background(), filepath.Join(s.dir, "missing.json"))
+	s.requireErrorContains(err, "unable to read service account file")
+
+	// malformed service account file
+	path := s.writeFile("malformed.json", []byte("{"))
+	_, err = newHTTPClient(context.Background(), path)
+	s.requireErrorContains(err, "unable to parse service account file")
+
+	// incomplete service account file
+	path = s.writeFile("incomplete.json", []byte(`{"client_email": "blog@PROJECT.iam.gserviceaccount.com"}`))
+	_, err = newHTTPClient(context.Background(), path)
+	s.Require().EqualError(err, "service account file is missing client_email, private_key or token_uri")
+
+	// valid service account file
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	data, err := json.Marshal(map[string]string{
+		"client_email": "blog@PROJECT.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    "https://oauth2.googleapis.com/token",
+	})
+	s.Require().NoError(err)
+	path = s.writeFile("valid.json", data)
+	client, err := newHTTPClient(context.Background(), path)
+	s.Require().NoError(err)
+	s.Require().NotNil(client)
+
+	// default credentials
+	client, err = newHTTPClient(context.Background(), "")
+	s.Require().NoError(err)
+	s.Require().NotNil(client)
+}
+
+func (s *IITResolverSuite) TestGetPluginInfo() {
+	resp, err := s.resolver.GetPluginInfo(context.Background(), &plugin.GetPluginInfoRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.GetPluginInfoResponse{})
+}
+
+func (s *IITResolverSuite) serveCompute(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/projects/PROJECT/aggregated/instances" {
+		http.Error(w, "unexpected path", http.StatusNotFound)
+		return
+	}
+	if filter := req.URL.Query().Get("filter"); filter != s.filter {
+		http.Error(w, "unexpected filter", http.StatusBadRequest)
+		return
+	}
+	if s.status != http.StatusOK {
+		http.Error(w, "oh no", s.status)
+		return
+	}
+
+	type instancesScopedList struct {
+		Instances []*computeInstance `json:"instances,omitempty"`
+	}
+	list := map[string]map[string]instancesScopedList{
+		"items": {
+			"zones/us-west1-a": {},
+		},
+	}
+	if s.instance != nil {
+		list["items"]["zones/us-west1-b"] = instancesScopedList{
+			Instances: []*computeInstance{s.instance},
+		}
+	}
+	s.Require().NoError(json.NewEncoder(w).Encode(list))
+}
+
+func (s *IITResolverSuite) newResolver() {
+	resolver := NewIITResolverPlugin()
+	resolver.hooks.computeEndpoint = s.server.URL
+	resolver.hooks.newHTTPClient = func(ctx context.Context, serviceAccountFile string) (*http.Client, error) {
+		s.Require().Equal("", serviceAccountFile)
+		return s.server.Client(), nil
+	}
+	s.resolver = noderesolver.NewBuiltIn(resolver)
+}
+
+func (s *IITResolverSuite) configureResolver() {
+	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *IITResolverSuite) configureResolverWithTemplate(agentPathTemplate string) {
+	resp, err := s.resolver.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf("agent_path_template = %q", agentPathTemplate),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(resp, &plugin.ConfigureResponse{})
+}
+
+func (s *IITResolverSuite) writeFile(name string, data []byte) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func (s *IITResolverSuite) requireErrorContains(err error, contains string) {
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), contains)
+}
+
+func (s *IITResolverSuite) assertResolveSuccess(selectorValues ...string) {
+	sort.Strings(selectorValues)
+	selectors := &common.Selectors{}
+	for _, selectorValue := range selectorValues {
+		selectors.Entries = append(selectors.Entries, &common.Selector{
+			Type:  "gcp_iit",
+			Value: selectorValue,
+		})
+	}
+	expected := &noderesolver.ResolveResponse{
+		Map: map[string]*common.Selectors{
+			gcpAgentID: selectors,
+		},
+	}
+	actual, err := s.doResolve(gcpAgentID)
+	s.Require().NoError(err)
+	s.Require().Equal(expected, actual)
+}
+
+func (s *IITResolverSuite) assertResolveFailure(spiffeID, containsErr string) {
+	resp, err := s.doResolve(spiffeID)
+	s.requireErrorContains(err, containsErr)
+	s.Require().Nil(resp)
+}
+
+func (s *IITResolverSuite) doResolve(spiffeID string) (*noderesolver.ResolveResponse, error) {
+	return s.resolver.Resolve(context.Background(), &noderesolver.ResolveRequest{
+		BaseSpiffeIdList: []string{spiffeID},
+	})
+}
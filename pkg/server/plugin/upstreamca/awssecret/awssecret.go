@@ -17,6 +17,8 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/upstreamca"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -106,6 +108,17 @@ func (m *awssecretPlugin) SubmitCSR(ctx context.Context, request *upstreamca.Sub
 	}, nil
 }
 
+// StreamBundleUpdates is not supported; the upstream bundle is static.
+func (*awssecretPlugin) StreamBundleUpdates(*upstreamca.StreamBundleUpdatesRequest, upstreamca.StreamBundleUpdates_PluginStream) error {
+	return status.Error(codes.Unimplemented, "bundle update streaming is not supported")
+}
+
+// PublishJWTKey is not supported; there is no upstream trust domain bundle
+// to publish to.
+func (*awssecretPlugin) PublishJWTKey(context.Context, *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "publishing JWT keys is not supported")
+}
+
 func New() upstreamca.Plugin {
 	return newPlugin(newSecretsManagerClient)
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/spiffe/spire/pkg/common/x509util"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/upstreamca"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Configuration struct {
@@ -111,6 +113,17 @@ func (m *diskPlugin) SubmitCSR(ctx context.Context, request *upstreamca.SubmitCS
 	}, nil
 }
 
+// StreamBundleUpdates is not supported; the upstream bundle is static.
+func (*diskPlugin) StreamBundleUpdates(*upstreamca.StreamBundleUpdatesRequest, upstreamca.StreamBundleUpdates_PluginStream) error {
+	return status.Error(codes.Unimplemented, "bundle update streaming is not supported")
+}
+
+// PublishJWTKey is not supported; there is no upstream trust domain bundle
+// to publish to.
+func (*diskPlugin) PublishJWTKey(context.Context, *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "publishing JWT keys is not supported")
+}
+
 func New() (m upstreamca.Plugin) {
 	return &diskPlugin{
 		serialNumber: x509util.NewSerialNumber(),
@@ -13,6 +13,8 @@ import (
 	"github.com/spiffe/spire/proto/server/upstreamca"
 	testutil "github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -77,6 +79,24 @@ func TestDisk_GetPluginInfo(t *testing.T) {
 	require.NotNil(t, res)
 }
 
+func TestDisk_StreamBundleUpdatesUnimplemented(t *testing.T) {
+	m, err := newWithDefault("_test_data/keys/EC/private_key.pem", "_test_data/keys/EC/cert.pem")
+	require.NoError(t, err)
+
+	stream, err := upstreamca.NewBuiltIn(m).StreamBundleUpdates(ctx, &upstreamca.StreamBundleUpdatesRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestDisk_PublishJWTKeyUnimplemented(t *testing.T) {
+	m, err := newWithDefault("_test_data/keys/EC/private_key.pem", "_test_data/keys/EC/cert.pem")
+	require.NoError(t, err)
+
+	_, err = m.PublishJWTKey(ctx, &upstreamca.PublishJWTKeyRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
 func TestDisk_SubmitValidCSR(t *testing.T) {
 	m, err := newWithDefault("_test_data/keys/EC/private_key.pem", "_test_data/keys/EC/cert.pem")
 
@@ -6,14 +6,22 @@ import (
 	"errors"
 	"net/url"
 	"sync"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hashicorp/hcl"
 	"github.com/spiffe/spire/proto/api/node"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 	"google.golang.org/grpc/credentials"
 )
 
+const (
+	// how often the upstream bundle is fetched while streaming bundle updates
+	defaultBundlePollInterval = 30 * time.Second
+)
+
 type Configuration struct {
 	ServerAddr        string `hcl:"server_address" json:"server_address"`
 	ServerPort        string `hcl:"server_port" json:"server_port"`
@@ -26,6 +34,11 @@ type spirePlugin struct {
 
 	trustDomain url.URL
 	config      *Configuration
+
+	hooks struct {
+		fetchBundle        func(context.Context) (*common.Bundle, error)
+		bundlePollInterval time.Duration
+	}
 }
 
 func (m *spirePlugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
@@ -88,8 +101,98 @@ func (m *spirePlugin) SubmitCSR(ctx context.Context, request *upstreamca.SubmitC
 	}, nil
 }
 
+// StreamBundleUpdates polls the upstream SPIRE server for its trust bundle
+// and sends it every time it changes (including the first time it is
+// fetched).
+func (m *spirePlugin) StreamBundleUpdates(request *upstreamca.StreamBundleUpdatesRequest, stream upstreamca.StreamBundleUpdates_PluginStream) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(m.hooks.bundlePollInterval)
+	defer ticker.Stop()
+
+	var current *common.Bundle
+	for {
+		bundle, err := m.hooks.fetchBundle(ctx)
+		if err != nil {
+			return err
+		}
+		if current == nil || !proto.Equal(current, bundle) {
+			if err := stream.Send(&upstreamca.StreamBundleUpdatesResponse{
+				UpstreamBundle: bundle,
+			}); err != nil {
+				return err
+			}
+			current = bundle
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// PublishJWTKey publishes the JWT signing key to the upstream SPIRE server
+// and returns the JWT signing keys of the upstream trust domain.
+func (m *spirePlugin) PublishJWTKey(ctx context.Context, req *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if req.JwtKey == nil {
+		return nil, errors.New("request missing JWT key")
+	}
+
+	wCert, wKey, wBundle, err := m.getWorkloadSVID(ctx, m.config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := m.newNodeClientConn(ctx, wCert, wKey, wBundle)
+	if err != nil {
+		return nil, err
+	}
+	nodeClient := node.NewNodeClient(conn)
+	defer conn.Close()
+
+	jwtKeys, err := m.pushJWTKeyUpstream(ctx, nodeClient, req.JwtKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &upstreamca.PublishJWTKeyResponse{
+		UpstreamJwtKeys: jwtKeys,
+	}, nil
+}
+
+func (m *spirePlugin) fetchBundle(ctx context.Context) (*common.Bundle, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	wCert, wKey, wBundle, err := m.getWorkloadSVID(ctx, m.config)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := m.newNodeClientConn(ctx, wCert, wKey, wBundle)
+	if err != nil {
+		return nil, err
+	}
+	nodeClient := node.NewNodeClient(conn)
+	defer conn.Close()
+
+	return m.fetchBundleUpstream(ctx, nodeClient)
+}
+
 func New() (m upstreamca.Plugin) {
-	return &spirePlugin{}
+	return newPlugin()
+}
+
+func newPlugin() *spirePlugin {
+	p := &spirePlugin{}
+	p.hooks.fetchBundle = p.fetchBundle
+	p.hooks.bundlePollInterval = defaultBundlePollInterval
+	return p
 }
 
 func certificatesDER(certs []*x509.Certificate) (der []byte) {
@@ -16,6 +16,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/api/node"
+	"github.com/spiffe/spire/proto/common"
 )
 
 func (m *spirePlugin) submitCSRUpstreamCA(ctx context.Context, nodeClient node.NodeClient, csr []byte) ([]*x509.Certificate, *bundleutil.Bundle, error) {
@@ -42,6 +43,46 @@ func (m *spirePlugin) submitCSRUpstreamCA(ctx context.Context, nodeClient node.N
 	return m.getCertFromResponse(nodeResponse)
 }
 
+// fetchBundleUpstream fetches the trust bundle from the upstream SPIRE server
+// by sending an update request without CSRs.
+func (m *spirePlugin) fetchBundleUpstream(ctx context.Context, nodeClient node.NodeClient) (*common.Bundle, error) {
+	stream, err := nodeClient.FetchX509SVID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&node.FetchX509SVIDRequest{}); err != nil {
+		return nil, err
+	}
+
+	stream.CloseSend()
+
+	nodeResponse, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeResponse.SvidUpdate == nil {
+		return nil, errors.New("response missing svid update")
+	}
+	bundle := nodeResponse.SvidUpdate.Bundles[m.trustDomain.String()]
+	if bundle == nil {
+		return nil, errors.New("missing bundle")
+	}
+	return bundle, nil
+}
+
+// pushJWTKeyUpstream publishes the JWT signing key to the upstream SPIRE
+// server and returns the resulting JWT signing keys of the upstream bundle.
+func (m *spirePlugin) pushJWTKeyUpstream(ctx context.Context, nodeClient node.NodeClient, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+	resp, err := nodeClient.PushJWTKeyUpstream(ctx, &node.PushJWTKeyUpstreamRequest{
+		JwtKey: jwtKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.JwtSigningKeys, nil
+}
+
 func (m *spirePlugin) getCertFromResponse(response *node.FetchX509SVIDResponse) ([]*x509.Certificate, *bundleutil.Bundle, error) {
 	if response.SvidUpdate == nil {
 		return nil, nil, errors.New("response missing svid update")
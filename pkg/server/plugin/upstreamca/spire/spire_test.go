@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/x509svid"
@@ -23,6 +24,7 @@ import (
 	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/upstreamca"
+	mock_node "github.com/spiffe/spire/test/mock/proto/api/node"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -238,6 +240,12 @@ func (h *handler) Attest(stream node_pb.Node_AttestServer) (err error) {
 	return errors.New("NOT IMPLEMENTED")
 }
 
+func (h *handler) PushJWTKeyUpstream(ctx context.Context, req *node_pb.PushJWTKeyUpstreamRequest) (*node_pb.PushJWTKeyUpstreamResponse, error) {
+	return &node_pb.PushJWTKeyUpstreamResponse{
+		JwtSigningKeys: []*common.PublicKey{req.JwtKey},
+	}, nil
+}
+
 func TestSpirePlugin_Configure(t *testing.T) {
 	pluginConfig := &spi.ConfigureRequest{
 		Configuration: config,
@@ -324,3 +332,103 @@ func newWithDefault(addr string, socketPath string) (upstreamca.Plugin, error) {
 	_, err = m.Configure(ctx, pluginConfig)
 	return m, err
 }
+
+func TestSpirePlugin_StreamBundleUpdates(t *testing.T) {
+	bundle1 := &common.Bundle{
+		TrustDomainId:  "spiffe://localhost",
+		RootCas:        []*common.Certificate{{DerBytes: []byte("ROOT1")}},
+		JwtSigningKeys: []*common.PublicKey{{Kid: "KID1"}},
+	}
+	bundle2 := &common.Bundle{
+		TrustDomainId:  "spiffe://localhost",
+		RootCas:        []*common.Certificate{{DerBytes: []byte("ROOT1")}, {DerBytes: []byte("ROOT2")}},
+		JwtSigningKeys: []*common.PublicKey{{Kid: "KID1"}},
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the bundle is only sent when it changes. once all of the bundles have
+	// been fetched, the stream is canceled.
+	fetches := []*common.Bundle{bundle1, bundle1, bundle2, bundle2}
+	m := newPlugin()
+	m.hooks.bundlePollInterval = time.Millisecond
+	m.hooks.fetchBundle = func(context.Context) (*common.Bundle, error) {
+		bundle := fetches[0]
+		fetches = fetches[1:]
+		if len(fetches) == 0 {
+			cancel()
+		}
+		return bundle, nil
+	}
+
+	stream := &fakeBundleUpdatesStream{ctx: ctx}
+	require.NoError(t, m.StreamBundleUpdates(&upstreamca.StreamBundleUpdatesRequest{}, stream))
+	require.Equal(t, []*upstreamca.StreamBundleUpdatesResponse{
+		{UpstreamBundle: bundle1},
+		{UpstreamBundle: bundle2},
+	}, stream.sent)
+}
+
+func TestSpirePlugin_StreamBundleUpdatesFailsIfFetchFails(t *testing.T) {
+	m := newPlugin()
+	m.hooks.fetchBundle = func(context.Context) (*common.Bundle, error) {
+		return nil, errors.New("oh no")
+	}
+
+	stream := &fakeBundleUpdatesStream{ctx: ctx}
+	require.EqualError(t, m.StreamBundleUpdates(&upstreamca.StreamBundleUpdatesRequest{}, stream), "oh no")
+	require.Empty(t, stream.sent)
+}
+
+func TestSpirePlugin_PublishJWTKeyRequiresKey(t *testing.T) {
+	m := newPlugin()
+	_, err := m.PublishJWTKey(ctx, &upstreamca.PublishJWTKeyRequest{})
+	require.EqualError(t, err, "request missing JWT key")
+}
+
+func TestSpirePlugin_PushJWTKeyUpstream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	jwtKey := &common.PublicKey{Kid: "KID2"}
+	upstreamJWTKeys := []*common.PublicKey{{Kid: "KID1"}, jwtKey}
+
+	nodeClient := mock_node.NewMockNodeClient(mockCtrl)
+	nodeClient.EXPECT().PushJWTKeyUpstream(gomock.Any(), &node_pb.PushJWTKeyUpstreamRequest{
+		JwtKey: jwtKey,
+	}).Return(&node_pb.PushJWTKeyUpstreamResponse{
+		JwtSigningKeys: upstreamJWTKeys,
+	}, nil)
+
+	m := newPlugin()
+	jwtKeys, err := m.pushJWTKeyUpstream(ctx, nodeClient, jwtKey)
+	require.NoError(t, err)
+	require.Equal(t, upstreamJWTKeys, jwtKeys)
+}
+
+func TestSpirePlugin_PushJWTKeyUpstreamFails(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	nodeClient := mock_node.NewMockNodeClient(mockCtrl)
+	nodeClient.EXPECT().PushJWTKeyUpstream(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+
+	m := newPlugin()
+	_, err := m.pushJWTKeyUpstream(ctx, nodeClient, &common.PublicKey{Kid: "KID"})
+	require.EqualError(t, err, "oh no")
+}
+
+type fakeBundleUpdatesStream struct {
+	ctx  context.Context
+	sent []*upstreamca.StreamBundleUpdatesResponse
+}
+
+func (s *fakeBundleUpdatesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeBundleUpdatesStream) Send(resp *upstreamca.StreamBundleUpdatesResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
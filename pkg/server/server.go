@@ -23,6 +23,7 @@ import (
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/endpoints"
 	"github.com/spiffe/spire/pkg/server/svid"
+	common_pb "github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"google.golang.org/grpc"
 )
@@ -80,6 +81,17 @@ type Config struct {
 
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
+
+	// CAKeyType is the key type used for the X509 CA signing keys
+	CAKeyType common_pb.KeyType
+
+	// JWTKeyType is the key type used for the JWT signing keys
+	JWTKeyType common_pb.KeyType
+
+	// NodeAttestorCIDRs holds, by node attestor name, the networks agents
+	// are allowed to attest from. Node attestors without an entry are not
+	// restricted.
+	NodeAttestorCIDRs map[string][]*net.IPNet
 }
 
 type Server struct {
@@ -154,7 +166,7 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics)
+	endpointsServer := s.newEndpointsServer(cat, svidRotator, caManager, metrics)
 
 	err = util.RunTasks(ctx,
 		caManager.Run,
@@ -239,6 +251,8 @@ func (s *Server) newCAManager(ctx context.Context, catalog catalog.Catalog, metr
 		SVIDTTL:        s.config.SVIDTTL,
 		CATTL:          s.config.CATTL,
 		CASubject:      s.config.CASubject,
+		CAKeyType:      s.config.CAKeyType,
+		JWTKeyType:     s.config.JWTKeyType,
 		CertsPath:      s.caCertsPath(),
 	})
 	if err := caManager.Initialize(ctx); err != nil {
@@ -260,16 +274,19 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidRotator svid.Rotator, serverCA ca.ServerCA, metrics telemetry.Metrics) endpoints.Server {
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidRotator svid.Rotator, caManager ca.Manager, metrics telemetry.Metrics) endpoints.Server {
 	return endpoints.New(&endpoints.Config{
 		TCPAddr:     s.config.BindAddress,
 		UDSAddr:     s.config.BindUDSAddress,
 		SVIDStream:  svidRotator.Subscribe(),
 		TrustDomain: s.config.TrustDomain,
 		Catalog:     catalog,
-		ServerCA:    serverCA,
+		ServerCA:    caManager.CA(),
+		CAManager:   caManager,
 		Log:         s.config.Log.WithField("subsystem_name", "endpoints"),
 		Metrics:     metrics,
+
+		NodeAttestorCIDRs: s.config.NodeAttestorCIDRs,
 	})
 }
 
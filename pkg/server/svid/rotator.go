@@ -80,6 +80,12 @@ func (r *rotator) shouldRotate() bool {
 		return true
 	}
 
+	// rotate if the SVID was not signed by the CA currently in use (e.g.
+	// after the CA was rotated manually), since the old CA may be revoked.
+	if ca := r.c.ServerCA.X509CA(); ca != nil && s.SVID[0].CheckSignatureFrom(ca) != nil {
+		return true
+	}
+
 	ttl := s.SVID[0].NotAfter.Sub(r.hooks.now())
 	watermark := s.SVID[0].NotAfter.Sub(s.SVID[0].NotBefore) / 2
 
@@ -15,6 +15,7 @@ type KeyManager interface {
 	GenerateKeyPair(context.Context, *GenerateKeyPairRequest) (*GenerateKeyPairResponse, error)
 	StorePrivateKey(context.Context, *StorePrivateKeyRequest) (*StorePrivateKeyResponse, error)
 	FetchPrivateKey(context.Context, *FetchPrivateKeyRequest) (*FetchPrivateKeyResponse, error)
+	DeletePrivateKey(context.Context, *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error)
 }
 
 // Plugin is the interface implemented by plugin implementations
@@ -22,6 +23,7 @@ type Plugin interface {
 	GenerateKeyPair(context.Context, *GenerateKeyPairRequest) (*GenerateKeyPairResponse, error)
 	StorePrivateKey(context.Context, *StorePrivateKeyRequest) (*StorePrivateKeyResponse, error)
 	FetchPrivateKey(context.Context, *FetchPrivateKeyRequest) (*FetchPrivateKeyResponse, error)
+	DeletePrivateKey(context.Context, *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error)
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 }
@@ -62,6 +64,14 @@ func (b BuiltIn) FetchPrivateKey(ctx context.Context, req *FetchPrivateKeyReques
 	return resp, nil
 }
 
+func (b BuiltIn) DeletePrivateKey(ctx context.Context, req *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error) {
+	resp, err := b.plugin.DeletePrivateKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	resp, err := b.plugin.Configure(ctx, req)
 	if err != nil {
@@ -118,6 +128,9 @@ func (s *GRPCServer) StorePrivateKey(ctx context.Context, req *StorePrivateKeyRe
 func (s *GRPCServer) FetchPrivateKey(ctx context.Context, req *FetchPrivateKeyRequest) (*FetchPrivateKeyResponse, error) {
 	return s.Plugin.FetchPrivateKey(ctx, req)
 }
+func (s *GRPCServer) DeletePrivateKey(ctx context.Context, req *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error) {
+	return s.Plugin.DeletePrivateKey(ctx, req)
+}
 func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return s.Plugin.Configure(ctx, req)
 }
@@ -138,6 +151,9 @@ func (c *GRPCClient) StorePrivateKey(ctx context.Context, req *StorePrivateKeyRe
 func (c *GRPCClient) FetchPrivateKey(ctx context.Context, req *FetchPrivateKeyRequest) (*FetchPrivateKeyResponse, error) {
 	return c.client.FetchPrivateKey(ctx, req)
 }
+func (c *GRPCClient) DeletePrivateKey(ctx context.Context, req *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error) {
+	return c.client.DeletePrivateKey(ctx, req)
+}
 func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return c.client.Configure(ctx, req)
 }
@@ -6,6 +6,7 @@ package keymanager
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/spiffe/spire/proto/common"
 import plugin "github.com/spiffe/spire/proto/common/plugin"
 
 import (
@@ -39,18 +40,21 @@ type GetPluginInfoRequest = plugin.GetPluginInfoRequest
 // GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
 type GetPluginInfoResponse = plugin.GetPluginInfoResponse
 
-// * Represents an empty request
+// * Represents a request for a new key pair
 type GenerateKeyPairRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// * Type of the key pair. Key managers generate an EC P-256 key pair
+	// when unspecified, and may not support other types.
+	KeyType              common.KeyType `protobuf:"varint,1,opt,name=key_type,json=keyType,proto3,enum=spire.common.KeyType" json:"key_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GenerateKeyPairRequest) Reset()         { *m = GenerateKeyPairRequest{} }
 func (m *GenerateKeyPairRequest) String() string { return proto.CompactTextString(m) }
 func (*GenerateKeyPairRequest) ProtoMessage()    {}
 func (*GenerateKeyPairRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{0}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{0}
 }
 func (m *GenerateKeyPairRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateKeyPairRequest.Unmarshal(m, b)
@@ -70,6 +74,13 @@ func (m *GenerateKeyPairRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GenerateKeyPairRequest proto.InternalMessageInfo
 
+func (m *GenerateKeyPairRequest) GetKeyType() common.KeyType {
+	if m != nil {
+		return m.KeyType
+	}
+	return common.KeyType_UNSPECIFIED_KEY_TYPE
+}
+
 // * Represents a public and private key pair
 type GenerateKeyPairResponse struct {
 	// * Public key
@@ -85,7 +96,7 @@ func (m *GenerateKeyPairResponse) Reset()         { *m = GenerateKeyPairResponse
 func (m *GenerateKeyPairResponse) String() string { return proto.CompactTextString(m) }
 func (*GenerateKeyPairResponse) ProtoMessage()    {}
 func (*GenerateKeyPairResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{1}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{1}
 }
 func (m *GenerateKeyPairResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateKeyPairResponse.Unmarshal(m, b)
@@ -122,7 +133,10 @@ func (m *GenerateKeyPairResponse) GetPrivateKey() []byte {
 // * Represents a private key
 type StorePrivateKeyRequest struct {
 	// * Private key
-	PrivateKey           []byte   `protobuf:"bytes,1,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
+	PrivateKey []byte `protobuf:"bytes,1,opt,name=privateKey,proto3" json:"privateKey,omitempty"`
+	// * Identifier of the private key. Empty for the agent key. Keys with an
+	// identifier are PKCS#8 encoded.
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -132,7 +146,7 @@ func (m *StorePrivateKeyRequest) Reset()         { *m = StorePrivateKeyRequest{}
 func (m *StorePrivateKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*StorePrivateKeyRequest) ProtoMessage()    {}
 func (*StorePrivateKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{2}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{2}
 }
 func (m *StorePrivateKeyRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StorePrivateKeyRequest.Unmarshal(m, b)
@@ -159,6 +173,13 @@ func (m *StorePrivateKeyRequest) GetPrivateKey() []byte {
 	return nil
 }
 
+func (m *StorePrivateKeyRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 // * Represents an empty response
 type StorePrivateKeyResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -170,7 +191,7 @@ func (m *StorePrivateKeyResponse) Reset()         { *m = StorePrivateKeyResponse
 func (m *StorePrivateKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*StorePrivateKeyResponse) ProtoMessage()    {}
 func (*StorePrivateKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{3}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{3}
 }
 func (m *StorePrivateKeyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StorePrivateKeyResponse.Unmarshal(m, b)
@@ -190,8 +211,10 @@ func (m *StorePrivateKeyResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StorePrivateKeyResponse proto.InternalMessageInfo
 
-// * Represents an empty request
+// * Represents a request for a private key
 type FetchPrivateKeyRequest struct {
+	// * Identifier of the private key. Empty for the agent key.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -201,7 +224,7 @@ func (m *FetchPrivateKeyRequest) Reset()         { *m = FetchPrivateKeyRequest{}
 func (m *FetchPrivateKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchPrivateKeyRequest) ProtoMessage()    {}
 func (*FetchPrivateKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{4}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{4}
 }
 func (m *FetchPrivateKeyRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchPrivateKeyRequest.Unmarshal(m, b)
@@ -221,6 +244,13 @@ func (m *FetchPrivateKeyRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_FetchPrivateKeyRequest proto.InternalMessageInfo
 
+func (m *FetchPrivateKeyRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 // * Represents a private key
 type FetchPrivateKeyResponse struct {
 	// * Private key
@@ -234,7 +264,7 @@ func (m *FetchPrivateKeyResponse) Reset()         { *m = FetchPrivateKeyResponse
 func (m *FetchPrivateKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchPrivateKeyResponse) ProtoMessage()    {}
 func (*FetchPrivateKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_e9d4b64145aa1c10, []int{5}
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{5}
 }
 func (m *FetchPrivateKeyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchPrivateKeyResponse.Unmarshal(m, b)
@@ -261,6 +291,77 @@ func (m *FetchPrivateKeyResponse) GetPrivateKey() []byte {
 	return nil
 }
 
+// * Represents a request to delete a private key
+type DeletePrivateKeyRequest struct {
+	// * Identifier of the private key.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePrivateKeyRequest) Reset()         { *m = DeletePrivateKeyRequest{} }
+func (m *DeletePrivateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*DeletePrivateKeyRequest) ProtoMessage()    {}
+func (*DeletePrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{6}
+}
+func (m *DeletePrivateKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePrivateKeyRequest.Unmarshal(m, b)
+}
+func (m *DeletePrivateKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePrivateKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeletePrivateKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePrivateKeyRequest.Merge(dst, src)
+}
+func (m *DeletePrivateKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_DeletePrivateKeyRequest.Size(m)
+}
+func (m *DeletePrivateKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePrivateKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePrivateKeyRequest proto.InternalMessageInfo
+
+func (m *DeletePrivateKeyRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// * Represents an empty response
+type DeletePrivateKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeletePrivateKeyResponse) Reset()         { *m = DeletePrivateKeyResponse{} }
+func (m *DeletePrivateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*DeletePrivateKeyResponse) ProtoMessage()    {}
+func (*DeletePrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_keymanager_41656f9a7f608e49, []int{7}
+}
+func (m *DeletePrivateKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeletePrivateKeyResponse.Unmarshal(m, b)
+}
+func (m *DeletePrivateKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeletePrivateKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeletePrivateKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeletePrivateKeyResponse.Merge(dst, src)
+}
+func (m *DeletePrivateKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_DeletePrivateKeyResponse.Size(m)
+}
+func (m *DeletePrivateKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeletePrivateKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeletePrivateKeyResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*GenerateKeyPairRequest)(nil), "spire.agent.keymanager.GenerateKeyPairRequest")
 	proto.RegisterType((*GenerateKeyPairResponse)(nil), "spire.agent.keymanager.GenerateKeyPairResponse")
@@ -268,6 +369,8 @@ func init() {
 	proto.RegisterType((*StorePrivateKeyResponse)(nil), "spire.agent.keymanager.StorePrivateKeyResponse")
 	proto.RegisterType((*FetchPrivateKeyRequest)(nil), "spire.agent.keymanager.FetchPrivateKeyRequest")
 	proto.RegisterType((*FetchPrivateKeyResponse)(nil), "spire.agent.keymanager.FetchPrivateKeyResponse")
+	proto.RegisterType((*DeletePrivateKeyRequest)(nil), "spire.agent.keymanager.DeletePrivateKeyRequest")
+	proto.RegisterType((*DeletePrivateKeyResponse)(nil), "spire.agent.keymanager.DeletePrivateKeyResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -288,6 +391,11 @@ type KeyManagerClient interface {
 	StorePrivateKey(ctx context.Context, in *StorePrivateKeyRequest, opts ...grpc.CallOption) (*StorePrivateKeyResponse, error)
 	// * Returns the most recently stored private key. For use after node restarts.
 	FetchPrivateKey(ctx context.Context, in *FetchPrivateKeyRequest, opts ...grpc.CallOption) (*FetchPrivateKeyResponse, error)
+	// * Deletes the private key stored with an identifier. Deleting a key
+	// that does not exist is not an error. The agent only stores private keys
+	// with an identifier if the plugin implements this method, so plugins that
+	// predate identifiers keep working with the agent key alone.
+	DeletePrivateKey(ctx context.Context, in *DeletePrivateKeyRequest, opts ...grpc.CallOption) (*DeletePrivateKeyResponse, error)
 	// * Applies the plugin configuration and returns configuration errors.
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// * Returns the version and related metadata of the plugin.
@@ -329,6 +437,15 @@ func (c *keyManagerClient) FetchPrivateKey(ctx context.Context, in *FetchPrivate
 	return out, nil
 }
 
+func (c *keyManagerClient) DeletePrivateKey(ctx context.Context, in *DeletePrivateKeyRequest, opts ...grpc.CallOption) (*DeletePrivateKeyResponse, error) {
+	out := new(DeletePrivateKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.agent.keymanager.KeyManager/DeletePrivateKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyManagerClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := c.cc.Invoke(ctx, "/spire.agent.keymanager.KeyManager/Configure", in, out, opts...)
@@ -355,6 +472,11 @@ type KeyManagerServer interface {
 	StorePrivateKey(context.Context, *StorePrivateKeyRequest) (*StorePrivateKeyResponse, error)
 	// * Returns the most recently stored private key. For use after node restarts.
 	FetchPrivateKey(context.Context, *FetchPrivateKeyRequest) (*FetchPrivateKeyResponse, error)
+	// * Deletes the private key stored with an identifier. Deleting a key
+	// that does not exist is not an error. The agent only stores private keys
+	// with an identifier if the plugin implements this method, so plugins that
+	// predate identifiers keep working with the agent key alone.
+	DeletePrivateKey(context.Context, *DeletePrivateKeyRequest) (*DeletePrivateKeyResponse, error)
 	// * Applies the plugin configuration and returns configuration errors.
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// * Returns the version and related metadata of the plugin.
@@ -419,6 +541,24 @@ func _KeyManager_FetchPrivateKey_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeyManager_DeletePrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyManagerServer).DeletePrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.agent.keymanager.KeyManager/DeletePrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyManagerServer).DeletePrivateKey(ctx, req.(*DeletePrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _KeyManager_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -471,6 +611,10 @@ var _KeyManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchPrivateKey",
 			Handler:    _KeyManager_FetchPrivateKey_Handler,
 		},
+		{
+			MethodName: "DeletePrivateKey",
+			Handler:    _KeyManager_DeletePrivateKey_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _KeyManager_Configure_Handler,
@@ -484,30 +628,35 @@ var _KeyManager_serviceDesc = grpc.ServiceDesc{
 	Metadata: "keymanager.proto",
 }
 
-func init() { proto.RegisterFile("keymanager.proto", fileDescriptor_keymanager_e9d4b64145aa1c10) }
-
-var fileDescriptor_keymanager_e9d4b64145aa1c10 = []byte{
-	// 343 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0x9d, 0x88, 0xb0, 0xc7, 0x64, 0x92, 0xc3, 0x36, 0x87, 0x88, 0x14, 0x14, 0xf5, 0x90,
-	0x82, 0x5e, 0xe6, 0x55, 0xc1, 0x21, 0x43, 0x28, 0xf3, 0x20, 0xec, 0xd6, 0x95, 0xd7, 0x2e, 0xb8,
-	0x26, 0x31, 0x4d, 0x85, 0xfe, 0x4f, 0xfe, 0x91, 0xb2, 0x26, 0xdb, 0xb4, 0x6d, 0xd8, 0x4e, 0x85,
-	0xf7, 0xbd, 0xef, 0xfd, 0xf2, 0xbe, 0xa4, 0x70, 0xfa, 0x89, 0x45, 0x1a, 0xf2, 0x30, 0x41, 0x45,
-	0xa5, 0x12, 0x5a, 0x90, 0x5e, 0x26, 0x99, 0x42, 0x1a, 0x26, 0xc8, 0x35, 0xdd, 0xaa, 0xc3, 0x51,
-	0xc2, 0xf4, 0x22, 0x9f, 0xd3, 0x48, 0xa4, 0x7e, 0x26, 0x59, 0x1c, 0xa3, 0x5f, 0x76, 0xfa, 0xa5,
-	0xcd, 0x8f, 0x44, 0x9a, 0x0a, 0xee, 0xcb, 0x65, 0x9e, 0xb0, 0xf5, 0xc7, 0x4c, 0xf4, 0x06, 0xd0,
-	0x1b, 0x23, 0x47, 0x15, 0x6a, 0x9c, 0x60, 0x11, 0x84, 0x4c, 0x4d, 0xf1, 0x2b, 0xc7, 0x4c, 0x7b,
-	0x1f, 0xd0, 0xaf, 0x29, 0x99, 0x14, 0x3c, 0x43, 0x72, 0x0e, 0x6d, 0x99, 0xcf, 0x97, 0x2c, 0x9a,
-	0x60, 0x31, 0x68, 0x5d, 0xb6, 0x6e, 0x3a, 0xd3, 0x6d, 0x81, 0x5c, 0x00, 0x48, 0xc5, 0xbe, 0x8d,
-	0x6f, 0x70, 0x58, 0xca, 0x7f, 0x2a, 0xde, 0x08, 0x7a, 0xef, 0x5a, 0x28, 0x0c, 0x36, 0x25, 0x8b,
-	0xac, 0x38, 0x5b, 0x35, 0xe7, 0x19, 0xf4, 0x6b, 0x4e, 0x73, 0xa4, 0xd5, 0x1e, 0x2f, 0xa8, 0xa3,
-	0x45, 0x6d, 0xa8, 0xf7, 0x08, 0xfd, 0x9a, 0x62, 0xf7, 0xd8, 0xc1, 0xbb, 0xff, 0x39, 0x02, 0x98,
-	0x60, 0xf1, 0x66, 0x52, 0x26, 0x0a, 0xba, 0x95, 0x44, 0x08, 0xa5, 0xcd, 0x37, 0x42, 0x9b, 0x43,
-	0x1d, 0xfa, 0x7b, 0xf7, 0xdb, 0x23, 0x2a, 0xe8, 0x56, 0x56, 0x76, 0x33, 0x9b, 0x53, 0x75, 0x33,
-	0x1d, 0x59, 0xae, 0x98, 0x95, 0xc4, 0xdc, 0xcc, 0xe6, 0xd0, 0xdd, 0x4c, 0xd7, 0x55, 0xcc, 0xa0,
-	0xfd, 0x2c, 0x78, 0xcc, 0x92, 0x5c, 0x21, 0xb9, 0xb2, 0x6e, 0xf3, 0x6e, 0xa9, 0x7d, 0xb0, 0x1b,
-	0x7d, 0x0d, 0xb9, 0xde, 0xd5, 0x66, 0x67, 0xc7, 0x70, 0x32, 0x46, 0x1d, 0x94, 0xf2, 0x2b, 0x8f,
-	0x05, 0xb9, 0x6d, 0x34, 0xfe, 0xeb, 0x59, 0x33, 0xee, 0xf6, 0x69, 0x35, 0x9c, 0xa7, 0xce, 0x0c,
-	0xb6, 0x9b, 0x06, 0x07, 0xf3, 0xe3, 0xf2, 0x17, 0x7b, 0xf8, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x5f,
-	0xc0, 0xc2, 0xe6, 0xc8, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("keymanager.proto", fileDescriptor_keymanager_41656f9a7f608e49) }
+
+var fileDescriptor_keymanager_41656f9a7f608e49 = []byte{
+	// 426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x5b, 0x6b, 0xd4, 0x40,
+	0x14, 0x76, 0x17, 0xbc, 0xec, 0xa1, 0xb6, 0x65, 0xc0, 0xcd, 0x1a, 0x44, 0x24, 0xa0, 0xb4, 0x3e,
+	0xcc, 0xd4, 0xfa, 0xa2, 0xaf, 0x2a, 0x56, 0x0d, 0x42, 0x88, 0x82, 0xd0, 0x17, 0xc9, 0xa6, 0x27,
+	0xe9, 0xb0, 0x9b, 0xcc, 0x38, 0x99, 0x08, 0xf3, 0x7b, 0xfc, 0xa3, 0x42, 0x26, 0xd9, 0x74, 0x73,
+	0x61, 0xf3, 0x34, 0xe1, 0x9c, 0xef, 0x72, 0x2e, 0x33, 0x81, 0xd3, 0x0d, 0x9a, 0x2c, 0xca, 0xa3,
+	0x14, 0x15, 0x95, 0x4a, 0x68, 0x41, 0x96, 0x85, 0xe4, 0x0a, 0x69, 0x94, 0x62, 0xae, 0x69, 0x9b,
+	0x75, 0xdf, 0xa5, 0x5c, 0xdf, 0x96, 0x6b, 0x1a, 0x8b, 0x8c, 0x15, 0x92, 0x27, 0x09, 0xb2, 0x0a,
+	0xc9, 0x2a, 0x1a, 0x8b, 0x45, 0x96, 0x89, 0x9c, 0xc9, 0x6d, 0x99, 0xf2, 0xe6, 0xb0, 0x8a, 0xee,
+	0x9b, 0x49, 0x4c, 0x7b, 0x58, 0x8a, 0xf7, 0x0d, 0x96, 0x57, 0x98, 0xa3, 0x8a, 0x34, 0xfa, 0x68,
+	0x82, 0x88, 0xab, 0x10, 0xff, 0x94, 0x58, 0x68, 0x72, 0x01, 0x8f, 0x36, 0x68, 0x7e, 0x6b, 0x23,
+	0x71, 0x35, 0x7b, 0x31, 0x3b, 0x3b, 0xbe, 0x7c, 0x42, 0x6d, 0xc5, 0xb5, 0x80, 0x8f, 0xe6, 0xa7,
+	0x91, 0x18, 0x3e, 0xdc, 0xd8, 0x0f, 0xef, 0x17, 0x38, 0x3d, 0xad, 0x42, 0x8a, 0xbc, 0x40, 0xf2,
+	0x0c, 0x16, 0xb2, 0x5c, 0x6f, 0x79, 0xec, 0xa3, 0xa9, 0xd4, 0x8e, 0xc2, 0x36, 0x40, 0x9e, 0x03,
+	0x48, 0xc5, 0xff, 0x5a, 0xde, 0x6a, 0x5e, 0xa5, 0xef, 0x44, 0xbc, 0x2f, 0xb0, 0xfc, 0xa1, 0x85,
+	0xc2, 0x60, 0x17, 0x6a, 0x8a, 0xdc, 0x67, 0xce, 0xba, 0x4c, 0x72, 0x0c, 0x73, 0x7e, 0x53, 0x29,
+	0x2e, 0xc2, 0x39, 0xbf, 0xf1, 0x9e, 0x82, 0xd3, 0x53, 0xb2, 0x25, 0x7a, 0x67, 0xb0, 0xfc, 0x8c,
+	0x3a, 0xbe, 0xed, 0x9b, 0x58, 0x91, 0xd9, 0x4e, 0xe4, 0x3d, 0x38, 0x3d, 0x64, 0xdd, 0xe7, 0x81,
+	0x7a, 0xbc, 0x73, 0x70, 0x3e, 0xe1, 0x16, 0x35, 0x1e, 0x76, 0x71, 0x61, 0xd5, 0x87, 0x5a, 0x9b,
+	0xcb, 0x7f, 0xf7, 0x01, 0x7c, 0x34, 0xdf, 0xed, 0x8d, 0x21, 0x0a, 0x4e, 0x3a, 0x83, 0x27, 0x94,
+	0x0e, 0xdf, 0x2e, 0x3a, 0xbc, 0x6d, 0x97, 0x4d, 0xc6, 0xd7, 0x9d, 0x2a, 0x38, 0xe9, 0x4c, 0x72,
+	0xdc, 0x73, 0x78, 0x79, 0x2e, 0x9b, 0x8c, 0x6f, 0x3d, 0x3b, 0x83, 0x1f, 0xf7, 0x1c, 0xde, 0xa5,
+	0xcb, 0x26, 0xe3, 0x6b, 0xcf, 0x12, 0x4e, 0xbb, 0x6b, 0x20, 0xa3, 0x22, 0x23, 0xbb, 0x75, 0x2f,
+	0xa6, 0x13, 0x6a, 0xdb, 0x6b, 0x58, 0x7c, 0x14, 0x79, 0xc2, 0xd3, 0x52, 0x21, 0x79, 0xb9, 0xff,
+	0xf0, 0xea, 0x37, 0xbf, 0xcb, 0x37, 0x2e, 0xaf, 0x0e, 0xc1, 0x6a, 0xed, 0x04, 0x1e, 0x5f, 0xa1,
+	0x0e, 0xaa, 0xf4, 0xd7, 0x3c, 0x11, 0xe4, 0x7c, 0x90, 0xb8, 0x87, 0x69, 0x3c, 0x5e, 0x4f, 0x81,
+	0x5a, 0x9f, 0x0f, 0x47, 0xd7, 0xd0, 0xb6, 0x1a, 0xdc, 0x5b, 0x3f, 0xa8, 0x7e, 0x39, 0x6f, 0xff,
+	0x0f, 0x00, 0x1d, 0xbf, 0xd1, 0x03, 0x0b, 0x05, 0x00, 0x00,
 }
@@ -39,7 +39,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{0}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{0}
 }
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundle.Unmarshal(m, b)
@@ -92,7 +92,7 @@ func (m *X509SVID) Reset()         { *m = X509SVID{} }
 func (m *X509SVID) String() string { return proto.CompactTextString(m) }
 func (*X509SVID) ProtoMessage()    {}
 func (*X509SVID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{1}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{1}
 }
 func (m *X509SVID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_X509SVID.Unmarshal(m, b)
@@ -160,7 +160,7 @@ func (m *X509SVIDUpdate) Reset()         { *m = X509SVIDUpdate{} }
 func (m *X509SVIDUpdate) String() string { return proto.CompactTextString(m) }
 func (*X509SVIDUpdate) ProtoMessage()    {}
 func (*X509SVIDUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{2}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{2}
 }
 func (m *X509SVIDUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_X509SVIDUpdate.Unmarshal(m, b)
@@ -233,7 +233,7 @@ func (m *JSR) Reset()         { *m = JSR{} }
 func (m *JSR) String() string { return proto.CompactTextString(m) }
 func (*JSR) ProtoMessage()    {}
 func (*JSR) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{3}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{3}
 }
 func (m *JSR) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JSR.Unmarshal(m, b)
@@ -291,7 +291,7 @@ func (m *JWTSVID) Reset()         { *m = JWTSVID{} }
 func (m *JWTSVID) String() string { return proto.CompactTextString(m) }
 func (*JWTSVID) ProtoMessage()    {}
 func (*JWTSVID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{4}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{4}
 }
 func (m *JWTSVID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JWTSVID.Unmarshal(m, b)
@@ -339,17 +339,21 @@ type AttestRequest struct {
 	// Certificate signing request.
 	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
 	// Attestation challenge response
-	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Response []byte `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	// Attestation data from additional node attestors. The SPIFFE ID of the
+	// node is derived from attestation_data, while the selectors produced by
+	// every node attestor are combined.
+	AdditionalAttestationData []*common.AttestationData `protobuf:"bytes,4,rep,name=additional_attestation_data,json=additionalAttestationData,proto3" json:"additional_attestation_data,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                  `json:"-"`
+	XXX_unrecognized          []byte                    `json:"-"`
+	XXX_sizecache             int32                     `json:"-"`
 }
 
 func (m *AttestRequest) Reset()         { *m = AttestRequest{} }
 func (m *AttestRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestRequest) ProtoMessage()    {}
 func (*AttestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{5}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{5}
 }
 func (m *AttestRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestRequest.Unmarshal(m, b)
@@ -390,6 +394,13 @@ func (m *AttestRequest) GetResponse() []byte {
 	return nil
 }
 
+func (m *AttestRequest) GetAdditionalAttestationData() []*common.AttestationData {
+	if m != nil {
+		return m.AdditionalAttestationData
+	}
+	return nil
+}
+
 // Represents a response that contains  map of signed SVIDs and an array of
 // all current Registration Entries which are relevant to the caller SPIFFE ID
 type AttestResponse struct {
@@ -399,17 +410,21 @@ type AttestResponse struct {
 	// This is a challenge issued by the server to the node. If populated, the
 	// node is expected to respond with another AttestRequest with the response.
 	// This field is mutually exclusive with the update field.
-	Challenge            []byte   `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Challenge []byte `protobuf:"bytes,2,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Identifies the node attestor the challenge is issued for. Zero for the
+	// node attestor that provided attestation_data, otherwise the position
+	// (starting at one) of the corresponding additional_attestation_data.
+	ChallengeAttestorIndex uint32   `protobuf:"varint,3,opt,name=challenge_attestor_index,json=challengeAttestorIndex,proto3" json:"challenge_attestor_index,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *AttestResponse) Reset()         { *m = AttestResponse{} }
 func (m *AttestResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestResponse) ProtoMessage()    {}
 func (*AttestResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{6}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{6}
 }
 func (m *AttestResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestResponse.Unmarshal(m, b)
@@ -443,6 +458,13 @@ func (m *AttestResponse) GetChallenge() []byte {
 	return nil
 }
 
+func (m *AttestResponse) GetChallengeAttestorIndex() uint32 {
+	if m != nil {
+		return m.ChallengeAttestorIndex
+	}
+	return 0
+}
+
 // Represents a request with a list of CSR.
 type FetchX509SVIDRequest struct {
 	// A list of CSRs
@@ -456,7 +478,7 @@ func (m *FetchX509SVIDRequest) Reset()         { *m = FetchX509SVIDRequest{} }
 func (m *FetchX509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchX509SVIDRequest) ProtoMessage()    {}
 func (*FetchX509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{7}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{7}
 }
 func (m *FetchX509SVIDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchX509SVIDRequest.Unmarshal(m, b)
@@ -498,7 +520,7 @@ func (m *FetchX509SVIDResponse) Reset()         { *m = FetchX509SVIDResponse{} }
 func (m *FetchX509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchX509SVIDResponse) ProtoMessage()    {}
 func (*FetchX509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{8}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{8}
 }
 func (m *FetchX509SVIDResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchX509SVIDResponse.Unmarshal(m, b)
@@ -537,7 +559,7 @@ func (m *FetchJWTSVIDRequest) Reset()         { *m = FetchJWTSVIDRequest{} }
 func (m *FetchJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchJWTSVIDRequest) ProtoMessage()    {}
 func (*FetchJWTSVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{9}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{9}
 }
 func (m *FetchJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchJWTSVIDRequest.Unmarshal(m, b)
@@ -576,7 +598,7 @@ func (m *FetchJWTSVIDResponse) Reset()         { *m = FetchJWTSVIDResponse{} }
 func (m *FetchJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchJWTSVIDResponse) ProtoMessage()    {}
 func (*FetchJWTSVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_bac2d1ca7cb46d82, []int{10}
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{10}
 }
 func (m *FetchJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchJWTSVIDResponse.Unmarshal(m, b)
@@ -603,6 +625,87 @@ func (m *FetchJWTSVIDResponse) GetSvid() *JWTSVID {
 	return nil
 }
 
+// Represents a request to publish a JWT signing key upstream.
+type PushJWTKeyUpstreamRequest struct {
+	// The JWT signing public key of the downstream server
+	JwtKey               *common.PublicKey `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PushJWTKeyUpstreamRequest) Reset()         { *m = PushJWTKeyUpstreamRequest{} }
+func (m *PushJWTKeyUpstreamRequest) String() string { return proto.CompactTextString(m) }
+func (*PushJWTKeyUpstreamRequest) ProtoMessage()    {}
+func (*PushJWTKeyUpstreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{11}
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Unmarshal(m, b)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Marshal(b, m, deterministic)
+}
+func (dst *PushJWTKeyUpstreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushJWTKeyUpstreamRequest.Merge(dst, src)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Size() int {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Size(m)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushJWTKeyUpstreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushJWTKeyUpstreamRequest proto.InternalMessageInfo
+
+func (m *PushJWTKeyUpstreamRequest) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+// Represents a response that contains the JWT signing keys of the
+// upstream trust domain bundle, including the published key.
+type PushJWTKeyUpstreamResponse struct {
+	// The JWT signing keys of the upstream trust domain bundle
+	JwtSigningKeys       []*common.PublicKey `protobuf:"bytes,1,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PushJWTKeyUpstreamResponse) Reset()         { *m = PushJWTKeyUpstreamResponse{} }
+func (m *PushJWTKeyUpstreamResponse) String() string { return proto.CompactTextString(m) }
+func (*PushJWTKeyUpstreamResponse) ProtoMessage()    {}
+func (*PushJWTKeyUpstreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_cf2d414df6bda9eb, []int{12}
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Unmarshal(m, b)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Marshal(b, m, deterministic)
+}
+func (dst *PushJWTKeyUpstreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushJWTKeyUpstreamResponse.Merge(dst, src)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Size() int {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Size(m)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushJWTKeyUpstreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushJWTKeyUpstreamResponse proto.InternalMessageInfo
+
+func (m *PushJWTKeyUpstreamResponse) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Bundle)(nil), "spire.api.node.Bundle")
 	proto.RegisterType((*X509SVID)(nil), "spire.api.node.X509SVID")
@@ -618,6 +721,8 @@ func init() {
 	proto.RegisterType((*FetchX509SVIDResponse)(nil), "spire.api.node.FetchX509SVIDResponse")
 	proto.RegisterType((*FetchJWTSVIDRequest)(nil), "spire.api.node.FetchJWTSVIDRequest")
 	proto.RegisterType((*FetchJWTSVIDResponse)(nil), "spire.api.node.FetchJWTSVIDResponse")
+	proto.RegisterType((*PushJWTKeyUpstreamRequest)(nil), "spire.api.node.PushJWTKeyUpstreamRequest")
+	proto.RegisterType((*PushJWTKeyUpstreamResponse)(nil), "spire.api.node.PushJWTKeyUpstreamResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -640,6 +745,10 @@ type NodeClient interface {
 	FetchX509SVID(ctx context.Context, opts ...grpc.CallOption) (Node_FetchX509SVIDClient, error)
 	// Fetches a signed JWT-SVID for a workload intended for a specific audience.
 	FetchJWTSVID(ctx context.Context, in *FetchJWTSVIDRequest, opts ...grpc.CallOption) (*FetchJWTSVIDResponse, error)
+	// Publishes a JWT signing key of a downstream server to the trust domain
+	// bundle so it can be used to validate JWT-SVIDs it signs. Only callable
+	// by downstream workloads.
+	PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error)
 }
 
 type nodeClient struct {
@@ -721,6 +830,15 @@ func (c *nodeClient) FetchJWTSVID(ctx context.Context, in *FetchJWTSVIDRequest,
 	return out, nil
 }
 
+func (c *nodeClient) PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error) {
+	out := new(PushJWTKeyUpstreamResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.node.Node/PushJWTKeyUpstream", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServer is the server API for Node service.
 type NodeServer interface {
 	// Attest the node, get base node SVID.
@@ -731,6 +849,10 @@ type NodeServer interface {
 	FetchX509SVID(Node_FetchX509SVIDServer) error
 	// Fetches a signed JWT-SVID for a workload intended for a specific audience.
 	FetchJWTSVID(context.Context, *FetchJWTSVIDRequest) (*FetchJWTSVIDResponse, error)
+	// Publishes a JWT signing key of a downstream server to the trust domain
+	// bundle so it can be used to validate JWT-SVIDs it signs. Only callable
+	// by downstream workloads.
+	PushJWTKeyUpstream(context.Context, *PushJWTKeyUpstreamRequest) (*PushJWTKeyUpstreamResponse, error)
 }
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
@@ -807,6 +929,24 @@ func _Node_FetchJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_PushJWTKeyUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushJWTKeyUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).PushJWTKeyUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/PushJWTKeyUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).PushJWTKeyUpstream(ctx, req.(*PushJWTKeyUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.node.Node",
 	HandlerType: (*NodeServer)(nil),
@@ -815,6 +955,10 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchJWTSVID",
 			Handler:    _Node_FetchJWTSVID_Handler,
 		},
+		{
+			MethodName: "PushJWTKeyUpstream",
+			Handler:    _Node_PushJWTKeyUpstream_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -833,55 +977,64 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 	Metadata: "node.proto",
 }
 
-func init() { proto.RegisterFile("node.proto", fileDescriptor_node_bac2d1ca7cb46d82) }
-
-var fileDescriptor_node_bac2d1ca7cb46d82 = []byte{
-	// 750 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdb, 0x4f, 0x1a, 0x4d,
-	0x14, 0xcf, 0xb2, 0x80, 0x70, 0x40, 0xd4, 0x91, 0xcf, 0x8f, 0x8f, 0xaf, 0x5a, 0xb3, 0xd5, 0x94,
-	0x6a, 0xb3, 0x58, 0x8c, 0x49, 0xdb, 0x34, 0x31, 0x08, 0x34, 0xd5, 0x26, 0x8d, 0x19, 0x6c, 0x6b,
-	0x2f, 0x09, 0x1d, 0x77, 0x47, 0xd9, 0x8a, 0xbb, 0xb8, 0x33, 0x98, 0xfa, 0xdc, 0x87, 0xbe, 0xf6,
-	0x4f, 0x6e, 0xe6, 0xc2, 0x65, 0x01, 0xe5, 0xa5, 0x4f, 0x3b, 0xf3, 0x3b, 0xbf, 0xf3, 0x3b, 0x97,
-	0x39, 0x27, 0x0b, 0xe0, 0x07, 0x2e, 0xb5, 0xbb, 0x61, 0xc0, 0x03, 0x94, 0x63, 0x5d, 0x2f, 0xa4,
-	0x36, 0xe9, 0x7a, 0xb6, 0x40, 0x8b, 0xcf, 0x2e, 0x3c, 0xde, 0xee, 0x9d, 0xd9, 0x4e, 0x70, 0x55,
-	0x66, 0x5d, 0xef, 0xfc, 0x9c, 0x96, 0x25, 0xa3, 0x2c, 0xe9, 0x65, 0x27, 0xb8, 0xba, 0x0a, 0x7c,
-	0xfd, 0x51, 0x12, 0xd6, 0x2e, 0x24, 0x0f, 0x7a, 0xbe, 0xdb, 0xa1, 0x28, 0x07, 0x31, 0xcf, 0x2d,
-	0x18, 0xeb, 0x46, 0x29, 0x8d, 0x63, 0x9e, 0x8b, 0xfe, 0x83, 0x94, 0x43, 0x5a, 0x0e, 0x0d, 0x39,
-	0x2b, 0xc4, 0xd6, 0x8d, 0x52, 0x16, 0xcf, 0x39, 0xa4, 0x26, 0xae, 0xd6, 0x35, 0xa4, 0x4e, 0xf7,
-	0x76, 0x5e, 0x34, 0x3f, 0x1c, 0xd6, 0xd1, 0x63, 0x58, 0xa8, 0x37, 0x8e, 0x71, 0xa3, 0x56, 0x3d,
-	0x69, 0xd4, 0x25, 0x5d, 0x6a, 0x64, 0x71, 0x6e, 0x08, 0x0b, 0x2f, 0xb4, 0x0a, 0x20, 0xac, 0x2d,
-	0xa7, 0x4d, 0x3c, 0xbf, 0x60, 0x4a, 0x4e, 0x5a, 0x20, 0x35, 0x01, 0x08, 0x33, 0xfd, 0x21, 0x92,
-	0x65, 0x2d, 0xc2, 0x65, 0x40, 0x13, 0xa7, 0x35, 0x52, 0xe5, 0xd6, 0xcf, 0x04, 0xe4, 0xfa, 0x31,
-	0xdf, 0x77, 0x5d, 0xc2, 0x29, 0xda, 0x87, 0x04, 0xbb, 0xf1, 0x5c, 0x56, 0x30, 0xd6, 0xcd, 0x52,
-	0xa6, 0xf2, 0xc4, 0x8e, 0x76, 0xc3, 0x8e, 0xd2, 0xed, 0xa6, 0xe0, 0x36, 0x7c, 0x1e, 0xde, 0x62,
-	0xe5, 0x87, 0xb6, 0x61, 0x69, 0x24, 0xf5, 0x33, 0xd9, 0x06, 0x5d, 0xea, 0xe2, 0xd0, 0xa0, 0xdb,
-	0x83, 0x21, 0x1f, 0xd2, 0x0b, 0x8f, 0xf1, 0x90, 0x70, 0x2f, 0xf0, 0x5b, 0xd4, 0xe7, 0xa1, 0x47,
-	0x59, 0xc1, 0x94, 0xc1, 0x1f, 0xea, 0xe0, 0xba, 0xb7, 0x78, 0x84, 0xa9, 0x42, 0x2e, 0x87, 0x63,
-	0x90, 0x47, 0x19, 0x72, 0x01, 0x4d, 0x24, 0xc0, 0x0a, 0x71, 0xa9, 0xb8, 0x37, 0xa3, 0x9c, 0xf1,
-	0x04, 0x75, 0x69, 0x4b, 0x13, 0x38, 0x6a, 0xc0, 0x5c, 0x5f, 0x3a, 0x21, 0xa5, 0xb7, 0x67, 0x48,
-	0x47, 0x04, 0xfb, 0xbe, 0x45, 0x0c, 0x30, 0x6c, 0x21, 0x5a, 0x04, 0xf3, 0x92, 0xde, 0xea, 0x71,
-	0x11, 0x47, 0x64, 0x43, 0xe2, 0x86, 0x74, 0x7a, 0xaa, 0x83, 0x99, 0x4a, 0xe1, 0xae, 0x20, 0x58,
-	0xd1, 0x5e, 0xc6, 0x9e, 0x1b, 0xc5, 0xaf, 0xb0, 0x32, 0xbd, 0x8e, 0x29, 0xfa, 0x4f, 0xa3, 0xfa,
-	0x2b, 0xe3, 0xfa, 0xca, 0x7d, 0x54, 0xfd, 0x18, 0xb2, 0x33, 0x34, 0xb7, 0xa2, 0x9a, 0xf9, 0xe8,
-	0x2b, 0x4e, 0x28, 0x5a, 0xc7, 0x60, 0x1e, 0x35, 0x31, 0xfa, 0x1f, 0xd2, 0x6a, 0xbd, 0x5a, 0x83,
-	0x8d, 0x49, 0x29, 0xe0, 0xd0, 0x45, 0x45, 0x48, 0x91, 0x9e, 0xeb, 0x51, 0xdf, 0x11, 0xb2, 0xa6,
-	0xb0, 0xf5, 0xef, 0x22, 0x03, 0xce, 0x3b, 0x72, 0xf8, 0x13, 0x58, 0x1c, 0xad, 0x2f, 0x30, 0x77,
-	0xf4, 0xf1, 0x44, 0x6e, 0x52, 0x1e, 0x12, 0x3c, 0xb8, 0xa4, 0xbe, 0x56, 0x54, 0x97, 0x19, 0x7b,
-	0x21, 0x52, 0xf1, 0x18, 0xeb, 0x51, 0x57, 0x58, 0x4d, 0x69, 0x4d, 0x29, 0xa0, 0xca, 0xad, 0x5f,
-	0x06, 0xcc, 0x57, 0x39, 0xa7, 0x8c, 0x63, 0x7a, 0xdd, 0xa3, 0x8c, 0xa3, 0x37, 0xb0, 0x48, 0x24,
-	0xa0, 0x86, 0xd8, 0x25, 0x9c, 0xc8, 0x70, 0x99, 0xca, 0x6a, 0xb4, 0xf6, 0xea, 0x90, 0x55, 0x27,
-	0x9c, 0xe0, 0x05, 0x12, 0x05, 0x44, 0x29, 0x0e, 0x0b, 0xf5, 0xba, 0x88, 0xa3, 0x28, 0x3c, 0xa4,
-	0xac, 0x1b, 0xf8, 0x8c, 0xea, 0xf5, 0x1e, 0xdc, 0xad, 0x00, 0x72, 0xfd, 0x44, 0x14, 0x82, 0xf6,
-	0x21, 0x23, 0xb6, 0xb0, 0xd5, 0x93, 0x33, 0xa7, 0x93, 0x58, 0xbb, 0x7f, 0x32, 0x31, 0x08, 0x17,
-	0xbd, 0xfe, 0x0f, 0x20, 0xed, 0xb4, 0x49, 0xa7, 0x43, 0xfd, 0x8b, 0xfe, 0xd6, 0x0e, 0x01, 0x6b,
-	0x0b, 0xf2, 0xaf, 0x29, 0x77, 0xda, 0x83, 0xa9, 0xd3, 0x0d, 0x40, 0x10, 0x77, 0x58, 0xc8, 0xe4,
-	0xcb, 0x64, 0xb1, 0x3c, 0x5b, 0xa7, 0xf0, 0xcf, 0x18, 0xf7, 0x2f, 0xe5, 0x68, 0xbd, 0x82, 0x65,
-	0xa9, 0xac, 0x9f, 0xb8, 0x9f, 0xc4, 0x26, 0x98, 0xdf, 0x59, 0xa8, 0xf5, 0x96, 0xc7, 0xf5, 0x8e,
-	0x9a, 0x18, 0x0b, 0xbb, 0x55, 0xd3, 0x35, 0x0c, 0xbc, 0x75, 0x5a, 0xdb, 0x10, 0x17, 0x31, 0xb4,
-	0xff, 0xbf, 0x13, 0xfe, 0x9a, 0x2e, 0x49, 0x95, 0xdf, 0x31, 0x88, 0xbf, 0x0b, 0x5c, 0x8a, 0xde,
-	0x42, 0x52, 0x3d, 0x01, 0x5a, 0x1d, 0xf7, 0x88, 0xcc, 0x48, 0x71, 0xed, 0x2e, 0xb3, 0x0a, 0x5f,
-	0x32, 0x76, 0x0c, 0xf4, 0x0d, 0xe6, 0x23, 0x2d, 0x43, 0x1b, 0xe3, 0x4e, 0xd3, 0xba, 0x5f, 0xdc,
-	0x9c, 0xc1, 0x1a, 0x89, 0xf0, 0x09, 0xb2, 0xa3, 0xc5, 0xa3, 0x47, 0x53, 0x5d, 0xa3, 0x8d, 0x2d,
-	0x6e, 0xdc, 0x4f, 0x52, 0xf2, 0x07, 0xc9, 0xcf, 0x71, 0x61, 0x3c, 0x4b, 0xca, 0x5f, 0xe0, 0xee,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe6, 0x3b, 0x85, 0x2e, 0x53, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("node.proto", fileDescriptor_node_cf2d414df6bda9eb) }
+
+var fileDescriptor_node_cf2d414df6bda9eb = []byte{
+	// 896 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x6d, 0x4f, 0x1b, 0x47,
+	0x10, 0xd6, 0xf9, 0x6c, 0x63, 0x0f, 0xc6, 0x21, 0x8b, 0x4b, 0xcd, 0xa5, 0xa4, 0xe8, 0x9a, 0xa8,
+	0x14, 0x2a, 0x43, 0x89, 0x22, 0xa5, 0x55, 0xa5, 0xc8, 0x01, 0x57, 0x05, 0xab, 0x95, 0xb5, 0x4e,
+	0xda, 0xf4, 0x4d, 0xd7, 0xe5, 0x6e, 0x63, 0x16, 0xcc, 0x9d, 0x73, 0xbb, 0x17, 0xe2, 0xcf, 0xfd,
+	0x35, 0xfd, 0x63, 0xfd, 0x17, 0x95, 0xaa, 0x7d, 0x39, 0xdb, 0x77, 0x36, 0xb8, 0x1f, 0xf2, 0x89,
+	0xdd, 0x99, 0x67, 0x9e, 0x99, 0x67, 0x76, 0x86, 0x33, 0x40, 0x18, 0x05, 0xb4, 0x35, 0x8a, 0x23,
+	0x11, 0xa1, 0x3a, 0x1f, 0xb1, 0x98, 0xb6, 0xc8, 0x88, 0xb5, 0xa4, 0xd5, 0xf9, 0x6a, 0xc0, 0xc4,
+	0x45, 0x72, 0xde, 0xf2, 0xa3, 0xeb, 0x03, 0x3e, 0x62, 0x6f, 0xde, 0xd0, 0x03, 0x85, 0x38, 0x50,
+	0xf0, 0x03, 0x3f, 0xba, 0xbe, 0x8e, 0x42, 0xf3, 0x47, 0x53, 0xb8, 0x4f, 0xa0, 0xfc, 0x22, 0x09,
+	0x83, 0x21, 0x45, 0x75, 0x28, 0xb0, 0xa0, 0x69, 0xed, 0x58, 0xbb, 0x55, 0x5c, 0x60, 0x01, 0xda,
+	0x82, 0x8a, 0x4f, 0x3c, 0x9f, 0xc6, 0x82, 0x37, 0x0b, 0x3b, 0xd6, 0x6e, 0x0d, 0xaf, 0xf8, 0xe4,
+	0x58, 0x5e, 0xdd, 0xb7, 0x50, 0x79, 0xfd, 0xf4, 0xf0, 0xeb, 0xfe, 0x4f, 0xa7, 0x27, 0xe8, 0x73,
+	0xb8, 0x77, 0xd2, 0xe9, 0xe1, 0xce, 0x71, 0xfb, 0x65, 0xe7, 0x44, 0xc1, 0x15, 0x47, 0x0d, 0xd7,
+	0xa7, 0x66, 0x19, 0x85, 0xb6, 0x01, 0xa4, 0xd7, 0xf3, 0x2f, 0x08, 0x0b, 0x9b, 0xb6, 0xc2, 0x54,
+	0xa5, 0xe5, 0x58, 0x1a, 0xa4, 0x9b, 0xbe, 0x97, 0xc5, 0x72, 0x8f, 0x08, 0x95, 0xd0, 0xc6, 0x55,
+	0x63, 0x69, 0x0b, 0xf7, 0xaf, 0x12, 0xd4, 0xd3, 0x9c, 0xaf, 0x46, 0x01, 0x11, 0x14, 0x3d, 0x87,
+	0x12, 0x7f, 0xc7, 0x02, 0xde, 0xb4, 0x76, 0xec, 0xdd, 0xd5, 0xa3, 0x2f, 0x5a, 0xd9, 0x6e, 0xb4,
+	0xb2, 0xf0, 0x56, 0x5f, 0x62, 0x3b, 0xa1, 0x88, 0xc7, 0x58, 0xc7, 0xa1, 0x7d, 0xb8, 0x3f, 0x53,
+	0xfa, 0xb9, 0x6a, 0x83, 0x91, 0xba, 0x3e, 0x75, 0x98, 0xf6, 0x60, 0x68, 0xc4, 0x74, 0xc0, 0xb8,
+	0x88, 0x89, 0x60, 0x51, 0xe8, 0xd1, 0x50, 0xc4, 0x8c, 0xf2, 0xa6, 0xad, 0x92, 0x7f, 0x6a, 0x92,
+	0x9b, 0xde, 0xe2, 0x19, 0xa4, 0x4e, 0xb9, 0x11, 0xe7, 0x4c, 0x8c, 0x72, 0x14, 0x00, 0x9a, 0x2b,
+	0x80, 0x37, 0x8b, 0x8a, 0xf1, 0xe9, 0x12, 0x39, 0xf9, 0x02, 0x8d, 0xb4, 0xfb, 0x73, 0x76, 0xd4,
+	0x81, 0x95, 0x94, 0xba, 0xa4, 0xa8, 0xf7, 0x97, 0x50, 0x67, 0x08, 0xd3, 0x58, 0x07, 0x03, 0x4c,
+	0x5b, 0x88, 0xd6, 0xc1, 0xbe, 0xa2, 0x63, 0x33, 0x2e, 0xf2, 0x88, 0x5a, 0x50, 0x7a, 0x47, 0x86,
+	0x89, 0xee, 0xe0, 0xea, 0x51, 0xf3, 0xb6, 0x24, 0x58, 0xc3, 0xbe, 0x29, 0x3c, 0xb3, 0x9c, 0xdf,
+	0x61, 0x73, 0xb1, 0x8e, 0x05, 0xfc, 0x5f, 0x66, 0xf9, 0x37, 0xf3, 0xfc, 0x3a, 0x7c, 0x96, 0xbd,
+	0x07, 0xb5, 0x25, 0x9c, 0x7b, 0x59, 0xce, 0x46, 0xf6, 0x15, 0xe7, 0x18, 0xdd, 0x1e, 0xd8, 0x67,
+	0x7d, 0x8c, 0x1e, 0x40, 0x55, 0xaf, 0x97, 0x37, 0xd9, 0x98, 0x8a, 0x36, 0x9c, 0x06, 0xc8, 0x81,
+	0x0a, 0x49, 0x02, 0x46, 0x43, 0x5f, 0xd2, 0xda, 0xd2, 0x97, 0xde, 0x65, 0x05, 0x42, 0x0c, 0xd5,
+	0xf0, 0x97, 0xb0, 0x3c, 0xba, 0xbf, 0xc1, 0xca, 0xd9, 0xcf, 0x2f, 0xd5, 0x26, 0x35, 0xa0, 0x24,
+	0xa2, 0x2b, 0x1a, 0x1a, 0x46, 0x7d, 0x59, 0xb2, 0x17, 0xb2, 0x14, 0xc6, 0x79, 0x42, 0x03, 0xe9,
+	0xb5, 0x95, 0xb7, 0xa2, 0x0d, 0x6d, 0xe1, 0xfe, 0x63, 0xc1, 0x5a, 0x5b, 0x08, 0xca, 0x05, 0xa6,
+	0x6f, 0x13, 0xca, 0x05, 0xfa, 0x1e, 0xd6, 0x89, 0x32, 0xe8, 0x21, 0x0e, 0x88, 0x20, 0x2a, 0xdd,
+	0xea, 0xd1, 0x76, 0x56, 0x7b, 0x7b, 0x8a, 0x3a, 0x21, 0x82, 0xe0, 0x7b, 0x24, 0x6b, 0x90, 0x52,
+	0x7c, 0x1e, 0x9b, 0x75, 0x91, 0x47, 0x29, 0x3c, 0xa6, 0x7c, 0x14, 0x85, 0x9c, 0x9a, 0xf5, 0x9e,
+	0xdc, 0xd1, 0x1f, 0xf0, 0x80, 0x04, 0x01, 0x93, 0xd1, 0x64, 0xe8, 0xcd, 0x95, 0xa0, 0x47, 0x7e,
+	0x49, 0x09, 0x5b, 0x53, 0x86, 0x9c, 0xcb, 0xfd, 0xdb, 0x82, 0x7a, 0x2a, 0xd4, 0x64, 0x7c, 0x0e,
+	0xab, 0x72, 0xcb, 0xbd, 0x44, 0xcd, 0xb4, 0x11, 0xf9, 0xf0, 0xee, 0xc9, 0xc7, 0x20, 0x43, 0xf4,
+	0x19, 0x7d, 0x02, 0x55, 0xff, 0x82, 0x0c, 0x87, 0x34, 0x1c, 0xa4, 0xff, 0x15, 0xa6, 0x06, 0xf4,
+	0x0c, 0x9a, 0x93, 0x8b, 0xd1, 0x13, 0xc5, 0x1e, 0x0b, 0x03, 0xfa, 0x5e, 0x89, 0x5f, 0xc3, 0x9b,
+	0x13, 0x7f, 0xdb, 0xb8, 0x4f, 0xa5, 0xd7, 0xdd, 0x83, 0xc6, 0x77, 0x54, 0xf8, 0x17, 0x93, 0x7d,
+	0x30, 0x4f, 0x83, 0xa0, 0xe8, 0xf3, 0x98, 0xab, 0x99, 0xa9, 0x61, 0x75, 0x76, 0x5f, 0xc3, 0x47,
+	0x39, 0xec, 0x07, 0x52, 0xe7, 0x7e, 0x0b, 0x1b, 0x8a, 0xd9, 0x0c, 0x5f, 0x5a, 0xc4, 0x63, 0xb0,
+	0x2f, 0x79, 0x6c, 0xf8, 0x36, 0xf2, 0x7c, 0x67, 0x7d, 0x8c, 0xa5, 0xdf, 0x3d, 0x86, 0x46, 0x36,
+	0xda, 0x94, 0xb5, 0x0f, 0x45, 0x99, 0xc3, 0xc4, 0x7f, 0x3c, 0x17, 0x6f, 0xe0, 0x0a, 0xe4, 0xfe,
+	0x00, 0x5b, 0xbd, 0x84, 0x4b, 0x8e, 0x2e, 0x1d, 0xbf, 0x1a, 0x71, 0x11, 0x53, 0x72, 0x9d, 0x16,
+	0x72, 0x08, 0x2b, 0x97, 0x37, 0xc2, 0x4b, 0xf7, 0x75, 0x4a, 0x66, 0x86, 0xa3, 0x97, 0x9c, 0x0f,
+	0x99, 0xdf, 0xa5, 0x63, 0x5c, 0xbe, 0xbc, 0x11, 0x5d, 0x3a, 0x76, 0x3d, 0x70, 0x16, 0xd1, 0x99,
+	0xca, 0xda, 0xb0, 0x2e, 0xf9, 0x38, 0x1b, 0x84, 0x2c, 0x1c, 0x48, 0xde, 0xf4, 0xbb, 0x71, 0x2b,
+	0x71, 0xfd, 0xf2, 0x46, 0xf4, 0x35, 0xbe, 0x4b, 0xc7, 0xfc, 0xe8, 0xdf, 0x02, 0x14, 0x7f, 0x8c,
+	0x02, 0x8a, 0xba, 0x50, 0xd6, 0x4f, 0x8a, 0xb6, 0xf3, 0x0a, 0x33, 0xdb, 0xe6, 0x3c, 0xbc, 0xcd,
+	0xad, 0x8b, 0xda, 0xb5, 0x0e, 0x2d, 0xf4, 0x27, 0xac, 0x65, 0x9e, 0x18, 0x3d, 0xca, 0x07, 0x2d,
+	0x9a, 0x16, 0xe7, 0xf1, 0x12, 0xd4, 0x4c, 0x86, 0x5f, 0xa0, 0x36, 0xfb, 0x58, 0xe8, 0xb3, 0x85,
+	0xa1, 0xd9, 0x41, 0x70, 0x1e, 0xdd, 0x0d, 0x32, 0x5d, 0xbd, 0x02, 0x34, 0xdf, 0x73, 0x34, 0xf7,
+	0x25, 0xbe, 0xf5, 0x99, 0x9d, 0xbd, 0xff, 0x03, 0xd5, 0xc9, 0x5e, 0x94, 0x7f, 0x2d, 0x4a, 0xc8,
+	0x79, 0x59, 0xfd, 0x72, 0x79, 0xf2, 0xdf, 0x00, 0xff, 0x50, 0x2e, 0x6c, 0x0a, 0x09, 0x00, 0x00,
 }
@@ -52,7 +52,7 @@ func (x DeleteFederatedBundleRequest_Mode) String() string {
 	return proto.EnumName(DeleteFederatedBundleRequest_Mode_name, int32(x))
 }
 func (DeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{6, 0}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{6, 0}
 }
 
 // A type that represents the id of an entry.
@@ -68,7 +68,7 @@ func (m *RegistrationEntryID) Reset()         { *m = RegistrationEntryID{} }
 func (m *RegistrationEntryID) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntryID) ProtoMessage()    {}
 func (*RegistrationEntryID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{0}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{0}
 }
 func (m *RegistrationEntryID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntryID.Unmarshal(m, b)
@@ -108,7 +108,7 @@ func (m *ParentID) Reset()         { *m = ParentID{} }
 func (m *ParentID) String() string { return proto.CompactTextString(m) }
 func (*ParentID) ProtoMessage()    {}
 func (*ParentID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{1}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{1}
 }
 func (m *ParentID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ParentID.Unmarshal(m, b)
@@ -148,7 +148,7 @@ func (m *SpiffeID) Reset()         { *m = SpiffeID{} }
 func (m *SpiffeID) String() string { return proto.CompactTextString(m) }
 func (*SpiffeID) ProtoMessage()    {}
 func (*SpiffeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{2}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{2}
 }
 func (m *SpiffeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SpiffeID.Unmarshal(m, b)
@@ -188,7 +188,7 @@ func (m *UpdateEntryRequest) Reset()         { *m = UpdateEntryRequest{} }
 func (m *UpdateEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateEntryRequest) ProtoMessage()    {}
 func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{3}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{3}
 }
 func (m *UpdateEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateEntryRequest.Unmarshal(m, b)
@@ -232,7 +232,7 @@ func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
 func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundle) ProtoMessage()    {}
 func (*FederatedBundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{4}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{4}
 }
 func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FederatedBundle.Unmarshal(m, b)
@@ -286,7 +286,7 @@ func (m *FederatedBundleID) Reset()         { *m = FederatedBundleID{} }
 func (m *FederatedBundleID) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundleID) ProtoMessage()    {}
 func (*FederatedBundleID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{5}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{5}
 }
 func (m *FederatedBundleID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FederatedBundleID.Unmarshal(m, b)
@@ -325,7 +325,7 @@ func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBun
 func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
 func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{6}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{6}
 }
 func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteFederatedBundleRequest.Unmarshal(m, b)
@@ -364,7 +364,19 @@ type JoinToken struct {
 	// The join token. If not set, one will be generated
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// TTL in seconds
-	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Ttl int32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// SPIFFE ID of the agents attesting with the token. If not set, the
+	// SPIFFE ID is derived from the token.
+	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Selectors assigned to the agents attesting with the token
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Number of times the token can be used. If not set, the token can be
+	// used once.
+	MaxUses int32 `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of times the token has been used (output only)
+	Uses int32 `protobuf:"varint,6,opt,name=uses,proto3" json:"uses,omitempty"`
+	// Expiration in seconds since unix epoch (output only)
+	ExpiresAt            int64    `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -374,7 +386,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{7}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{7}
 }
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
@@ -408,6 +420,192 @@ func (m *JoinToken) GetTtl() int32 {
 	return 0
 }
 
+func (m *JoinToken) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *JoinToken) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUses() int32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// Represents a ListJoinTokens request
+type ListJoinTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJoinTokensRequest) Reset()         { *m = ListJoinTokensRequest{} }
+func (m *ListJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensRequest) ProtoMessage()    {}
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{8}
+}
+func (m *ListJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *ListJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensRequest.Merge(dst, src)
+}
+func (m *ListJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensRequest.Size(m)
+}
+func (m *ListJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensRequest proto.InternalMessageInfo
+
+// Represents a ListJoinTokens response
+type ListJoinTokensResponse struct {
+	// List of all outstanding join tokens
+	JoinTokens           []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJoinTokensResponse) Reset()         { *m = ListJoinTokensResponse{} }
+func (m *ListJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensResponse) ProtoMessage()    {}
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{9}
+}
+func (m *ListJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *ListJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensResponse.Merge(dst, src)
+}
+func (m *ListJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensResponse.Size(m)
+}
+func (m *ListJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensResponse proto.InternalMessageInfo
+
+func (m *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if m != nil {
+		return m.JoinTokens
+	}
+	return nil
+}
+
+// Represents a RevokeJoinToken request
+type RevokeJoinTokenRequest struct {
+	// The join token to revoke
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeJoinTokenRequest) Reset()         { *m = RevokeJoinTokenRequest{} }
+func (m *RevokeJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeJoinTokenRequest) ProtoMessage()    {}
+func (*RevokeJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{10}
+}
+func (m *RevokeJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *RevokeJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (dst *RevokeJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJoinTokenRequest.Merge(dst, src)
+}
+func (m *RevokeJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeJoinTokenRequest.Size(m)
+}
+func (m *RevokeJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJoinTokenRequest proto.InternalMessageInfo
+
+func (m *RevokeJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// Represents a RevokeJoinToken response
+type RevokeJoinTokenResponse struct {
+	// The revoked join token
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RevokeJoinTokenResponse) Reset()         { *m = RevokeJoinTokenResponse{} }
+func (m *RevokeJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeJoinTokenResponse) ProtoMessage()    {}
+func (*RevokeJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{11}
+}
+func (m *RevokeJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *RevokeJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (dst *RevokeJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeJoinTokenResponse.Merge(dst, src)
+}
+func (m *RevokeJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeJoinTokenResponse.Size(m)
+}
+func (m *RevokeJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeJoinTokenResponse proto.InternalMessageInfo
+
+func (m *RevokeJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
 // CA Bundle of the server
 type Bundle struct {
 	// ASN.1 DER data of the bundle (deprecated).
@@ -423,7 +621,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{8}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{12}
 }
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundle.Unmarshal(m, b)
@@ -468,7 +666,7 @@ func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
 func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsRequest) ProtoMessage()    {}
 func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{9}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{13}
 }
 func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListAgentsRequest.Unmarshal(m, b)
@@ -501,7 +699,7 @@ func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
 func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAgentsResponse) ProtoMessage()    {}
 func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{10}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{14}
 }
 func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
@@ -542,7 +740,7 @@ func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
 func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentRequest) ProtoMessage()    {}
 func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{11}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{15}
 }
 func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvictAgentRequest.Unmarshal(m, b)
@@ -582,7 +780,7 @@ func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
 func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
 func (*EvictAgentResponse) ProtoMessage()    {}
 func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_f1c410388c141894, []int{12}
+	return fileDescriptor_registration_63110d999d8b18ad, []int{16}
 }
 func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EvictAgentResponse.Unmarshal(m, b)
@@ -609,6 +807,171 @@ func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+// Represents a CA keypair set (X.509 CA and JWT signing key)
+type CAKeypairSet struct {
+	// Slot the keypair set occupies (i.e. "A" or "B")
+	Slot string `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	// Whether the keypair set is active (used for signing) or only prepared
+	Active bool `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	// ASN.1 DER data of the X.509 CA certificate
+	X509Ca []byte `protobuf:"bytes,3,opt,name=x509_ca,json=x509Ca,proto3" json:"x509_ca,omitempty"`
+	// Key ID of the X.509 CA (hex encoded subject key ID)
+	X509CaKeyId string `protobuf:"bytes,4,opt,name=x509_ca_key_id,json=x509CaKeyId,proto3" json:"x509_ca_key_id,omitempty"`
+	// JWT signing public key
+	JwtSigningKey        *common.PublicKey `protobuf:"bytes,5,opt,name=jwt_signing_key,json=jwtSigningKey,proto3" json:"jwt_signing_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CAKeypairSet) Reset()         { *m = CAKeypairSet{} }
+func (m *CAKeypairSet) String() string { return proto.CompactTextString(m) }
+func (*CAKeypairSet) ProtoMessage()    {}
+func (*CAKeypairSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{17}
+}
+func (m *CAKeypairSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAKeypairSet.Unmarshal(m, b)
+}
+func (m *CAKeypairSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAKeypairSet.Marshal(b, m, deterministic)
+}
+func (dst *CAKeypairSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAKeypairSet.Merge(dst, src)
+}
+func (m *CAKeypairSet) XXX_Size() int {
+	return xxx_messageInfo_CAKeypairSet.Size(m)
+}
+func (m *CAKeypairSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAKeypairSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAKeypairSet proto.InternalMessageInfo
+
+func (m *CAKeypairSet) GetSlot() string {
+	if m != nil {
+		return m.Slot
+	}
+	return ""
+}
+
+func (m *CAKeypairSet) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *CAKeypairSet) GetX509Ca() []byte {
+	if m != nil {
+		return m.X509Ca
+	}
+	return nil
+}
+
+func (m *CAKeypairSet) GetX509CaKeyId() string {
+	if m != nil {
+		return m.X509CaKeyId
+	}
+	return ""
+}
+
+func (m *CAKeypairSet) GetJwtSigningKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKey
+	}
+	return nil
+}
+
+// Represents a ListCAKeypairSets response
+type ListCAKeypairSetsResponse struct {
+	// The active and prepared keypair sets
+	KeypairSets          []*CAKeypairSet `protobuf:"bytes,1,rep,name=keypair_sets,json=keypairSets,proto3" json:"keypair_sets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListCAKeypairSetsResponse) Reset()         { *m = ListCAKeypairSetsResponse{} }
+func (m *ListCAKeypairSetsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCAKeypairSetsResponse) ProtoMessage()    {}
+func (*ListCAKeypairSetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{18}
+}
+func (m *ListCAKeypairSetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCAKeypairSetsResponse.Unmarshal(m, b)
+}
+func (m *ListCAKeypairSetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCAKeypairSetsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListCAKeypairSetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCAKeypairSetsResponse.Merge(dst, src)
+}
+func (m *ListCAKeypairSetsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCAKeypairSetsResponse.Size(m)
+}
+func (m *ListCAKeypairSetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCAKeypairSetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCAKeypairSetsResponse proto.InternalMessageInfo
+
+func (m *ListCAKeypairSetsResponse) GetKeypairSets() []*CAKeypairSet {
+	if m != nil {
+		return m.KeypairSets
+	}
+	return nil
+}
+
+// Represents a RevokeCAKey request. Exactly one of the key IDs must be set.
+type RevokeCAKeyRequest struct {
+	// Key ID of the X.509 CA to revoke (hex encoded subject key ID)
+	X509CaKeyId string `protobuf:"bytes,1,opt,name=x509_ca_key_id,json=x509CaKeyId,proto3" json:"x509_ca_key_id,omitempty"`
+	// Key ID of the JWT signing key to revoke
+	JwtKeyId             string   `protobuf:"bytes,2,opt,name=jwt_key_id,json=jwtKeyId,proto3" json:"jwt_key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeCAKeyRequest) Reset()         { *m = RevokeCAKeyRequest{} }
+func (m *RevokeCAKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeCAKeyRequest) ProtoMessage()    {}
+func (*RevokeCAKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_63110d999d8b18ad, []int{19}
+}
+func (m *RevokeCAKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCAKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeCAKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCAKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *RevokeCAKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCAKeyRequest.Merge(dst, src)
+}
+func (m *RevokeCAKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeCAKeyRequest.Size(m)
+}
+func (m *RevokeCAKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCAKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCAKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeCAKeyRequest) GetX509CaKeyId() string {
+	if m != nil {
+		return m.X509CaKeyId
+	}
+	return ""
+}
+
+func (m *RevokeCAKeyRequest) GetJwtKeyId() string {
+	if m != nil {
+		return m.JwtKeyId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
 	proto.RegisterType((*ParentID)(nil), "spire.api.registration.ParentID")
@@ -618,11 +981,18 @@ func init() {
 	proto.RegisterType((*FederatedBundleID)(nil), "spire.api.registration.FederatedBundleID")
 	proto.RegisterType((*DeleteFederatedBundleRequest)(nil), "spire.api.registration.DeleteFederatedBundleRequest")
 	proto.RegisterType((*JoinToken)(nil), "spire.api.registration.JoinToken")
+	proto.RegisterType((*ListJoinTokensRequest)(nil), "spire.api.registration.ListJoinTokensRequest")
+	proto.RegisterType((*ListJoinTokensResponse)(nil), "spire.api.registration.ListJoinTokensResponse")
+	proto.RegisterType((*RevokeJoinTokenRequest)(nil), "spire.api.registration.RevokeJoinTokenRequest")
+	proto.RegisterType((*RevokeJoinTokenResponse)(nil), "spire.api.registration.RevokeJoinTokenResponse")
 	proto.RegisterType((*Bundle)(nil), "spire.api.registration.Bundle")
 	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.registration.ListAgentsRequest")
 	proto.RegisterType((*ListAgentsResponse)(nil), "spire.api.registration.ListAgentsResponse")
 	proto.RegisterType((*EvictAgentRequest)(nil), "spire.api.registration.EvictAgentRequest")
 	proto.RegisterType((*EvictAgentResponse)(nil), "spire.api.registration.EvictAgentResponse")
+	proto.RegisterType((*CAKeypairSet)(nil), "spire.api.registration.CAKeypairSet")
+	proto.RegisterType((*ListCAKeypairSetsResponse)(nil), "spire.api.registration.ListCAKeypairSetsResponse")
+	proto.RegisterType((*RevokeCAKeyRequest)(nil), "spire.api.registration.RevokeCAKeyRequest")
 	proto.RegisterEnum("spire.api.registration.DeleteFederatedBundleRequest_Mode", DeleteFederatedBundleRequest_Mode_name, DeleteFederatedBundleRequest_Mode_value)
 }
 
@@ -666,12 +1036,24 @@ type RegistrationClient interface {
 	DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*common.Empty, error)
 	// Create a new join token
 	CreateJoinToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*JoinToken, error)
+	// Lists the outstanding join tokens
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// Revokes a join token so it can no longer be used
+	RevokeJoinToken(ctx context.Context, in *RevokeJoinTokenRequest, opts ...grpc.CallOption) (*RevokeJoinTokenResponse, error)
 	// Retrieves the CA bundle.
 	FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(ctx context.Context, in *EvictAgentRequest, opts ...grpc.CallOption) (*EvictAgentResponse, error)
 	// ListAgents will list all attested nodes
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// Lists the active and prepared CA keypair sets
+	ListCAKeypairSets(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*ListCAKeypairSetsResponse, error)
+	// Prepares a new CA keypair set, to be activated later
+	PrepareCAKeypairSet(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CAKeypairSet, error)
+	// Activates the prepared CA keypair set immediately
+	ActivateCAKeypairSet(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CAKeypairSet, error)
+	// Revokes a CA key that is no longer active, removing it from the bundle
+	RevokeCAKey(ctx context.Context, in *RevokeCAKeyRequest, opts ...grpc.CallOption) (*common.Empty, error)
 }
 
 type registrationClient struct {
@@ -831,6 +1213,24 @@ func (c *registrationClient) CreateJoinToken(ctx context.Context, in *JoinToken,
 	return out, nil
 }
 
+func (c *registrationClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) RevokeJoinToken(ctx context.Context, in *RevokeJoinTokenRequest, opts ...grpc.CallOption) (*RevokeJoinTokenResponse, error) {
+	out := new(RevokeJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/RevokeJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error) {
 	out := new(Bundle)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/FetchBundle", in, out, opts...)
@@ -858,6 +1258,42 @@ func (c *registrationClient) ListAgents(ctx context.Context, in *ListAgentsReque
 	return out, nil
 }
 
+func (c *registrationClient) ListCAKeypairSets(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*ListCAKeypairSetsResponse, error) {
+	out := new(ListCAKeypairSetsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListCAKeypairSets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) PrepareCAKeypairSet(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CAKeypairSet, error) {
+	out := new(CAKeypairSet)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/PrepareCAKeypairSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ActivateCAKeypairSet(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*CAKeypairSet, error) {
+	out := new(CAKeypairSet)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ActivateCAKeypairSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) RevokeCAKey(ctx context.Context, in *RevokeCAKeyRequest, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/RevokeCAKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationServer is the server API for Registration service.
 type RegistrationServer interface {
 	// Creates an entry in the Registration table, used to assign SPIFFE IDs to nodes and workloads.
@@ -888,12 +1324,24 @@ type RegistrationServer interface {
 	DeleteFederatedBundle(context.Context, *DeleteFederatedBundleRequest) (*common.Empty, error)
 	// Create a new join token
 	CreateJoinToken(context.Context, *JoinToken) (*JoinToken, error)
+	// Lists the outstanding join tokens
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	// Revokes a join token so it can no longer be used
+	RevokeJoinToken(context.Context, *RevokeJoinTokenRequest) (*RevokeJoinTokenResponse, error)
 	// Retrieves the CA bundle.
 	FetchBundle(context.Context, *common.Empty) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(context.Context, *EvictAgentRequest) (*EvictAgentResponse, error)
 	// ListAgents will list all attested nodes
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// Lists the active and prepared CA keypair sets
+	ListCAKeypairSets(context.Context, *common.Empty) (*ListCAKeypairSetsResponse, error)
+	// Prepares a new CA keypair set, to be activated later
+	PrepareCAKeypairSet(context.Context, *common.Empty) (*CAKeypairSet, error)
+	// Activates the prepared CA keypair set immediately
+	ActivateCAKeypairSet(context.Context, *common.Empty) (*CAKeypairSet, error)
+	// Revokes a CA key that is no longer active, removing it from the bundle
+	RevokeCAKey(context.Context, *RevokeCAKeyRequest) (*common.Empty, error)
 }
 
 func RegisterRegistrationServer(s *grpc.Server, srv RegistrationServer) {
@@ -1155,6 +1603,42 @@ func _Registration_CreateJoinToken_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_RevokeJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).RevokeJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/RevokeJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).RevokeJoinToken(ctx, req.(*RevokeJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(common.Empty)
 	if err := dec(in); err != nil {
@@ -1209,6 +1693,78 @@ func _Registration_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ListCAKeypairSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListCAKeypairSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListCAKeypairSets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListCAKeypairSets(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_PrepareCAKeypairSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).PrepareCAKeypairSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/PrepareCAKeypairSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).PrepareCAKeypairSet(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ActivateCAKeypairSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ActivateCAKeypairSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ActivateCAKeypairSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ActivateCAKeypairSet(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_RevokeCAKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCAKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).RevokeCAKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/RevokeCAKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).RevokeCAKey(ctx, req.(*RevokeCAKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Registration_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.registration.Registration",
 	HandlerType: (*RegistrationServer)(nil),
@@ -1265,6 +1821,14 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateJoinToken",
 			Handler:    _Registration_CreateJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _Registration_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "RevokeJoinToken",
+			Handler:    _Registration_RevokeJoinToken_Handler,
+		},
 		{
 			MethodName: "FetchBundle",
 			Handler:    _Registration_FetchBundle_Handler,
@@ -1277,6 +1841,22 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _Registration_ListAgents_Handler,
 		},
+		{
+			MethodName: "ListCAKeypairSets",
+			Handler:    _Registration_ListCAKeypairSets_Handler,
+		},
+		{
+			MethodName: "PrepareCAKeypairSet",
+			Handler:    _Registration_PrepareCAKeypairSet_Handler,
+		},
+		{
+			MethodName: "ActivateCAKeypairSet",
+			Handler:    _Registration_ActivateCAKeypairSet_Handler,
+		},
+		{
+			MethodName: "RevokeCAKey",
+			Handler:    _Registration_RevokeCAKey_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1288,56 +1868,81 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 	Metadata: "registration.proto",
 }
 
-func init() { proto.RegisterFile("registration.proto", fileDescriptor_registration_f1c410388c141894) }
-
-var fileDescriptor_registration_f1c410388c141894 = []byte{
-	// 758 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x51, 0x6f, 0xda, 0x48,
-	0x10, 0x3e, 0x93, 0x80, 0x92, 0x01, 0x11, 0x58, 0x48, 0x14, 0x59, 0xa7, 0xbb, 0xc4, 0xa7, 0x53,
-	0xd3, 0xb4, 0x32, 0x29, 0x69, 0x1f, 0xfa, 0x08, 0xd8, 0x48, 0x34, 0x49, 0x1b, 0x19, 0xd2, 0x4a,
-	0xc9, 0x43, 0x04, 0xf6, 0x24, 0x71, 0x0b, 0xb6, 0x6b, 0x6f, 0x2a, 0xe5, 0xad, 0x7f, 0xa5, 0xbf,
-	0xa0, 0x7f, 0xb1, 0xf2, 0x7a, 0x6d, 0x8c, 0xb1, 0x83, 0x5b, 0xb5, 0x4f, 0x78, 0x3d, 0xdf, 0x7c,
-	0xf3, 0x79, 0x66, 0x76, 0x06, 0x20, 0x2e, 0xde, 0x9a, 0x1e, 0x75, 0xc7, 0xd4, 0xb4, 0x2d, 0xd9,
-	0x71, 0x6d, 0x6a, 0x93, 0x1d, 0xcf, 0x31, 0x5d, 0x94, 0xc7, 0x8e, 0x29, 0xc7, 0xad, 0xe2, 0x8b,
-	0x5b, 0x93, 0xde, 0xdd, 0x4f, 0x64, 0xdd, 0x9e, 0xb5, 0x3c, 0xc7, 0xbc, 0xb9, 0xc1, 0x16, 0x43,
-	0xb6, 0x98, 0x5b, 0x4b, 0xb7, 0x67, 0x33, 0xdb, 0xe2, 0x3f, 0x01, 0x95, 0xf4, 0x3f, 0x34, 0xb4,
-	0x18, 0x85, 0x6a, 0x51, 0xf7, 0x61, 0xa0, 0x90, 0x2a, 0x14, 0x4c, 0x63, 0x57, 0xd8, 0x13, 0x0e,
-	0x36, 0xb5, 0x82, 0x69, 0x48, 0x22, 0x6c, 0x9c, 0x8f, 0x5d, 0xb4, 0x68, 0xba, 0x6d, 0xc8, 0x82,
-	0xa5, 0xd8, 0x4e, 0x80, 0x5c, 0x38, 0xc6, 0x98, 0x22, 0x23, 0xd6, 0xf0, 0xf3, 0x3d, 0x7a, 0x94,
-	0xbc, 0x82, 0x22, 0xfa, 0x67, 0x06, 0x2c, 0xb7, 0xff, 0x95, 0x83, 0xef, 0xe1, 0xc2, 0x96, 0xf4,
-	0x68, 0x01, 0x5a, 0xfa, 0x26, 0xc0, 0x56, 0x1f, 0x0d, 0x74, 0xc7, 0x14, 0x8d, 0xee, 0xbd, 0x65,
-	0x4c, 0x91, 0x1c, 0x41, 0x53, 0x51, 0xcf, 0x35, 0xb5, 0xd7, 0x19, 0xa9, 0xca, 0x75, 0xf0, 0xd1,
-	0xd7, 0x91, 0x04, 0x32, 0xb7, 0x71, 0x89, 0x06, 0x91, 0xa1, 0x11, 0xf3, 0xd0, 0xc7, 0xd7, 0x3a,
-	0xba, 0xd4, 0xdb, 0x2d, 0xec, 0x09, 0x07, 0x15, 0xad, 0x3e, 0x37, 0xf5, 0xc6, 0x3d, 0xdf, 0x40,
-	0x9e, 0x43, 0x69, 0xc2, 0x62, 0xed, 0xae, 0x31, 0xb5, 0xcd, 0x45, 0xb5, 0x81, 0x0e, 0x8d, 0x63,
-	0xa4, 0xff, 0xa0, 0x9e, 0x90, 0x98, 0x92, 0x95, 0xef, 0x02, 0xfc, 0xad, 0xe0, 0x14, 0x29, 0x26,
-	0xb0, 0x61, 0x82, 0x12, 0x0e, 0xe4, 0x0c, 0xd6, 0x67, 0xb6, 0x81, 0x4c, 0x64, 0xb5, 0xfd, 0x5a,
-	0x4e, 0xaf, 0xbf, 0xfc, 0x18, 0xa7, 0x7c, 0x66, 0x1b, 0xa8, 0x31, 0x1a, 0xe9, 0x08, 0xd6, 0xfd,
-	0x13, 0xa9, 0xc0, 0x86, 0xa6, 0x0e, 0x47, 0xda, 0xa0, 0x37, 0xaa, 0xfd, 0x45, 0x00, 0x4a, 0x8a,
-	0x7a, 0xaa, 0x8e, 0xd4, 0x9a, 0x40, 0xaa, 0x00, 0xca, 0x60, 0x38, 0x7c, 0xd7, 0x1b, 0x74, 0x46,
-	0x6a, 0xad, 0x20, 0x1d, 0xc3, 0xe6, 0x1b, 0xdb, 0xb4, 0x46, 0xf6, 0x27, 0xb4, 0x48, 0x13, 0x8a,
-	0xd4, 0x7f, 0xe0, 0x02, 0x83, 0x03, 0xa9, 0xc1, 0x1a, 0xa5, 0x53, 0x26, 0xb1, 0xa8, 0xf9, 0x8f,
-	0xd2, 0x0d, 0x94, 0x78, 0x95, 0x32, 0x72, 0x2e, 0xac, 0xce, 0x79, 0x21, 0x47, 0xce, 0x1b, 0x50,
-	0x3f, 0x35, 0x3d, 0xda, 0xb9, 0x45, 0x8b, 0x7a, 0xfc, 0x73, 0xa5, 0x3e, 0x90, 0xf8, 0x4b, 0xcf,
-	0xb1, 0x2d, 0xcf, 0x6f, 0x97, 0xa2, 0x65, 0x1b, 0xe8, 0x87, 0x5e, 0x3b, 0x28, 0xb7, 0xc5, 0x45,
-	0xde, 0x0e, 0xa5, 0xe8, 0x51, 0x34, 0xde, 0xfa, 0xa9, 0x0a, 0x80, 0x52, 0x0b, 0xea, 0xea, 0x17,
-	0x53, 0x0f, 0x88, 0xc2, 0xfa, 0x88, 0xb0, 0xe1, 0xf1, 0x96, 0xe7, 0x49, 0x88, 0xce, 0x92, 0x02,
-	0x24, 0xee, 0xc0, 0x03, 0xcb, 0xb0, 0xee, 0xf3, 0xf1, 0x8e, 0x7f, 0x2c, 0x2e, 0xc3, 0xb5, 0xbf,
-	0x56, 0xa0, 0x12, 0xbf, 0x08, 0xe4, 0x0a, 0xca, 0x3d, 0x17, 0xc3, 0x9b, 0x44, 0x56, 0xdd, 0x19,
-	0xf1, 0x59, 0x56, 0x93, 0xa4, 0x5d, 0xf7, 0x2b, 0x28, 0x07, 0xbd, 0x13, 0x90, 0xff, 0x8c, 0xaf,
-	0xb8, 0x4a, 0x09, 0xb9, 0x04, 0xe8, 0x23, 0xd5, 0xef, 0xfe, 0x04, 0x77, 0x1f, 0x2a, 0x11, 0xb7,
-	0x89, 0x1e, 0x69, 0x2c, 0x3a, 0xa8, 0x33, 0x87, 0x3e, 0x88, 0xfb, 0x8f, 0xb3, 0xf8, 0x7e, 0x97,
-	0x50, 0x8e, 0xcd, 0x29, 0x72, 0x98, 0x25, 0x72, 0x79, 0x98, 0xad, 0xd6, 0x78, 0x01, 0x55, 0xbf,
-	0x13, 0xbb, 0x0f, 0xd1, 0x04, 0xdd, 0xcb, 0xa2, 0x0f, 0x11, 0x79, 0x24, 0x9f, 0x84, 0xb4, 0x43,
-	0x9c, 0xa2, 0x4e, 0x6d, 0x97, 0xec, 0x2c, 0x3a, 0x85, 0xef, 0xf3, 0x90, 0x45, 0x1a, 0xa3, 0x49,
-	0x9e, 0xa9, 0x31, 0x44, 0xe4, 0xa3, 0xdd, 0x0e, 0x9a, 0x36, 0x39, 0xb6, 0x9f, 0x64, 0xb1, 0x27,
-	0x80, 0x62, 0x5a, 0x41, 0xc9, 0x47, 0x68, 0xb2, 0xaa, 0x27, 0x59, 0x9f, 0xe6, 0x64, 0x1d, 0x28,
-	0x62, 0x5e, 0x01, 0xe4, 0x3d, 0x34, 0xfd, 0xcc, 0x24, 0x5e, 0x67, 0x74, 0x5a, 0x5e, 0xd6, 0x23,
-	0xc1, 0x4f, 0x4d, 0xd0, 0x4c, 0xbf, 0x37, 0x35, 0x13, 0xd8, 0x4e, 0xdd, 0x02, 0xe4, 0xe5, 0xaf,
-	0x2c, 0x8d, 0xf4, 0x18, 0x1f, 0x60, 0x2b, 0xa8, 0xea, 0x7c, 0x25, 0xec, 0x67, 0xb1, 0x47, 0x10,
-	0x71, 0x35, 0x84, 0x74, 0xa1, 0xcc, 0xea, 0xca, 0x25, 0xa7, 0xa6, 0xf8, 0x9f, 0x2c, 0x1a, 0xee,
-	0xa4, 0x03, 0xcc, 0xc7, 0x6f, 0x76, 0x47, 0x2c, 0xcd, 0x74, 0xf1, 0x30, 0x0f, 0x94, 0x4f, 0x73,
-	0x1d, 0x60, 0xbe, 0x5c, 0xb2, 0x83, 0x2c, 0x6d, 0xa5, 0xec, 0x20, 0xcb, 0xbb, 0xaa, 0x5b, 0xbd,
-	0xac, 0xc4, 0x21, 0x93, 0x12, 0xfb, 0xc7, 0x76, 0xfc, 0x23, 0x00, 0x00, 0xff, 0xff, 0x44, 0xf4,
-	0x42, 0xb5, 0x12, 0x0a, 0x00, 0x00,
+func init() { proto.RegisterFile("registration.proto", fileDescriptor_registration_63110d999d8b18ad) }
+
+var fileDescriptor_registration_63110d999d8b18ad = []byte{
+	// 1164 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0x6d, 0x73, 0xdb, 0x44,
+	0x10, 0x46, 0x8e, 0xe3, 0xc4, 0x6b, 0xe3, 0x24, 0x17, 0x27, 0x71, 0x45, 0x81, 0x54, 0x85, 0x21,
+	0x04, 0x90, 0xd3, 0xd0, 0x7e, 0xe8, 0x27, 0x70, 0x6c, 0x85, 0x31, 0x6e, 0x4b, 0x90, 0x1d, 0x3a,
+	0x93, 0x30, 0xe3, 0x51, 0xa4, 0x4b, 0x2a, 0xc7, 0x96, 0x8c, 0xee, 0xf2, 0xe2, 0x9f, 0xc3, 0x2f,
+	0xe0, 0x23, 0xc3, 0xff, 0xe0, 0x07, 0x31, 0xba, 0x3b, 0xbd, 0xd8, 0x96, 0x62, 0xc1, 0x94, 0x4f,
+	0xd1, 0xdd, 0x3e, 0xfb, 0xdc, 0x73, 0xbb, 0x7b, 0xde, 0x0d, 0x20, 0x0f, 0x5f, 0xd9, 0x84, 0x7a,
+	0x06, 0xb5, 0x5d, 0x47, 0x1d, 0x7b, 0x2e, 0x75, 0xd1, 0x36, 0x19, 0xdb, 0x1e, 0x56, 0x8d, 0xb1,
+	0xad, 0xc6, 0xad, 0xf2, 0xb3, 0x2b, 0x9b, 0xbe, 0xbb, 0xb9, 0x50, 0x4d, 0x77, 0x54, 0x27, 0x63,
+	0xfb, 0xf2, 0x12, 0xd7, 0x19, 0xb2, 0xce, 0xdc, 0xea, 0xa6, 0x3b, 0x1a, 0xb9, 0x8e, 0xf8, 0xc3,
+	0xa9, 0x94, 0xcf, 0x61, 0x53, 0x8f, 0x51, 0x68, 0x0e, 0xf5, 0x26, 0xed, 0x16, 0xaa, 0x40, 0xce,
+	0xb6, 0x6a, 0xd2, 0xae, 0xb4, 0x57, 0xd4, 0x73, 0xb6, 0xa5, 0xc8, 0xb0, 0x7a, 0x62, 0x78, 0xd8,
+	0xa1, 0xc9, 0xb6, 0x2e, 0x3b, 0x2c, 0xc1, 0xd6, 0x01, 0x74, 0x3a, 0xb6, 0x0c, 0x8a, 0x19, 0xb1,
+	0x8e, 0x7f, 0xbb, 0xc1, 0x84, 0xa2, 0x17, 0xb0, 0x8c, 0xfd, 0x35, 0x03, 0x96, 0x0e, 0x3f, 0x55,
+	0xf9, 0x7d, 0x84, 0xb0, 0x39, 0x3d, 0x3a, 0x47, 0x2b, 0xbf, 0x4b, 0xb0, 0x76, 0x8c, 0x2d, 0xec,
+	0x19, 0x14, 0x5b, 0x47, 0x37, 0x8e, 0x35, 0xc4, 0xe8, 0x00, 0xaa, 0x2d, 0xed, 0x44, 0xd7, 0x9a,
+	0x8d, 0x9e, 0xd6, 0xea, 0xf3, 0x4b, 0xf7, 0x43, 0x09, 0x28, 0xb2, 0x09, 0x89, 0x16, 0x52, 0x61,
+	0x33, 0xe6, 0x61, 0x1a, 0x7d, 0x13, 0x7b, 0x94, 0xd4, 0x72, 0xbb, 0xd2, 0x5e, 0x59, 0xdf, 0x88,
+	0x4c, 0x4d, 0xa3, 0xe9, 0x1b, 0xd0, 0xd7, 0x50, 0xb8, 0x60, 0x67, 0xd5, 0x96, 0x98, 0xda, 0xea,
+	0xb4, 0x5a, 0xae, 0x43, 0x17, 0x18, 0xe5, 0x29, 0x6c, 0xcc, 0x48, 0x4c, 0x88, 0xca, 0x1f, 0x12,
+	0x3c, 0x6e, 0xe1, 0x21, 0xa6, 0x78, 0x06, 0x1b, 0x04, 0x68, 0xc6, 0x01, 0xbd, 0x86, 0xfc, 0xc8,
+	0xb5, 0x30, 0x13, 0x59, 0x39, 0x7c, 0xa9, 0x26, 0xe7, 0x5f, 0x7d, 0x88, 0x53, 0x7d, 0xed, 0x5a,
+	0x58, 0x67, 0x34, 0xca, 0x01, 0xe4, 0xfd, 0x15, 0x2a, 0xc3, 0xaa, 0xae, 0x75, 0x7b, 0x7a, 0xbb,
+	0xd9, 0x5b, 0xff, 0x00, 0x01, 0x14, 0x5a, 0xda, 0x2b, 0xad, 0xa7, 0xad, 0x4b, 0xa8, 0x02, 0xd0,
+	0x6a, 0x77, 0xbb, 0x3f, 0x35, 0xdb, 0x8d, 0x9e, 0xb6, 0x9e, 0x53, 0xfe, 0x96, 0xa0, 0xf8, 0xa3,
+	0x6b, 0x3b, 0x3d, 0xf7, 0x1a, 0x3b, 0xa8, 0x0a, 0xcb, 0xd4, 0xff, 0x10, 0x0a, 0xf9, 0x02, 0xad,
+	0xc3, 0x12, 0xa5, 0x43, 0xa6, 0x71, 0x59, 0xf7, 0x3f, 0xd1, 0x47, 0x50, 0x8c, 0x32, 0xb2, 0xc4,
+	0xb0, 0xab, 0x24, 0xc8, 0xc3, 0x73, 0x28, 0x12, 0x3c, 0xc4, 0x26, 0x75, 0x3d, 0x52, 0xcb, 0xef,
+	0x2e, 0xed, 0x95, 0x0e, 0xb7, 0xa7, 0x43, 0xdb, 0x15, 0x66, 0x3d, 0x02, 0xa2, 0x47, 0xb0, 0x3a,
+	0x32, 0xee, 0xfb, 0x37, 0x04, 0x93, 0xda, 0x32, 0x3b, 0x69, 0x65, 0x64, 0xdc, 0x9f, 0x12, 0x4c,
+	0x10, 0x82, 0x3c, 0xdb, 0x2e, 0xb0, 0x6d, 0xf6, 0x8d, 0x3e, 0x06, 0xc0, 0xf7, 0x3e, 0x27, 0xe9,
+	0x1b, 0xb4, 0xb6, 0xb2, 0x2b, 0xed, 0x2d, 0xe9, 0x45, 0xb1, 0xd3, 0xa0, 0xca, 0x0e, 0x6c, 0xbd,
+	0xb2, 0x09, 0x0d, 0x6f, 0x46, 0x44, 0xb0, 0x94, 0x5f, 0x61, 0x7b, 0xd6, 0x40, 0xc6, 0xae, 0x43,
+	0x30, 0x3a, 0x82, 0xd2, 0xc0, 0xb5, 0x9d, 0x3e, 0xbb, 0x33, 0xa9, 0x49, 0x4c, 0xf8, 0x93, 0xb4,
+	0x8c, 0x84, 0x04, 0x3a, 0x0c, 0x42, 0x2e, 0x45, 0x85, 0x6d, 0x1d, 0xdf, 0xba, 0xd7, 0x38, 0x32,
+	0x8b, 0xc4, 0x27, 0x46, 0x56, 0x39, 0x87, 0x9d, 0x39, 0xbc, 0x90, 0xf3, 0x3d, 0x40, 0x24, 0x47,
+	0xbc, 0xa7, 0x0c, 0x6a, 0x8a, 0xa1, 0x1a, 0xe5, 0x12, 0x0a, 0xe2, 0x2d, 0xa5, 0xbc, 0x0c, 0x69,
+	0xf1, 0xcb, 0xc8, 0x65, 0x78, 0x19, 0x9b, 0xb0, 0xe1, 0x87, 0xb4, 0x71, 0x85, 0x1d, 0x1a, 0xc6,
+	0xf9, 0x18, 0x50, 0x7c, 0x53, 0x5c, 0xea, 0x00, 0x96, 0x1d, 0xd7, 0xc2, 0x41, 0x74, 0xe5, 0x69,
+	0xde, 0x06, 0xa5, 0x98, 0x50, 0x6c, 0xbd, 0xf1, 0x0b, 0x9a, 0x03, 0x95, 0x3a, 0x6c, 0x68, 0xb7,
+	0xb6, 0xc9, 0x89, 0x82, 0x60, 0xca, 0x10, 0x54, 0x5b, 0x4b, 0xc4, 0x33, 0x5c, 0x2b, 0x2d, 0x40,
+	0x71, 0x07, 0x71, 0xb0, 0x0a, 0x79, 0x9f, 0x4f, 0xc4, 0xf1, 0xa1, 0x73, 0x19, 0x4e, 0xf9, 0x4b,
+	0x82, 0x72, 0xb3, 0xd1, 0xc1, 0x93, 0xb1, 0x61, 0x7b, 0x5d, 0x4c, 0xfd, 0x1a, 0x24, 0x43, 0x97,
+	0x8a, 0xe3, 0xd8, 0x37, 0xda, 0x86, 0x82, 0x61, 0x52, 0xfb, 0x96, 0x87, 0x69, 0x55, 0x17, 0x2b,
+	0xb4, 0x03, 0x2b, 0xf7, 0x2f, 0x0e, 0x5e, 0xf6, 0x4d, 0x83, 0xbd, 0x8d, 0xb2, 0x5e, 0xf0, 0x97,
+	0x4d, 0x03, 0x3d, 0x85, 0x8a, 0x30, 0xf4, 0xaf, 0xf1, 0xc4, 0x7f, 0x3b, 0x79, 0x46, 0x57, 0xe2,
+	0xf6, 0x0e, 0x9e, 0xb4, 0x2d, 0xf4, 0x1d, 0xac, 0x0d, 0xee, 0x68, 0x9f, 0xd8, 0x57, 0x8e, 0xed,
+	0x5c, 0xf9, 0x40, 0xf6, 0x1e, 0x4a, 0x87, 0x3b, 0xd3, 0xaa, 0x4f, 0x6e, 0x2e, 0x86, 0xb6, 0xd9,
+	0xc1, 0x13, 0xfd, 0xc3, 0xc1, 0x1d, 0xed, 0x72, 0x78, 0x07, 0x4f, 0x14, 0x0b, 0x1e, 0xf9, 0xa1,
+	0x8f, 0xcb, 0x8f, 0x32, 0xf0, 0x03, 0x94, 0xaf, 0xf9, 0x76, 0x9f, 0x60, 0x1a, 0x24, 0xe2, 0xb3,
+	0xb4, 0xc2, 0x8a, 0x93, 0xe8, 0xa5, 0xeb, 0x88, 0x50, 0x79, 0x0b, 0x88, 0x97, 0x2e, 0x83, 0x04,
+	0x99, 0x99, 0xbf, 0xa1, 0x34, 0x7f, 0xc3, 0xc7, 0x00, 0xfe, 0x0d, 0x05, 0x20, 0xc7, 0x13, 0x38,
+	0xb8, 0xa3, 0xcc, 0x7a, 0xf8, 0xe7, 0x3a, 0x94, 0xe3, 0x9d, 0x02, 0x9d, 0x43, 0xa9, 0xe9, 0xe1,
+	0xa0, 0xd5, 0xa0, 0x45, 0x4d, 0x45, 0xfe, 0x2a, 0xed, 0x32, 0x49, 0xfd, 0xf0, 0x1c, 0x4a, 0xfc,
+	0xc7, 0x95, 0x93, 0xff, 0x1b, 0x5f, 0x79, 0x91, 0x12, 0x74, 0x06, 0x70, 0x8c, 0xa9, 0xf9, 0xee,
+	0xff, 0xe0, 0x3e, 0x86, 0x72, 0xc8, 0x6d, 0x63, 0x82, 0x36, 0xa7, 0x1d, 0xb4, 0xd1, 0x98, 0x4e,
+	0xe4, 0x27, 0x0f, 0xb3, 0xf8, 0x7e, 0x67, 0x50, 0x8a, 0x35, 0x72, 0xb4, 0x9f, 0x26, 0x72, 0xbe,
+	0xdb, 0x2f, 0xd6, 0x78, 0x0a, 0x15, 0xbf, 0x12, 0x8f, 0x26, 0xe1, 0x88, 0xb1, 0x9b, 0x46, 0x1f,
+	0x20, 0xb2, 0x48, 0xee, 0x04, 0xb4, 0x41, 0x1f, 0x41, 0x29, 0xfd, 0x25, 0x0b, 0x59, 0xa8, 0x31,
+	0x1c, 0x75, 0x52, 0x35, 0x06, 0x88, 0x6c, 0xb4, 0x5b, 0xbc, 0x68, 0x67, 0xe7, 0x9a, 0x2f, 0xd2,
+	0xd8, 0x67, 0x80, 0x72, 0x52, 0x42, 0xd1, 0x00, 0xaa, 0x2c, 0xeb, 0xb3, 0xac, 0x5f, 0x66, 0x64,
+	0x6d, 0xb7, 0xe4, 0xac, 0x02, 0xd0, 0x2f, 0x50, 0xf5, 0x23, 0x33, 0xb3, 0x9d, 0x52, 0x69, 0x59,
+	0x59, 0x0f, 0x24, 0x3f, 0x34, 0xbc, 0x98, 0xde, 0x6f, 0x68, 0x2e, 0x60, 0x2b, 0x71, 0x4c, 0x42,
+	0xcf, 0xff, 0xcb, 0x54, 0x95, 0x7c, 0xc6, 0x5b, 0x58, 0xe3, 0x59, 0x8d, 0x46, 0xa6, 0xc5, 0x3d,
+	0x59, 0x5e, 0x0c, 0x41, 0x2e, 0xaf, 0xc2, 0x70, 0x83, 0xa0, 0x6f, 0xd2, 0x9c, 0x12, 0xe7, 0x1a,
+	0x59, 0xcd, 0x0a, 0x17, 0x7d, 0xc0, 0x83, 0xb5, 0x99, 0xc9, 0x03, 0xa9, 0xe9, 0xbf, 0x4f, 0x49,
+	0x23, 0x8d, 0x5c, 0xcf, 0x8c, 0x8f, 0x26, 0x2c, 0x56, 0xbc, 0x22, 0x2f, 0x89, 0x75, 0xf4, 0x49,
+	0x1a, 0xa9, 0x70, 0x32, 0x01, 0xa2, 0xf6, 0x9e, 0x5e, 0xf6, 0x73, 0x33, 0x83, 0xbc, 0x9f, 0x05,
+	0x2a, 0x84, 0x9a, 0x00, 0xd1, 0xf0, 0x92, 0x7e, 0xc8, 0xdc, 0xd4, 0x23, 0xef, 0x67, 0x81, 0x8a,
+	0x43, 0xce, 0xf9, 0xd8, 0x34, 0xd5, 0xa6, 0x93, 0x63, 0xf2, 0xec, 0x21, 0xd6, 0xe4, 0x36, 0x7f,
+	0x02, 0x9b, 0x27, 0x1e, 0x1e, 0x1b, 0x1e, 0x8e, 0xdb, 0x93, 0xe9, 0x33, 0x35, 0x7f, 0xf4, 0x33,
+	0x54, 0x1b, 0xfe, 0x78, 0x63, 0xd0, 0xf7, 0x46, 0xf9, 0x06, 0x4a, 0xb1, 0x11, 0x22, 0xbd, 0xf5,
+	0xcc, 0xcf, 0x19, 0x89, 0xaf, 0xf3, 0xa8, 0x72, 0x56, 0x8e, 0xfb, 0x5d, 0x14, 0xd8, 0x7f, 0xc2,
+	0xdf, 0xfe, 0x33, 0x00, 0x6e, 0xa6, 0x61, 0x6e, 0x6a, 0x0f, 0x00, 0x00,
 }
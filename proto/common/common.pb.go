@@ -18,6 +18,42 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// * Type of a private key
+type KeyType int32
+
+const (
+	KeyType_UNSPECIFIED_KEY_TYPE KeyType = 0
+	KeyType_EC_P256              KeyType = 1
+	KeyType_EC_P384              KeyType = 2
+	KeyType_RSA_1024             KeyType = 3
+	KeyType_RSA_2048             KeyType = 4
+	KeyType_RSA_4096             KeyType = 5
+)
+
+var KeyType_name = map[int32]string{
+	0: "UNSPECIFIED_KEY_TYPE",
+	1: "EC_P256",
+	2: "EC_P384",
+	3: "RSA_1024",
+	4: "RSA_2048",
+	5: "RSA_4096",
+}
+var KeyType_value = map[string]int32{
+	"UNSPECIFIED_KEY_TYPE": 0,
+	"EC_P256":              1,
+	"EC_P384":              2,
+	"RSA_1024":             3,
+	"RSA_2048":             4,
+	"RSA_4096":             5,
+}
+
+func (x KeyType) String() string {
+	return proto.EnumName(KeyType_name, int32(x))
+}
+func (KeyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_common_f45b06df570e93ba, []int{0}
+}
+
 // * Represents an empty message
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -29,7 +65,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{0}
+	return fileDescriptor_common_f45b06df570e93ba, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -64,7 +100,7 @@ func (m *AttestationData) Reset()         { *m = AttestationData{} }
 func (m *AttestationData) String() string { return proto.CompactTextString(m) }
 func (*AttestationData) ProtoMessage()    {}
 func (*AttestationData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{1}
+	return fileDescriptor_common_f45b06df570e93ba, []int{1}
 }
 func (m *AttestationData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationData.Unmarshal(m, b)
@@ -115,7 +151,7 @@ func (m *Selector) Reset()         { *m = Selector{} }
 func (m *Selector) String() string { return proto.CompactTextString(m) }
 func (*Selector) ProtoMessage()    {}
 func (*Selector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{2}
+	return fileDescriptor_common_f45b06df570e93ba, []int{2}
 }
 func (m *Selector) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Selector.Unmarshal(m, b)
@@ -162,7 +198,7 @@ func (m *Selectors) Reset()         { *m = Selectors{} }
 func (m *Selectors) String() string { return proto.CompactTextString(m) }
 func (*Selectors) ProtoMessage()    {}
 func (*Selectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{3}
+	return fileDescriptor_common_f45b06df570e93ba, []int{3}
 }
 func (m *Selectors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Selectors.Unmarshal(m, b)
@@ -198,7 +234,11 @@ type AttestedNode struct {
 	// Node certificate serial number
 	CertSerialNumber string `protobuf:"bytes,3,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
 	// Node certificate not_after (seconds since unix epoch)
-	CertNotAfter         int64    `protobuf:"varint,4,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	CertNotAfter int64 `protobuf:"varint,4,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	// IP address the node last connected from
+	LastSeenIp string `protobuf:"bytes,5,opt,name=last_seen_ip,json=lastSeenIp,proto3" json:"last_seen_ip,omitempty"`
+	// Time the node was last seen (seconds since unix epoch)
+	LastSeenAt           int64    `protobuf:"varint,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -208,7 +248,7 @@ func (m *AttestedNode) Reset()         { *m = AttestedNode{} }
 func (m *AttestedNode) String() string { return proto.CompactTextString(m) }
 func (*AttestedNode) ProtoMessage()    {}
 func (*AttestedNode) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{4}
+	return fileDescriptor_common_f45b06df570e93ba, []int{4}
 }
 func (m *AttestedNode) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestedNode.Unmarshal(m, b)
@@ -256,6 +296,20 @@ func (m *AttestedNode) GetCertNotAfter() int64 {
 	return 0
 }
 
+func (m *AttestedNode) GetLastSeenIp() string {
+	if m != nil {
+		return m.LastSeenIp
+	}
+	return ""
+}
+
+func (m *AttestedNode) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
 // * This is a curated record that the Server uses to set up and
 // manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
@@ -289,7 +343,7 @@ func (m *RegistrationEntry) Reset()         { *m = RegistrationEntry{} }
 func (m *RegistrationEntry) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntry) ProtoMessage()    {}
 func (*RegistrationEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{5}
+	return fileDescriptor_common_f45b06df570e93ba, []int{5}
 }
 func (m *RegistrationEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntry.Unmarshal(m, b)
@@ -378,7 +432,7 @@ func (m *RegistrationEntries) Reset()         { *m = RegistrationEntries{} }
 func (m *RegistrationEntries) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntries) ProtoMessage()    {}
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{6}
+	return fileDescriptor_common_f45b06df570e93ba, []int{6}
 }
 func (m *RegistrationEntries) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntries.Unmarshal(m, b)
@@ -417,7 +471,7 @@ func (m *Certificate) Reset()         { *m = Certificate{} }
 func (m *Certificate) String() string { return proto.CompactTextString(m) }
 func (*Certificate) ProtoMessage()    {}
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{7}
+	return fileDescriptor_common_f45b06df570e93ba, []int{7}
 }
 func (m *Certificate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Certificate.Unmarshal(m, b)
@@ -461,7 +515,7 @@ func (m *PublicKey) Reset()         { *m = PublicKey{} }
 func (m *PublicKey) String() string { return proto.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()    {}
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{8}
+	return fileDescriptor_common_f45b06df570e93ba, []int{8}
 }
 func (m *PublicKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublicKey.Unmarshal(m, b)
@@ -518,7 +572,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_cc2c2acbe08ae165, []int{9}
+	return fileDescriptor_common_f45b06df570e93ba, []int{9}
 }
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundle.Unmarshal(m, b)
@@ -570,47 +624,55 @@ func init() {
 	proto.RegisterType((*Certificate)(nil), "spire.common.Certificate")
 	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
 	proto.RegisterType((*Bundle)(nil), "spire.common.Bundle")
-}
-
-func init() { proto.RegisterFile("common.proto", fileDescriptor_common_cc2c2acbe08ae165) }
-
-var fileDescriptor_common_cc2c2acbe08ae165 = []byte{
-	// 591 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x4f, 0x6f, 0xd3, 0x4e,
-	0x10, 0x95, 0xeb, 0x26, 0xb1, 0xa7, 0xe9, 0x9f, 0xdf, 0xf6, 0x07, 0xb8, 0x42, 0x40, 0x64, 0x01,
-	0x8a, 0x10, 0x8a, 0x50, 0xe9, 0xa5, 0x07, 0x0e, 0xfd, 0x77, 0x88, 0x2a, 0x55, 0x95, 0x8b, 0x84,
-	0xe0, 0x62, 0x6d, 0xb2, 0x93, 0x76, 0xdb, 0x78, 0xd7, 0xda, 0x9d, 0x10, 0xfc, 0x21, 0xf8, 0x20,
-	0x9c, 0xf9, 0x82, 0x68, 0xd7, 0xa4, 0xa9, 0x03, 0x12, 0xb7, 0xdd, 0xb7, 0x6f, 0xc6, 0xef, 0xbd,
-	0x19, 0x19, 0xba, 0x63, 0x5d, 0x14, 0x5a, 0x0d, 0x4a, 0xa3, 0x49, 0xb3, 0xae, 0x2d, 0xa5, 0xc1,
-	0x41, 0x8d, 0xa5, 0x1d, 0x68, 0x9d, 0x15, 0x25, 0x55, 0xe9, 0x21, 0x6c, 0x1f, 0x11, 0xa1, 0x25,
-	0x4e, 0x52, 0xab, 0x53, 0x4e, 0x9c, 0x31, 0x58, 0xa7, 0xaa, 0xc4, 0x24, 0xe8, 0x05, 0xfd, 0x38,
-	0xf3, 0x67, 0x87, 0x09, 0x4e, 0x3c, 0x59, 0xeb, 0x05, 0xfd, 0x6e, 0xe6, 0xcf, 0xe9, 0x01, 0x44,
-	0x57, 0x38, 0xc5, 0x31, 0x69, 0xf3, 0xd7, 0x9a, 0xff, 0xa1, 0xf5, 0x95, 0x4f, 0x67, 0xe8, 0x8b,
-	0xe2, 0xac, 0xbe, 0xa4, 0x1f, 0x20, 0x5e, 0x54, 0x59, 0xf6, 0x0e, 0x3a, 0xa8, 0xc8, 0x48, 0xb4,
-	0x49, 0xd0, 0x0b, 0xfb, 0x1b, 0xfb, 0x8f, 0x07, 0x0f, 0x65, 0x0e, 0x16, 0xcc, 0x6c, 0x41, 0x4b,
-	0x7f, 0x06, 0xd0, 0xad, 0x05, 0xa3, 0xb8, 0xd0, 0x02, 0xd9, 0x53, 0x88, 0x6d, 0x29, 0x27, 0x13,
-	0xcc, 0xa5, 0xf8, 0xfd, 0xf9, 0xa8, 0x06, 0x86, 0x82, 0xed, 0xc3, 0x23, 0xbe, 0x74, 0x97, 0x3b,
-	0xd9, 0xb9, 0xd7, 0x59, 0x4b, 0xda, 0xe5, 0x4d, 0xeb, 0x1f, 0x9d, 0xec, 0xb7, 0xc0, 0xc6, 0x68,
-	0x28, 0xb7, 0x68, 0x24, 0x9f, 0xe6, 0x6a, 0x56, 0x8c, 0xd0, 0x24, 0xa1, 0x2f, 0xd8, 0x71, 0x2f,
-	0x57, 0xfe, 0xe1, 0xc2, 0xe3, 0xec, 0x25, 0x6c, 0x79, 0xb6, 0xd2, 0x94, 0xf3, 0x09, 0xa1, 0x49,
-	0xd6, 0x7b, 0x41, 0x3f, 0xcc, 0xba, 0x0e, 0xbd, 0xd0, 0x74, 0xe4, 0xb0, 0xf4, 0xfb, 0x1a, 0xfc,
-	0x97, 0xe1, 0xb5, 0xb4, 0x64, 0xfc, 0xc7, 0xce, 0x14, 0x99, 0x8a, 0x1d, 0x40, 0x6c, 0x17, 0x51,
-	0xfc, 0xc3, 0xff, 0x92, 0xe8, 0x0c, 0x97, 0xdc, 0xa0, 0x22, 0x67, 0xb8, 0xf6, 0x11, 0xd5, 0xc0,
-	0x50, 0x34, 0xd3, 0x08, 0x57, 0xd2, 0xd8, 0x81, 0x90, 0x68, 0xea, 0x05, 0xb6, 0x32, 0x77, 0x64,
-	0xaf, 0x60, 0x6b, 0x82, 0x02, 0x0d, 0x27, 0xb4, 0xf9, 0x5c, 0xd2, 0x4d, 0xd2, 0xea, 0x85, 0xfd,
-	0x38, 0xdb, 0xbc, 0x47, 0x3f, 0x49, 0xba, 0x61, 0x7b, 0x10, 0xb9, 0xfc, 0x2b, 0xd7, 0xb4, 0xed,
-	0x9b, 0xfa, 0x79, 0x54, 0x43, 0xe1, 0x86, 0xcc, 0x45, 0x21, 0x55, 0xd2, 0xe9, 0x05, 0xfd, 0x28,
-	0xab, 0x2f, 0xec, 0x39, 0x80, 0xd0, 0x73, 0x65, 0xc9, 0x20, 0x2f, 0x92, 0xc8, 0x3f, 0x3d, 0x40,
-	0xd2, 0x4b, 0xd8, 0x5d, 0x8d, 0x43, 0xa2, 0x65, 0x87, 0xab, 0xeb, 0xf0, 0xa2, 0x19, 0xc7, 0x1f,
-	0x11, 0x2e, 0xf7, 0xe2, 0x0d, 0x6c, 0x9c, 0xa0, 0x21, 0x39, 0x91, 0x63, 0x4e, 0x7e, 0x2b, 0x04,
-	0x9a, 0x7c, 0x54, 0x91, 0xef, 0xe5, 0x96, 0x36, 0x12, 0x68, 0x8e, 0xdd, 0x3d, 0xfd, 0x0c, 0xf1,
-	0xe5, 0x6c, 0x34, 0x95, 0xe3, 0x73, 0xac, 0xd8, 0x33, 0x80, 0xf2, 0x4e, 0x7e, 0x6b, 0x50, 0x63,
-	0x87, 0x78, 0xae, 0xcb, 0xec, 0xee, 0x3e, 0x67, 0x77, 0x74, 0xad, 0x97, 0xc3, 0x0e, 0xfd, 0xb0,
-	0x23, 0xb5, 0x18, 0xf4, 0x8f, 0x00, 0xda, 0xc7, 0x33, 0x25, 0xa6, 0xc8, 0x5e, 0xc3, 0x36, 0x99,
-	0x99, 0xa5, 0x5c, 0xe8, 0x82, 0x4b, 0xb5, 0x5c, 0xcf, 0x4d, 0x0f, 0x9f, 0x7a, 0x74, 0x28, 0xd8,
-	0x01, 0x44, 0x46, 0x6b, 0xca, 0xc7, 0xdc, 0x26, 0x6b, 0xde, 0xf5, 0x5e, 0xd3, 0xf5, 0x03, 0x5f,
-	0x59, 0xc7, 0x51, 0x4f, 0xb8, 0x65, 0x47, 0xb0, 0x73, 0x3b, 0xa7, 0xdc, 0xca, 0x6b, 0x25, 0xd5,
-	0x75, 0x7e, 0x87, 0x95, 0x4d, 0x42, 0x5f, 0xfd, 0xa4, 0x59, 0x7d, 0xef, 0x34, 0xdb, 0xba, 0x9d,
-	0xd3, 0x55, 0xcd, 0x3f, 0xc7, 0xca, 0x1e, 0x47, 0x5f, 0xda, 0x35, 0x67, 0xd4, 0xf6, 0xbf, 0x88,
-	0xf7, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x56, 0x58, 0xbb, 0x7c, 0x32, 0x04, 0x00, 0x00,
+	proto.RegisterEnum("spire.common.KeyType", KeyType_name, KeyType_value)
+}
+
+func init() { proto.RegisterFile("common.proto", fileDescriptor_common_f45b06df570e93ba) }
+
+var fileDescriptor_common_f45b06df570e93ba = []byte{
+	// 699 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xd1, 0x8e, 0x22, 0x45,
+	0x14, 0xb5, 0xa7, 0x07, 0xe8, 0xbe, 0xc3, 0xce, 0xb6, 0xb5, 0xab, 0xf6, 0xc6, 0xa8, 0xa4, 0xa3,
+	0x86, 0x6c, 0xcc, 0x04, 0x59, 0xdc, 0xec, 0x3c, 0xf8, 0xc0, 0x30, 0x98, 0x10, 0x12, 0x42, 0x9a,
+	0x35, 0x66, 0x7c, 0xa9, 0x14, 0xf4, 0x85, 0xad, 0x1d, 0xa8, 0xee, 0x54, 0x5d, 0xc4, 0xfe, 0x08,
+	0x3f, 0xc4, 0x2f, 0xf4, 0xd5, 0x54, 0xf5, 0x02, 0xc3, 0x68, 0xb2, 0x6f, 0x75, 0x4f, 0x9d, 0x7b,
+	0x39, 0xf7, 0xd4, 0xa1, 0xa1, 0xb9, 0xc8, 0x37, 0x9b, 0x5c, 0x5d, 0x15, 0x3a, 0xa7, 0x9c, 0x35,
+	0x4d, 0x21, 0x35, 0x5e, 0x55, 0x58, 0xd2, 0x80, 0xda, 0x70, 0x53, 0x50, 0x99, 0x5c, 0xc3, 0xd3,
+	0x3e, 0x11, 0x1a, 0x12, 0x24, 0x73, 0x75, 0x2b, 0x48, 0x30, 0x06, 0xe7, 0x54, 0x16, 0x18, 0x7b,
+	0x2d, 0xaf, 0x1d, 0xa6, 0xee, 0x6c, 0xb1, 0x4c, 0x90, 0x88, 0xcf, 0x5a, 0x5e, 0xbb, 0x99, 0xba,
+	0x73, 0xd2, 0x83, 0x60, 0x86, 0x6b, 0x5c, 0x50, 0xae, 0xff, 0xb7, 0xe7, 0x39, 0xd4, 0xfe, 0x10,
+	0xeb, 0x2d, 0xba, 0xa6, 0x30, 0xad, 0x8a, 0xe4, 0x67, 0x08, 0xf7, 0x5d, 0x86, 0x75, 0xa0, 0x81,
+	0x8a, 0xb4, 0x44, 0x13, 0x7b, 0x2d, 0xbf, 0x7d, 0xd1, 0xfd, 0xfc, 0xea, 0xa1, 0xcc, 0xab, 0x3d,
+	0x33, 0xdd, 0xd3, 0x92, 0x7f, 0x3c, 0x68, 0x56, 0x82, 0x31, 0x9b, 0xe4, 0x19, 0xb2, 0x2f, 0x21,
+	0x34, 0x85, 0x5c, 0x2e, 0x91, 0xcb, 0xec, 0xc3, 0xcf, 0x07, 0x15, 0x30, 0xca, 0x58, 0x17, 0x3e,
+	0x13, 0xc7, 0xed, 0xb8, 0x95, 0xcd, 0x9d, 0xce, 0x4a, 0xd2, 0x33, 0x71, 0xba, 0xfa, 0x5b, 0x2b,
+	0xfb, 0x07, 0x60, 0x0b, 0xd4, 0xc4, 0x0d, 0x6a, 0x29, 0xd6, 0x5c, 0x6d, 0x37, 0x73, 0xd4, 0xb1,
+	0xef, 0x1a, 0x22, 0x7b, 0x33, 0x73, 0x17, 0x13, 0x87, 0xb3, 0x6f, 0xe1, 0xd2, 0xb1, 0x55, 0x4e,
+	0x5c, 0x2c, 0x09, 0x75, 0x7c, 0xde, 0xf2, 0xda, 0x7e, 0xda, 0xb4, 0xe8, 0x24, 0xa7, 0xbe, 0xc5,
+	0x58, 0x0b, 0x9a, 0x6b, 0x61, 0xec, 0x4c, 0x54, 0x5c, 0x16, 0x71, 0xcd, 0x4d, 0x03, 0x8b, 0xcd,
+	0x10, 0xd5, 0xa8, 0x38, 0x65, 0x08, 0x8a, 0xeb, 0x6e, 0xca, 0x81, 0xd1, 0xa7, 0xe4, 0xaf, 0x33,
+	0xf8, 0x34, 0xc5, 0x95, 0x34, 0xa4, 0x9d, 0xe0, 0xa1, 0x22, 0x5d, 0xb2, 0x1e, 0x84, 0x66, 0x6f,
+	0xe7, 0x47, 0x3c, 0x3c, 0x12, 0xad, 0x69, 0x85, 0xd0, 0xa8, 0xc8, 0x9a, 0x56, 0x79, 0x11, 0x54,
+	0xc0, 0x28, 0x3b, 0x75, 0xd4, 0x7f, 0xe4, 0x68, 0x04, 0x3e, 0xd1, 0xda, 0x2d, 0x59, 0x4b, 0xed,
+	0x91, 0x7d, 0x07, 0x97, 0x4b, 0xcc, 0x50, 0x0b, 0x42, 0xc3, 0x77, 0x92, 0xde, 0xc5, 0xb5, 0x96,
+	0xdf, 0x0e, 0xd3, 0x27, 0x07, 0xf4, 0x37, 0x49, 0xef, 0xd8, 0x0b, 0x08, 0xec, 0x1b, 0x96, 0x76,
+	0x68, 0xdd, 0x0d, 0x75, 0x6f, 0x5a, 0x8e, 0x32, 0x1b, 0x14, 0x91, 0x6d, 0xa4, 0x8a, 0x1b, 0x2d,
+	0xaf, 0x1d, 0xa4, 0x55, 0xc1, 0xbe, 0x06, 0xc8, 0xf2, 0x9d, 0x32, 0xa4, 0x51, 0x6c, 0xe2, 0xc0,
+	0x5d, 0x3d, 0x40, 0x92, 0x29, 0x3c, 0x7b, 0x6c, 0x87, 0x44, 0xc3, 0xae, 0x1f, 0x47, 0xea, 0x9b,
+	0x53, 0x3b, 0xfe, 0x63, 0xe1, 0x31, 0x5b, 0x2f, 0xe1, 0x62, 0x80, 0x9a, 0xe4, 0x52, 0x2e, 0x04,
+	0xb9, 0x64, 0x65, 0xa8, 0xf9, 0xbc, 0x24, 0x37, 0xcb, 0x06, 0x3f, 0xc8, 0x50, 0xdf, 0xd8, 0x3a,
+	0xb9, 0x83, 0x70, 0xba, 0x9d, 0xaf, 0xe5, 0x62, 0x8c, 0x25, 0xfb, 0x0a, 0xa0, 0xb8, 0x97, 0x7f,
+	0x9e, 0x50, 0x43, 0x8b, 0x38, 0xae, 0xf5, 0xec, 0xfe, 0xe0, 0xb3, 0x3d, 0xda, 0xd1, 0xc7, 0xc0,
+	0xf8, 0xee, 0xa9, 0x03, 0xf5, 0x21, 0x2c, 0xc9, 0xdf, 0x1e, 0xd4, 0x6f, 0xb6, 0x2a, 0x5b, 0x23,
+	0xfb, 0x1e, 0x9e, 0x92, 0xde, 0x1a, 0xe2, 0x59, 0xbe, 0x11, 0x52, 0x1d, 0x23, 0xfe, 0xc4, 0xc1,
+	0xb7, 0x0e, 0x1d, 0x65, 0xac, 0x07, 0x81, 0xce, 0x73, 0xe2, 0x0b, 0x61, 0xe2, 0x33, 0xb7, 0xf5,
+	0x8b, 0xd3, 0xad, 0x1f, 0xec, 0x95, 0x36, 0x2c, 0x75, 0x20, 0x0c, 0xeb, 0x43, 0xf4, 0x7e, 0x47,
+	0xdc, 0xc8, 0x95, 0x92, 0x6a, 0xc5, 0xef, 0xb1, 0x34, 0xb1, 0xef, 0xba, 0xbf, 0x38, 0xed, 0x3e,
+	0x6c, 0x9a, 0x5e, 0xbe, 0xdf, 0xd1, 0xac, 0xe2, 0x8f, 0xb1, 0x34, 0x2f, 0x57, 0xd0, 0x18, 0x63,
+	0xe9, 0xfe, 0x37, 0x31, 0x3c, 0xff, 0x75, 0x32, 0x9b, 0x0e, 0x07, 0xa3, 0x5f, 0x46, 0xc3, 0x5b,
+	0x3e, 0x1e, 0xde, 0xf1, 0xb7, 0x77, 0xd3, 0x61, 0xf4, 0x09, 0xbb, 0x80, 0xc6, 0x70, 0xc0, 0xa7,
+	0xdd, 0x9f, 0x5e, 0x47, 0xde, 0xbe, 0x78, 0xf5, 0xa6, 0x17, 0x9d, 0xb1, 0x26, 0x04, 0xe9, 0xac,
+	0xcf, 0x7f, 0xec, 0x74, 0x7b, 0x91, 0xbf, 0xaf, 0xba, 0x9d, 0xde, 0x9b, 0xe8, 0x7c, 0x5f, 0xf5,
+	0x3a, 0xd7, 0xaf, 0xa3, 0xda, 0x4d, 0xf0, 0x7b, 0xbd, 0x12, 0x33, 0xaf, 0xbb, 0xef, 0xd9, 0xab,
+	0x7f, 0x07, 0x00, 0x64, 0xdd, 0x85, 0x6d, 0xdf, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,934 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: cri.proto
+
+package cri
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// * ImageSpec is an internal representation of an image.
+type ImageSpec struct {
+	// * Container's Image field (e.g. imageID or imageDigest).
+	Image                string   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageSpec) Reset()         { *m = ImageSpec{} }
+func (m *ImageSpec) String() string { return proto.CompactTextString(m) }
+func (*ImageSpec) ProtoMessage()    {}
+func (*ImageSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{0}
+}
+func (m *ImageSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageSpec.Unmarshal(m, b)
+}
+func (m *ImageSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageSpec.Marshal(b, m, deterministic)
+}
+func (dst *ImageSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageSpec.Merge(dst, src)
+}
+func (m *ImageSpec) XXX_Size() int {
+	return xxx_messageInfo_ImageSpec.Size(m)
+}
+func (m *ImageSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageSpec proto.InternalMessageInfo
+
+func (m *ImageSpec) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+// * ContainerMetadata holds all necessary information for building the
+// container name.
+type ContainerMetadata struct {
+	// * Name of the container.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// * Attempt number of creating the container.
+	Attempt              uint32   `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerMetadata) Reset()         { *m = ContainerMetadata{} }
+func (m *ContainerMetadata) String() string { return proto.CompactTextString(m) }
+func (*ContainerMetadata) ProtoMessage()    {}
+func (*ContainerMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{1}
+}
+func (m *ContainerMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerMetadata.Unmarshal(m, b)
+}
+func (m *ContainerMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerMetadata.Marshal(b, m, deterministic)
+}
+func (dst *ContainerMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerMetadata.Merge(dst, src)
+}
+func (m *ContainerMetadata) XXX_Size() int {
+	return xxx_messageInfo_ContainerMetadata.Size(m)
+}
+func (m *ContainerMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerMetadata proto.InternalMessageInfo
+
+func (m *ContainerMetadata) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ContainerMetadata) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+// * ContainerFilter is used to filter containers. All those fields are
+// combined with 'AND'.
+type ContainerFilter struct {
+	// * ID of the container.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContainerFilter) Reset()         { *m = ContainerFilter{} }
+func (m *ContainerFilter) String() string { return proto.CompactTextString(m) }
+func (*ContainerFilter) ProtoMessage()    {}
+func (*ContainerFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{2}
+}
+func (m *ContainerFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerFilter.Unmarshal(m, b)
+}
+func (m *ContainerFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerFilter.Marshal(b, m, deterministic)
+}
+func (dst *ContainerFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerFilter.Merge(dst, src)
+}
+func (m *ContainerFilter) XXX_Size() int {
+	return xxx_messageInfo_ContainerFilter.Size(m)
+}
+func (m *ContainerFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerFilter proto.InternalMessageInfo
+
+func (m *ContainerFilter) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// * Represents a request to list containers.
+type ListContainersRequest struct {
+	Filter               *ContainerFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListContainersRequest) Reset()         { *m = ListContainersRequest{} }
+func (m *ListContainersRequest) String() string { return proto.CompactTextString(m) }
+func (*ListContainersRequest) ProtoMessage()    {}
+func (*ListContainersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{3}
+}
+func (m *ListContainersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersRequest.Unmarshal(m, b)
+}
+func (m *ListContainersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListContainersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersRequest.Merge(dst, src)
+}
+func (m *ListContainersRequest) XXX_Size() int {
+	return xxx_messageInfo_ListContainersRequest.Size(m)
+}
+func (m *ListContainersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersRequest proto.InternalMessageInfo
+
+func (m *ListContainersRequest) GetFilter() *ContainerFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+// * Container provides the runtime information for a container, such as ID,
+// hash, state of the container.
+type Container struct {
+	// * ID of the container, used by the container runtime to identify
+	// a container.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// * ID of the sandbox to which this container belongs.
+	PodSandboxId string `protobuf:"bytes,2,opt,name=pod_sandbox_id,json=podSandboxId,proto3" json:"pod_sandbox_id,omitempty"`
+	// * Metadata of the container.
+	Metadata *ContainerMetadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// * Spec of the image.
+	Image *ImageSpec `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	// * Reference to the image in use. For most runtimes, this should be an
+	// image ID.
+	ImageRef string `protobuf:"bytes,5,opt,name=image_ref,json=imageRef,proto3" json:"image_ref,omitempty"`
+	// * Key-value pairs that may be used to scope and select individual
+	// resources.
+	Labels map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// * Unstructured key-value map holding arbitrary metadata.
+	Annotations          map[string]string `protobuf:"bytes,9,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Container) Reset()         { *m = Container{} }
+func (m *Container) String() string { return proto.CompactTextString(m) }
+func (*Container) ProtoMessage()    {}
+func (*Container) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{4}
+}
+func (m *Container) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Container.Unmarshal(m, b)
+}
+func (m *Container) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Container.Marshal(b, m, deterministic)
+}
+func (dst *Container) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Container.Merge(dst, src)
+}
+func (m *Container) XXX_Size() int {
+	return xxx_messageInfo_Container.Size(m)
+}
+func (m *Container) XXX_DiscardUnknown() {
+	xxx_messageInfo_Container.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Container proto.InternalMessageInfo
+
+func (m *Container) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Container) GetPodSandboxId() string {
+	if m != nil {
+		return m.PodSandboxId
+	}
+	return ""
+}
+
+func (m *Container) GetMetadata() *ContainerMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Container) GetImage() *ImageSpec {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *Container) GetImageRef() string {
+	if m != nil {
+		return m.ImageRef
+	}
+	return ""
+}
+
+func (m *Container) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *Container) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+// * Represents the containers matching the filter.
+type ListContainersResponse struct {
+	// * List of containers.
+	Containers           []*Container `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListContainersResponse) Reset()         { *m = ListContainersResponse{} }
+func (m *ListContainersResponse) String() string { return proto.CompactTextString(m) }
+func (*ListContainersResponse) ProtoMessage()    {}
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{5}
+}
+func (m *ListContainersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListContainersResponse.Unmarshal(m, b)
+}
+func (m *ListContainersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListContainersResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListContainersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListContainersResponse.Merge(dst, src)
+}
+func (m *ListContainersResponse) XXX_Size() int {
+	return xxx_messageInfo_ListContainersResponse.Size(m)
+}
+func (m *ListContainersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListContainersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListContainersResponse proto.InternalMessageInfo
+
+func (m *ListContainersResponse) GetContainers() []*Container {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+// * Represents a request for the status of a pod sandbox.
+type PodSandboxStatusRequest struct {
+	// * ID of the PodSandbox for which to retrieve status.
+	PodSandboxId         string   `protobuf:"bytes,1,opt,name=pod_sandbox_id,json=podSandboxId,proto3" json:"pod_sandbox_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodSandboxStatusRequest) Reset()         { *m = PodSandboxStatusRequest{} }
+func (m *PodSandboxStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*PodSandboxStatusRequest) ProtoMessage()    {}
+func (*PodSandboxStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{6}
+}
+func (m *PodSandboxStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSandboxStatusRequest.Unmarshal(m, b)
+}
+func (m *PodSandboxStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSandboxStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *PodSandboxStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSandboxStatusRequest.Merge(dst, src)
+}
+func (m *PodSandboxStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_PodSandboxStatusRequest.Size(m)
+}
+func (m *PodSandboxStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSandboxStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSandboxStatusRequest proto.InternalMessageInfo
+
+func (m *PodSandboxStatusRequest) GetPodSandboxId() string {
+	if m != nil {
+		return m.PodSandboxId
+	}
+	return ""
+}
+
+// * PodSandboxMetadata holds all necessary information for building the
+// sandbox name.
+type PodSandboxMetadata struct {
+	// * Pod name of the sandbox.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// * Pod UID of the sandbox.
+	Uid string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// * Pod namespace of the sandbox.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// * Attempt number of creating the sandbox.
+	Attempt              uint32   `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodSandboxMetadata) Reset()         { *m = PodSandboxMetadata{} }
+func (m *PodSandboxMetadata) String() string { return proto.CompactTextString(m) }
+func (*PodSandboxMetadata) ProtoMessage()    {}
+func (*PodSandboxMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{7}
+}
+func (m *PodSandboxMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSandboxMetadata.Unmarshal(m, b)
+}
+func (m *PodSandboxMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSandboxMetadata.Marshal(b, m, deterministic)
+}
+func (dst *PodSandboxMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSandboxMetadata.Merge(dst, src)
+}
+func (m *PodSandboxMetadata) XXX_Size() int {
+	return xxx_messageInfo_PodSandboxMetadata.Size(m)
+}
+func (m *PodSandboxMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSandboxMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSandboxMetadata proto.InternalMessageInfo
+
+func (m *PodSandboxMetadata) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PodSandboxMetadata) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *PodSandboxMetadata) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PodSandboxMetadata) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+// * PodSandboxStatus contains the status of the PodSandbox.
+type PodSandboxStatus struct {
+	// * ID of the sandbox.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// * Metadata of the sandbox.
+	Metadata *PodSandboxMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// * Labels are key-value pairs that may be used to scope and select
+	// individual resources.
+	Labels map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// * Unstructured key-value map holding arbitrary metadata.
+	Annotations          map[string]string `protobuf:"bytes,8,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PodSandboxStatus) Reset()         { *m = PodSandboxStatus{} }
+func (m *PodSandboxStatus) String() string { return proto.CompactTextString(m) }
+func (*PodSandboxStatus) ProtoMessage()    {}
+func (*PodSandboxStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{8}
+}
+func (m *PodSandboxStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSandboxStatus.Unmarshal(m, b)
+}
+func (m *PodSandboxStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSandboxStatus.Marshal(b, m, deterministic)
+}
+func (dst *PodSandboxStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSandboxStatus.Merge(dst, src)
+}
+func (m *PodSandboxStatus) XXX_Size() int {
+	return xxx_messageInfo_PodSandboxStatus.Size(m)
+}
+func (m *PodSandboxStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSandboxStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSandboxStatus proto.InternalMessageInfo
+
+func (m *PodSandboxStatus) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PodSandboxStatus) GetMetadata() *PodSandboxMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PodSandboxStatus) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *PodSandboxStatus) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+// * Represents the status of a pod sandbox.
+type PodSandboxStatusResponse struct {
+	// * Status of the PodSandbox.
+	Status               *PodSandboxStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PodSandboxStatusResponse) Reset()         { *m = PodSandboxStatusResponse{} }
+func (m *PodSandboxStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*PodSandboxStatusResponse) ProtoMessage()    {}
+func (*PodSandboxStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{9}
+}
+func (m *PodSandboxStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodSandboxStatusResponse.Unmarshal(m, b)
+}
+func (m *PodSandboxStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodSandboxStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *PodSandboxStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodSandboxStatusResponse.Merge(dst, src)
+}
+func (m *PodSandboxStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_PodSandboxStatusResponse.Size(m)
+}
+func (m *PodSandboxStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodSandboxStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodSandboxStatusResponse proto.InternalMessageInfo
+
+func (m *PodSandboxStatusResponse) GetStatus() *PodSandboxStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+// * Represents a request for the status of an image.
+type ImageStatusRequest struct {
+	// * Spec of the image.
+	Image                *ImageSpec `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ImageStatusRequest) Reset()         { *m = ImageStatusRequest{} }
+func (m *ImageStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ImageStatusRequest) ProtoMessage()    {}
+func (*ImageStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{10}
+}
+func (m *ImageStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageStatusRequest.Unmarshal(m, b)
+}
+func (m *ImageStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageStatusRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImageStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageStatusRequest.Merge(dst, src)
+}
+func (m *ImageStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ImageStatusRequest.Size(m)
+}
+func (m *ImageStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageStatusRequest proto.InternalMessageInfo
+
+func (m *ImageStatusRequest) GetImage() *ImageSpec {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+// * Basic information about a container image.
+type Image struct {
+	// * ID of the image.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// * Other names by which this image is known.
+	RepoTags []string `protobuf:"bytes,2,rep,name=repo_tags,json=repoTags,proto3" json:"repo_tags,omitempty"`
+	// * Digests by which this image is known.
+	RepoDigests          []string `protobuf:"bytes,3,rep,name=repo_digests,json=repoDigests,proto3" json:"repo_digests,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Image) Reset()         { *m = Image{} }
+func (m *Image) String() string { return proto.CompactTextString(m) }
+func (*Image) ProtoMessage()    {}
+func (*Image) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{11}
+}
+func (m *Image) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Image.Unmarshal(m, b)
+}
+func (m *Image) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Image.Marshal(b, m, deterministic)
+}
+func (dst *Image) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Image.Merge(dst, src)
+}
+func (m *Image) XXX_Size() int {
+	return xxx_messageInfo_Image.Size(m)
+}
+func (m *Image) XXX_DiscardUnknown() {
+	xxx_messageInfo_Image.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Image proto.InternalMessageInfo
+
+func (m *Image) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Image) GetRepoTags() []string {
+	if m != nil {
+		return m.RepoTags
+	}
+	return nil
+}
+
+func (m *Image) GetRepoDigests() []string {
+	if m != nil {
+		return m.RepoDigests
+	}
+	return nil
+}
+
+// * Represents the status of an image.
+type ImageStatusResponse struct {
+	// * Status of the image.
+	Image                *Image   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImageStatusResponse) Reset()         { *m = ImageStatusResponse{} }
+func (m *ImageStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*ImageStatusResponse) ProtoMessage()    {}
+func (*ImageStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cri_645b9471bbe86d7c, []int{12}
+}
+func (m *ImageStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImageStatusResponse.Unmarshal(m, b)
+}
+func (m *ImageStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImageStatusResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImageStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImageStatusResponse.Merge(dst, src)
+}
+func (m *ImageStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_ImageStatusResponse.Size(m)
+}
+func (m *ImageStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImageStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImageStatusResponse proto.InternalMessageInfo
+
+func (m *ImageStatusResponse) GetImage() *Image {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ImageSpec)(nil), "runtime.v1alpha2.ImageSpec")
+	proto.RegisterType((*ContainerMetadata)(nil), "runtime.v1alpha2.ContainerMetadata")
+	proto.RegisterType((*ContainerFilter)(nil), "runtime.v1alpha2.ContainerFilter")
+	proto.RegisterType((*ListContainersRequest)(nil), "runtime.v1alpha2.ListContainersRequest")
+	proto.RegisterType((*Container)(nil), "runtime.v1alpha2.Container")
+	proto.RegisterMapType((map[string]string)(nil), "runtime.v1alpha2.Container.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "runtime.v1alpha2.Container.LabelsEntry")
+	proto.RegisterType((*ListContainersResponse)(nil), "runtime.v1alpha2.ListContainersResponse")
+	proto.RegisterType((*PodSandboxStatusRequest)(nil), "runtime.v1alpha2.PodSandboxStatusRequest")
+	proto.RegisterType((*PodSandboxMetadata)(nil), "runtime.v1alpha2.PodSandboxMetadata")
+	proto.RegisterType((*PodSandboxStatus)(nil), "runtime.v1alpha2.PodSandboxStatus")
+	proto.RegisterMapType((map[string]string)(nil), "runtime.v1alpha2.PodSandboxStatus.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "runtime.v1alpha2.PodSandboxStatus.LabelsEntry")
+	proto.RegisterType((*PodSandboxStatusResponse)(nil), "runtime.v1alpha2.PodSandboxStatusResponse")
+	proto.RegisterType((*ImageStatusRequest)(nil), "runtime.v1alpha2.ImageStatusRequest")
+	proto.RegisterType((*Image)(nil), "runtime.v1alpha2.Image")
+	proto.RegisterType((*ImageStatusResponse)(nil), "runtime.v1alpha2.ImageStatusResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RuntimeServiceClient is the client API for RuntimeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RuntimeServiceClient interface {
+	// * ListContainers lists all containers by filters.
+	ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error)
+	// * PodSandboxStatus returns the status of the PodSandbox. If the
+	// PodSandbox is not present, returns an error.
+	PodSandboxStatus(ctx context.Context, in *PodSandboxStatusRequest, opts ...grpc.CallOption) (*PodSandboxStatusResponse, error)
+}
+
+type runtimeServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRuntimeServiceClient(cc *grpc.ClientConn) RuntimeServiceClient {
+	return &runtimeServiceClient{cc}
+}
+
+func (c *runtimeServiceClient) ListContainers(ctx context.Context, in *ListContainersRequest, opts ...grpc.CallOption) (*ListContainersResponse, error) {
+	out := new(ListContainersResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha2.RuntimeService/ListContainers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) PodSandboxStatus(ctx context.Context, in *PodSandboxStatusRequest, opts ...grpc.CallOption) (*PodSandboxStatusResponse, error) {
+	out := new(PodSandboxStatusResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha2.RuntimeService/PodSandboxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RuntimeServiceServer is the server API for RuntimeService service.
+type RuntimeServiceServer interface {
+	// * ListContainers lists all containers by filters.
+	ListContainers(context.Context, *ListContainersRequest) (*ListContainersResponse, error)
+	// * PodSandboxStatus returns the status of the PodSandbox. If the
+	// PodSandbox is not present, returns an error.
+	PodSandboxStatus(context.Context, *PodSandboxStatusRequest) (*PodSandboxStatusResponse, error)
+}
+
+func RegisterRuntimeServiceServer(s *grpc.Server, srv RuntimeServiceServer) {
+	s.RegisterService(&_RuntimeService_serviceDesc, srv)
+}
+
+func _RuntimeService_ListContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListContainers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha2.RuntimeService/ListContainers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListContainers(ctx, req.(*ListContainersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_PodSandboxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PodSandboxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).PodSandboxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha2.RuntimeService/PodSandboxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).PodSandboxStatus(ctx, req.(*PodSandboxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RuntimeService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "runtime.v1alpha2.RuntimeService",
+	HandlerType: (*RuntimeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListContainers",
+			Handler:    _RuntimeService_ListContainers_Handler,
+		},
+		{
+			MethodName: "PodSandboxStatus",
+			Handler:    _RuntimeService_PodSandboxStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cri.proto",
+}
+
+// ImageServiceClient is the client API for ImageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ImageServiceClient interface {
+	// * ImageStatus returns the status of the image. If the image is not
+	// present, returns a response with ImageStatusResponse.Image set to nil.
+	ImageStatus(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error)
+}
+
+type imageServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewImageServiceClient(cc *grpc.ClientConn) ImageServiceClient {
+	return &imageServiceClient{cc}
+}
+
+func (c *imageServiceClient) ImageStatus(ctx context.Context, in *ImageStatusRequest, opts ...grpc.CallOption) (*ImageStatusResponse, error) {
+	out := new(ImageStatusResponse)
+	err := c.cc.Invoke(ctx, "/runtime.v1alpha2.ImageService/ImageStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImageServiceServer is the server API for ImageService service.
+type ImageServiceServer interface {
+	// * ImageStatus returns the status of the image. If the image is not
+	// present, returns a response with ImageStatusResponse.Image set to nil.
+	ImageStatus(context.Context, *ImageStatusRequest) (*ImageStatusResponse, error)
+}
+
+func RegisterImageServiceServer(s *grpc.Server, srv ImageServiceServer) {
+	s.RegisterService(&_ImageService_serviceDesc, srv)
+}
+
+func _ImageService_ImageStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImageStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImageServiceServer).ImageStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/runtime.v1alpha2.ImageService/ImageStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImageServiceServer).ImageStatus(ctx, req.(*ImageStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ImageService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "runtime.v1alpha2.ImageService",
+	HandlerType: (*ImageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ImageStatus",
+			Handler:    _ImageService_ImageStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cri.proto",
+}
+
+func init() { proto.RegisterFile("cri.proto", fileDescriptor_cri_645b9471bbe86d7c) }
+
+var fileDescriptor_cri_645b9471bbe86d7c = []byte{
+	// 679 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x95, 0x5b, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x95, 0x64, 0xed, 0x96, 0xd3, 0x51, 0x8a, 0xb9, 0x2c, 0xda, 0x78, 0x68, 0xc3, 0x10,
+	0x05, 0x41, 0xa5, 0x75, 0x2f, 0x6c, 0x48, 0x8c, 0xc1, 0x18, 0x9a, 0x34, 0x10, 0x72, 0x19, 0x48,
+	0x7b, 0xa9, 0xbc, 0xc4, 0x2b, 0x86, 0x36, 0x09, 0xb1, 0x3b, 0xb1, 0x2f, 0x8b, 0xf8, 0x24, 0x08,
+	0xc5, 0x76, 0xb3, 0x5c, 0xba, 0xb6, 0xbc, 0xf1, 0x66, 0x1f, 0x9f, 0xcb, 0xdf, 0x27, 0x3f, 0x9f,
+	0x80, 0xed, 0xc5, 0xac, 0x13, 0xc5, 0xa1, 0x08, 0x51, 0x23, 0x1e, 0x07, 0x82, 0x8d, 0x68, 0xe7,
+	0x62, 0x8b, 0x0c, 0xa3, 0xaf, 0xa4, 0xeb, 0xb6, 0xc0, 0x3e, 0x1a, 0x91, 0x01, 0xed, 0x45, 0xd4,
+	0x43, 0x77, 0xa0, 0xc2, 0x92, 0x8d, 0x63, 0x34, 0x8d, 0xb6, 0x8d, 0xd5, 0xc6, 0xdd, 0x87, 0x5b,
+	0x6f, 0xc2, 0x40, 0x10, 0x16, 0xd0, 0xf8, 0x3d, 0x15, 0xc4, 0x27, 0x82, 0x20, 0x04, 0x4b, 0x01,
+	0x19, 0x4d, 0x3c, 0xe5, 0x1a, 0x39, 0xb0, 0x4c, 0x84, 0xa0, 0xa3, 0x48, 0x38, 0x66, 0xd3, 0x68,
+	0xdf, 0xc0, 0x93, 0xad, 0xdb, 0x82, 0x9b, 0x69, 0x8a, 0x43, 0x36, 0x14, 0x34, 0x46, 0x75, 0x30,
+	0x99, 0xaf, 0xc3, 0x4d, 0xe6, 0xbb, 0x18, 0xee, 0x1e, 0x33, 0x2e, 0x52, 0x37, 0x8e, 0xe9, 0x8f,
+	0x31, 0xe5, 0x02, 0xed, 0x40, 0xf5, 0x5c, 0x86, 0x48, 0xe7, 0x5a, 0xb7, 0xd5, 0x29, 0x5e, 0xa2,
+	0x53, 0xc8, 0x8d, 0x75, 0x80, 0xfb, 0xcb, 0x02, 0x3b, 0x3d, 0x2b, 0x56, 0x44, 0x9b, 0x50, 0x8f,
+	0x42, 0xbf, 0xcf, 0x49, 0xe0, 0x9f, 0x85, 0x3f, 0xfb, 0xcc, 0x97, 0xaa, 0x6d, 0xbc, 0x1a, 0x85,
+	0x7e, 0x4f, 0x19, 0x8f, 0x7c, 0xb4, 0x07, 0x2b, 0x23, 0x7d, 0x69, 0xc7, 0x92, 0x02, 0x1e, 0xcc,
+	0x10, 0x30, 0xe9, 0x0f, 0x4e, 0x83, 0xd0, 0xd6, 0xa4, 0xa9, 0x4b, 0x32, 0x7a, 0xa3, 0x1c, 0x9d,
+	0x7e, 0x00, 0xdd, 0x71, 0xb4, 0x01, 0xb6, 0x5c, 0xf4, 0x63, 0x7a, 0xee, 0x54, 0xa4, 0xa8, 0x15,
+	0x69, 0xc0, 0xf4, 0x1c, 0xed, 0x41, 0x75, 0x48, 0xce, 0xe8, 0x90, 0x3b, 0x2b, 0x4d, 0xab, 0x5d,
+	0xeb, 0x3e, 0x9a, 0x21, 0xa7, 0x73, 0x2c, 0x3d, 0xdf, 0x06, 0x22, 0xbe, 0xc4, 0x3a, 0x0c, 0x7d,
+	0x80, 0x1a, 0x09, 0x82, 0x50, 0x10, 0xc1, 0xc2, 0x80, 0x3b, 0xb6, 0xcc, 0xf2, 0x74, 0x56, 0x96,
+	0xfd, 0x2b, 0x77, 0x95, 0x2a, 0x9b, 0x60, 0x7d, 0x07, 0x6a, 0x99, 0x32, 0xa8, 0x01, 0xd6, 0x77,
+	0x7a, 0xa9, 0xfb, 0x9c, 0x2c, 0x13, 0xac, 0x2e, 0xc8, 0x70, 0x4c, 0x75, 0x7f, 0xd5, 0x66, 0xd7,
+	0x7c, 0x6e, 0xac, 0xbf, 0x84, 0x46, 0x31, 0xf7, 0xbf, 0xc4, 0xbb, 0x27, 0x70, 0xaf, 0x08, 0x0d,
+	0x8f, 0xc2, 0x80, 0x53, 0xf4, 0x02, 0xc0, 0x4b, 0xad, 0x8e, 0xd1, 0xb4, 0xa6, 0xb7, 0x3e, 0x8d,
+	0xc4, 0x19, 0x77, 0x77, 0x0f, 0xd6, 0x3e, 0xa6, 0x0c, 0xf4, 0x04, 0x11, 0xe3, 0x94, 0xc6, 0x32,
+	0x34, 0x46, 0x19, 0x1a, 0x37, 0x06, 0x74, 0x95, 0x60, 0xe6, 0x9b, 0x69, 0x80, 0x35, 0x4e, 0xc9,
+	0x4b, 0x96, 0xe8, 0x3e, 0xd8, 0xc9, 0x09, 0x8f, 0x88, 0x47, 0x25, 0x71, 0x36, 0xbe, 0x32, 0x64,
+	0xdf, 0xd8, 0x52, 0xfe, 0x8d, 0xfd, 0x31, 0xa1, 0x51, 0x54, 0x5d, 0x62, 0xfe, 0x55, 0x86, 0x66,
+	0x53, 0xf2, 0xb8, 0x59, 0x6e, 0x4a, 0x59, 0x7a, 0x06, 0xe7, 0xc3, 0x14, 0xbf, 0x65, 0xd9, 0xd4,
+	0xce, 0xac, 0x78, 0xa5, 0x62, 0x2a, 0x85, 0x27, 0x79, 0x0a, 0x15, 0xcb, 0xdb, 0x0b, 0x24, 0xfb,
+	0x6f, 0x61, 0xfc, 0x0c, 0x4e, 0x99, 0x1a, 0x8d, 0xe3, 0x2e, 0x54, 0xb9, 0xb4, 0xe8, 0x21, 0xe6,
+	0xce, 0xbf, 0x28, 0xd6, 0x11, 0xee, 0x3b, 0x40, 0x6a, 0x42, 0xe4, 0x40, 0xdc, 0xca, 0xce, 0xea,
+	0x85, 0xc6, 0x8a, 0xfb, 0x05, 0x2a, 0xd2, 0x56, 0xa2, 0x62, 0x03, 0xec, 0x98, 0x46, 0x61, 0x5f,
+	0x90, 0x01, 0x77, 0xcc, 0xa6, 0x95, 0xcc, 0x9b, 0xc4, 0xf0, 0x89, 0x0c, 0x38, 0x6a, 0xc1, 0xaa,
+	0x3c, 0xf4, 0xd9, 0x80, 0x72, 0xc1, 0x1d, 0x4b, 0x9e, 0xd7, 0x12, 0xdb, 0x81, 0x32, 0xb9, 0x07,
+	0x70, 0x3b, 0xa7, 0x50, 0x5f, 0xfa, 0x59, 0x5e, 0xe2, 0xda, 0x35, 0x12, 0xb5, 0xbc, 0xee, 0x6f,
+	0x03, 0xea, 0x58, 0x79, 0xf4, 0x68, 0x7c, 0xc1, 0x3c, 0x8a, 0x3c, 0xa8, 0xe7, 0xdf, 0x37, 0x9a,
+	0x32, 0xed, 0xa6, 0xfe, 0x36, 0xd6, 0xdb, 0xf3, 0x1d, 0xb5, 0x4c, 0x36, 0xe5, 0xdd, 0x3c, 0x5e,
+	0xe0, 0xfb, 0xe8, 0x42, 0x4f, 0x16, 0x71, 0x55, 0xa5, 0xba, 0xdf, 0x60, 0x55, 0x35, 0x4a, 0xdf,
+	0xef, 0x14, 0x6a, 0x99, 0xc6, 0xa1, 0xcd, 0xeb, 0x3e, 0x62, 0xae, 0xe0, 0xc3, 0x39, 0x5e, 0xaa,
+	0xd6, 0xeb, 0xca, 0xa9, 0xe5, 0xc5, 0xec, 0xac, 0x2a, 0xff, 0xfc, 0xdb, 0x7f, 0x07, 0x00, 0x8f,
+	0x7b, 0xf4, 0x95, 0x06, 0x08, 0x00, 0x00,
+}
@@ -22,8 +22,10 @@ type DataStore interface {
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	BindNodeSelectors(context.Context, *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
@@ -32,8 +34,12 @@ type DataStore interface {
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 }
 
 // Plugin is the interface implemented by plugin implementations
@@ -48,8 +54,10 @@ type Plugin interface {
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	BindNodeSelectors(context.Context, *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
@@ -58,8 +66,12 @@ type Plugin interface {
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 }
@@ -156,6 +168,14 @@ func (b BuiltIn) UpdateAttestedNode(ctx context.Context, req *UpdateAttestedNode
 	return resp, nil
 }
 
+func (b BuiltIn) UpdateAttestedNodeLastSeen(ctx context.Context, req *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error) {
+	resp, err := b.plugin.UpdateAttestedNodeLastSeen(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) DeleteAttestedNode(ctx context.Context, req *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	resp, err := b.plugin.DeleteAttestedNode(ctx, req)
 	if err != nil {
@@ -172,6 +192,14 @@ func (b BuiltIn) SetNodeSelectors(ctx context.Context, req *SetNodeSelectorsRequ
 	return resp, nil
 }
 
+func (b BuiltIn) BindNodeSelectors(ctx context.Context, req *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error) {
+	resp, err := b.plugin.BindNodeSelectors(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	resp, err := b.plugin.GetNodeSelectors(ctx, req)
 	if err != nil {
@@ -236,6 +264,14 @@ func (b BuiltIn) FetchJoinToken(ctx context.Context, req *FetchJoinTokenRequest)
 	return resp, nil
 }
 
+func (b BuiltIn) ListJoinTokens(ctx context.Context, req *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	resp, err := b.plugin.ListJoinTokens(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	resp, err := b.plugin.DeleteJoinToken(ctx, req)
 	if err != nil {
@@ -244,6 +280,14 @@ func (b BuiltIn) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenReques
 	return resp, nil
 }
 
+func (b BuiltIn) UseJoinToken(ctx context.Context, req *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	resp, err := b.plugin.UseJoinToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	resp, err := b.plugin.PruneJoinTokens(ctx, req)
 	if err != nil {
@@ -252,6 +296,22 @@ func (b BuiltIn) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensReques
 	return resp, nil
 }
 
+func (b BuiltIn) FetchCAJournal(ctx context.Context, req *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	resp, err := b.plugin.FetchCAJournal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) SetCAJournal(ctx context.Context, req *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	resp, err := b.plugin.SetCAJournal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	resp, err := b.plugin.Configure(ctx, req)
 	if err != nil {
@@ -329,12 +389,18 @@ func (s *GRPCServer) ListAttestedNodes(ctx context.Context, req *ListAttestedNod
 func (s *GRPCServer) UpdateAttestedNode(ctx context.Context, req *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
 	return s.Plugin.UpdateAttestedNode(ctx, req)
 }
+func (s *GRPCServer) UpdateAttestedNodeLastSeen(ctx context.Context, req *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error) {
+	return s.Plugin.UpdateAttestedNodeLastSeen(ctx, req)
+}
 func (s *GRPCServer) DeleteAttestedNode(ctx context.Context, req *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	return s.Plugin.DeleteAttestedNode(ctx, req)
 }
 func (s *GRPCServer) SetNodeSelectors(ctx context.Context, req *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return s.Plugin.SetNodeSelectors(ctx, req)
 }
+func (s *GRPCServer) BindNodeSelectors(ctx context.Context, req *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error) {
+	return s.Plugin.BindNodeSelectors(ctx, req)
+}
 func (s *GRPCServer) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return s.Plugin.GetNodeSelectors(ctx, req)
 }
@@ -359,12 +425,24 @@ func (s *GRPCServer) CreateJoinToken(ctx context.Context, req *CreateJoinTokenRe
 func (s *GRPCServer) FetchJoinToken(ctx context.Context, req *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return s.Plugin.FetchJoinToken(ctx, req)
 }
+func (s *GRPCServer) ListJoinTokens(ctx context.Context, req *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return s.Plugin.ListJoinTokens(ctx, req)
+}
 func (s *GRPCServer) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return s.Plugin.DeleteJoinToken(ctx, req)
 }
+func (s *GRPCServer) UseJoinToken(ctx context.Context, req *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return s.Plugin.UseJoinToken(ctx, req)
+}
 func (s *GRPCServer) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return s.Plugin.PruneJoinTokens(ctx, req)
 }
+func (s *GRPCServer) FetchCAJournal(ctx context.Context, req *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return s.Plugin.FetchCAJournal(ctx, req)
+}
+func (s *GRPCServer) SetCAJournal(ctx context.Context, req *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return s.Plugin.SetCAJournal(ctx, req)
+}
 func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return s.Plugin.Configure(ctx, req)
 }
@@ -406,12 +484,18 @@ func (c *GRPCClient) ListAttestedNodes(ctx context.Context, req *ListAttestedNod
 func (c *GRPCClient) UpdateAttestedNode(ctx context.Context, req *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error) {
 	return c.client.UpdateAttestedNode(ctx, req)
 }
+func (c *GRPCClient) UpdateAttestedNodeLastSeen(ctx context.Context, req *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error) {
+	return c.client.UpdateAttestedNodeLastSeen(ctx, req)
+}
 func (c *GRPCClient) DeleteAttestedNode(ctx context.Context, req *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error) {
 	return c.client.DeleteAttestedNode(ctx, req)
 }
 func (c *GRPCClient) SetNodeSelectors(ctx context.Context, req *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return c.client.SetNodeSelectors(ctx, req)
 }
+func (c *GRPCClient) BindNodeSelectors(ctx context.Context, req *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error) {
+	return c.client.BindNodeSelectors(ctx, req)
+}
 func (c *GRPCClient) GetNodeSelectors(ctx context.Context, req *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return c.client.GetNodeSelectors(ctx, req)
 }
@@ -436,12 +520,24 @@ func (c *GRPCClient) CreateJoinToken(ctx context.Context, req *CreateJoinTokenRe
 func (c *GRPCClient) FetchJoinToken(ctx context.Context, req *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return c.client.FetchJoinToken(ctx, req)
 }
+func (c *GRPCClient) ListJoinTokens(ctx context.Context, req *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return c.client.ListJoinTokens(ctx, req)
+}
 func (c *GRPCClient) DeleteJoinToken(ctx context.Context, req *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error) {
 	return c.client.DeleteJoinToken(ctx, req)
 }
+func (c *GRPCClient) UseJoinToken(ctx context.Context, req *UseJoinTokenRequest) (*UseJoinTokenResponse, error) {
+	return c.client.UseJoinToken(ctx, req)
+}
 func (c *GRPCClient) PruneJoinTokens(ctx context.Context, req *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return c.client.PruneJoinTokens(ctx, req)
 }
+func (c *GRPCClient) FetchCAJournal(ctx context.Context, req *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return c.client.FetchCAJournal(ctx, req)
+}
+func (c *GRPCClient) SetCAJournal(ctx context.Context, req *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return c.client.SetCAJournal(ctx, req)
+}
 func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return c.client.Configure(ctx, req)
 }
@@ -126,7 +126,7 @@ func (x DeleteBundleRequest_Mode) String() string {
 	return proto.EnumName(DeleteBundleRequest_Mode_name, int32(x))
 }
 func (DeleteBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{10, 0}
+	return fileDescriptor_datastore_91810f0809db6859, []int{10, 0}
 }
 
 type BySelectors_MatchBehavior int32
@@ -149,7 +149,7 @@ func (x BySelectors_MatchBehavior) String() string {
 	return proto.EnumName(BySelectors_MatchBehavior_name, int32(x))
 }
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{31, 0}
+	return fileDescriptor_datastore_91810f0809db6859, []int{35, 0}
 }
 
 type CreateBundleRequest struct {
@@ -163,7 +163,7 @@ func (m *CreateBundleRequest) Reset()         { *m = CreateBundleRequest{} }
 func (m *CreateBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBundleRequest) ProtoMessage()    {}
 func (*CreateBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{0}
+	return fileDescriptor_datastore_91810f0809db6859, []int{0}
 }
 func (m *CreateBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateBundleRequest.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *CreateBundleResponse) Reset()         { *m = CreateBundleResponse{} }
 func (m *CreateBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateBundleResponse) ProtoMessage()    {}
 func (*CreateBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{1}
+	return fileDescriptor_datastore_91810f0809db6859, []int{1}
 }
 func (m *CreateBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateBundleResponse.Unmarshal(m, b)
@@ -239,7 +239,7 @@ func (m *FetchBundleRequest) Reset()         { *m = FetchBundleRequest{} }
 func (m *FetchBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchBundleRequest) ProtoMessage()    {}
 func (*FetchBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{2}
+	return fileDescriptor_datastore_91810f0809db6859, []int{2}
 }
 func (m *FetchBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchBundleRequest.Unmarshal(m, b)
@@ -277,7 +277,7 @@ func (m *FetchBundleResponse) Reset()         { *m = FetchBundleResponse{} }
 func (m *FetchBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchBundleResponse) ProtoMessage()    {}
 func (*FetchBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{3}
+	return fileDescriptor_datastore_91810f0809db6859, []int{3}
 }
 func (m *FetchBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchBundleResponse.Unmarshal(m, b)
@@ -314,7 +314,7 @@ func (m *ListBundlesRequest) Reset()         { *m = ListBundlesRequest{} }
 func (m *ListBundlesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBundlesRequest) ProtoMessage()    {}
 func (*ListBundlesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{4}
+	return fileDescriptor_datastore_91810f0809db6859, []int{4}
 }
 func (m *ListBundlesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListBundlesRequest.Unmarshal(m, b)
@@ -345,7 +345,7 @@ func (m *ListBundlesResponse) Reset()         { *m = ListBundlesResponse{} }
 func (m *ListBundlesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListBundlesResponse) ProtoMessage()    {}
 func (*ListBundlesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{5}
+	return fileDescriptor_datastore_91810f0809db6859, []int{5}
 }
 func (m *ListBundlesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListBundlesResponse.Unmarshal(m, b)
@@ -383,7 +383,7 @@ func (m *UpdateBundleRequest) Reset()         { *m = UpdateBundleRequest{} }
 func (m *UpdateBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateBundleRequest) ProtoMessage()    {}
 func (*UpdateBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{6}
+	return fileDescriptor_datastore_91810f0809db6859, []int{6}
 }
 func (m *UpdateBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateBundleRequest.Unmarshal(m, b)
@@ -421,7 +421,7 @@ func (m *UpdateBundleResponse) Reset()         { *m = UpdateBundleResponse{} }
 func (m *UpdateBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateBundleResponse) ProtoMessage()    {}
 func (*UpdateBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{7}
+	return fileDescriptor_datastore_91810f0809db6859, []int{7}
 }
 func (m *UpdateBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateBundleResponse.Unmarshal(m, b)
@@ -459,7 +459,7 @@ func (m *AppendBundleRequest) Reset()         { *m = AppendBundleRequest{} }
 func (m *AppendBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*AppendBundleRequest) ProtoMessage()    {}
 func (*AppendBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{8}
+	return fileDescriptor_datastore_91810f0809db6859, []int{8}
 }
 func (m *AppendBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AppendBundleRequest.Unmarshal(m, b)
@@ -497,7 +497,7 @@ func (m *AppendBundleResponse) Reset()         { *m = AppendBundleResponse{} }
 func (m *AppendBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*AppendBundleResponse) ProtoMessage()    {}
 func (*AppendBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{9}
+	return fileDescriptor_datastore_91810f0809db6859, []int{9}
 }
 func (m *AppendBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AppendBundleResponse.Unmarshal(m, b)
@@ -536,7 +536,7 @@ func (m *DeleteBundleRequest) Reset()         { *m = DeleteBundleRequest{} }
 func (m *DeleteBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleRequest) ProtoMessage()    {}
 func (*DeleteBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{10}
+	return fileDescriptor_datastore_91810f0809db6859, []int{10}
 }
 func (m *DeleteBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteBundleRequest.Unmarshal(m, b)
@@ -581,7 +581,7 @@ func (m *DeleteBundleResponse) Reset()         { *m = DeleteBundleResponse{} }
 func (m *DeleteBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleResponse) ProtoMessage()    {}
 func (*DeleteBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{11}
+	return fileDescriptor_datastore_91810f0809db6859, []int{11}
 }
 func (m *DeleteBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteBundleResponse.Unmarshal(m, b)
@@ -622,7 +622,7 @@ func (m *NodeSelectors) Reset()         { *m = NodeSelectors{} }
 func (m *NodeSelectors) String() string { return proto.CompactTextString(m) }
 func (*NodeSelectors) ProtoMessage()    {}
 func (*NodeSelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{12}
+	return fileDescriptor_datastore_91810f0809db6859, []int{12}
 }
 func (m *NodeSelectors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeSelectors.Unmarshal(m, b)
@@ -667,7 +667,7 @@ func (m *SetNodeSelectorsRequest) Reset()         { *m = SetNodeSelectorsRequest
 func (m *SetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*SetNodeSelectorsRequest) ProtoMessage()    {}
 func (*SetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{13}
+	return fileDescriptor_datastore_91810f0809db6859, []int{13}
 }
 func (m *SetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetNodeSelectorsRequest.Unmarshal(m, b)
@@ -704,7 +704,7 @@ func (m *SetNodeSelectorsResponse) Reset()         { *m = SetNodeSelectorsRespon
 func (m *SetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*SetNodeSelectorsResponse) ProtoMessage()    {}
 func (*SetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{14}
+	return fileDescriptor_datastore_91810f0809db6859, []int{14}
 }
 func (m *SetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetNodeSelectorsResponse.Unmarshal(m, b)
@@ -724,6 +724,94 @@ func (m *SetNodeSelectorsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SetNodeSelectorsResponse proto.InternalMessageInfo
 
+type BindNodeSelectorsRequest struct {
+	// SPIFFE ID to bind to the attested node
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Type of the node selector that records the binding. Its value is the
+	// SPIFFE ID of the attested node.
+	SelectorType string `protobuf:"bytes,2,opt,name=selector_type,json=selectorType,proto3" json:"selector_type,omitempty"`
+	// SPIFFE ID of the attested node
+	AttestedNodeSpiffeId string   `protobuf:"bytes,3,opt,name=attested_node_spiffe_id,json=attestedNodeSpiffeId,proto3" json:"attested_node_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BindNodeSelectorsRequest) Reset()         { *m = BindNodeSelectorsRequest{} }
+func (m *BindNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*BindNodeSelectorsRequest) ProtoMessage()    {}
+func (*BindNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{15}
+}
+func (m *BindNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BindNodeSelectorsRequest.Unmarshal(m, b)
+}
+func (m *BindNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BindNodeSelectorsRequest.Marshal(b, m, deterministic)
+}
+func (dst *BindNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BindNodeSelectorsRequest.Merge(dst, src)
+}
+func (m *BindNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_BindNodeSelectorsRequest.Size(m)
+}
+func (m *BindNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BindNodeSelectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BindNodeSelectorsRequest proto.InternalMessageInfo
+
+func (m *BindNodeSelectorsRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *BindNodeSelectorsRequest) GetSelectorType() string {
+	if m != nil {
+		return m.SelectorType
+	}
+	return ""
+}
+
+func (m *BindNodeSelectorsRequest) GetAttestedNodeSpiffeId() string {
+	if m != nil {
+		return m.AttestedNodeSpiffeId
+	}
+	return ""
+}
+
+type BindNodeSelectorsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BindNodeSelectorsResponse) Reset()         { *m = BindNodeSelectorsResponse{} }
+func (m *BindNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*BindNodeSelectorsResponse) ProtoMessage()    {}
+func (*BindNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{16}
+}
+func (m *BindNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BindNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *BindNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BindNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (dst *BindNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BindNodeSelectorsResponse.Merge(dst, src)
+}
+func (m *BindNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_BindNodeSelectorsResponse.Size(m)
+}
+func (m *BindNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BindNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BindNodeSelectorsResponse proto.InternalMessageInfo
+
 type GetNodeSelectorsRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -735,7 +823,7 @@ func (m *GetNodeSelectorsRequest) Reset()         { *m = GetNodeSelectorsRequest
 func (m *GetNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsRequest) ProtoMessage()    {}
 func (*GetNodeSelectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{15}
+	return fileDescriptor_datastore_91810f0809db6859, []int{17}
 }
 func (m *GetNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNodeSelectorsRequest.Unmarshal(m, b)
@@ -773,7 +861,7 @@ func (m *GetNodeSelectorsResponse) Reset()         { *m = GetNodeSelectorsRespon
 func (m *GetNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetNodeSelectorsResponse) ProtoMessage()    {}
 func (*GetNodeSelectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{16}
+	return fileDescriptor_datastore_91810f0809db6859, []int{18}
 }
 func (m *GetNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetNodeSelectorsResponse.Unmarshal(m, b)
@@ -811,7 +899,7 @@ func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeReq
 func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeRequest) ProtoMessage()    {}
 func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{17}
+	return fileDescriptor_datastore_91810f0809db6859, []int{19}
 }
 func (m *CreateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestedNodeRequest.Unmarshal(m, b)
@@ -849,7 +937,7 @@ func (m *CreateAttestedNodeResponse) Reset()         { *m = CreateAttestedNodeRe
 func (m *CreateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeResponse) ProtoMessage()    {}
 func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{18}
+	return fileDescriptor_datastore_91810f0809db6859, []int{20}
 }
 func (m *CreateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestedNodeResponse.Unmarshal(m, b)
@@ -887,7 +975,7 @@ func (m *FetchAttestedNodeRequest) Reset()         { *m = FetchAttestedNodeReque
 func (m *FetchAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeRequest) ProtoMessage()    {}
 func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{19}
+	return fileDescriptor_datastore_91810f0809db6859, []int{21}
 }
 func (m *FetchAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchAttestedNodeRequest.Unmarshal(m, b)
@@ -925,7 +1013,7 @@ func (m *FetchAttestedNodeResponse) Reset()         { *m = FetchAttestedNodeResp
 func (m *FetchAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeResponse) ProtoMessage()    {}
 func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{20}
+	return fileDescriptor_datastore_91810f0809db6859, []int{22}
 }
 func (m *FetchAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchAttestedNodeResponse.Unmarshal(m, b)
@@ -964,7 +1052,7 @@ func (m *ListAttestedNodesRequest) Reset()         { *m = ListAttestedNodesReque
 func (m *ListAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesRequest) ProtoMessage()    {}
 func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{21}
+	return fileDescriptor_datastore_91810f0809db6859, []int{23}
 }
 func (m *ListAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListAttestedNodesRequest.Unmarshal(m, b)
@@ -1010,7 +1098,7 @@ func (m *ListAttestedNodesResponse) Reset()         { *m = ListAttestedNodesResp
 func (m *ListAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesResponse) ProtoMessage()    {}
 func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{22}
+	return fileDescriptor_datastore_91810f0809db6859, []int{24}
 }
 func (m *ListAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListAttestedNodesResponse.Unmarshal(m, b)
@@ -1057,7 +1145,7 @@ func (m *UpdateAttestedNodeRequest) Reset()         { *m = UpdateAttestedNodeReq
 func (m *UpdateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeRequest) ProtoMessage()    {}
 func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{23}
+	return fileDescriptor_datastore_91810f0809db6859, []int{25}
 }
 func (m *UpdateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateAttestedNodeRequest.Unmarshal(m, b)
@@ -1109,7 +1197,7 @@ func (m *UpdateAttestedNodeResponse) Reset()         { *m = UpdateAttestedNodeRe
 func (m *UpdateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeResponse) ProtoMessage()    {}
 func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{24}
+	return fileDescriptor_datastore_91810f0809db6859, []int{26}
 }
 func (m *UpdateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateAttestedNodeResponse.Unmarshal(m, b)
@@ -1136,6 +1224,98 @@ func (m *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type UpdateAttestedNodeLastSeenRequest struct {
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	LastSeenIp           string   `protobuf:"bytes,2,opt,name=last_seen_ip,json=lastSeenIp,proto3" json:"last_seen_ip,omitempty"`
+	LastSeenAt           int64    `protobuf:"varint,3,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) Reset()         { *m = UpdateAttestedNodeLastSeenRequest{} }
+func (m *UpdateAttestedNodeLastSeenRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateAttestedNodeLastSeenRequest) ProtoMessage()    {}
+func (*UpdateAttestedNodeLastSeenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{27}
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Unmarshal(m, b)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateAttestedNodeLastSeenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Merge(dst, src)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.Size(m)
+}
+func (m *UpdateAttestedNodeLastSeenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAttestedNodeLastSeenRequest proto.InternalMessageInfo
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetLastSeenIp() string {
+	if m != nil {
+		return m.LastSeenIp
+	}
+	return ""
+}
+
+func (m *UpdateAttestedNodeLastSeenRequest) GetLastSeenAt() int64 {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return 0
+}
+
+type UpdateAttestedNodeLastSeenResponse struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UpdateAttestedNodeLastSeenResponse) Reset()         { *m = UpdateAttestedNodeLastSeenResponse{} }
+func (m *UpdateAttestedNodeLastSeenResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateAttestedNodeLastSeenResponse) ProtoMessage()    {}
+func (*UpdateAttestedNodeLastSeenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{28}
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Unmarshal(m, b)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateAttestedNodeLastSeenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Merge(dst, src)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.Size(m)
+}
+func (m *UpdateAttestedNodeLastSeenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateAttestedNodeLastSeenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateAttestedNodeLastSeenResponse proto.InternalMessageInfo
+
+func (m *UpdateAttestedNodeLastSeenResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type DeleteAttestedNodeRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1147,7 +1327,7 @@ func (m *DeleteAttestedNodeRequest) Reset()         { *m = DeleteAttestedNodeReq
 func (m *DeleteAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{25}
+	return fileDescriptor_datastore_91810f0809db6859, []int{29}
 }
 func (m *DeleteAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteAttestedNodeRequest.Unmarshal(m, b)
@@ -1185,7 +1365,7 @@ func (m *DeleteAttestedNodeResponse) Reset()         { *m = DeleteAttestedNodeRe
 func (m *DeleteAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{26}
+	return fileDescriptor_datastore_91810f0809db6859, []int{30}
 }
 func (m *DeleteAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteAttestedNodeResponse.Unmarshal(m, b)
@@ -1223,7 +1403,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{27}
+	return fileDescriptor_datastore_91810f0809db6859, []int{31}
 }
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRegistrationEntryRequest.Unmarshal(m, b)
@@ -1261,7 +1441,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{28}
+	return fileDescriptor_datastore_91810f0809db6859, []int{32}
 }
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRegistrationEntryResponse.Unmarshal(m, b)
@@ -1299,7 +1479,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{29}
+	return fileDescriptor_datastore_91810f0809db6859, []int{33}
 }
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchRegistrationEntryRequest.Unmarshal(m, b)
@@ -1337,7 +1517,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{30}
+	return fileDescriptor_datastore_91810f0809db6859, []int{34}
 }
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchRegistrationEntryResponse.Unmarshal(m, b)
@@ -1376,7 +1556,7 @@ func (m *BySelectors) Reset()         { *m = BySelectors{} }
 func (m *BySelectors) String() string { return proto.CompactTextString(m) }
 func (*BySelectors) ProtoMessage()    {}
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{31}
+	return fileDescriptor_datastore_91810f0809db6859, []int{35}
 }
 func (m *BySelectors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BySelectors.Unmarshal(m, b)
@@ -1422,7 +1602,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{32}
+	return fileDescriptor_datastore_91810f0809db6859, []int{36}
 }
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Pagination.Unmarshal(m, b)
@@ -1470,7 +1650,7 @@ func (m *ListRegistrationEntriesRequest) Reset()         { *m = ListRegistration
 func (m *ListRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesRequest) ProtoMessage()    {}
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{33}
+	return fileDescriptor_datastore_91810f0809db6859, []int{37}
 }
 func (m *ListRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRegistrationEntriesRequest.Unmarshal(m, b)
@@ -1530,7 +1710,7 @@ func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistratio
 func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
 func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{34}
+	return fileDescriptor_datastore_91810f0809db6859, []int{38}
 }
 func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListRegistrationEntriesResponse.Unmarshal(m, b)
@@ -1575,7 +1755,7 @@ func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrati
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{35}
+	return fileDescriptor_datastore_91810f0809db6859, []int{39}
 }
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRegistrationEntryRequest.Unmarshal(m, b)
@@ -1613,7 +1793,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{36}
+	return fileDescriptor_datastore_91810f0809db6859, []int{40}
 }
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRegistrationEntryResponse.Unmarshal(m, b)
@@ -1651,7 +1831,7 @@ func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrati
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{37}
+	return fileDescriptor_datastore_91810f0809db6859, []int{41}
 }
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRegistrationEntryRequest.Unmarshal(m, b)
@@ -1689,7 +1869,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{38}
+	return fileDescriptor_datastore_91810f0809db6859, []int{42}
 }
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRegistrationEntryResponse.Unmarshal(m, b)
@@ -1720,7 +1900,17 @@ type JoinToken struct {
 	// Token value
 	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	// Expiration in seconds since unix epoch
-	Expiry               int64    `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// SPIFFE ID assigned to the agents attesting with the token. If unset,
+	// the SPIFFE ID is derived from the token.
+	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Selectors assigned to the agents attesting with the token
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Number of times the token can be used. Zero means the token can be
+	// used once.
+	MaxUses int32 `protobuf:"varint,5,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of times the token has been used
+	Uses                 int32    `protobuf:"varint,6,opt,name=uses,proto3" json:"uses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1730,7 +1920,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{39}
+	return fileDescriptor_datastore_91810f0809db6859, []int{43}
 }
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
@@ -1764,6 +1954,34 @@ func (m *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+func (m *JoinToken) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *JoinToken) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUses() int32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
 type CreateJoinTokenRequest struct {
 	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -1775,7 +1993,7 @@ func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{}
 func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenRequest) ProtoMessage()    {}
 func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{40}
+	return fileDescriptor_datastore_91810f0809db6859, []int{44}
 }
 func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateJoinTokenRequest.Unmarshal(m, b)
@@ -1813,7 +2031,7 @@ func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse
 func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateJoinTokenResponse) ProtoMessage()    {}
 func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{41}
+	return fileDescriptor_datastore_91810f0809db6859, []int{45}
 }
 func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateJoinTokenResponse.Unmarshal(m, b)
@@ -1851,7 +2069,7 @@ func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
 func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenRequest) ProtoMessage()    {}
 func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{42}
+	return fileDescriptor_datastore_91810f0809db6859, []int{46}
 }
 func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchJoinTokenRequest.Unmarshal(m, b)
@@ -1889,7 +2107,7 @@ func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{}
 func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchJoinTokenResponse) ProtoMessage()    {}
 func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{43}
+	return fileDescriptor_datastore_91810f0809db6859, []int{47}
 }
 func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchJoinTokenResponse.Unmarshal(m, b)
@@ -1927,7 +2145,7 @@ func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{}
 func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenRequest) ProtoMessage()    {}
 func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{44}
+	return fileDescriptor_datastore_91810f0809db6859, []int{48}
 }
 func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteJoinTokenRequest.Unmarshal(m, b)
@@ -1965,7 +2183,7 @@ func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse
 func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteJoinTokenResponse) ProtoMessage()    {}
 func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{45}
+	return fileDescriptor_datastore_91810f0809db6859, []int{49}
 }
 func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteJoinTokenResponse.Unmarshal(m, b)
@@ -1992,6 +2210,151 @@ func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
 	return nil
 }
 
+type ListJoinTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJoinTokensRequest) Reset()         { *m = ListJoinTokensRequest{} }
+func (m *ListJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensRequest) ProtoMessage()    {}
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{50}
+}
+func (m *ListJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *ListJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensRequest.Merge(dst, src)
+}
+func (m *ListJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensRequest.Size(m)
+}
+func (m *ListJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensRequest proto.InternalMessageInfo
+
+type ListJoinTokensResponse struct {
+	JoinTokens           []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJoinTokensResponse) Reset()         { *m = ListJoinTokensResponse{} }
+func (m *ListJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensResponse) ProtoMessage()    {}
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{51}
+}
+func (m *ListJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *ListJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensResponse.Merge(dst, src)
+}
+func (m *ListJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensResponse.Size(m)
+}
+func (m *ListJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensResponse proto.InternalMessageInfo
+
+func (m *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if m != nil {
+		return m.JoinTokens
+	}
+	return nil
+}
+
+type UseJoinTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UseJoinTokenRequest) Reset()         { *m = UseJoinTokenRequest{} }
+func (m *UseJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenRequest) ProtoMessage()    {}
+func (*UseJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{52}
+}
+func (m *UseJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *UseJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (dst *UseJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenRequest.Merge(dst, src)
+}
+func (m *UseJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenRequest.Size(m)
+}
+func (m *UseJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenRequest proto.InternalMessageInfo
+
+func (m *UseJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type UseJoinTokenResponse struct {
+	// The token after its use, or unset if there is no such token
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UseJoinTokenResponse) Reset()         { *m = UseJoinTokenResponse{} }
+func (m *UseJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*UseJoinTokenResponse) ProtoMessage()    {}
+func (*UseJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{53}
+}
+func (m *UseJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UseJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *UseJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UseJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (dst *UseJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UseJoinTokenResponse.Merge(dst, src)
+}
+func (m *UseJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_UseJoinTokenResponse.Size(m)
+}
+func (m *UseJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UseJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UseJoinTokenResponse proto.InternalMessageInfo
+
+func (m *UseJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
 type PruneJoinTokensRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2003,7 +2366,7 @@ func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{}
 func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensRequest) ProtoMessage()    {}
 func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{46}
+	return fileDescriptor_datastore_91810f0809db6859, []int{54}
 }
 func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PruneJoinTokensRequest.Unmarshal(m, b)
@@ -2040,7 +2403,7 @@ func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse
 func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
 func (*PruneJoinTokensResponse) ProtoMessage()    {}
 func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_2844d8face5c3336, []int{47}
+	return fileDescriptor_datastore_91810f0809db6859, []int{55}
 }
 func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PruneJoinTokensResponse.Unmarshal(m, b)
@@ -2060,6 +2423,218 @@ func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
 
+type CAJournal struct {
+	// Trust domain the journal belongs to
+	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	// Opaque journal contents, owned by the server CA manager
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Version of the journal. Incremented every time the journal is set.
+	Version              int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CAJournal) Reset()         { *m = CAJournal{} }
+func (m *CAJournal) String() string { return proto.CompactTextString(m) }
+func (*CAJournal) ProtoMessage()    {}
+func (*CAJournal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{56}
+}
+func (m *CAJournal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAJournal.Unmarshal(m, b)
+}
+func (m *CAJournal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAJournal.Marshal(b, m, deterministic)
+}
+func (dst *CAJournal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAJournal.Merge(dst, src)
+}
+func (m *CAJournal) XXX_Size() int {
+	return xxx_messageInfo_CAJournal.Size(m)
+}
+func (m *CAJournal) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAJournal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAJournal proto.InternalMessageInfo
+
+func (m *CAJournal) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+func (m *CAJournal) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CAJournal) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type FetchCAJournalRequest struct {
+	TrustDomainId        string   `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchCAJournalRequest) Reset()         { *m = FetchCAJournalRequest{} }
+func (m *FetchCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalRequest) ProtoMessage()    {}
+func (*FetchCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{57}
+}
+func (m *FetchCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalRequest.Unmarshal(m, b)
+}
+func (m *FetchCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalRequest.Merge(dst, src)
+}
+func (m *FetchCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalRequest.Size(m)
+}
+func (m *FetchCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalRequest proto.InternalMessageInfo
+
+func (m *FetchCAJournalRequest) GetTrustDomainId() string {
+	if m != nil {
+		return m.TrustDomainId
+	}
+	return ""
+}
+
+type FetchCAJournalResponse struct {
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FetchCAJournalResponse) Reset()         { *m = FetchCAJournalResponse{} }
+func (m *FetchCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalResponse) ProtoMessage()    {}
+func (*FetchCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{58}
+}
+func (m *FetchCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalResponse.Unmarshal(m, b)
+}
+func (m *FetchCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (dst *FetchCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalResponse.Merge(dst, src)
+}
+func (m *FetchCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalResponse.Size(m)
+}
+func (m *FetchCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalResponse proto.InternalMessageInfo
+
+func (m *FetchCAJournalResponse) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
+type SetCAJournalRequest struct {
+	// The journal to store. The version must match the version currently
+	// stored (or be zero if no journal is stored yet), otherwise the request
+	// fails.
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalRequest) Reset()         { *m = SetCAJournalRequest{} }
+func (m *SetCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalRequest) ProtoMessage()    {}
+func (*SetCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{59}
+}
+func (m *SetCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalRequest.Unmarshal(m, b)
+}
+func (m *SetCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalRequest.Merge(dst, src)
+}
+func (m *SetCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalRequest.Size(m)
+}
+func (m *SetCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalRequest proto.InternalMessageInfo
+
+func (m *SetCAJournalRequest) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
+type SetCAJournalResponse struct {
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalResponse) Reset()         { *m = SetCAJournalResponse{} }
+func (m *SetCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalResponse) ProtoMessage()    {}
+func (*SetCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_91810f0809db6859, []int{60}
+}
+func (m *SetCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalResponse.Unmarshal(m, b)
+}
+func (m *SetCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalResponse.Merge(dst, src)
+}
+func (m *SetCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalResponse.Size(m)
+}
+func (m *SetCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalResponse proto.InternalMessageInfo
+
+func (m *SetCAJournalResponse) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
 	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
@@ -2076,6 +2651,8 @@ func init() {
 	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
 	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
 	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
+	proto.RegisterType((*BindNodeSelectorsRequest)(nil), "spire.server.datastore.BindNodeSelectorsRequest")
+	proto.RegisterType((*BindNodeSelectorsResponse)(nil), "spire.server.datastore.BindNodeSelectorsResponse")
 	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
 	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
 	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
@@ -2086,6 +2663,8 @@ func init() {
 	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
 	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
 	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*UpdateAttestedNodeLastSeenRequest)(nil), "spire.server.datastore.UpdateAttestedNodeLastSeenRequest")
+	proto.RegisterType((*UpdateAttestedNodeLastSeenResponse)(nil), "spire.server.datastore.UpdateAttestedNodeLastSeenResponse")
 	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
 	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
 	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
@@ -2107,8 +2686,17 @@ func init() {
 	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
 	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
 	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*ListJoinTokensRequest)(nil), "spire.server.datastore.ListJoinTokensRequest")
+	proto.RegisterType((*ListJoinTokensResponse)(nil), "spire.server.datastore.ListJoinTokensResponse")
+	proto.RegisterType((*UseJoinTokenRequest)(nil), "spire.server.datastore.UseJoinTokenRequest")
+	proto.RegisterType((*UseJoinTokenResponse)(nil), "spire.server.datastore.UseJoinTokenResponse")
 	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
 	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+	proto.RegisterType((*CAJournal)(nil), "spire.server.datastore.CAJournal")
+	proto.RegisterType((*FetchCAJournalRequest)(nil), "spire.server.datastore.FetchCAJournalRequest")
+	proto.RegisterType((*FetchCAJournalResponse)(nil), "spire.server.datastore.FetchCAJournalResponse")
+	proto.RegisterType((*SetCAJournalRequest)(nil), "spire.server.datastore.SetCAJournalRequest")
+	proto.RegisterType((*SetCAJournalResponse)(nil), "spire.server.datastore.SetCAJournalResponse")
 	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
 	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
 }
@@ -2145,10 +2733,15 @@ type DataStoreClient interface {
 	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Records the address and time a specific attested node was last seen
+	UpdateAttestedNodeLastSeen(ctx context.Context, in *UpdateAttestedNodeLastSeenRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeLastSeenResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
+	// Binds a specific node id to an attested node, unless it is already
+	// bound to another attested node
+	BindNodeSelectors(ctx context.Context, in *BindNodeSelectorsRequest, opts ...grpc.CallOption) (*BindNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
 	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
 	// Creates a registration entry
@@ -2165,10 +2758,19 @@ type DataStoreClient interface {
 	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
 	// Fetches a specific join token
 	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Records a use of a join token, deleting it once it has been used the
+	// maximum number of times
+	UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Fetches the CA journal for a trust domain
+	FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error)
+	// Sets the CA journal for a trust domain, failing on a version mismatch
+	SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error)
 	// Applies the plugin configuration
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -2273,6 +2875,15 @@ func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAtte
 	return out, nil
 }
 
+func (c *dataStoreClient) UpdateAttestedNodeLastSeen(ctx context.Context, in *UpdateAttestedNodeLastSeenRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeLastSeenResponse, error) {
+	out := new(UpdateAttestedNodeLastSeenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNodeLastSeen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
 	out := new(DeleteAttestedNodeResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
@@ -2291,6 +2902,15 @@ func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelec
 	return out, nil
 }
 
+func (c *dataStoreClient) BindNodeSelectors(ctx context.Context, in *BindNodeSelectorsRequest, opts ...grpc.CallOption) (*BindNodeSelectorsResponse, error) {
+	out := new(BindNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/BindNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
 	out := new(GetNodeSelectorsResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetNodeSelectors", in, out, opts...)
@@ -2363,6 +2983,15 @@ func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinToken
 	return out, nil
 }
 
+func (c *dataStoreClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
 	out := new(DeleteJoinTokenResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
@@ -2372,6 +3001,15 @@ func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTok
 	return out, nil
 }
 
+func (c *dataStoreClient) UseJoinToken(ctx context.Context, in *UseJoinTokenRequest, opts ...grpc.CallOption) (*UseJoinTokenResponse, error) {
+	out := new(UseJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UseJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
 	out := new(PruneJoinTokensResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
@@ -2381,6 +3019,24 @@ func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinToke
 	return out, nil
 }
 
+func (c *dataStoreClient) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error) {
+	out := new(FetchCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchCAJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error) {
+	out := new(SetCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetCAJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, opts...)
@@ -2421,10 +3077,15 @@ type DataStoreServer interface {
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	// Records the address and time a specific attested node was last seen
+	UpdateAttestedNodeLastSeen(context.Context, *UpdateAttestedNodeLastSeenRequest) (*UpdateAttestedNodeLastSeenResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
+	// Binds a specific node id to an attested node, unless it is already
+	// bound to another attested node
+	BindNodeSelectors(context.Context, *BindNodeSelectorsRequest) (*BindNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	// Creates a registration entry
@@ -2441,10 +3102,19 @@ type DataStoreServer interface {
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	// Fetches a specific join token
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+	// Records a use of a join token, deleting it once it has been used the
+	// maximum number of times
+	UseJoinToken(context.Context, *UseJoinTokenRequest) (*UseJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Fetches the CA journal for a trust domain
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	// Sets the CA journal for a trust domain, failing on a version mismatch
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -2635,6 +3305,24 @@ func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UpdateAttestedNodeLastSeen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAttestedNodeLastSeenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateAttestedNodeLastSeen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateAttestedNodeLastSeen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateAttestedNodeLastSeen(ctx, req.(*UpdateAttestedNodeLastSeenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAttestedNodeRequest)
 	if err := dec(in); err != nil {
@@ -2671,6 +3359,24 @@ func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_BindNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).BindNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/BindNodeSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).BindNodeSelectors(ctx, req.(*BindNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNodeSelectorsRequest)
 	if err := dec(in); err != nil {
@@ -2815,6 +3521,24 @@ func _DataStore_FetchJoinToken_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteJoinTokenRequest)
 	if err := dec(in); err != nil {
@@ -2833,6 +3557,24 @@ func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_UseJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UseJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UseJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UseJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UseJoinToken(ctx, req.(*UseJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
@@ -2851,6 +3593,42 @@ func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_FetchCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchCAJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchCAJournal(ctx, req.(*FetchCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCAJournalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetCAJournal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/SetCAJournal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetCAJournal(ctx, req.(*SetCAJournalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -2931,6 +3709,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAttestedNode",
 			Handler:    _DataStore_UpdateAttestedNode_Handler,
 		},
+		{
+			MethodName: "UpdateAttestedNodeLastSeen",
+			Handler:    _DataStore_UpdateAttestedNodeLastSeen_Handler,
+		},
 		{
 			MethodName: "DeleteAttestedNode",
 			Handler:    _DataStore_DeleteAttestedNode_Handler,
@@ -2939,6 +3721,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetNodeSelectors",
 			Handler:    _DataStore_SetNodeSelectors_Handler,
 		},
+		{
+			MethodName: "BindNodeSelectors",
+			Handler:    _DataStore_BindNodeSelectors_Handler,
+		},
 		{
 			MethodName: "GetNodeSelectors",
 			Handler:    _DataStore_GetNodeSelectors_Handler,
@@ -2971,14 +3757,30 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchJoinToken",
 			Handler:    _DataStore_FetchJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _DataStore_ListJoinTokens_Handler,
+		},
 		{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
 		},
+		{
+			MethodName: "UseJoinToken",
+			Handler:    _DataStore_UseJoinToken_Handler,
+		},
 		{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "FetchCAJournal",
+			Handler:    _DataStore_FetchCAJournal_Handler,
+		},
+		{
+			MethodName: "SetCAJournal",
+			Handler:    _DataStore_SetCAJournal_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
@@ -2992,107 +3794,129 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 	Metadata: "datastore.proto",
 }
 
-func init() { proto.RegisterFile("datastore.proto", fileDescriptor_datastore_2844d8face5c3336) }
-
-var fileDescriptor_datastore_2844d8face5c3336 = []byte{
-	// 1582 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0x5b, 0x6f, 0xdb, 0xc6,
-	0x12, 0x0e, 0x7d, 0x8b, 0x35, 0x92, 0x2f, 0x59, 0xfb, 0xd8, 0x92, 0x72, 0x8e, 0xed, 0xc3, 0x73,
-	0x12, 0xa4, 0xb9, 0x50, 0xb6, 0x9a, 0x38, 0x49, 0x5b, 0x34, 0xb5, 0x2e, 0x51, 0xd5, 0x38, 0xa9,
-	0x40, 0x29, 0x4d, 0x90, 0x00, 0x15, 0x28, 0x73, 0x25, 0x33, 0x95, 0x48, 0x95, 0x5c, 0xa5, 0x51,
-	0xf2, 0x03, 0x0a, 0x14, 0x7d, 0xe9, 0x3f, 0xe8, 0x5b, 0x5f, 0x0a, 0xf4, 0xa9, 0xef, 0xfd, 0x69,
-	0x05, 0x77, 0x49, 0x91, 0x14, 0xb9, 0xb4, 0x24, 0xbb, 0x4f, 0x8e, 0x96, 0x33, 0xdf, 0x7c, 0x33,
-	0xbb, 0x3b, 0x3b, 0x33, 0x81, 0x35, 0x55, 0x21, 0x8a, 0x45, 0x0c, 0x13, 0x4b, 0x7d, 0xd3, 0x20,
-	0x06, 0xda, 0xb2, 0xfa, 0x9a, 0x89, 0x25, 0x0b, 0x9b, 0x6f, 0xb1, 0x29, 0x8d, 0xbe, 0x66, 0x77,
-	0x3a, 0x86, 0xd1, 0xe9, 0xe2, 0x1c, 0x95, 0x6a, 0x0d, 0xda, 0xb9, 0x1f, 0x4c, 0xa5, 0xdf, 0xc7,
-	0xa6, 0xc5, 0xf4, 0xb2, 0x0f, 0x3a, 0x1a, 0x39, 0x1d, 0xb4, 0xa4, 0x13, 0xa3, 0x97, 0xb3, 0xfa,
-	0x5a, 0xbb, 0x8d, 0x73, 0x14, 0x89, 0x29, 0xe4, 0x4e, 0x8c, 0x5e, 0xcf, 0xd0, 0x73, 0xfd, 0xee,
-	0xa0, 0xa3, 0xb9, 0x7f, 0x1c, 0xcd, 0x83, 0x89, 0x34, 0xd9, 0x1f, 0xa6, 0x22, 0x16, 0x61, 0xa3,
-	0x68, 0x62, 0x85, 0xe0, 0xc2, 0x40, 0x57, 0xbb, 0x58, 0xc6, 0xdf, 0x0f, 0xb0, 0x45, 0xd0, 0x6d,
-	0x58, 0x6a, 0xd1, 0x85, 0xb4, 0xb0, 0x27, 0xdc, 0x48, 0xe6, 0x37, 0x25, 0xe6, 0x8c, 0xa3, 0xeb,
-	0x08, 0x3b, 0x32, 0x62, 0x09, 0x36, 0x83, 0x20, 0x56, 0xdf, 0xd0, 0x2d, 0x3c, 0x25, 0xca, 0x67,
-	0x80, 0x1e, 0x63, 0x72, 0x72, 0x1a, 0x64, 0x72, 0x1d, 0xd6, 0x88, 0x39, 0xb0, 0x48, 0x53, 0x35,
-	0x7a, 0x8a, 0xa6, 0x37, 0x35, 0x95, 0x82, 0x25, 0xe4, 0x15, 0xba, 0x5c, 0xa2, 0xab, 0x55, 0xd5,
-	0x76, 0x24, 0xa0, 0x3d, 0x13, 0x85, 0x4d, 0x40, 0xc7, 0x9a, 0x45, 0xd8, 0xaa, 0xe5, 0x50, 0x10,
-	0xcb, 0xb0, 0x11, 0x58, 0x75, 0xa0, 0x25, 0xb8, 0xcc, 0xd4, 0xac, 0xb4, 0xb0, 0x37, 0xcf, 0xc5,
-	0x76, 0x85, 0x6c, 0x86, 0xcf, 0xfb, 0xea, 0xf9, 0x43, 0x1d, 0x04, 0x99, 0xc9, 0xcf, 0x22, 0x6c,
-	0x1c, 0xf5, 0xfb, 0x58, 0x57, 0xcf, 0x49, 0x25, 0x08, 0x32, 0x13, 0x95, 0x3f, 0x05, 0xd8, 0x28,
-	0xe1, 0x2e, 0x1e, 0x0f, 0xcb, 0x84, 0xfb, 0x8e, 0x4a, 0xb0, 0xd0, 0x33, 0x54, 0x9c, 0x9e, 0xdb,
-	0x13, 0x6e, 0xac, 0xe6, 0xf7, 0xa5, 0xe8, 0x4b, 0x27, 0x45, 0x98, 0x90, 0x9e, 0x1a, 0x2a, 0x96,
-	0xa9, 0xb6, 0xb8, 0x0f, 0x0b, 0xf6, 0x2f, 0x94, 0x82, 0x65, 0xb9, 0x5c, 0x6f, 0xc8, 0xd5, 0x62,
-	0x63, 0xfd, 0x12, 0x02, 0x58, 0x2a, 0x95, 0x8f, 0xcb, 0x8d, 0xf2, 0xba, 0x80, 0x56, 0x01, 0x4a,
-	0xd5, 0x7a, 0xfd, 0xeb, 0x62, 0xf5, 0xa8, 0x51, 0x5e, 0x9f, 0xb3, 0xbd, 0x0f, 0x62, 0xce, 0xe4,
-	0x7d, 0x0b, 0x56, 0x9e, 0x19, 0x2a, 0xae, 0xe3, 0x2e, 0x3e, 0x21, 0x86, 0x69, 0xa1, 0xab, 0x90,
-	0x60, 0x37, 0xd7, 0x73, 0x78, 0x99, 0x2d, 0x54, 0x55, 0x74, 0x17, 0x12, 0x96, 0x2b, 0x99, 0x9e,
-	0xa3, 0x67, 0x6e, 0x2b, 0x08, 0xef, 0x02, 0xc9, 0x9e, 0xa0, 0xf8, 0x2d, 0x6c, 0xd7, 0x31, 0x09,
-	0x98, 0x71, 0x83, 0x5c, 0xf4, 0x03, 0x32, 0xbe, 0xd7, 0x78, 0x11, 0x0c, 0x02, 0xf8, 0xf0, 0xb3,
-	0x90, 0x0e, 0xe3, 0xb3, 0x68, 0x88, 0x87, 0xb0, 0x5d, 0xe1, 0xd8, 0x8e, 0xf3, 0x54, 0x6c, 0x42,
-	0xba, 0xc2, 0xc1, 0xbc, 0x18, 0xd2, 0x4f, 0x20, 0xc3, 0x52, 0xd6, 0x11, 0x21, 0xd8, 0x22, 0x58,
-	0xb5, 0x25, 0x5d, 0x6a, 0x12, 0x2c, 0xe8, 0xf6, 0x99, 0x62, 0xe0, 0xd9, 0x60, 0x88, 0x03, 0x0a,
-	0x54, 0x4e, 0x3c, 0x86, 0x6c, 0x14, 0xd8, 0x28, 0x4f, 0x4c, 0x87, 0x76, 0x1f, 0xd2, 0x34, 0x93,
-	0x45, 0x31, 0x8b, 0x0d, 0xda, 0x13, 0xc8, 0x44, 0x28, 0xce, 0xc8, 0xe2, 0x37, 0x01, 0xd2, 0x76,
-	0xd6, 0xf3, 0x7f, 0x1a, 0xed, 0x5d, 0x05, 0xae, 0xb4, 0x86, 0x4d, 0xfc, 0xce, 0xc6, 0xb0, 0x9a,
-	0x2d, 0xdc, 0x36, 0x4c, 0x17, 0xf9, 0xaa, 0xc4, 0x9e, 0x37, 0xc9, 0x7d, 0xde, 0xa4, 0xaa, 0x4e,
-	0x0e, 0xef, 0x7e, 0xa3, 0x74, 0x07, 0x58, 0x5e, 0x6b, 0x0d, 0xcb, 0x4c, 0xa9, 0x40, 0x75, 0x50,
-	0x01, 0xa0, 0xaf, 0x74, 0x34, 0x5d, 0x21, 0x9a, 0xa1, 0xd3, 0x3b, 0x9c, 0xcc, 0x8b, 0xbc, 0xcd,
-	0xac, 0x8d, 0x24, 0x65, 0x9f, 0x96, 0xf8, 0x8b, 0x00, 0x99, 0x08, 0xa6, 0x8e, 0xdf, 0xfb, 0xb0,
-	0x68, 0xfb, 0xe3, 0xe6, 0xe8, 0x38, 0xc7, 0x99, 0xe0, 0x85, 0x70, 0xfa, 0x59, 0x80, 0x0c, 0xcb,
-	0xd3, 0xd3, 0xee, 0x22, 0xba, 0x0d, 0xe8, 0x04, 0x9b, 0xa4, 0x69, 0x61, 0x53, 0x53, 0xba, 0x4d,
-	0x7d, 0xd0, 0x6b, 0x61, 0x93, 0xd2, 0x48, 0xc8, 0xeb, 0xf6, 0x97, 0x3a, 0xfd, 0xf0, 0x8c, 0xae,
-	0xa3, 0xff, 0xc3, 0x2a, 0x95, 0xd6, 0x0d, 0xd2, 0x54, 0xda, 0x04, 0x9b, 0xe9, 0xf9, 0x3d, 0xe1,
-	0xc6, 0xbc, 0x9c, 0xb2, 0x57, 0x9f, 0x19, 0xe4, 0xc8, 0x5e, 0xb3, 0x0f, 0x68, 0x14, 0x9b, 0x19,
-	0x8f, 0xc6, 0x03, 0xc8, 0xb0, 0xd4, 0x37, 0xf5, 0x09, 0x3d, 0x86, 0x6c, 0x94, 0xe6, 0x8c, 0x3c,
-	0x5e, 0xc0, 0x0e, 0xbb, 0x76, 0x32, 0xee, 0x68, 0x16, 0x31, 0x69, 0xe8, 0xcb, 0x3a, 0x31, 0x87,
-	0x2e, 0x99, 0x7b, 0xb0, 0x88, 0xed, 0xdf, 0x0e, 0xe4, 0x6e, 0x10, 0x32, 0xac, 0xc6, 0xa4, 0xc5,
-	0x97, 0xb0, 0xcb, 0x05, 0x76, 0xb8, 0xce, 0x88, 0xfc, 0x09, 0xfc, 0x87, 0x5e, 0x51, 0x2e, 0xe3,
-	0x0c, 0x2c, 0x53, 0x49, 0x2f, 0x7a, 0x97, 0xe9, 0xef, 0xaa, 0x6a, 0xbb, 0xcb, 0xd3, 0x3d, 0x1f,
-	0xa9, 0xbf, 0x04, 0x48, 0x16, 0x86, 0xde, 0x1b, 0x74, 0x37, 0x98, 0x60, 0x27, 0x7b, 0x66, 0x50,
-	0x05, 0x16, 0x7b, 0x0a, 0x39, 0x39, 0x75, 0x5e, 0xe2, 0x03, 0xde, 0x8d, 0xf1, 0x59, 0x92, 0x9e,
-	0xda, 0x0a, 0x05, 0x7c, 0xaa, 0xbc, 0xd5, 0x0c, 0x53, 0x66, 0xfa, 0x62, 0x1e, 0x56, 0x02, 0xeb,
-	0x68, 0x0d, 0x92, 0x4f, 0x8f, 0x1a, 0xc5, 0x2f, 0x9b, 0xe5, 0x97, 0x47, 0xf4, 0x5d, 0x5e, 0x87,
-	0x14, 0x5b, 0xa8, 0x3f, 0x2f, 0xd4, 0xcb, 0x8d, 0x75, 0x41, 0x7c, 0x04, 0xe0, 0xdd, 0x44, 0xb4,
-	0x09, 0x8b, 0xc4, 0xf8, 0x0e, 0xeb, 0x4e, 0x04, 0xd9, 0x0f, 0xfb, 0x64, 0xf6, 0x95, 0x0e, 0x6e,
-	0x5a, 0xda, 0x7b, 0x56, 0x2e, 0x2c, 0xca, 0xcb, 0xf6, 0x42, 0x5d, 0x7b, 0x8f, 0xc5, 0xdf, 0xe7,
-	0x60, 0xc7, 0x4e, 0x22, 0xe3, 0x41, 0xd2, 0xbc, 0xa4, 0xf7, 0x39, 0xa4, 0x5a, 0xc3, 0x66, 0x5f,
-	0x31, 0xb1, 0x4e, 0xdc, 0xed, 0x49, 0xe6, 0xff, 0x1d, 0xca, 0x77, 0x75, 0x62, 0x6a, 0x7a, 0x87,
-	0x25, 0x3c, 0x68, 0x0d, 0x6b, 0x54, 0xa1, 0xaa, 0xa2, 0xc7, 0x54, 0xdf, 0xff, 0x80, 0xdb, 0xfa,
-	0xff, 0x9b, 0x20, 0x4e, 0x72, 0xb2, 0xe5, 0xdb, 0x1e, 0xc6, 0xc3, 0xbb, 0x64, 0xf3, 0x93, 0xf1,
-	0xa8, 0xbb, 0x09, 0x26, 0x98, 0xdf, 0x16, 0x66, 0xca, 0x6f, 0xbf, 0x0a, 0xb0, 0xcb, 0x0d, 0x97,
-	0x73, 0x1a, 0x1f, 0x02, 0x3d, 0xba, 0xda, 0x28, 0xf7, 0x9e, 0x79, 0x1e, 0x5d, 0xf9, 0x0b, 0x49,
-	0xc1, 0x2f, 0x60, 0x87, 0xe5, 0xbc, 0x7f, 0x20, 0x3b, 0x70, 0x81, 0xcf, 0x77, 0x11, 0x3f, 0x85,
-	0x1d, 0x96, 0x1e, 0x67, 0x49, 0x0f, 0x2f, 0x61, 0x97, 0xab, 0x7c, 0x3e, 0x5a, 0x0f, 0x21, 0xf1,
-	0x95, 0xa1, 0xe9, 0x0d, 0x7a, 0x8b, 0xa2, 0xef, 0xd6, 0x16, 0x2c, 0xd1, 0x6a, 0x60, 0x48, 0x37,
-	0x6b, 0x5e, 0x76, 0x7e, 0x89, 0xaf, 0x60, 0x8b, 0x65, 0xd2, 0x11, 0x80, 0xeb, 0xc9, 0x17, 0x00,
-	0x6f, 0x0c, 0x4d, 0x6f, 0x7a, 0x60, 0xc9, 0xfc, 0x7f, 0x79, 0x5b, 0xec, 0x69, 0x27, 0xde, 0xb8,
-	0xff, 0x14, 0x5f, 0xc3, 0x76, 0x08, 0xdb, 0x71, 0xf4, 0xfc, 0xe0, 0x77, 0xe0, 0x5f, 0x34, 0xd9,
-	0x86, 0x78, 0x47, 0xfa, 0x6f, 0xfb, 0x39, 0x2e, 0x7e, 0x61, 0x54, 0x24, 0xd8, 0x62, 0x1b, 0x3b,
-	0x21, 0x97, 0xd7, 0xb0, 0x1d, 0x92, 0xbf, 0x30, 0x32, 0x8f, 0x60, 0xab, 0x66, 0x0e, 0x74, 0x0f,
-	0x7b, 0x94, 0x1e, 0xaf, 0xc1, 0x6a, 0x44, 0x41, 0x38, 0x2f, 0xaf, 0x60, 0x7f, 0xc5, 0x27, 0x66,
-	0x60, 0x3b, 0x04, 0xc0, 0xd8, 0xe5, 0xff, 0xd8, 0x82, 0x44, 0x49, 0x21, 0x4a, 0xdd, 0x36, 0x8f,
-	0x34, 0x48, 0xf9, 0x87, 0x0a, 0xe8, 0x16, 0x8f, 0x67, 0xc4, 0xfc, 0x22, 0x7b, 0x7b, 0x32, 0x61,
-	0x27, 0x2c, 0x6d, 0x48, 0xfa, 0x66, 0x07, 0xe8, 0x26, 0x4f, 0x39, 0x3c, 0x9e, 0xc8, 0xde, 0x9a,
-	0x48, 0xd6, 0xb3, 0xe3, 0x1b, 0x24, 0xf0, 0xed, 0x84, 0x67, 0x10, 0x7c, 0x3b, 0x51, 0x93, 0x09,
-	0x0d, 0x52, 0xfe, 0x21, 0x01, 0x3f, 0x74, 0x11, 0xf3, 0x08, 0x7e, 0xe8, 0x22, 0xe7, 0x0e, 0x1a,
-	0xa4, 0xfc, 0x43, 0x00, 0xbe, 0xa9, 0x88, 0x79, 0x03, 0xdf, 0x54, 0xe4, 0x5c, 0x41, 0x83, 0x94,
-	0xbf, 0xe3, 0xe6, 0x9b, 0x8a, 0xe8, 0xf5, 0xf9, 0xa6, 0x22, 0x9b, 0xf8, 0x0f, 0x80, 0xc2, 0x0d,
-	0x1d, 0x3a, 0x88, 0x3f, 0x54, 0x11, 0xd5, 0x70, 0x36, 0x3f, 0x8d, 0x8a, 0x63, 0xfc, 0x1d, 0x5c,
-	0x09, 0xb5, 0x71, 0x68, 0x3f, 0xf6, 0x9c, 0x45, 0x99, 0x3e, 0x98, 0x42, 0xc3, 0xb3, 0x1c, 0x6a,
-	0xa4, 0xf8, 0x96, 0x79, 0xdd, 0x21, 0xdf, 0x32, 0xbf, 0x4b, 0xfb, 0x00, 0x28, 0xdc, 0xa0, 0xf0,
-	0x03, 0xce, 0x6d, 0xad, 0xf8, 0x01, 0x8f, 0xe9, 0x7f, 0x3e, 0x00, 0x0a, 0x77, 0x25, 0x7c, 0xe3,
-	0xdc, 0xde, 0x87, 0x6f, 0x3c, 0xa6, 0xe9, 0x19, 0xc0, 0xfa, 0xf8, 0xf4, 0x04, 0xe5, 0x78, 0x38,
-	0x9c, 0x39, 0x4e, 0x76, 0x7f, 0x72, 0x05, 0xcf, 0x6c, 0x65, 0x62, 0xb3, 0x95, 0x69, 0xcd, 0x72,
-	0x67, 0x37, 0x3f, 0x09, 0xee, 0xa3, 0x1d, 0xaa, 0x36, 0xd0, 0x61, 0xfc, 0x5d, 0xe1, 0xd5, 0x44,
-	0xd9, 0xfb, 0x53, 0xeb, 0x39, 0x64, 0x7e, 0x14, 0x9c, 0x57, 0x3b, 0xcc, 0xe5, 0x5e, 0xec, 0xe5,
-	0xe1, 0x52, 0x39, 0x9c, 0x56, 0xcd, 0x17, 0x16, 0x4e, 0x39, 0xcd, 0x0f, 0x4b, 0x7c, 0xbb, 0xc2,
-	0x0f, 0xcb, 0x59, 0x75, 0xbb, 0x4d, 0x86, 0x53, 0xe0, 0xf2, 0xc9, 0xc4, 0x97, 0xda, 0x7c, 0x32,
-	0x67, 0x55, 0xd2, 0x36, 0x19, 0x4e, 0x59, 0xcb, 0x27, 0x13, 0x5f, 0x44, 0xf3, 0xc9, 0x9c, 0x55,
-	0x3f, 0x9b, 0xb0, 0x36, 0x56, 0x71, 0x22, 0x29, 0xfe, 0xf0, 0x8d, 0x97, 0x6c, 0xd9, 0xdc, 0xc4,
-	0xf2, 0x8e, 0x4d, 0x03, 0x56, 0x83, 0x95, 0x25, 0xba, 0x13, 0x7b, 0xc8, 0x42, 0x16, 0xa5, 0x49,
-	0xc5, 0x3d, 0x27, 0xc7, 0xca, 0x47, 0xbe, 0x93, 0xd1, 0x75, 0x29, 0xdf, 0x49, 0x5e, 0x5d, 0x6a,
-	0xc2, 0xda, 0x58, 0x51, 0xc8, 0xb7, 0x19, 0x5d, 0x7e, 0xf2, 0x6d, 0x72, 0xaa, 0x4d, 0xf4, 0x0a,
-	0x12, 0x45, 0x43, 0x6f, 0x6b, 0x9d, 0x81, 0x89, 0xd1, 0xb5, 0x60, 0x2b, 0xe4, 0xfc, 0xaf, 0xda,
-	0xe8, 0xbb, 0x6b, 0xe4, 0xfa, 0x59, 0x62, 0xa3, 0x42, 0x6f, 0xa5, 0x82, 0x49, 0x8d, 0x7e, 0xae,
-	0xea, 0x6d, 0x03, 0x7d, 0x14, 0xa9, 0x18, 0x90, 0x71, 0x6d, 0xdc, 0x9c, 0x44, 0x94, 0xd9, 0x29,
-	0x24, 0x5f, 0x25, 0x46, 0x8e, 0xd6, 0x2e, 0xd5, 0x84, 0xda, 0x5c, 0x6b, 0x89, 0xf6, 0xff, 0x1f,
-	0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xae, 0x80, 0xda, 0x59, 0x8f, 0x1c, 0x00, 0x00,
+func init() { proto.RegisterFile("datastore.proto", fileDescriptor_datastore_91810f0809db6859) }
+
+var fileDescriptor_datastore_91810f0809db6859 = []byte{
+	// 1930 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xdd, 0x73, 0xdb, 0xc6,
+	0x11, 0x0f, 0xf4, 0xcd, 0x25, 0xf5, 0x91, 0x93, 0x22, 0x91, 0x70, 0x2b, 0x3b, 0x48, 0x9d, 0x49,
+	0x63, 0x05, 0x94, 0x58, 0xdb, 0xca, 0x47, 0xa7, 0xae, 0x48, 0x31, 0x2a, 0x13, 0xd9, 0xd5, 0x80,
+	0x54, 0xe3, 0x71, 0x3a, 0xc5, 0x80, 0xe2, 0x89, 0x86, 0x4b, 0x02, 0x28, 0x70, 0x74, 0xcd, 0xf4,
+	0xb5, 0x33, 0x9d, 0xe9, 0xf4, 0xa5, 0x93, 0x7f, 0xa0, 0x6f, 0x7d, 0xe9, 0x6b, 0x9f, 0xfa, 0xd2,
+	0xff, 0xaa, 0xaf, 0x1d, 0xdc, 0x1d, 0x08, 0x80, 0xc0, 0xd1, 0x00, 0xa5, 0x3c, 0x89, 0x38, 0xec,
+	0x6f, 0xf7, 0xb7, 0x8b, 0xbd, 0xbd, 0xdb, 0x1d, 0xc1, 0x66, 0xcf, 0x20, 0x86, 0x47, 0x6c, 0x17,
+	0xab, 0x8e, 0x6b, 0x13, 0x1b, 0xed, 0x7a, 0x8e, 0xe9, 0x62, 0xd5, 0xc3, 0xee, 0x6b, 0xec, 0xaa,
+	0x93, 0xb7, 0xf2, 0x7e, 0xdf, 0xb6, 0xfb, 0x03, 0x5c, 0xa5, 0x52, 0xdd, 0xd1, 0x75, 0xf5, 0x8f,
+	0xae, 0xe1, 0x38, 0xd8, 0xf5, 0x18, 0x4e, 0xfe, 0xb4, 0x6f, 0x92, 0x97, 0xa3, 0xae, 0x7a, 0x65,
+	0x0f, 0xab, 0x9e, 0x63, 0x5e, 0x5f, 0xe3, 0x2a, 0xd5, 0xc4, 0x00, 0xd5, 0x2b, 0x7b, 0x38, 0xb4,
+	0xad, 0xaa, 0x33, 0x18, 0xf5, 0xcd, 0xe0, 0x0f, 0x47, 0x1e, 0x65, 0x42, 0xb2, 0x3f, 0x0c, 0xa2,
+	0x34, 0x60, 0xbb, 0xe1, 0x62, 0x83, 0xe0, 0xfa, 0xc8, 0xea, 0x0d, 0xb0, 0x86, 0xff, 0x30, 0xc2,
+	0x1e, 0x41, 0x07, 0xb0, 0xd2, 0xa5, 0x0b, 0x65, 0xe9, 0x9e, 0xf4, 0x51, 0xb1, 0xb6, 0xa3, 0x32,
+	0x67, 0x38, 0x96, 0x0b, 0x73, 0x19, 0xe5, 0x14, 0x76, 0xe2, 0x4a, 0x3c, 0xc7, 0xb6, 0x3c, 0x9c,
+	0x53, 0xcb, 0xcf, 0x01, 0x7d, 0x89, 0xc9, 0xd5, 0xcb, 0x38, 0x93, 0x0f, 0x61, 0x93, 0xb8, 0x23,
+	0x8f, 0xe8, 0x3d, 0x7b, 0x68, 0x98, 0x96, 0x6e, 0xf6, 0xa8, 0xb2, 0x82, 0xb6, 0x4e, 0x97, 0x4f,
+	0xe9, 0x6a, 0xab, 0xe7, 0x3b, 0x12, 0x43, 0xcf, 0x45, 0x61, 0x07, 0xd0, 0xb9, 0xe9, 0x11, 0xb6,
+	0xea, 0x71, 0x0a, 0x4a, 0x13, 0xb6, 0x63, 0xab, 0x5c, 0xb5, 0x0a, 0xab, 0x0c, 0xe6, 0x95, 0xa5,
+	0x7b, 0x8b, 0x42, 0xdd, 0x81, 0x90, 0xcf, 0xf0, 0xd2, 0xe9, 0xdd, 0x3c, 0xd4, 0x71, 0x25, 0x73,
+	0xf9, 0xd9, 0x80, 0xed, 0x13, 0xc7, 0xc1, 0x56, 0xef, 0x86, 0x54, 0xe2, 0x4a, 0xe6, 0xa2, 0xf2,
+	0x6f, 0x09, 0xb6, 0x4f, 0xf1, 0x00, 0x13, 0x3c, 0xd7, 0x77, 0x47, 0xa7, 0xb0, 0x34, 0xb4, 0x7b,
+	0xb8, 0xbc, 0x70, 0x4f, 0xfa, 0x68, 0xa3, 0x76, 0xa8, 0xa6, 0x6f, 0x3a, 0x35, 0xc5, 0x84, 0xfa,
+	0xd4, 0xee, 0x61, 0x8d, 0xa2, 0x95, 0x43, 0x58, 0xf2, 0x9f, 0x50, 0x09, 0xd6, 0xb4, 0x66, 0xbb,
+	0xa3, 0xb5, 0x1a, 0x9d, 0xad, 0x77, 0x10, 0xc0, 0xca, 0x69, 0xf3, 0xbc, 0xd9, 0x69, 0x6e, 0x49,
+	0x68, 0x03, 0xe0, 0xb4, 0xd5, 0x6e, 0xff, 0xba, 0xd1, 0x3a, 0xe9, 0x34, 0xb7, 0x16, 0x7c, 0xef,
+	0xe3, 0x3a, 0xe7, 0xf2, 0xbe, 0x0b, 0xeb, 0xcf, 0xec, 0x1e, 0x6e, 0xe3, 0x01, 0xbe, 0x22, 0xb6,
+	0xeb, 0xa1, 0x3b, 0x50, 0x60, 0x3b, 0x37, 0x74, 0x78, 0x8d, 0x2d, 0xb4, 0x7a, 0xe8, 0x21, 0x14,
+	0xbc, 0x40, 0xb2, 0xbc, 0x40, 0x73, 0x6e, 0x37, 0xae, 0x3e, 0x50, 0xa4, 0x85, 0x82, 0xca, 0xef,
+	0x60, 0xaf, 0x8d, 0x49, 0xcc, 0x4c, 0x10, 0xe4, 0x46, 0x54, 0x21, 0xe3, 0x7b, 0x5f, 0x14, 0xc1,
+	0xb8, 0x82, 0x88, 0x7e, 0x19, 0xca, 0x49, 0xfd, 0x2c, 0x1a, 0xca, 0xf7, 0x12, 0x94, 0xeb, 0xa6,
+	0xd5, 0x4b, 0xb5, 0x3e, 0xd3, 0xd7, 0x0f, 0x60, 0x3d, 0x30, 0xa1, 0x93, 0xb1, 0xc3, 0x3e, 0x70,
+	0x41, 0x2b, 0x05, 0x8b, 0x9d, 0xb1, 0x83, 0xd1, 0x23, 0xd8, 0x33, 0x08, 0xc1, 0x1e, 0xc1, 0x3d,
+	0xdd, 0xb2, 0x7b, 0x58, 0x0f, 0xf5, 0x2d, 0x52, 0xf1, 0x9d, 0xe0, 0x35, 0x25, 0xc0, 0x75, 0x2b,
+	0x77, 0xa0, 0x92, 0x42, 0x8a, 0x53, 0x7e, 0x0c, 0x7b, 0x67, 0x98, 0xe4, 0x26, 0xac, 0xe8, 0x50,
+	0x3e, 0x13, 0x84, 0xe1, 0x76, 0xe2, 0xfc, 0x35, 0x54, 0x58, 0x95, 0x3d, 0x89, 0xf8, 0x14, 0x50,
+	0x53, 0x61, 0xc9, 0x0f, 0x00, 0x57, 0x2e, 0xc7, 0xb3, 0x22, 0x06, 0xa0, 0x72, 0xca, 0x39, 0xc8,
+	0x69, 0xca, 0x26, 0xa5, 0x2d, 0x9f, 0xb6, 0x63, 0x28, 0xd3, 0xe2, 0x9b, 0xc6, 0x6c, 0x66, 0xd0,
+	0xbe, 0x86, 0x4a, 0x0a, 0x70, 0x4e, 0x16, 0xff, 0x94, 0xa0, 0xec, 0x17, 0xea, 0xe8, 0xab, 0xc9,
+	0xb7, 0x3b, 0x83, 0x77, 0xbb, 0x63, 0x1d, 0xbf, 0xf1, 0x75, 0x78, 0x7a, 0x17, 0x5f, 0xdb, 0x6e,
+	0xa0, 0xf9, 0x8e, 0xca, 0x4e, 0x64, 0x35, 0x38, 0x91, 0xd5, 0x96, 0x45, 0x1e, 0x3f, 0xfc, 0x8d,
+	0x31, 0x18, 0x61, 0x6d, 0xb3, 0x3b, 0x6e, 0x32, 0x50, 0x9d, 0x62, 0x50, 0x1d, 0xc0, 0x31, 0xfa,
+	0xa6, 0x65, 0x10, 0xd3, 0xb6, 0x68, 0x56, 0x16, 0x6b, 0x8a, 0xe8, 0x63, 0x5e, 0x4c, 0x24, 0xb5,
+	0x08, 0x4a, 0xf9, 0xbb, 0x04, 0x95, 0x14, 0xa6, 0xdc, 0xef, 0x43, 0x58, 0xf6, 0xfd, 0x09, 0x8e,
+	0x95, 0x59, 0x8e, 0x33, 0xc1, 0x5b, 0xe1, 0xf4, 0x37, 0x09, 0x2a, 0xec, 0x68, 0xc9, 0xfb, 0x15,
+	0xd1, 0x01, 0xa0, 0x2b, 0xec, 0x12, 0xdd, 0xc3, 0xae, 0x69, 0x0c, 0x74, 0x6b, 0x34, 0xec, 0x62,
+	0x97, 0x6f, 0xd8, 0x2d, 0xff, 0x4d, 0x9b, 0xbe, 0x78, 0x46, 0xd7, 0xd1, 0x4f, 0x60, 0x83, 0x4a,
+	0x5b, 0x36, 0xd1, 0x8d, 0x6b, 0x82, 0x5d, 0xba, 0x57, 0x17, 0xb5, 0x92, 0xbf, 0xfa, 0xcc, 0x26,
+	0x27, 0xfe, 0x9a, 0x9f, 0xa0, 0x69, 0x6c, 0xe6, 0x4c, 0x8d, 0x3f, 0x4b, 0xf0, 0x7e, 0x52, 0xdd,
+	0xb9, 0xe1, 0x91, 0x36, 0xc6, 0x56, 0x26, 0x27, 0xef, 0x41, 0x69, 0x60, 0x78, 0xbe, 0x93, 0xd8,
+	0xd2, 0x4d, 0x87, 0xbb, 0x07, 0x03, 0xae, 0xa3, 0xe5, 0xc4, 0x25, 0x0c, 0xc2, 0xdd, 0x9a, 0x48,
+	0x9c, 0x10, 0xa5, 0x03, 0xca, 0x2c, 0x16, 0x73, 0x3a, 0xf7, 0x29, 0x54, 0xd8, 0x51, 0x94, 0x7b,
+	0xfb, 0x9d, 0x83, 0x9c, 0x86, 0x9c, 0x93, 0xc7, 0x37, 0xb0, 0xcf, 0x6a, 0x8a, 0x86, 0xfb, 0xa6,
+	0x47, 0x5c, 0x9a, 0x57, 0x4d, 0x8b, 0xb8, 0xe3, 0x80, 0xcc, 0x23, 0x58, 0xc6, 0xfe, 0x33, 0x57,
+	0x79, 0x37, 0xae, 0x32, 0x09, 0x63, 0xd2, 0xca, 0x73, 0xb8, 0x2b, 0x54, 0xcc, 0xb9, 0xce, 0xa9,
+	0xf9, 0x73, 0xf8, 0x31, 0xad, 0x3f, 0x42, 0xc6, 0x15, 0x58, 0xa3, 0x92, 0x61, 0xf4, 0x56, 0xe9,
+	0x73, 0xab, 0xe7, 0xbb, 0x2b, 0xc2, 0xde, 0x8c, 0xd4, 0x7f, 0x25, 0x28, 0xd6, 0xc7, 0xe1, 0x9d,
+	0xe0, 0x61, 0xfc, 0xf4, 0xc8, 0x76, 0xec, 0xa3, 0x33, 0x58, 0x1e, 0x1a, 0xe4, 0xea, 0x25, 0xbf,
+	0x19, 0x1d, 0x89, 0xca, 0x41, 0xc4, 0x92, 0xfa, 0xd4, 0x07, 0xd4, 0xf1, 0x4b, 0xe3, 0xb5, 0x69,
+	0xbb, 0x1a, 0xc3, 0x2b, 0x35, 0x58, 0x8f, 0xad, 0xa3, 0x4d, 0x28, 0x3e, 0x3d, 0xe9, 0x34, 0x7e,
+	0xa5, 0x37, 0x9f, 0x9f, 0xd0, 0x7b, 0xd2, 0x16, 0x94, 0xd8, 0x42, 0xfb, 0xb2, 0xde, 0x6e, 0x76,
+	0xb6, 0x24, 0xe5, 0x09, 0x40, 0x58, 0x66, 0xd0, 0x0e, 0x2c, 0x13, 0xfb, 0xf7, 0xd8, 0xe2, 0x11,
+	0x64, 0x0f, 0x7e, 0x66, 0x3a, 0x46, 0x1f, 0xeb, 0x9e, 0xf9, 0x1d, 0x3b, 0xdd, 0x97, 0xb5, 0x35,
+	0x7f, 0xa1, 0x6d, 0x7e, 0x87, 0x95, 0x7f, 0x2d, 0xc0, 0xbe, 0x5f, 0x21, 0xa7, 0x83, 0x64, 0x86,
+	0x15, 0xfd, 0x17, 0x50, 0xea, 0x8e, 0x75, 0xc7, 0x70, 0xb1, 0x45, 0x82, 0xcf, 0x53, 0xac, 0xfd,
+	0x28, 0x51, 0xcc, 0xdb, 0xc4, 0x35, 0xad, 0x3e, 0xab, 0xe6, 0xd0, 0x1d, 0x5f, 0x50, 0x40, 0xab,
+	0x87, 0xbe, 0xa4, 0xf8, 0xe8, 0x85, 0xca, 0xc7, 0x7f, 0x90, 0x21, 0x4e, 0x5a, 0xb1, 0x1b, 0x3e,
+	0x70, 0x1e, 0xf1, 0x9b, 0x47, 0x06, 0x1e, 0xc1, 0x6d, 0x64, 0xaa, 0x78, 0x2f, 0xcd, 0x55, 0xbc,
+	0xff, 0x21, 0xc1, 0x5d, 0x61, 0xb8, 0x78, 0x36, 0x7e, 0x06, 0x34, 0x75, 0xcd, 0xc9, 0xc1, 0xf2,
+	0xd6, 0x7c, 0x0c, 0xe4, 0x6f, 0xe5, 0x7c, 0xf9, 0x06, 0xf6, 0x59, 0xed, 0xfb, 0x01, 0xaa, 0x83,
+	0x50, 0xf1, 0xcd, 0x36, 0xe2, 0x17, 0xb0, 0xcf, 0xca, 0xe3, 0x3c, 0xe5, 0xe1, 0x39, 0xdc, 0x15,
+	0x82, 0x6f, 0x46, 0xeb, 0x3f, 0x12, 0x14, 0xbe, 0xb2, 0x4d, 0xab, 0x43, 0xb7, 0x51, 0xfa, 0xe6,
+	0xda, 0x85, 0x15, 0x7a, 0xd7, 0x19, 0xd3, 0xaf, 0xb5, 0xa8, 0xf1, 0xa7, 0xf8, 0x71, 0xb0, 0x38,
+	0xab, 0xbf, 0x58, 0xca, 0x5a, 0x68, 0x2a, 0xb0, 0x36, 0x34, 0xde, 0xe8, 0x23, 0x0f, 0x7b, 0xe5,
+	0x65, 0xba, 0x8d, 0x57, 0x87, 0xc6, 0x9b, 0x4b, 0x0f, 0x7b, 0x08, 0xc1, 0x12, 0x5d, 0x5e, 0xa1,
+	0xcb, 0xf4, 0xb7, 0xf2, 0x02, 0x76, 0x59, 0x31, 0x9f, 0xb8, 0x10, 0x04, 0xf3, 0x97, 0x00, 0xaf,
+	0x6c, 0xd3, 0xd2, 0x43, 0x77, 0x8a, 0xb5, 0xf7, 0x45, 0x59, 0x16, 0xa2, 0x0b, 0xaf, 0x82, 0x9f,
+	0xca, 0xb7, 0xb0, 0x97, 0xd0, 0xcd, 0x63, 0x7d, 0x73, 0xe5, 0x9f, 0xc0, 0x7b, 0xb4, 0xde, 0x27,
+	0x78, 0xa7, 0x7e, 0x01, 0xdf, 0xcf, 0x69, 0xf1, 0x5b, 0xa3, 0xa2, 0xc2, 0x2e, 0xcb, 0xad, 0x8c,
+	0x5c, 0xbe, 0x85, 0xbd, 0x84, 0xfc, 0xad, 0x91, 0xd9, 0x83, 0xf7, 0xfc, 0xd2, 0x33, 0x79, 0x37,
+	0x99, 0x9b, 0xfc, 0x16, 0x76, 0xa7, 0x5f, 0x70, 0xa3, 0x75, 0x28, 0x86, 0x46, 0x83, 0x72, 0x94,
+	0xc1, 0x2a, 0x4c, 0xac, 0x7a, 0xca, 0x03, 0xd8, 0xbe, 0xf4, 0xb2, 0x06, 0xe0, 0x39, 0xec, 0xc4,
+	0x85, 0x6f, 0xcd, 0xfb, 0x27, 0xb0, 0x7b, 0xe1, 0x8e, 0x2c, 0x9c, 0x70, 0x1f, 0xdd, 0x87, 0x8d,
+	0x94, 0x76, 0x63, 0x51, 0x5b, 0xc7, 0xd1, 0x7e, 0x42, 0xa9, 0xc0, 0x5e, 0x42, 0x01, 0x6f, 0x45,
+	0x0d, 0x28, 0x34, 0x4e, 0xbe, 0xb2, 0x47, 0xae, 0x65, 0x0c, 0x32, 0x0f, 0x44, 0x10, 0x2c, 0xf9,
+	0x94, 0xe9, 0xbe, 0x2f, 0x69, 0xf4, 0x37, 0x2a, 0xc3, 0xea, 0x6b, 0xec, 0x7a, 0x7e, 0xf1, 0x66,
+	0x97, 0xd2, 0xe0, 0x51, 0x79, 0xc2, 0x93, 0x7a, 0x62, 0x27, 0xef, 0xdc, 0xed, 0x12, 0x76, 0xa7,
+	0x15, 0xf0, 0xd8, 0x7e, 0x01, 0xab, 0xaf, 0xd8, 0xd2, 0xdb, 0x02, 0x1b, 0x62, 0x03, 0x84, 0xa2,
+	0xc1, 0x76, 0x1b, 0x93, 0x04, 0xab, 0x1b, 0xe9, 0x6c, 0xc3, 0x4e, 0x5c, 0xe7, 0x2d, 0x10, 0xad,
+	0xfd, 0xef, 0x0e, 0x14, 0x4e, 0x0d, 0x62, 0xb4, 0x7d, 0x01, 0x64, 0x42, 0x29, 0x3a, 0x09, 0x45,
+	0x0f, 0x84, 0x9a, 0x92, 0x43, 0x57, 0xf9, 0x20, 0x9b, 0x30, 0x67, 0x7d, 0x0d, 0xc5, 0xc8, 0xc0,
+	0x13, 0x7d, 0x2c, 0x02, 0x27, 0x67, 0xaa, 0xf2, 0x83, 0x4c, 0xb2, 0xa1, 0x9d, 0xc8, 0xf4, 0x53,
+	0x6c, 0x27, 0x39, 0x38, 0x95, 0x1f, 0x64, 0x92, 0xe5, 0x76, 0x4c, 0x28, 0x45, 0x27, 0x9b, 0xe2,
+	0xd0, 0xa5, 0x0c, 0x51, 0xe5, 0x83, 0x6c, 0xc2, 0xa1, 0xa9, 0xe8, 0xe4, 0x52, 0x6c, 0x2a, 0x65,
+	0x48, 0x2a, 0x1f, 0x64, 0x13, 0x0e, 0x4d, 0x45, 0xc7, 0x84, 0x62, 0x53, 0x29, 0x03, 0x4a, 0xf9,
+	0x20, 0x9b, 0x30, 0x37, 0xf5, 0x27, 0x40, 0xc9, 0x91, 0x0e, 0x3a, 0x9a, 0x9d, 0x54, 0x29, 0x2d,
+	0xa3, 0x5c, 0xcb, 0x03, 0xe1, 0xc6, 0xdf, 0xc0, 0xbb, 0x89, 0x41, 0x0e, 0x3a, 0x9c, 0x99, 0x67,
+	0x69, 0xa6, 0x8f, 0x72, 0x20, 0x42, 0xcb, 0x89, 0x51, 0x8a, 0xd8, 0xb2, 0x68, 0x3e, 0x24, 0x1f,
+	0xe5, 0x40, 0x84, 0x01, 0x4f, 0x76, 0xf3, 0xe2, 0x80, 0x0b, 0x87, 0x2b, 0x72, 0x2d, 0x0f, 0x84,
+	0x1b, 0xff, 0x5e, 0x02, 0x59, 0x3c, 0x4b, 0x40, 0x9f, 0x65, 0x57, 0x39, 0x35, 0x05, 0x91, 0x3f,
+	0x9f, 0x07, 0x1a, 0x86, 0x24, 0x39, 0x50, 0x10, 0x87, 0x44, 0x38, 0xb6, 0x90, 0x6b, 0x79, 0x20,
+	0xdc, 0xf8, 0x08, 0xb6, 0xa6, 0x07, 0xd1, 0xa8, 0x2a, 0xd2, 0x23, 0x18, 0x89, 0xcb, 0x87, 0xd9,
+	0x01, 0x61, 0x02, 0x26, 0xa6, 0xc9, 0xe2, 0x04, 0x14, 0x4d, 0xc3, 0xe5, 0xa3, 0x1c, 0x88, 0xd0,
+	0xe1, 0xb3, 0xcc, 0x0e, 0x9f, 0xe5, 0x75, 0x58, 0x38, 0xcd, 0xfe, 0xab, 0x14, 0x5c, 0xb3, 0x13,
+	0x2d, 0x0a, 0x7a, 0x3c, 0xbb, 0x76, 0x88, 0x1a, 0x29, 0xf9, 0x38, 0x37, 0x8e, 0x93, 0xf9, 0x8b,
+	0xc4, 0x2f, 0x20, 0x49, 0x2e, 0x8f, 0x66, 0x16, 0x13, 0x21, 0x95, 0xc7, 0x79, 0x61, 0x91, 0xb0,
+	0x08, 0x7a, 0x70, 0x71, 0x58, 0x66, 0xcf, 0x38, 0xe4, 0xe3, 0xdc, 0xb8, 0x08, 0x19, 0x41, 0x57,
+	0x2c, 0x26, 0x33, 0xbb, 0x3f, 0x97, 0x8f, 0x73, 0xe3, 0x22, 0x64, 0x04, 0xbd, 0xb0, 0x98, 0xcc,
+	0xec, 0xce, 0x5b, 0x3e, 0xce, 0x8d, 0xe3, 0x64, 0x5c, 0xd8, 0x9c, 0xea, 0x11, 0x91, 0x3a, 0x3b,
+	0xf9, 0xa6, 0x7b, 0x0c, 0xb9, 0x9a, 0x59, 0x9e, 0xdb, 0xb4, 0x61, 0x23, 0xde, 0x0b, 0xa2, 0x4f,
+	0x66, 0x26, 0x59, 0xc2, 0xa2, 0x9a, 0x55, 0x3c, 0x34, 0x18, 0x6f, 0xbd, 0xc4, 0x06, 0x53, 0x7b,
+	0x37, 0x59, 0xcd, 0x2a, 0x1e, 0x46, 0x75, 0xaa, 0xc3, 0x14, 0x47, 0x35, 0xbd, 0x75, 0x95, 0xab,
+	0x99, 0xe5, 0x23, 0x37, 0xc6, 0x48, 0x53, 0x37, 0xe3, 0xc6, 0x98, 0xec, 0x13, 0xe5, 0x83, 0x6c,
+	0xc2, 0xa1, 0x7b, 0x53, 0x4d, 0x9a, 0xd8, 0xbd, 0xf4, 0x76, 0x50, 0xae, 0x66, 0x96, 0x9f, 0x4a,
+	0x9a, 0xb0, 0x05, 0x9c, 0x9d, 0x34, 0xd3, 0xcd, 0x92, 0xac, 0x66, 0x15, 0x0f, 0xe3, 0x19, 0xed,
+	0x8f, 0xc4, 0xf1, 0x4c, 0xe9, 0xcc, 0xe4, 0x83, 0x6c, 0xc2, 0xdc, 0xd4, 0x0b, 0x28, 0x34, 0x6c,
+	0xeb, 0xda, 0xec, 0x8f, 0x5c, 0x8c, 0xee, 0xc7, 0x67, 0x4c, 0xfc, 0x5f, 0x5a, 0x26, 0xef, 0x03,
+	0x0b, 0x1f, 0xbe, 0x4d, 0x6c, 0xd2, 0xb0, 0xac, 0x9f, 0x61, 0x72, 0x41, 0x5f, 0xb7, 0xac, 0x6b,
+	0x1b, 0xfd, 0x34, 0x15, 0x18, 0x93, 0x09, 0x6c, 0x7c, 0x9c, 0x45, 0x94, 0xd9, 0xa9, 0x17, 0x5f,
+	0x14, 0x26, 0x5e, 0x5e, 0xbc, 0x73, 0x21, 0x5d, 0x2c, 0x74, 0x57, 0xe8, 0xb0, 0xf7, 0x67, 0xff,
+	0x1f, 0x00, 0xf0, 0xdd, 0x0d, 0xcd, 0x0c, 0x24, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ package keymanager
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/spiffe/spire/proto/common"
 import plugin "github.com/spiffe/spire/proto/common/plugin"
 
 import (
@@ -39,41 +40,6 @@ type GetPluginInfoRequest = plugin.GetPluginInfoRequest
 // GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
 type GetPluginInfoResponse = plugin.GetPluginInfoResponse
 
-type KeyType int32
-
-const (
-	KeyType_UNSPECIFIED_KEY_TYPE KeyType = 0
-	KeyType_EC_P256              KeyType = 1
-	KeyType_EC_P384              KeyType = 2
-	KeyType_RSA_1024             KeyType = 3
-	KeyType_RSA_2048             KeyType = 4
-	KeyType_RSA_4096             KeyType = 5
-)
-
-var KeyType_name = map[int32]string{
-	0: "UNSPECIFIED_KEY_TYPE",
-	1: "EC_P256",
-	2: "EC_P384",
-	3: "RSA_1024",
-	4: "RSA_2048",
-	5: "RSA_4096",
-}
-var KeyType_value = map[string]int32{
-	"UNSPECIFIED_KEY_TYPE": 0,
-	"EC_P256":              1,
-	"EC_P384":              2,
-	"RSA_1024":             3,
-	"RSA_2048":             4,
-	"RSA_4096":             5,
-}
-
-func (x KeyType) String() string {
-	return proto.EnumName(KeyType_name, int32(x))
-}
-func (KeyType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{0}
-}
-
 type HashAlgorithm int32
 
 const (
@@ -123,23 +89,23 @@ func (x HashAlgorithm) String() string {
 	return proto.EnumName(HashAlgorithm_name, int32(x))
 }
 func (HashAlgorithm) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{1}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{0}
 }
 
 type PublicKey struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Type                 KeyType  `protobuf:"varint,2,opt,name=type,proto3,enum=spire.server.keymanager.KeyType" json:"type,omitempty"`
-	PkixData             []byte   `protobuf:"bytes,3,opt,name=pkix_data,json=pkixData,proto3" json:"pkix_data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                 common.KeyType `protobuf:"varint,2,opt,name=type,proto3,enum=spire.common.KeyType" json:"type,omitempty"`
+	PkixData             []byte         `protobuf:"bytes,3,opt,name=pkix_data,json=pkixData,proto3" json:"pkix_data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *PublicKey) Reset()         { *m = PublicKey{} }
 func (m *PublicKey) String() string { return proto.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()    {}
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{0}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{0}
 }
 func (m *PublicKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PublicKey.Unmarshal(m, b)
@@ -166,11 +132,11 @@ func (m *PublicKey) GetId() string {
 	return ""
 }
 
-func (m *PublicKey) GetType() KeyType {
+func (m *PublicKey) GetType() common.KeyType {
 	if m != nil {
 		return m.Type
 	}
-	return KeyType_UNSPECIFIED_KEY_TYPE
+	return common.KeyType_UNSPECIFIED_KEY_TYPE
 }
 
 func (m *PublicKey) GetPkixData() []byte {
@@ -181,18 +147,18 @@ func (m *PublicKey) GetPkixData() []byte {
 }
 
 type GenerateKeyRequest struct {
-	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
-	KeyType              KeyType  `protobuf:"varint,2,opt,name=key_type,json=keyType,proto3,enum=spire.server.keymanager.KeyType" json:"key_type,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	KeyId                string         `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	KeyType              common.KeyType `protobuf:"varint,2,opt,name=key_type,json=keyType,proto3,enum=spire.common.KeyType" json:"key_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GenerateKeyRequest) Reset()         { *m = GenerateKeyRequest{} }
 func (m *GenerateKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*GenerateKeyRequest) ProtoMessage()    {}
 func (*GenerateKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{1}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{1}
 }
 func (m *GenerateKeyRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateKeyRequest.Unmarshal(m, b)
@@ -219,11 +185,11 @@ func (m *GenerateKeyRequest) GetKeyId() string {
 	return ""
 }
 
-func (m *GenerateKeyRequest) GetKeyType() KeyType {
+func (m *GenerateKeyRequest) GetKeyType() common.KeyType {
 	if m != nil {
 		return m.KeyType
 	}
-	return KeyType_UNSPECIFIED_KEY_TYPE
+	return common.KeyType_UNSPECIFIED_KEY_TYPE
 }
 
 type GenerateKeyResponse struct {
@@ -237,7 +203,7 @@ func (m *GenerateKeyResponse) Reset()         { *m = GenerateKeyResponse{} }
 func (m *GenerateKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*GenerateKeyResponse) ProtoMessage()    {}
 func (*GenerateKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{2}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{2}
 }
 func (m *GenerateKeyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateKeyResponse.Unmarshal(m, b)
@@ -275,7 +241,7 @@ func (m *GetPublicKeyRequest) Reset()         { *m = GetPublicKeyRequest{} }
 func (m *GetPublicKeyRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPublicKeyRequest) ProtoMessage()    {}
 func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{3}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{3}
 }
 func (m *GetPublicKeyRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPublicKeyRequest.Unmarshal(m, b)
@@ -313,7 +279,7 @@ func (m *GetPublicKeyResponse) Reset()         { *m = GetPublicKeyResponse{} }
 func (m *GetPublicKeyResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPublicKeyResponse) ProtoMessage()    {}
 func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{4}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{4}
 }
 func (m *GetPublicKeyResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPublicKeyResponse.Unmarshal(m, b)
@@ -350,7 +316,7 @@ func (m *GetPublicKeysRequest) Reset()         { *m = GetPublicKeysRequest{} }
 func (m *GetPublicKeysRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPublicKeysRequest) ProtoMessage()    {}
 func (*GetPublicKeysRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{5}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{5}
 }
 func (m *GetPublicKeysRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPublicKeysRequest.Unmarshal(m, b)
@@ -381,7 +347,7 @@ func (m *GetPublicKeysResponse) Reset()         { *m = GetPublicKeysResponse{} }
 func (m *GetPublicKeysResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPublicKeysResponse) ProtoMessage()    {}
 func (*GetPublicKeysResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{6}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{6}
 }
 func (m *GetPublicKeysResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPublicKeysResponse.Unmarshal(m, b)
@@ -420,7 +386,7 @@ func (m *PSSOptions) Reset()         { *m = PSSOptions{} }
 func (m *PSSOptions) String() string { return proto.CompactTextString(m) }
 func (*PSSOptions) ProtoMessage()    {}
 func (*PSSOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{7}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{7}
 }
 func (m *PSSOptions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PSSOptions.Unmarshal(m, b)
@@ -470,7 +436,7 @@ func (m *SignDataRequest) Reset()         { *m = SignDataRequest{} }
 func (m *SignDataRequest) String() string { return proto.CompactTextString(m) }
 func (*SignDataRequest) ProtoMessage()    {}
 func (*SignDataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{8}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{8}
 }
 func (m *SignDataRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignDataRequest.Unmarshal(m, b)
@@ -621,7 +587,7 @@ func (m *SignDataResponse) Reset()         { *m = SignDataResponse{} }
 func (m *SignDataResponse) String() string { return proto.CompactTextString(m) }
 func (*SignDataResponse) ProtoMessage()    {}
 func (*SignDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_keymanager_6ff2c2b415389f2b, []int{9}
+	return fileDescriptor_keymanager_fcb360229c77629f, []int{9}
 }
 func (m *SignDataResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignDataResponse.Unmarshal(m, b)
@@ -659,7 +625,6 @@ func init() {
 	proto.RegisterType((*PSSOptions)(nil), "spire.server.keymanager.PSSOptions")
 	proto.RegisterType((*SignDataRequest)(nil), "spire.server.keymanager.SignDataRequest")
 	proto.RegisterType((*SignDataResponse)(nil), "spire.server.keymanager.SignDataResponse")
-	proto.RegisterEnum("spire.server.keymanager.KeyType", KeyType_name, KeyType_value)
 	proto.RegisterEnum("spire.server.keymanager.HashAlgorithm", HashAlgorithm_name, HashAlgorithm_value)
 }
 
@@ -912,57 +877,53 @@ var _KeyManager_serviceDesc = grpc.ServiceDesc{
 	Metadata: "keymanager.proto",
 }
 
-func init() { proto.RegisterFile("keymanager.proto", fileDescriptor_keymanager_6ff2c2b415389f2b) }
-
-var fileDescriptor_keymanager_6ff2c2b415389f2b = []byte{
-	// 771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x6d, 0x6f, 0xda, 0x48,
-	0x10, 0xc6, 0x84, 0xd7, 0x31, 0x10, 0x6b, 0x2f, 0xb9, 0x43, 0xdc, 0xe9, 0x8a, 0x5c, 0x35, 0x22,
-	0x69, 0x6a, 0x88, 0x03, 0x28, 0x55, 0x3f, 0x11, 0x42, 0x02, 0x22, 0x69, 0x90, 0x49, 0xa5, 0x26,
-	0xaa, 0x64, 0x39, 0x61, 0xb1, 0x2d, 0xc0, 0x76, 0xbd, 0xa6, 0xaa, 0xa5, 0xfe, 0xaf, 0xfe, 0xa1,
-	0xfe, 0x88, 0x7e, 0xac, 0x6c, 0xaf, 0x79, 0x49, 0x4a, 0x42, 0xd5, 0x7e, 0xf2, 0xcc, 0xec, 0xf3,
-	0xcc, 0xb3, 0x33, 0xb3, 0xbb, 0x06, 0x6e, 0x84, 0xdd, 0x89, 0x62, 0x28, 0x2a, 0xb6, 0x05, 0xcb,
-	0x36, 0x1d, 0x13, 0xfd, 0x43, 0x2c, 0xdd, 0xc6, 0x02, 0xc1, 0xf6, 0x27, 0x6c, 0x0b, 0xf3, 0xe5,
-	0xc2, 0x91, 0xaa, 0x3b, 0xda, 0xf4, 0x56, 0xb8, 0x33, 0x27, 0x65, 0x62, 0xe9, 0xc3, 0x21, 0x2e,
-	0xfb, 0xd0, 0xb2, 0xcf, 0x2b, 0xdf, 0x99, 0x93, 0x89, 0x69, 0x94, 0xad, 0xf1, 0x54, 0xd5, 0xc3,
-	0x4f, 0x90, 0x92, 0x37, 0x20, 0xdd, 0x9b, 0xde, 0x8e, 0xf5, 0xbb, 0x2e, 0x76, 0x51, 0x0e, 0xa2,
-	0xfa, 0x20, 0xcf, 0x14, 0x99, 0x52, 0x5a, 0x8a, 0xea, 0x03, 0x54, 0x85, 0x98, 0xe3, 0x5a, 0x38,
-	0x1f, 0x2d, 0x32, 0xa5, 0x9c, 0x58, 0x14, 0x56, 0xc8, 0x0b, 0x5d, 0xec, 0x5e, 0xb9, 0x16, 0x96,
-	0x7c, 0x34, 0xfa, 0x17, 0xd2, 0xd6, 0x48, 0xff, 0x2c, 0x0f, 0x14, 0x47, 0xc9, 0x6f, 0x14, 0x99,
-	0x52, 0x46, 0x4a, 0x79, 0x81, 0x13, 0xc5, 0x51, 0x78, 0x0d, 0xd0, 0x19, 0x36, 0xb0, 0xad, 0x38,
-	0xb8, 0x8b, 0x5d, 0x09, 0x7f, 0x9c, 0x62, 0xe2, 0xa0, 0x6d, 0x48, 0x8c, 0xb0, 0x2b, 0xcf, 0xc4,
-	0xe3, 0x23, 0xec, 0x76, 0x06, 0xe8, 0x0d, 0xa4, 0xbc, 0xf0, 0x2f, 0xed, 0x21, 0x39, 0x0a, 0x0c,
-	0xfe, 0x3d, 0xfc, 0xb5, 0xa4, 0x44, 0x2c, 0xd3, 0x20, 0x18, 0x35, 0x00, 0x2c, 0xbf, 0x60, 0x79,
-	0x84, 0x5d, 0x5f, 0x8e, 0x15, 0xf9, 0x95, 0x59, 0x67, 0xbd, 0x91, 0xd2, 0x56, 0x68, 0xf2, 0xfb,
-	0x5e, 0x66, 0x67, 0xbe, 0xf4, 0x68, 0x11, 0xfc, 0x35, 0x6c, 0x2d, 0xa3, 0xff, 0xdc, 0x46, 0xfe,
-	0x5e, 0x4e, 0x4d, 0xe8, 0x4e, 0xf8, 0x0f, 0xb0, 0x7d, 0x2f, 0x4e, 0x35, 0x9b, 0xc0, 0xce, 0x35,
-	0x49, 0x9e, 0x29, 0x6e, 0xac, 0x29, 0x0a, 0x33, 0x51, 0xc2, 0x7f, 0x01, 0xe8, 0xf5, 0xfb, 0x97,
-	0x96, 0xa3, 0x9b, 0x06, 0x41, 0xcf, 0x80, 0x25, 0xca, 0xd8, 0x91, 0xc7, 0xd8, 0x50, 0x1d, 0xcd,
-	0xaf, 0x23, 0x2e, 0x81, 0x17, 0x3a, 0xf7, 0x23, 0xe8, 0x02, 0x72, 0x9a, 0x42, 0x34, 0x59, 0x19,
-	0xab, 0xa6, 0xad, 0x3b, 0xda, 0x84, 0x8e, 0x72, 0x67, 0xa5, 0x6c, 0x5b, 0x21, 0x5a, 0x23, 0x44,
-	0x4b, 0x59, 0x6d, 0xd1, 0xe5, 0xbf, 0x31, 0xb0, 0xd9, 0xd7, 0x55, 0xc3, 0x3b, 0x4d, 0x4f, 0x1c,
-	0x1f, 0x04, 0xb1, 0x85, 0x33, 0xe8, 0xdb, 0xe8, 0xf2, 0xf7, 0x76, 0xd3, 0x8e, 0xdc, 0xdb, 0x0f,
-	0x3a, 0x05, 0xd6, 0x22, 0x44, 0x36, 0x83, 0x76, 0xe4, 0x63, 0xfe, 0x1c, 0x9f, 0xaf, 0x6e, 0xe9,
-	0xac, 0x73, 0xed, 0x88, 0x04, 0x16, 0x21, 0xd4, 0x3b, 0xce, 0x02, 0x4b, 0x74, 0xd5, 0xc0, 0xb6,
-	0x97, 0x8a, 0xf0, 0x15, 0xe0, 0xe6, 0x55, 0xd2, 0xe9, 0xfd, 0x07, 0x69, 0x0f, 0xa2, 0x38, 0x53,
-	0x1b, 0xfb, 0x95, 0x66, 0xa4, 0x79, 0x60, 0x4f, 0x85, 0x24, 0xbd, 0x03, 0x28, 0x0f, 0x5b, 0xef,
-	0xde, 0xf6, 0x7b, 0xad, 0x66, 0xe7, 0xb4, 0xd3, 0x3a, 0x91, 0xbb, 0xad, 0x6b, 0xf9, 0xea, 0xba,
-	0xd7, 0xe2, 0x22, 0x88, 0x85, 0x64, 0xab, 0x29, 0xf7, 0xc4, 0x5a, 0x9d, 0x63, 0x42, 0xe7, 0xf0,
-	0xa8, 0xca, 0x45, 0x51, 0x06, 0x52, 0x52, 0xbf, 0x21, 0x1f, 0x54, 0xc4, 0x2a, 0xb7, 0x11, 0x7a,
-	0x62, 0xa5, 0x7a, 0xc4, 0xc5, 0x42, 0xaf, 0x5a, 0x79, 0x5d, 0xe7, 0xe2, 0x7b, 0x5f, 0x19, 0xc8,
-	0x2e, 0x35, 0x05, 0xfd, 0x0f, 0x85, 0x45, 0xbd, 0x76, 0xa3, 0xdf, 0x96, 0x1b, 0xe7, 0x67, 0x97,
-	0x52, 0xe7, 0xaa, 0x7d, 0xc1, 0x45, 0x10, 0x40, 0xa2, 0xdf, 0x6e, 0x88, 0x62, 0x95, 0x8b, 0x85,
-	0x76, 0xad, 0xce, 0xc5, 0xa9, 0xed, 0xe9, 0x27, 0xa8, 0x5d, 0x3b, 0x10, 0xb9, 0xa4, 0xa7, 0xe7,
-	0xc5, 0x65, 0x8f, 0x01, 0x73, 0xaf, 0x56, 0xe7, 0xd8, 0x99, 0xe7, 0xb1, 0x32, 0x33, 0xcf, 0xe3,
-	0x65, 0x51, 0x0e, 0x20, 0xc8, 0xe1, 0x33, 0x73, 0x8b, 0x7e, 0xad, 0xce, 0x6d, 0x8a, 0xdf, 0x63,
-	0x00, 0x5d, 0xec, 0x5e, 0x04, 0xb3, 0x40, 0x1a, 0xb0, 0x0b, 0x2f, 0x04, 0x7a, 0xb9, 0x72, 0x68,
-	0x0f, 0x5f, 0xac, 0xc2, 0xfe, 0x7a, 0x60, 0x3a, 0xb9, 0x11, 0x64, 0x16, 0x2f, 0x24, 0x7a, 0x8c,
-	0xfd, 0xe0, 0x61, 0x29, 0xbc, 0x5a, 0x13, 0x4d, 0xc5, 0x0c, 0xc8, 0x2e, 0xdd, 0x7e, 0xb4, 0x1e,
-	0x3f, 0x7c, 0x3d, 0x0a, 0xc2, 0xba, 0x70, 0xaa, 0x27, 0x43, 0x2a, 0x3c, 0xaa, 0xa8, 0xb4, 0x92,
-	0x7b, 0xef, 0xce, 0x16, 0x76, 0xd7, 0x40, 0x52, 0x81, 0x1b, 0x48, 0x37, 0x4d, 0x63, 0xa8, 0xab,
-	0x53, 0x1b, 0xa3, 0x17, 0x94, 0x17, 0xfc, 0xd3, 0x04, 0xfa, 0x33, 0x9b, 0xad, 0x87, 0xe9, 0x77,
-	0x9e, 0x82, 0xd1, 0xdc, 0xc3, 0xa0, 0x59, 0xfe, 0x72, 0xc7, 0x18, 0x9a, 0x68, 0xf7, 0xa7, 0xc4,
-	0x25, 0x4c, 0xa8, 0xb1, 0xb7, 0x0e, 0x34, 0xd0, 0x39, 0xce, 0xdc, 0xc0, 0xbc, 0xc4, 0x5e, 0xe4,
-	0x36, 0xe1, 0xff, 0x7e, 0x0f, 0x7f, 0x04, 0x00, 0x00, 0xff, 0xff, 0xc6, 0xd3, 0xf0, 0x11, 0xe5,
-	0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("keymanager.proto", fileDescriptor_keymanager_fcb360229c77629f) }
+
+var fileDescriptor_keymanager_fcb360229c77629f = []byte{
+	// 713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x6f, 0x6f, 0x9a, 0x5e,
+	0x14, 0xd6, 0xd6, 0x5a, 0x3d, 0xa8, 0x25, 0xf7, 0xf7, 0xeb, 0x66, 0xd8, 0xb2, 0x19, 0x96, 0x35,
+	0xb6, 0xeb, 0xb0, 0xb5, 0xb5, 0xe9, 0x5b, 0xfb, 0x17, 0x63, 0xbb, 0x1a, 0xec, 0x92, 0xad, 0xd9,
+	0x42, 0xa8, 0x5e, 0x81, 0xa0, 0xc0, 0xb8, 0xb8, 0x8c, 0x64, 0xdf, 0x6b, 0x5f, 0x68, 0x1f, 0x62,
+	0x2f, 0x17, 0xe0, 0x02, 0xda, 0xce, 0x96, 0x64, 0x7b, 0xc5, 0x39, 0xe7, 0x3e, 0xcf, 0x79, 0xce,
+	0x39, 0xdc, 0x03, 0xc0, 0x1a, 0xd8, 0x9b, 0x28, 0xa6, 0xa2, 0x62, 0x47, 0xb0, 0x1d, 0xcb, 0xb5,
+	0xd0, 0x53, 0x62, 0xeb, 0x0e, 0x16, 0x08, 0x76, 0xbe, 0x62, 0x47, 0x48, 0x8e, 0xb9, 0x43, 0x55,
+	0x77, 0xb5, 0xe9, 0xad, 0x30, 0xb0, 0x26, 0x0d, 0x62, 0xeb, 0xa3, 0x11, 0x6e, 0x04, 0xd0, 0x46,
+	0xc0, 0x6b, 0x0c, 0xac, 0xc9, 0xc4, 0x32, 0x1b, 0xf6, 0x78, 0xaa, 0xea, 0xd1, 0x23, 0x4c, 0xc9,
+	0xed, 0xa6, 0x62, 0x86, 0x8f, 0x90, 0xc2, 0x0f, 0xa0, 0xd8, 0x9b, 0xde, 0x8e, 0xf5, 0x41, 0x17,
+	0x7b, 0xa8, 0x02, 0x4b, 0xfa, 0xb0, 0x9a, 0xad, 0x65, 0xeb, 0x45, 0x69, 0x49, 0x1f, 0xa2, 0x4d,
+	0xc8, 0xb9, 0x9e, 0x8d, 0xab, 0x4b, 0xb5, 0x6c, 0xbd, 0xd2, 0x5c, 0x17, 0xc2, 0x8a, 0x29, 0xbf,
+	0x8b, 0xbd, 0x6b, 0xcf, 0xc6, 0x52, 0x00, 0x41, 0xcf, 0xa0, 0x68, 0x1b, 0xfa, 0x37, 0x79, 0xa8,
+	0xb8, 0x4a, 0x75, 0xb9, 0x96, 0xad, 0x97, 0xa4, 0x82, 0x1f, 0x38, 0x51, 0x5c, 0x85, 0xff, 0x0c,
+	0xe8, 0x1c, 0x9b, 0xd8, 0x51, 0x5c, 0xdc, 0xc5, 0x9e, 0x84, 0xbf, 0x4c, 0x31, 0x71, 0xd1, 0x3a,
+	0xe4, 0x0d, 0xec, 0xc9, 0xb1, 0xe2, 0x8a, 0x81, 0xbd, 0xce, 0x10, 0xed, 0x40, 0xc1, 0x0f, 0x3f,
+	0x2e, 0xbc, 0x6a, 0x84, 0x06, 0xff, 0x01, 0xfe, 0x9b, 0x4b, 0x4f, 0x6c, 0xcb, 0x24, 0x18, 0xb5,
+	0x01, 0xec, 0xa0, 0x35, 0xd9, 0xc0, 0x5e, 0xa0, 0xc1, 0x34, 0x79, 0x61, 0xc1, 0xd4, 0x85, 0x78,
+	0x0a, 0x52, 0xd1, 0x8e, 0x4c, 0x7e, 0xdb, 0xcf, 0xec, 0x26, 0x47, 0x0f, 0x56, 0xce, 0x7f, 0x84,
+	0xff, 0xe7, 0xd1, 0xff, 0xae, 0x90, 0x27, 0xf3, 0xa9, 0x09, 0xad, 0x84, 0xff, 0x04, 0xeb, 0x77,
+	0xe2, 0x54, 0xf3, 0x18, 0x98, 0x44, 0x93, 0x54, 0xb3, 0xb5, 0xe5, 0x94, 0xa2, 0x10, 0x8b, 0x12,
+	0xfe, 0x3b, 0x40, 0xaf, 0xdf, 0xbf, 0xb2, 0x5d, 0xdd, 0x32, 0x09, 0x7a, 0x09, 0x0c, 0x51, 0xc6,
+	0xae, 0x3c, 0xc6, 0xa6, 0xea, 0x6a, 0x41, 0x1f, 0x2b, 0x12, 0xf8, 0xa1, 0x8b, 0x20, 0x82, 0x2e,
+	0xa1, 0xa2, 0x29, 0x44, 0x93, 0x95, 0xb1, 0x6a, 0x39, 0xba, 0xab, 0x4d, 0xe8, 0xfb, 0xdb, 0x58,
+	0x28, 0x2b, 0x2a, 0x44, 0x6b, 0x47, 0x68, 0xa9, 0xac, 0xcd, 0xba, 0xfc, 0xcf, 0x2c, 0xac, 0xf5,
+	0x75, 0xd5, 0xf4, 0xaf, 0xd0, 0x23, 0x77, 0x06, 0x41, 0x6e, 0xe6, 0xe2, 0x05, 0x36, 0xba, 0xfa,
+	0xbb, 0x6a, 0xc4, 0xcc, 0x9d, 0x7a, 0xd0, 0x19, 0x30, 0x36, 0x21, 0xb2, 0x15, 0x8e, 0xa3, 0x9a,
+	0x0b, 0xde, 0xe3, 0xab, 0xc5, 0x23, 0x8d, 0x27, 0x27, 0x66, 0x24, 0xb0, 0x09, 0xa1, 0xde, 0x51,
+	0x19, 0x18, 0xa2, 0xab, 0x26, 0x76, 0xfc, 0x54, 0x84, 0xdf, 0x01, 0x36, 0xe9, 0x92, 0xbe, 0xbd,
+	0xe7, 0x50, 0xf4, 0x21, 0x8a, 0x3b, 0x75, 0x70, 0xd0, 0x69, 0x49, 0x4a, 0x02, 0x5b, 0x3f, 0xb2,
+	0x50, 0x9e, 0xab, 0x15, 0xbd, 0x00, 0xee, 0xfd, 0xbb, 0x7e, 0xef, 0xf4, 0xb8, 0x73, 0xd6, 0x39,
+	0x3d, 0x91, 0xc5, 0x76, 0x5f, 0x94, 0xdb, 0x17, 0xe7, 0x57, 0x52, 0xe7, 0x5a, 0xbc, 0x64, 0x33,
+	0x08, 0x20, 0xdf, 0x17, 0xdb, 0xcd, 0xe6, 0x3e, 0x9b, 0x8b, 0xec, 0xd6, 0x01, 0xbb, 0x42, 0xed,
+	0xbd, 0xc3, 0x7d, 0x36, 0x4f, 0xed, 0xd6, 0x6e, 0x93, 0x5d, 0x45, 0x25, 0x28, 0xf8, 0x71, 0xd9,
+	0x67, 0x40, 0xe2, 0xb5, 0x0e, 0x58, 0x26, 0xf6, 0x7c, 0x56, 0x29, 0xf6, 0x7c, 0x5e, 0x19, 0x55,
+	0x00, 0xc2, 0x1c, 0x01, 0xb3, 0x32, 0xeb, 0xb7, 0x0e, 0xd8, 0xb5, 0xe6, 0xaf, 0x1c, 0x40, 0x17,
+	0x7b, 0x97, 0xe1, 0x88, 0x90, 0x06, 0xcc, 0xcc, 0xe2, 0xa2, 0x37, 0x0b, 0x67, 0x79, 0xff, 0xeb,
+	0xc1, 0x6d, 0xa7, 0x03, 0xd3, 0x81, 0x1a, 0x50, 0x9a, 0xdd, 0x13, 0xf4, 0x10, 0xfb, 0xde, 0xbe,
+	0x73, 0x6f, 0x53, 0xa2, 0xa9, 0x98, 0x09, 0xe5, 0xd9, 0x38, 0x41, 0xe9, 0xf8, 0xd1, 0x52, 0x73,
+	0x42, 0x5a, 0x38, 0xd5, 0x93, 0xa1, 0x10, 0xdd, 0x20, 0x54, 0x5f, 0xc8, 0xbd, 0xb3, 0x4a, 0xdc,
+	0x66, 0x0a, 0x24, 0x15, 0xb8, 0x81, 0xe2, 0xb1, 0x65, 0x8e, 0x74, 0x75, 0xea, 0x60, 0xf4, 0x7a,
+	0xfe, 0x6b, 0x4c, 0x7f, 0x40, 0xf1, 0x79, 0x94, 0x7e, 0xe3, 0x31, 0x18, 0xcd, 0x3d, 0x0a, 0x87,
+	0x15, 0x1c, 0x77, 0xcc, 0x91, 0x85, 0x36, 0xff, 0x48, 0x9c, 0xc3, 0x44, 0x1a, 0x5b, 0x69, 0xa0,
+	0xa1, 0xce, 0x51, 0xe9, 0x06, 0x92, 0x16, 0x7b, 0x99, 0xdb, 0x7c, 0xf0, 0xff, 0xdb, 0xfb, 0x3d,
+	0x00, 0x53, 0x95, 0x21, 0xa0, 0x99, 0x07, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	go_plugin "github.com/hashicorp/go-plugin"
+	"github.com/spiffe/spire/proto/builtin"
 	"github.com/spiffe/spire/proto/common/plugin"
 	"google.golang.org/grpc"
 )
@@ -13,6 +14,8 @@ import (
 // UpstreamCA is the interface used by all non-catalog components.
 type UpstreamCA interface {
 	SubmitCSR(context.Context, *SubmitCSRRequest) (*SubmitCSRResponse, error)
+	StreamBundleUpdates(context.Context, *StreamBundleUpdatesRequest) (StreamBundleUpdates_Stream, error)
+	PublishJWTKey(context.Context, *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error)
 }
 
 // Plugin is the interface implemented by plugin implementations
@@ -20,6 +23,46 @@ type Plugin interface {
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 	SubmitCSR(context.Context, *SubmitCSRRequest) (*SubmitCSRResponse, error)
+	StreamBundleUpdates(*StreamBundleUpdatesRequest, StreamBundleUpdates_PluginStream) error
+	PublishJWTKey(context.Context, *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error)
+}
+
+type StreamBundleUpdates_Stream interface {
+	Context() context.Context
+	Recv() (*StreamBundleUpdatesResponse, error)
+}
+
+type streamBundleUpdates_Stream struct {
+	stream builtin.RecvStreamClient
+}
+
+func (s streamBundleUpdates_Stream) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s streamBundleUpdates_Stream) Recv() (*StreamBundleUpdatesResponse, error) {
+	m, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return m.(*StreamBundleUpdatesResponse), nil
+}
+
+type StreamBundleUpdates_PluginStream interface {
+	Context() context.Context
+	Send(*StreamBundleUpdatesResponse) error
+}
+
+type streamBundleUpdates_PluginStream struct {
+	stream builtin.RecvStreamServer
+}
+
+func (s streamBundleUpdates_PluginStream) Context() context.Context {
+	return s.stream.Context()
+}
+
+func (s streamBundleUpdates_PluginStream) Send(m *StreamBundleUpdatesResponse) error {
+	return s.stream.Send(m)
 }
 
 type BuiltIn struct {
@@ -58,6 +101,22 @@ func (b BuiltIn) SubmitCSR(ctx context.Context, req *SubmitCSRRequest) (*SubmitC
 	return resp, nil
 }
 
+func (b BuiltIn) StreamBundleUpdates(ctx context.Context, req *StreamBundleUpdatesRequest) (StreamBundleUpdates_Stream, error) {
+	clientStream, serverStream := builtin.RecvStreamPipe(ctx)
+	go func() {
+		serverStream.Close(b.plugin.StreamBundleUpdates(req, streamBundleUpdates_PluginStream{stream: serverStream}))
+	}()
+	return streamBundleUpdates_Stream{stream: clientStream}, nil
+}
+
+func (b BuiltIn) PublishJWTKey(ctx context.Context, req *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error) {
+	resp, err := b.plugin.PublishJWTKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 var Handshake = go_plugin.HandshakeConfig{
 	ProtocolVersion:  1,
 	MagicCookieKey:   "UpstreamCA",
@@ -98,6 +157,12 @@ func (s *GRPCServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInf
 func (s *GRPCServer) SubmitCSR(ctx context.Context, req *SubmitCSRRequest) (*SubmitCSRResponse, error) {
 	return s.Plugin.SubmitCSR(ctx, req)
 }
+func (s *GRPCServer) StreamBundleUpdates(req *StreamBundleUpdatesRequest, stream UpstreamCA_StreamBundleUpdatesServer) error {
+	return s.Plugin.StreamBundleUpdates(req, stream)
+}
+func (s *GRPCServer) PublishJWTKey(ctx context.Context, req *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error) {
+	return s.Plugin.PublishJWTKey(ctx, req)
+}
 
 type GRPCClient struct {
 	client UpstreamCAClient
@@ -112,3 +177,9 @@ func (c *GRPCClient) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInf
 func (c *GRPCClient) SubmitCSR(ctx context.Context, req *SubmitCSRRequest) (*SubmitCSRResponse, error) {
 	return c.client.SubmitCSR(ctx, req)
 }
+func (c *GRPCClient) StreamBundleUpdates(ctx context.Context, req *StreamBundleUpdatesRequest) (StreamBundleUpdates_Stream, error) {
+	return c.client.StreamBundleUpdates(ctx, req)
+}
+func (c *GRPCClient) PublishJWTKey(ctx context.Context, req *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error) {
+	return c.client.PublishJWTKey(ctx, req)
+}
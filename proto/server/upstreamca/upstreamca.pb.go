@@ -6,6 +6,7 @@ package upstreamca
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/spiffe/spire/proto/common"
 import plugin "github.com/spiffe/spire/proto/common/plugin"
 
 import (
@@ -39,6 +40,36 @@ type GetPluginInfoRequest = plugin.GetPluginInfoRequest
 // GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
 type GetPluginInfoResponse = plugin.GetPluginInfoResponse
 
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// AttestedNode from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestedNode = common.AttestedNode
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// Certificate from public import github.com/spiffe/spire/proto/common/common.proto
+type Certificate = common.Certificate
+
+// PublicKey from public import github.com/spiffe/spire/proto/common/common.proto
+type PublicKey = common.PublicKey
+
+// Bundle from public import github.com/spiffe/spire/proto/common/common.proto
+type Bundle = common.Bundle
+
 type SignedCertificate struct {
 	// Contains ASN.1 encoded certificates representing the signed certificate
 	// along with any intermediates necessary to chain the certificate back to
@@ -55,7 +86,7 @@ func (m *SignedCertificate) Reset()         { *m = SignedCertificate{} }
 func (m *SignedCertificate) String() string { return proto.CompactTextString(m) }
 func (*SignedCertificate) ProtoMessage()    {}
 func (*SignedCertificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_upstreamca_4e9788dc9d899bc3, []int{0}
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{0}
 }
 func (m *SignedCertificate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedCertificate.Unmarshal(m, b)
@@ -101,7 +132,7 @@ func (m *SubmitCSRRequest) Reset()         { *m = SubmitCSRRequest{} }
 func (m *SubmitCSRRequest) String() string { return proto.CompactTextString(m) }
 func (*SubmitCSRRequest) ProtoMessage()    {}
 func (*SubmitCSRRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_upstreamca_4e9788dc9d899bc3, []int{1}
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{1}
 }
 func (m *SubmitCSRRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitCSRRequest.Unmarshal(m, b)
@@ -144,7 +175,7 @@ func (m *SubmitCSRResponse) Reset()         { *m = SubmitCSRResponse{} }
 func (m *SubmitCSRResponse) String() string { return proto.CompactTextString(m) }
 func (*SubmitCSRResponse) ProtoMessage()    {}
 func (*SubmitCSRResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_upstreamca_4e9788dc9d899bc3, []int{2}
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{2}
 }
 func (m *SubmitCSRResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SubmitCSRResponse.Unmarshal(m, b)
@@ -185,10 +216,163 @@ func (m *SubmitCSRResponse) GetSignedCertificate() *SignedCertificate {
 	return nil
 }
 
+type StreamBundleUpdatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamBundleUpdatesRequest) Reset()         { *m = StreamBundleUpdatesRequest{} }
+func (m *StreamBundleUpdatesRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamBundleUpdatesRequest) ProtoMessage()    {}
+func (*StreamBundleUpdatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{3}
+}
+func (m *StreamBundleUpdatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBundleUpdatesRequest.Unmarshal(m, b)
+}
+func (m *StreamBundleUpdatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBundleUpdatesRequest.Marshal(b, m, deterministic)
+}
+func (dst *StreamBundleUpdatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBundleUpdatesRequest.Merge(dst, src)
+}
+func (m *StreamBundleUpdatesRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamBundleUpdatesRequest.Size(m)
+}
+func (m *StreamBundleUpdatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBundleUpdatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBundleUpdatesRequest proto.InternalMessageInfo
+
+type StreamBundleUpdatesResponse struct {
+	// The upstream trust bundle, containing the upstream X.509 roots and
+	// JWT signing keys.
+	UpstreamBundle       *common.Bundle `protobuf:"bytes,1,opt,name=upstream_bundle,json=upstreamBundle,proto3" json:"upstream_bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *StreamBundleUpdatesResponse) Reset()         { *m = StreamBundleUpdatesResponse{} }
+func (m *StreamBundleUpdatesResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamBundleUpdatesResponse) ProtoMessage()    {}
+func (*StreamBundleUpdatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{4}
+}
+func (m *StreamBundleUpdatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamBundleUpdatesResponse.Unmarshal(m, b)
+}
+func (m *StreamBundleUpdatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamBundleUpdatesResponse.Marshal(b, m, deterministic)
+}
+func (dst *StreamBundleUpdatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamBundleUpdatesResponse.Merge(dst, src)
+}
+func (m *StreamBundleUpdatesResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamBundleUpdatesResponse.Size(m)
+}
+func (m *StreamBundleUpdatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamBundleUpdatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamBundleUpdatesResponse proto.InternalMessageInfo
+
+func (m *StreamBundleUpdatesResponse) GetUpstreamBundle() *common.Bundle {
+	if m != nil {
+		return m.UpstreamBundle
+	}
+	return nil
+}
+
+type PublishJWTKeyRequest struct {
+	// The JWT signing key to publish to the upstream trust domain.
+	JwtKey               *common.PublicKey `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PublishJWTKeyRequest) Reset()         { *m = PublishJWTKeyRequest{} }
+func (m *PublishJWTKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishJWTKeyRequest) ProtoMessage()    {}
+func (*PublishJWTKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{5}
+}
+func (m *PublishJWTKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishJWTKeyRequest.Unmarshal(m, b)
+}
+func (m *PublishJWTKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishJWTKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *PublishJWTKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishJWTKeyRequest.Merge(dst, src)
+}
+func (m *PublishJWTKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishJWTKeyRequest.Size(m)
+}
+func (m *PublishJWTKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishJWTKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishJWTKeyRequest proto.InternalMessageInfo
+
+func (m *PublishJWTKeyRequest) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type PublishJWTKeyResponse struct {
+	// The JWT signing keys of the upstream trust domain, including the
+	// published key.
+	UpstreamJwtKeys      []*common.PublicKey `protobuf:"bytes,1,rep,name=upstream_jwt_keys,json=upstreamJwtKeys,proto3" json:"upstream_jwt_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PublishJWTKeyResponse) Reset()         { *m = PublishJWTKeyResponse{} }
+func (m *PublishJWTKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishJWTKeyResponse) ProtoMessage()    {}
+func (*PublishJWTKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_upstreamca_1ccddecf120301e6, []int{6}
+}
+func (m *PublishJWTKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishJWTKeyResponse.Unmarshal(m, b)
+}
+func (m *PublishJWTKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishJWTKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *PublishJWTKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishJWTKeyResponse.Merge(dst, src)
+}
+func (m *PublishJWTKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishJWTKeyResponse.Size(m)
+}
+func (m *PublishJWTKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishJWTKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishJWTKeyResponse proto.InternalMessageInfo
+
+func (m *PublishJWTKeyResponse) GetUpstreamJwtKeys() []*common.PublicKey {
+	if m != nil {
+		return m.UpstreamJwtKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignedCertificate)(nil), "spire.server.upstreamca.SignedCertificate")
 	proto.RegisterType((*SubmitCSRRequest)(nil), "spire.server.upstreamca.SubmitCSRRequest")
 	proto.RegisterType((*SubmitCSRResponse)(nil), "spire.server.upstreamca.SubmitCSRResponse")
+	proto.RegisterType((*StreamBundleUpdatesRequest)(nil), "spire.server.upstreamca.StreamBundleUpdatesRequest")
+	proto.RegisterType((*StreamBundleUpdatesResponse)(nil), "spire.server.upstreamca.StreamBundleUpdatesResponse")
+	proto.RegisterType((*PublishJWTKeyRequest)(nil), "spire.server.upstreamca.PublishJWTKeyRequest")
+	proto.RegisterType((*PublishJWTKeyResponse)(nil), "spire.server.upstreamca.PublishJWTKeyResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -209,6 +393,14 @@ type UpstreamCAClient interface {
 	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
 	// Signs a certificate from the request
 	SubmitCSR(ctx context.Context, in *SubmitCSRRequest, opts ...grpc.CallOption) (*SubmitCSRResponse, error)
+	// Streams the upstream trust bundle, sending it once the stream is
+	// established and again every time it changes. Optional; plugins that
+	// do not support it return an Unimplemented error.
+	StreamBundleUpdates(ctx context.Context, in *StreamBundleUpdatesRequest, opts ...grpc.CallOption) (UpstreamCA_StreamBundleUpdatesClient, error)
+	// Publishes a JWT signing key to the upstream trust domain so JWT-SVIDs
+	// signed with it can be validated across the hierarchy. Optional;
+	// plugins that do not support it return an Unimplemented error.
+	PublishJWTKey(ctx context.Context, in *PublishJWTKeyRequest, opts ...grpc.CallOption) (*PublishJWTKeyResponse, error)
 }
 
 type upstreamCAClient struct {
@@ -246,6 +438,47 @@ func (c *upstreamCAClient) SubmitCSR(ctx context.Context, in *SubmitCSRRequest,
 	return out, nil
 }
 
+func (c *upstreamCAClient) StreamBundleUpdates(ctx context.Context, in *StreamBundleUpdatesRequest, opts ...grpc.CallOption) (UpstreamCA_StreamBundleUpdatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UpstreamCA_serviceDesc.Streams[0], "/spire.server.upstreamca.UpstreamCA/StreamBundleUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &upstreamCAStreamBundleUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UpstreamCA_StreamBundleUpdatesClient interface {
+	Recv() (*StreamBundleUpdatesResponse, error)
+	grpc.ClientStream
+}
+
+type upstreamCAStreamBundleUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *upstreamCAStreamBundleUpdatesClient) Recv() (*StreamBundleUpdatesResponse, error) {
+	m := new(StreamBundleUpdatesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *upstreamCAClient) PublishJWTKey(ctx context.Context, in *PublishJWTKeyRequest, opts ...grpc.CallOption) (*PublishJWTKeyResponse, error) {
+	out := new(PublishJWTKeyResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.upstreamca.UpstreamCA/PublishJWTKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UpstreamCAServer is the server API for UpstreamCA service.
 type UpstreamCAServer interface {
 	// Responsible for configuration of the plugin.
@@ -254,6 +487,14 @@ type UpstreamCAServer interface {
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 	// Signs a certificate from the request
 	SubmitCSR(context.Context, *SubmitCSRRequest) (*SubmitCSRResponse, error)
+	// Streams the upstream trust bundle, sending it once the stream is
+	// established and again every time it changes. Optional; plugins that
+	// do not support it return an Unimplemented error.
+	StreamBundleUpdates(*StreamBundleUpdatesRequest, UpstreamCA_StreamBundleUpdatesServer) error
+	// Publishes a JWT signing key to the upstream trust domain so JWT-SVIDs
+	// signed with it can be validated across the hierarchy. Optional;
+	// plugins that do not support it return an Unimplemented error.
+	PublishJWTKey(context.Context, *PublishJWTKeyRequest) (*PublishJWTKeyResponse, error)
 }
 
 func RegisterUpstreamCAServer(s *grpc.Server, srv UpstreamCAServer) {
@@ -314,6 +555,45 @@ func _UpstreamCA_SubmitCSR_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UpstreamCA_StreamBundleUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBundleUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UpstreamCAServer).StreamBundleUpdates(m, &upstreamCAStreamBundleUpdatesServer{stream})
+}
+
+type UpstreamCA_StreamBundleUpdatesServer interface {
+	Send(*StreamBundleUpdatesResponse) error
+	grpc.ServerStream
+}
+
+type upstreamCAStreamBundleUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *upstreamCAStreamBundleUpdatesServer) Send(m *StreamBundleUpdatesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UpstreamCA_PublishJWTKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishJWTKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UpstreamCAServer).PublishJWTKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.upstreamca.UpstreamCA/PublishJWTKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UpstreamCAServer).PublishJWTKey(ctx, req.(*PublishJWTKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UpstreamCA_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.server.upstreamca.UpstreamCA",
 	HandlerType: (*UpstreamCAServer)(nil),
@@ -330,37 +610,57 @@ var _UpstreamCA_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitCSR",
 			Handler:    _UpstreamCA_SubmitCSR_Handler,
 		},
+		{
+			MethodName: "PublishJWTKey",
+			Handler:    _UpstreamCA_PublishJWTKey_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBundleUpdates",
+			Handler:       _UpstreamCA_StreamBundleUpdates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "upstreamca.proto",
 }
 
-func init() { proto.RegisterFile("upstreamca.proto", fileDescriptor_upstreamca_4e9788dc9d899bc3) }
-
-var fileDescriptor_upstreamca_4e9788dc9d899bc3 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcf, 0x6e, 0xa2, 0x50,
-	0x14, 0xc6, 0x07, 0x4d, 0x4c, 0x3c, 0xe3, 0xcc, 0xe8, 0x5d, 0xcc, 0x18, 0x13, 0x27, 0xc6, 0xcc,
-	0x1f, 0xeb, 0x02, 0x12, 0xbb, 0xe9, 0x56, 0x91, 0x34, 0xed, 0xca, 0xa0, 0x5d, 0xd4, 0x8d, 0x01,
-	0x3c, 0xe0, 0x4d, 0x84, 0x4b, 0xef, 0x9f, 0x3e, 0x59, 0x9f, 0xa7, 0xcf, 0xd2, 0x80, 0x17, 0xb1,
-	0x6a, 0x53, 0x57, 0xc0, 0xf9, 0x7e, 0xe7, 0x7c, 0xf7, 0x7e, 0x07, 0x68, 0xaa, 0x54, 0x48, 0x8e,
-	0x5e, 0x1c, 0x78, 0x66, 0xca, 0x99, 0x64, 0xe4, 0x97, 0x48, 0x29, 0x47, 0x53, 0x20, 0x7f, 0x46,
-	0x6e, 0x96, 0x72, 0xe7, 0x26, 0xa2, 0x72, 0xa3, 0x7c, 0x33, 0x60, 0xb1, 0x25, 0x52, 0x1a, 0x86,
-	0x68, 0xe5, 0xa8, 0x95, 0xf7, 0x59, 0x01, 0x8b, 0x63, 0x96, 0x58, 0xe9, 0x56, 0x45, 0xb4, 0x78,
-	0xec, 0x46, 0xf6, 0xef, 0xa1, 0x35, 0xa7, 0x51, 0x82, 0x6b, 0x1b, 0xb9, 0xa4, 0x21, 0x0d, 0x3c,
-	0x89, 0xa4, 0x0b, 0x10, 0x20, 0x97, 0xab, 0x60, 0xe3, 0xd1, 0xa4, 0x6d, 0xf4, 0x8c, 0x41, 0xc3,
-	0xad, 0x67, 0x15, 0x3b, 0x2b, 0x90, 0x9f, 0x50, 0xf3, 0x55, 0xb2, 0xde, 0x62, 0xbb, 0x92, 0x4b,
-	0xfa, 0xab, 0xff, 0x07, 0x9a, 0x73, 0xe5, 0xc7, 0x54, 0xda, 0x73, 0xd7, 0xc5, 0x27, 0x85, 0x42,
-	0x92, 0x26, 0x54, 0x03, 0xc1, 0xf5, 0x8c, 0xec, 0xb5, 0xff, 0x6a, 0x40, 0xeb, 0x00, 0x13, 0x29,
-	0x4b, 0x04, 0x92, 0xff, 0xf0, 0x63, 0xea, 0xcc, 0x5c, 0xc7, 0x1e, 0x2f, 0x9c, 0xe9, 0x2a, 0xf3,
-	0xd2, 0x3d, 0xdf, 0xcb, 0x72, 0x76, 0x44, 0xe2, 0xc0, 0xef, 0x03, 0xb0, 0xc8, 0x60, 0xc1, 0x95,
-	0x90, 0x93, 0xc3, 0x43, 0x75, 0x4b, 0xea, 0xe1, 0x14, 0x22, 0x8f, 0x40, 0x44, 0x7e, 0xef, 0xdc,
-	0x4b, 0x5f, 0xbc, 0x5d, 0xed, 0x19, 0x83, 0xaf, 0xa3, 0xa1, 0xf9, 0x41, 0xce, 0xe6, 0x49, 0x54,
-	0x6e, 0x4b, 0x1c, 0x97, 0x46, 0x2f, 0x15, 0x80, 0xc2, 0xd2, 0x1e, 0x93, 0x25, 0xd4, 0x6d, 0x96,
-	0x84, 0x34, 0x52, 0x1c, 0xc9, 0x5f, 0x3d, 0x7a, 0xb7, 0x11, 0x53, 0xaf, 0x62, 0xaf, 0xeb, 0xd4,
-	0x3a, 0xff, 0x3e, 0xc3, 0x74, 0x6a, 0x21, 0x7c, 0xbb, 0x45, 0x39, 0xcb, 0xe5, 0xbb, 0x24, 0x64,
-	0xe4, 0xea, 0x6c, 0xe3, 0x3b, 0xa6, 0xf0, 0x18, 0x5e, 0x82, 0x6a, 0x1f, 0x1f, 0xea, 0xfb, 0x95,
-	0xed, 0x3d, 0xce, 0xc4, 0x73, 0xb4, 0xfd, 0xce, 0xf0, 0x12, 0x74, 0xe7, 0x31, 0x69, 0x2c, 0xa1,
-	0xd4, 0x67, 0x5f, 0xfc, 0x5a, 0xfe, 0x83, 0x5e, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0x9e, 0x3b,
-	0x57, 0x68, 0x07, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("upstreamca.proto", fileDescriptor_upstreamca_1ccddecf120301e6) }
+
+var fileDescriptor_upstreamca_1ccddecf120301e6 = []byte{
+	// 535 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0xc5, 0x04, 0x05, 0xe5, 0xd2, 0x47, 0x32, 0x14, 0x1a, 0x19, 0x8a, 0x22, 0x8b, 0x47, 0x1b,
+	0x09, 0xa7, 0xb4, 0x2c, 0xd8, 0xb0, 0x68, 0xdd, 0x08, 0x48, 0x37, 0x96, 0x93, 0x0a, 0x51, 0x55,
+	0xb2, 0x6c, 0x67, 0x9c, 0x4c, 0x89, 0x1f, 0xcc, 0x8c, 0xa9, 0xb2, 0xe5, 0x6f, 0xf8, 0x29, 0xbe,
+	0x05, 0x79, 0x3c, 0x8e, 0x93, 0x34, 0x86, 0x74, 0xe5, 0xf8, 0xde, 0x73, 0xce, 0x3d, 0xf7, 0x11,
+	0x43, 0x3d, 0x89, 0x19, 0xa7, 0xd8, 0x09, 0x3c, 0x47, 0x8f, 0x69, 0xc4, 0x23, 0xb4, 0xcb, 0x62,
+	0x42, 0xb1, 0xce, 0x30, 0xfd, 0x89, 0xa9, 0x5e, 0xa4, 0xd5, 0x0f, 0x23, 0xc2, 0xc7, 0x89, 0xab,
+	0x7b, 0x51, 0xd0, 0x61, 0x31, 0xf1, 0x7d, 0xdc, 0x11, 0xd0, 0x8e, 0xe0, 0x75, 0xbc, 0x28, 0x08,
+	0xa2, 0xb0, 0x13, 0x4f, 0x92, 0x11, 0xc9, 0x1f, 0x99, 0xa4, 0xfa, 0x6e, 0x2d, 0x66, 0xf6, 0xc8,
+	0x28, 0x5a, 0x0f, 0x1a, 0x7d, 0x32, 0x0a, 0xf1, 0xd0, 0xc0, 0x94, 0x13, 0x9f, 0x78, 0x0e, 0xc7,
+	0x68, 0x0f, 0xc0, 0xc3, 0x94, 0xdb, 0xde, 0xd8, 0x21, 0x61, 0x53, 0x69, 0x29, 0xfb, 0x1b, 0x56,
+	0x2d, 0x8d, 0x18, 0x69, 0x00, 0x3d, 0x85, 0xaa, 0x9b, 0x84, 0xc3, 0x09, 0x6e, 0xde, 0x17, 0x29,
+	0xf9, 0xa6, 0xbd, 0x84, 0x7a, 0x3f, 0x71, 0x03, 0xc2, 0x8d, 0xbe, 0x65, 0xe1, 0x1f, 0x09, 0x66,
+	0x1c, 0xd5, 0xa1, 0xe2, 0x31, 0x2a, 0x35, 0xd2, 0x9f, 0xda, 0x1f, 0x05, 0x1a, 0x73, 0x30, 0x16,
+	0x47, 0x21, 0xc3, 0xe8, 0x0d, 0x6c, 0x9f, 0x75, 0x4d, 0xab, 0x6b, 0x9c, 0x0c, 0xba, 0x67, 0x76,
+	0x5a, 0x4b, 0x72, 0xb6, 0x8a, 0x70, 0x6a, 0x11, 0x75, 0xe1, 0xc5, 0x1c, 0x30, 0x1f, 0xdb, 0x80,
+	0x26, 0x8c, 0x9f, 0xce, 0x9b, 0xda, 0x2b, 0x50, 0x17, 0xb7, 0x41, 0xe8, 0x1b, 0x20, 0x26, 0xfa,
+	0xb6, 0xbd, 0xa2, 0xf1, 0x66, 0xa5, 0xa5, 0xec, 0x3f, 0x3a, 0x6a, 0xeb, 0x25, 0xab, 0xd1, 0x6f,
+	0x8d, 0xca, 0x6a, 0xb0, 0xe5, 0x90, 0xf6, 0x1c, 0xd4, 0xbe, 0x20, 0x64, 0xa5, 0x2e, 0xe2, 0xa1,
+	0xc3, 0x31, 0x93, 0x03, 0xd1, 0xae, 0xe0, 0xd9, 0xca, 0xac, 0x9c, 0xc3, 0x47, 0xd8, 0xce, 0xeb,
+	0xd9, 0x72, 0xc8, 0x8a, 0x30, 0xb5, 0x23, 0x4d, 0xc9, 0xed, 0x65, 0x6c, 0x6b, 0x2b, 0x07, 0x67,
+	0xef, 0xda, 0x67, 0xd8, 0x31, 0x13, 0x77, 0x42, 0xd8, 0xb8, 0xf7, 0x75, 0x70, 0x8e, 0xa7, 0xf9,
+	0x1a, 0x0e, 0xe1, 0xe1, 0xf5, 0x0d, 0xb7, 0xbf, 0xe3, 0xa9, 0x94, 0xdb, 0x5d, 0x94, 0x13, 0x24,
+	0x2f, 0x25, 0x54, 0xaf, 0x6f, 0xf8, 0x39, 0x9e, 0x6a, 0x57, 0xf0, 0x64, 0x49, 0x49, 0x3a, 0x34,
+	0xa0, 0x31, 0x73, 0x28, 0x35, 0x59, 0x53, 0x69, 0x55, 0xfe, 0x25, 0x3a, 0xeb, 0xa9, 0x27, 0xc4,
+	0xd9, 0xd1, 0xef, 0x07, 0x00, 0xf9, 0x5a, 0x8c, 0x13, 0x74, 0x09, 0x35, 0x23, 0x0a, 0x7d, 0x32,
+	0x4a, 0x28, 0x46, 0xaf, 0x16, 0x55, 0xe4, 0x85, 0xcf, 0xf2, 0xb2, 0x25, 0xf5, 0xf5, 0xff, 0x60,
+	0xd2, 0xaf, 0x0f, 0x9b, 0x9f, 0x30, 0x37, 0x45, 0xfa, 0x4b, 0xe8, 0x47, 0xe8, 0x60, 0x25, 0x71,
+	0x01, 0x93, 0xd7, 0x68, 0xaf, 0x03, 0x95, 0x75, 0x5c, 0xa8, 0xcd, 0xce, 0x1a, 0x1d, 0x94, 0x9f,
+	0xd0, 0xd2, 0x3f, 0x44, 0x6d, 0xaf, 0x03, 0x95, 0x35, 0x7e, 0x29, 0xf0, 0x78, 0xc5, 0xf5, 0xa0,
+	0xe3, 0x72, 0x8d, 0xd2, 0x4b, 0x54, 0xdf, 0xdf, 0x8d, 0x94, 0x59, 0x38, 0x54, 0x50, 0x08, 0x9b,
+	0x0b, 0x97, 0x81, 0xde, 0x96, 0x0a, 0xad, 0xba, 0x45, 0x55, 0x5f, 0x17, 0x9e, 0x55, 0x3c, 0xdd,
+	0xb8, 0x84, 0x02, 0x63, 0xde, 0x33, 0x15, 0xb7, 0x2a, 0xbe, 0x5d, 0xc7, 0x7f, 0x07, 0x00, 0xfa,
+	0x82, 0x7f, 0x72, 0x55, 0x05, 0x00, 0x00,
 }
@@ -30,6 +30,8 @@ var (
 	ErrNoSuchRegistrationEntry   = errors.New("no such registration entry")
 	ErrNoSuchToken               = errors.New("no such token")
 	ErrTokenAlreadyExists        = errors.New("token already exists")
+	ErrCAJournalVersionMismatch  = errors.New("CA journal version mismatch")
+	ErrBoundToAttestedNode       = errors.New("bound to another attested node")
 )
 
 type DataStore struct {
@@ -40,6 +42,7 @@ type DataStore struct {
 	nodeSelectors       map[string][]*common.Selector
 	registrationEntries map[string]*datastore.RegistrationEntry
 	tokens              map[string]*datastore.JoinToken
+	caJournals          map[string]*datastore.CAJournal
 
 	// relates bundles with entries that federate with them
 	bundleEntries map[string]map[string]bool
@@ -54,6 +57,7 @@ func New() *DataStore {
 		nodeSelectors:       make(map[string][]*common.Selector),
 		registrationEntries: make(map[string]*datastore.RegistrationEntry),
 		tokens:              make(map[string]*datastore.JoinToken),
+		caJournals:          make(map[string]*datastore.CAJournal),
 		bundleEntries:       make(map[string]map[string]bool),
 	}
 }
@@ -261,6 +265,24 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context,
 	}, nil
 }
 
+func (s *DataStore) UpdateAttestedNodeLastSeen(ctx context.Context,
+	req *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.attestedNodes[req.SpiffeId]
+	if !ok {
+		return nil, ErrNoSuchAttestedNode
+	}
+	node.LastSeenIp = req.LastSeenIp
+	node.LastSeenAt = req.LastSeenAt
+
+	return &datastore.UpdateAttestedNodeLastSeenResponse{
+		Node: cloneAttestedNode(node),
+	}, nil
+}
+
 func (s *DataStore) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 
@@ -288,6 +310,32 @@ func (s *DataStore) SetNodeSelectors(ctx context.Context,
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
 
+func (s *DataStore) BindNodeSelectors(ctx context.Context,
+	req *datastore.BindNodeSelectorsRequest) (*datastore.BindNodeSelectorsResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var selectors []*common.Selector
+	for _, selector := range s.nodeSelectors[req.SpiffeId] {
+		if selector.Type == req.SelectorType && selector.Value != req.AttestedNodeSpiffeId {
+			if _, ok := s.attestedNodes[selector.Value]; ok {
+				return nil, ErrBoundToAttestedNode
+			}
+			continue
+		}
+		if selector.Type != req.SelectorType {
+			selectors = append(selectors, selector)
+		}
+	}
+	selectors = append(selectors, &common.Selector{
+		Type:  req.SelectorType,
+		Value: req.AttestedNodeSpiffeId,
+	})
+	s.nodeSelectors[req.SpiffeId] = cloneSelectors(selectors)
+	return &datastore.BindNodeSelectorsResponse{}, nil
+}
+
 func (s *DataStore) GetNodeSelectors(ctx context.Context,
 	req *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
 
@@ -525,6 +573,20 @@ func (s *DataStore) FetchJoinToken(ctx context.Context, req *datastore.FetchJoin
 	}, nil
 }
 
+func (s *DataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListJoinTokensResponse)
+	for _, joinToken := range s.tokens {
+		resp.JoinTokens = append(resp.JoinTokens, cloneJoinToken(joinToken))
+	}
+	sort.Slice(resp.JoinTokens, func(i, j int) bool {
+		return resp.JoinTokens[i].Token < resp.JoinTokens[j].Token
+	})
+	return resp, nil
+}
+
 func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -540,6 +602,24 @@ func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJo
 	}, nil
 }
 
+func (s *DataStore) UseJoinToken(ctx context.Context, req *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	joinToken, ok := s.tokens[req.Token]
+	if !ok {
+		return &datastore.UseJoinTokenResponse{}, nil
+	}
+	joinToken.Uses++
+	if joinToken.Uses >= joinToken.MaxUses {
+		delete(s.tokens, req.Token)
+	}
+
+	return &datastore.UseJoinTokenResponse{
+		JoinToken: cloneJoinToken(joinToken),
+	}, nil
+}
+
 func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -553,6 +633,38 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func (s *DataStore) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.FetchCAJournalResponse)
+	if journal, ok := s.caJournals[req.TrustDomainId]; ok {
+		resp.Journal = cloneCAJournal(journal)
+	}
+	return resp, nil
+}
+
+func (s *DataStore) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var version int64
+	if journal, ok := s.caJournals[req.Journal.TrustDomainId]; ok {
+		version = journal.Version
+	}
+	if version != req.Journal.Version {
+		return nil, ErrCAJournalVersionMismatch
+	}
+
+	journal := cloneCAJournal(req.Journal)
+	journal.Version++
+	s.caJournals[journal.TrustDomainId] = journal
+
+	return &datastore.SetCAJournalResponse{
+		Journal: cloneCAJournal(journal),
+	}, nil
+}
+
 func (s *DataStore) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return &spi.ConfigureResponse{}, nil
 }
@@ -606,6 +718,10 @@ func cloneJoinToken(token *datastore.JoinToken) *datastore.JoinToken {
 	return proto.Clone(token).(*datastore.JoinToken)
 }
 
+func cloneCAJournal(journal *datastore.CAJournal) *datastore.CAJournal {
+	return proto.Clone(journal).(*datastore.CAJournal)
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -90,6 +90,10 @@ func (c *ServerCA) Bundle() []*x509.Certificate {
 	return c.bundle
 }
 
+func (c *ServerCA) X509CA() *x509.Certificate {
+	return c.certs[0]
+}
+
 func (c *ServerCA) SignX509SVID(ctx context.Context, csrDER []byte, ttl time.Duration) ([]*x509.Certificate, error) {
 	if ttl <= 0 {
 		ttl = c.options.DefaultTTL
@@ -100,7 +104,20 @@ func (c *ServerCA) SignX509SVID(ctx context.Context, csrDER []byte, ttl time.Dur
 	if err != nil {
 		return nil, err
 	}
+	return c.signX509SVID(template)
+}
+
+func (c *ServerCA) SignAgentX509SVID(ctx context.Context, csrDER []byte, agentID string) ([]*x509.Certificate, error) {
+	now := c.options.Now()
+	c.sn++
+	template, err := ca.CreateAgentX509SVIDTemplate(csrDER, c.trustDomain, agentID, now, now.Add(c.options.DefaultTTL), big.NewInt(c.sn))
+	if err != nil {
+		return nil, err
+	}
+	return c.signX509SVID(template)
+}
 
+func (c *ServerCA) signX509SVID(template *x509.Certificate) ([]*x509.Certificate, error) {
 	certDER, err := x509.CreateCertificate(rand.Reader, template, c.certs[0], template.PublicKey, c.signer)
 	if err != nil {
 		return nil, err
@@ -6,12 +6,14 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +43,11 @@ type UpstreamCA struct {
 	chain      []*x509.Certificate
 	upstreamCA *x509svid.UpstreamCA
 	config     Config
+
+	mu             sync.Mutex
+	extraRootCAs   []*x509.Certificate
+	jwtSigningKeys []*common.PublicKey
+	bundleChanged  chan struct{}
 }
 
 func New(t *testing.T, config Config) *UpstreamCA {
@@ -89,9 +96,10 @@ func New(t *testing.T, config Config) *UpstreamCA {
 		x509svid.UpstreamCAOptions{})
 
 	return &UpstreamCA{
-		chain:      chain,
-		upstreamCA: upstreamCA,
-		config:     config,
+		chain:         chain,
+		upstreamCA:    upstreamCA,
+		config:        config,
+		bundleChanged: make(chan struct{}),
 	}
 }
 
@@ -133,6 +141,141 @@ func (m *UpstreamCA) SubmitCSR(ctx context.Context, request *upstreamca.SubmitCS
 	}, nil
 }
 
+// AppendRootCA adds a root CA to the upstream bundle, as if the upstream CA
+// rotated. Open bundle update streams receive the updated bundle.
+func (m *UpstreamCA) AppendRootCA(rootCA *x509.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.extraRootCAs = append(m.extraRootCAs, rootCA)
+	m.notifyBundleChanged()
+}
+
+// AppendJWTSigningKey adds a JWT signing key to the upstream bundle. Open
+// bundle update streams receive the updated bundle.
+func (m *UpstreamCA) AppendJWTSigningKey(jwtSigningKey *common.PublicKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jwtSigningKeys = append(m.jwtSigningKeys, jwtSigningKey)
+	m.notifyBundleChanged()
+}
+
+// RemoveRootCA removes a root CA added with AppendRootCA from the upstream
+// bundle. Open bundle update streams receive the updated bundle.
+func (m *UpstreamCA) RemoveRootCA(rootCA *x509.Certificate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var extraRootCAs []*x509.Certificate
+	for _, extraRootCA := range m.extraRootCAs {
+		if !extraRootCA.Equal(rootCA) {
+			extraRootCAs = append(extraRootCAs, extraRootCA)
+		}
+	}
+	m.extraRootCAs = extraRootCAs
+	m.notifyBundleChanged()
+}
+
+// RemoveJWTSigningKey removes the JWT signing key with the given key ID from
+// the upstream bundle. Open bundle update streams receive the updated bundle.
+func (m *UpstreamCA) RemoveJWTSigningKey(kid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var jwtSigningKeys []*common.PublicKey
+	for _, jwtSigningKey := range m.jwtSigningKeys {
+		if jwtSigningKey.Kid != kid {
+			jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
+		}
+	}
+	m.jwtSigningKeys = jwtSigningKeys
+	m.notifyBundleChanged()
+}
+
+func (m *UpstreamCA) StreamBundleUpdates(ctx context.Context, request *upstreamca.StreamBundleUpdatesRequest) (upstreamca.StreamBundleUpdates_Stream, error) {
+	return &bundleUpdatesStream{
+		ctx: ctx,
+		m:   m,
+	}, nil
+}
+
+// PublishJWTKey adds the JWT signing key to the upstream bundle, unless a
+// key with the same key ID is already present, and returns the JWT signing
+// keys of the upstream bundle.
+func (m *UpstreamCA) PublishJWTKey(ctx context.Context, request *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	found := false
+	for _, jwtSigningKey := range m.jwtSigningKeys {
+		if jwtSigningKey.Kid == request.JwtKey.Kid {
+			found = true
+			break
+		}
+	}
+	if !found {
+		m.jwtSigningKeys = append(m.jwtSigningKeys, request.JwtKey)
+		m.notifyBundleChanged()
+	}
+
+	return &upstreamca.PublishJWTKeyResponse{
+		UpstreamJwtKeys: append([]*common.PublicKey(nil), m.jwtSigningKeys...),
+	}, nil
+}
+
+// JWTSigningKeys returns the JWT signing keys of the upstream bundle.
+func (m *UpstreamCA) JWTSigningKeys() []*common.PublicKey {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*common.PublicKey(nil), m.jwtSigningKeys...)
+}
+
+func (m *UpstreamCA) notifyBundleChanged() {
+	close(m.bundleChanged)
+	m.bundleChanged = make(chan struct{})
+}
+
+func (m *UpstreamCA) bundle() (*common.Bundle, chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bundle := &common.Bundle{
+		TrustDomainId:  "spiffe://" + m.config.TrustDomain,
+		JwtSigningKeys: m.jwtSigningKeys,
+	}
+	for _, rootCA := range append([]*x509.Certificate{m.Root()}, m.extraRootCAs...) {
+		bundle.RootCas = append(bundle.RootCas, &common.Certificate{
+			DerBytes: rootCA.Raw,
+		})
+	}
+	return bundle, m.bundleChanged
+}
+
+type bundleUpdatesStream struct {
+	ctx     context.Context
+	m       *UpstreamCA
+	changed chan struct{}
+}
+
+func (s *bundleUpdatesStream) Context() context.Context {
+	return s.ctx
+}
+
+// Recv returns the current bundle on the first call and then blocks until
+// the bundle changes.
+func (s *bundleUpdatesStream) Recv() (*upstreamca.StreamBundleUpdatesResponse, error) {
+	if s.changed != nil {
+		select {
+		case <-s.changed:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+
+	bundle, changed := s.m.bundle()
+	s.changed = changed
+	return &upstreamca.StreamBundleUpdatesResponse{
+		UpstreamBundle: bundle,
+	}, nil
+}
+
 func certsDER(certs []*x509.Certificate) []byte {
 	var out []byte
 	for _, cert := range certs {
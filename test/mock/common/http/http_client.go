@@ -33,15 +33,15 @@ func (m *MockhttpClient) EXPECT() *MockhttpClientMockRecorder {
 	return m.recorder
 }
 
-// Get mocks base method
-func (m *MockhttpClient) Get(url string) (*http.Response, error) {
-	ret := m.ctrl.Call(m, "Get", url)
+// Do mocks base method
+func (m *MockhttpClient) Do(req *http.Request) (*http.Response, error) {
+	ret := m.ctrl.Call(m, "Do", req)
 	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get
-func (mr *MockhttpClientMockRecorder) Get(url interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockhttpClient)(nil).Get), url)
+// Do indicates an expected call of Do
+func (mr *MockhttpClientMockRecorder) Do(req interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockhttpClient)(nil).Do), req)
 }
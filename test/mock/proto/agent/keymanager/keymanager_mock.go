@@ -35,6 +35,19 @@ func (m *MockKeyManager) EXPECT() *MockKeyManagerMockRecorder {
 	return m.recorder
 }
 
+// DeletePrivateKey mocks base method
+func (m *MockKeyManager) DeletePrivateKey(arg0 context.Context, arg1 *keymanager.DeletePrivateKeyRequest) (*keymanager.DeletePrivateKeyResponse, error) {
+	ret := m.ctrl.Call(m, "DeletePrivateKey", arg0, arg1)
+	ret0, _ := ret[0].(*keymanager.DeletePrivateKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrivateKey indicates an expected call of DeletePrivateKey
+func (mr *MockKeyManagerMockRecorder) DeletePrivateKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateKey", reflect.TypeOf((*MockKeyManager)(nil).DeletePrivateKey), arg0, arg1)
+}
+
 // FetchPrivateKey mocks base method
 func (m *MockKeyManager) FetchPrivateKey(arg0 context.Context, arg1 *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
 	ret := m.ctrl.Call(m, "FetchPrivateKey", arg0, arg1)
@@ -110,6 +123,19 @@ func (mr *MockPluginMockRecorder) Configure(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockPlugin)(nil).Configure), arg0, arg1)
 }
 
+// DeletePrivateKey mocks base method
+func (m *MockPlugin) DeletePrivateKey(arg0 context.Context, arg1 *keymanager.DeletePrivateKeyRequest) (*keymanager.DeletePrivateKeyResponse, error) {
+	ret := m.ctrl.Call(m, "DeletePrivateKey", arg0, arg1)
+	ret0, _ := ret[0].(*keymanager.DeletePrivateKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrivateKey indicates an expected call of DeletePrivateKey
+func (mr *MockPluginMockRecorder) DeletePrivateKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateKey", reflect.TypeOf((*MockPlugin)(nil).DeletePrivateKey), arg0, arg1)
+}
+
 // FetchPrivateKey mocks base method
 func (m *MockPlugin) FetchPrivateKey(arg0 context.Context, arg1 *keymanager.FetchPrivateKeyRequest) (*keymanager.FetchPrivateKeyResponse, error) {
 	ret := m.ctrl.Call(m, "FetchPrivateKey", arg0, arg1)
@@ -90,6 +90,24 @@ func (mr *MockNodeClientMockRecorder) FetchX509SVID(arg0 interface{}, arg1 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockNodeClient)(nil).FetchX509SVID), varargs...)
 }
 
+// PushJWTKeyUpstream mocks base method
+func (m *MockNodeClient) PushJWTKeyUpstream(arg0 context.Context, arg1 *node.PushJWTKeyUpstreamRequest, arg2 ...grpc.CallOption) (*node.PushJWTKeyUpstreamResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PushJWTKeyUpstream", varargs...)
+	ret0, _ := ret[0].(*node.PushJWTKeyUpstreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushJWTKeyUpstream indicates an expected call of PushJWTKeyUpstream
+func (mr *MockNodeClientMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeClient)(nil).PushJWTKeyUpstream), varargs...)
+}
+
 // MockNode_AttestClient is a mock of Node_AttestClient interface
 type MockNode_AttestClient struct {
 	ctrl     *gomock.Controller
@@ -510,6 +528,19 @@ func (mr *MockNodeServerMockRecorder) FetchX509SVID(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockNodeServer)(nil).FetchX509SVID), arg0)
 }
 
+// PushJWTKeyUpstream mocks base method
+func (m *MockNodeServer) PushJWTKeyUpstream(arg0 context.Context, arg1 *node.PushJWTKeyUpstreamRequest) (*node.PushJWTKeyUpstreamResponse, error) {
+	ret := m.ctrl.Call(m, "PushJWTKeyUpstream", arg0, arg1)
+	ret0, _ := ret[0].(*node.PushJWTKeyUpstreamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushJWTKeyUpstream indicates an expected call of PushJWTKeyUpstream
+func (mr *MockNodeServerMockRecorder) PushJWTKeyUpstream(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushJWTKeyUpstream", reflect.TypeOf((*MockNodeServer)(nil).PushJWTKeyUpstream), arg0, arg1)
+}
+
 // MockNode_FetchX509SVIDServer is a mock of Node_FetchX509SVIDServer interface
 type MockNode_FetchX509SVIDServer struct {
 	ctrl     *gomock.Controller
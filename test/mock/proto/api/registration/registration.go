@@ -36,6 +36,24 @@ func (m *MockRegistrationClient) EXPECT() *MockRegistrationClientMockRecorder {
 	return m.recorder
 }
 
+// ActivateCAKeypairSet mocks base method
+func (m *MockRegistrationClient) ActivateCAKeypairSet(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.CAKeypairSet, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActivateCAKeypairSet", varargs...)
+	ret0, _ := ret[0].(*registration.CAKeypairSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateCAKeypairSet indicates an expected call of ActivateCAKeypairSet
+func (mr *MockRegistrationClientMockRecorder) ActivateCAKeypairSet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateCAKeypairSet", reflect.TypeOf((*MockRegistrationClient)(nil).ActivateCAKeypairSet), varargs...)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationClient) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry, arg2 ...grpc.CallOption) (*registration.RegistrationEntryID, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -288,6 +306,24 @@ func (mr *MockRegistrationClientMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationClient)(nil).ListBySpiffeID), varargs...)
 }
 
+// ListCAKeypairSets mocks base method
+func (m *MockRegistrationClient) ListCAKeypairSets(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.ListCAKeypairSetsResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCAKeypairSets", varargs...)
+	ret0, _ := ret[0].(*registration.ListCAKeypairSetsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCAKeypairSets indicates an expected call of ListCAKeypairSets
+func (mr *MockRegistrationClientMockRecorder) ListCAKeypairSets(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCAKeypairSets", reflect.TypeOf((*MockRegistrationClient)(nil).ListCAKeypairSets), varargs...)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationClient) ListFederatedBundles(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (registration.Registration_ListFederatedBundlesClient, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -306,6 +342,78 @@ func (mr *MockRegistrationClientMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundles), varargs...)
 }
 
+// ListJoinTokens mocks base method
+func (m *MockRegistrationClient) ListJoinTokens(arg0 context.Context, arg1 *registration.ListJoinTokensRequest, arg2 ...grpc.CallOption) (*registration.ListJoinTokensResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListJoinTokens", varargs...)
+	ret0, _ := ret[0].(*registration.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockRegistrationClientMockRecorder) ListJoinTokens(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockRegistrationClient)(nil).ListJoinTokens), varargs...)
+}
+
+// PrepareCAKeypairSet mocks base method
+func (m *MockRegistrationClient) PrepareCAKeypairSet(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.CAKeypairSet, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrepareCAKeypairSet", varargs...)
+	ret0, _ := ret[0].(*registration.CAKeypairSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCAKeypairSet indicates an expected call of PrepareCAKeypairSet
+func (mr *MockRegistrationClientMockRecorder) PrepareCAKeypairSet(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCAKeypairSet", reflect.TypeOf((*MockRegistrationClient)(nil).PrepareCAKeypairSet), varargs...)
+}
+
+// RevokeCAKey mocks base method
+func (m *MockRegistrationClient) RevokeCAKey(arg0 context.Context, arg1 *registration.RevokeCAKeyRequest, arg2 ...grpc.CallOption) (*common.Empty, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeCAKey", varargs...)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeCAKey indicates an expected call of RevokeCAKey
+func (mr *MockRegistrationClientMockRecorder) RevokeCAKey(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCAKey", reflect.TypeOf((*MockRegistrationClient)(nil).RevokeCAKey), varargs...)
+}
+
+// RevokeJoinToken mocks base method
+func (m *MockRegistrationClient) RevokeJoinToken(arg0 context.Context, arg1 *registration.RevokeJoinTokenRequest, arg2 ...grpc.CallOption) (*registration.RevokeJoinTokenResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeJoinToken", varargs...)
+	ret0, _ := ret[0].(*registration.RevokeJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeJoinToken indicates an expected call of RevokeJoinToken
+func (mr *MockRegistrationClientMockRecorder) RevokeJoinToken(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeJoinToken", reflect.TypeOf((*MockRegistrationClient)(nil).RevokeJoinToken), varargs...)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationClient) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -365,6 +473,19 @@ func (m *MockRegistrationServer) EXPECT() *MockRegistrationServerMockRecorder {
 	return m.recorder
 }
 
+// ActivateCAKeypairSet mocks base method
+func (m *MockRegistrationServer) ActivateCAKeypairSet(arg0 context.Context, arg1 *common.Empty) (*registration.CAKeypairSet, error) {
+	ret := m.ctrl.Call(m, "ActivateCAKeypairSet", arg0, arg1)
+	ret0, _ := ret[0].(*registration.CAKeypairSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateCAKeypairSet indicates an expected call of ActivateCAKeypairSet
+func (mr *MockRegistrationServerMockRecorder) ActivateCAKeypairSet(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateCAKeypairSet", reflect.TypeOf((*MockRegistrationServer)(nil).ActivateCAKeypairSet), arg0, arg1)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationServer) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
 	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
@@ -547,6 +668,19 @@ func (mr *MockRegistrationServerMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationServer)(nil).ListBySpiffeID), arg0, arg1)
 }
 
+// ListCAKeypairSets mocks base method
+func (m *MockRegistrationServer) ListCAKeypairSets(arg0 context.Context, arg1 *common.Empty) (*registration.ListCAKeypairSetsResponse, error) {
+	ret := m.ctrl.Call(m, "ListCAKeypairSets", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListCAKeypairSetsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCAKeypairSets indicates an expected call of ListCAKeypairSets
+func (mr *MockRegistrationServerMockRecorder) ListCAKeypairSets(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCAKeypairSets", reflect.TypeOf((*MockRegistrationServer)(nil).ListCAKeypairSets), arg0, arg1)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationServer) ListFederatedBundles(arg0 *common.Empty, arg1 registration.Registration_ListFederatedBundlesServer) error {
 	ret := m.ctrl.Call(m, "ListFederatedBundles", arg0, arg1)
@@ -559,6 +693,58 @@ func (mr *MockRegistrationServerMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundles), arg0, arg1)
 }
 
+// ListJoinTokens mocks base method
+func (m *MockRegistrationServer) ListJoinTokens(arg0 context.Context, arg1 *registration.ListJoinTokensRequest) (*registration.ListJoinTokensResponse, error) {
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockRegistrationServerMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockRegistrationServer)(nil).ListJoinTokens), arg0, arg1)
+}
+
+// PrepareCAKeypairSet mocks base method
+func (m *MockRegistrationServer) PrepareCAKeypairSet(arg0 context.Context, arg1 *common.Empty) (*registration.CAKeypairSet, error) {
+	ret := m.ctrl.Call(m, "PrepareCAKeypairSet", arg0, arg1)
+	ret0, _ := ret[0].(*registration.CAKeypairSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCAKeypairSet indicates an expected call of PrepareCAKeypairSet
+func (mr *MockRegistrationServerMockRecorder) PrepareCAKeypairSet(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCAKeypairSet", reflect.TypeOf((*MockRegistrationServer)(nil).PrepareCAKeypairSet), arg0, arg1)
+}
+
+// RevokeCAKey mocks base method
+func (m *MockRegistrationServer) RevokeCAKey(arg0 context.Context, arg1 *registration.RevokeCAKeyRequest) (*common.Empty, error) {
+	ret := m.ctrl.Call(m, "RevokeCAKey", arg0, arg1)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeCAKey indicates an expected call of RevokeCAKey
+func (mr *MockRegistrationServerMockRecorder) RevokeCAKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCAKey", reflect.TypeOf((*MockRegistrationServer)(nil).RevokeCAKey), arg0, arg1)
+}
+
+// RevokeJoinToken mocks base method
+func (m *MockRegistrationServer) RevokeJoinToken(arg0 context.Context, arg1 *registration.RevokeJoinTokenRequest) (*registration.RevokeJoinTokenResponse, error) {
+	ret := m.ctrl.Call(m, "RevokeJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*registration.RevokeJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeJoinToken indicates an expected call of RevokeJoinToken
+func (mr *MockRegistrationServerMockRecorder) RevokeJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeJoinToken", reflect.TypeOf((*MockRegistrationServer)(nil).RevokeJoinToken), arg0, arg1)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationServer) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
 	ret := m.ctrl.Call(m, "UpdateEntry", arg0, arg1)
@@ -48,6 +48,19 @@ func (mr *MockDataStoreMockRecorder) AppendBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBundle", reflect.TypeOf((*MockDataStore)(nil).AppendBundle), arg0, arg1)
 }
 
+// BindNodeSelectors mocks base method
+func (m *MockDataStore) BindNodeSelectors(arg0 context.Context, arg1 *datastore.BindNodeSelectorsRequest) (*datastore.BindNodeSelectorsResponse, error) {
+	ret := m.ctrl.Call(m, "BindNodeSelectors", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BindNodeSelectorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindNodeSelectors indicates an expected call of BindNodeSelectors
+func (mr *MockDataStoreMockRecorder) BindNodeSelectors(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindNodeSelectors", reflect.TypeOf((*MockDataStore)(nil).BindNodeSelectors), arg0, arg1)
+}
+
 // CreateAttestedNode mocks base method
 func (m *MockDataStore) CreateAttestedNode(arg0 context.Context, arg1 *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	ret := m.ctrl.Call(m, "CreateAttestedNode", arg0, arg1)
@@ -178,6 +191,19 @@ func (mr *MockDataStoreMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockDataStore)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchCAJournal mocks base method
+func (m *MockDataStore) FetchCAJournal(arg0 context.Context, arg1 *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	ret := m.ctrl.Call(m, "FetchCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCAJournal indicates an expected call of FetchCAJournal
+func (mr *MockDataStoreMockRecorder) FetchCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCAJournal", reflect.TypeOf((*MockDataStore)(nil).FetchCAJournal), arg0, arg1)
+}
+
 // FetchJoinToken mocks base method
 func (m *MockDataStore) FetchJoinToken(arg0 context.Context, arg1 *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
 	ret := m.ctrl.Call(m, "FetchJoinToken", arg0, arg1)
@@ -243,6 +269,19 @@ func (mr *MockDataStoreMockRecorder) ListBundles(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockDataStore)(nil).ListBundles), arg0, arg1)
 }
 
+// ListJoinTokens mocks base method
+func (m *MockDataStore) ListJoinTokens(arg0 context.Context, arg1 *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockDataStoreMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockDataStore)(nil).ListJoinTokens), arg0, arg1)
+}
+
 // ListRegistrationEntries mocks base method
 func (m *MockDataStore) ListRegistrationEntries(arg0 context.Context, arg1 *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListRegistrationEntries", arg0, arg1)
@@ -269,6 +308,19 @@ func (mr *MockDataStoreMockRecorder) PruneJoinTokens(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneJoinTokens", reflect.TypeOf((*MockDataStore)(nil).PruneJoinTokens), arg0, arg1)
 }
 
+// SetCAJournal mocks base method
+func (m *MockDataStore) SetCAJournal(arg0 context.Context, arg1 *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	ret := m.ctrl.Call(m, "SetCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCAJournal indicates an expected call of SetCAJournal
+func (mr *MockDataStoreMockRecorder) SetCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAJournal", reflect.TypeOf((*MockDataStore)(nil).SetCAJournal), arg0, arg1)
+}
+
 // SetNodeSelectors mocks base method
 func (m *MockDataStore) SetNodeSelectors(arg0 context.Context, arg1 *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	ret := m.ctrl.Call(m, "SetNodeSelectors", arg0, arg1)
@@ -295,6 +347,19 @@ func (mr *MockDataStoreMockRecorder) UpdateAttestedNode(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttestedNode", reflect.TypeOf((*MockDataStore)(nil).UpdateAttestedNode), arg0, arg1)
 }
 
+// UpdateAttestedNodeLastSeen mocks base method
+func (m *MockDataStore) UpdateAttestedNodeLastSeen(arg0 context.Context, arg1 *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+	ret := m.ctrl.Call(m, "UpdateAttestedNodeLastSeen", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.UpdateAttestedNodeLastSeenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttestedNodeLastSeen indicates an expected call of UpdateAttestedNodeLastSeen
+func (mr *MockDataStoreMockRecorder) UpdateAttestedNodeLastSeen(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttestedNodeLastSeen", reflect.TypeOf((*MockDataStore)(nil).UpdateAttestedNodeLastSeen), arg0, arg1)
+}
+
 // UpdateBundle mocks base method
 func (m *MockDataStore) UpdateBundle(arg0 context.Context, arg1 *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
 	ret := m.ctrl.Call(m, "UpdateBundle", arg0, arg1)
@@ -321,6 +386,19 @@ func (mr *MockDataStoreMockRecorder) UpdateRegistrationEntry(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegistrationEntry", reflect.TypeOf((*MockDataStore)(nil).UpdateRegistrationEntry), arg0, arg1)
 }
 
+// UseJoinToken mocks base method
+func (m *MockDataStore) UseJoinToken(arg0 context.Context, arg1 *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	ret := m.ctrl.Call(m, "UseJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.UseJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseJoinToken indicates an expected call of UseJoinToken
+func (mr *MockDataStoreMockRecorder) UseJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseJoinToken", reflect.TypeOf((*MockDataStore)(nil).UseJoinToken), arg0, arg1)
+}
+
 // MockPlugin is a mock of Plugin interface
 type MockPlugin struct {
 	ctrl     *gomock.Controller
@@ -357,6 +435,19 @@ func (mr *MockPluginMockRecorder) AppendBundle(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBundle", reflect.TypeOf((*MockPlugin)(nil).AppendBundle), arg0, arg1)
 }
 
+// BindNodeSelectors mocks base method
+func (m *MockPlugin) BindNodeSelectors(arg0 context.Context, arg1 *datastore.BindNodeSelectorsRequest) (*datastore.BindNodeSelectorsResponse, error) {
+	ret := m.ctrl.Call(m, "BindNodeSelectors", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BindNodeSelectorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BindNodeSelectors indicates an expected call of BindNodeSelectors
+func (mr *MockPluginMockRecorder) BindNodeSelectors(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindNodeSelectors", reflect.TypeOf((*MockPlugin)(nil).BindNodeSelectors), arg0, arg1)
+}
+
 // Configure mocks base method
 func (m *MockPlugin) Configure(arg0 context.Context, arg1 *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	ret := m.ctrl.Call(m, "Configure", arg0, arg1)
@@ -500,6 +591,19 @@ func (mr *MockPluginMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockPlugin)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchCAJournal mocks base method
+func (m *MockPlugin) FetchCAJournal(arg0 context.Context, arg1 *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	ret := m.ctrl.Call(m, "FetchCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCAJournal indicates an expected call of FetchCAJournal
+func (mr *MockPluginMockRecorder) FetchCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCAJournal", reflect.TypeOf((*MockPlugin)(nil).FetchCAJournal), arg0, arg1)
+}
+
 // FetchJoinToken mocks base method
 func (m *MockPlugin) FetchJoinToken(arg0 context.Context, arg1 *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
 	ret := m.ctrl.Call(m, "FetchJoinToken", arg0, arg1)
@@ -578,6 +682,19 @@ func (mr *MockPluginMockRecorder) ListBundles(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockPlugin)(nil).ListBundles), arg0, arg1)
 }
 
+// ListJoinTokens mocks base method
+func (m *MockPlugin) ListJoinTokens(arg0 context.Context, arg1 *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockPluginMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockPlugin)(nil).ListJoinTokens), arg0, arg1)
+}
+
 // ListRegistrationEntries mocks base method
 func (m *MockPlugin) ListRegistrationEntries(arg0 context.Context, arg1 *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListRegistrationEntries", arg0, arg1)
@@ -604,6 +721,19 @@ func (mr *MockPluginMockRecorder) PruneJoinTokens(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneJoinTokens", reflect.TypeOf((*MockPlugin)(nil).PruneJoinTokens), arg0, arg1)
 }
 
+// SetCAJournal mocks base method
+func (m *MockPlugin) SetCAJournal(arg0 context.Context, arg1 *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	ret := m.ctrl.Call(m, "SetCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCAJournal indicates an expected call of SetCAJournal
+func (mr *MockPluginMockRecorder) SetCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAJournal", reflect.TypeOf((*MockPlugin)(nil).SetCAJournal), arg0, arg1)
+}
+
 // SetNodeSelectors mocks base method
 func (m *MockPlugin) SetNodeSelectors(arg0 context.Context, arg1 *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	ret := m.ctrl.Call(m, "SetNodeSelectors", arg0, arg1)
@@ -630,6 +760,19 @@ func (mr *MockPluginMockRecorder) UpdateAttestedNode(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttestedNode", reflect.TypeOf((*MockPlugin)(nil).UpdateAttestedNode), arg0, arg1)
 }
 
+// UpdateAttestedNodeLastSeen mocks base method
+func (m *MockPlugin) UpdateAttestedNodeLastSeen(arg0 context.Context, arg1 *datastore.UpdateAttestedNodeLastSeenRequest) (*datastore.UpdateAttestedNodeLastSeenResponse, error) {
+	ret := m.ctrl.Call(m, "UpdateAttestedNodeLastSeen", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.UpdateAttestedNodeLastSeenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttestedNodeLastSeen indicates an expected call of UpdateAttestedNodeLastSeen
+func (mr *MockPluginMockRecorder) UpdateAttestedNodeLastSeen(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttestedNodeLastSeen", reflect.TypeOf((*MockPlugin)(nil).UpdateAttestedNodeLastSeen), arg0, arg1)
+}
+
 // UpdateBundle mocks base method
 func (m *MockPlugin) UpdateBundle(arg0 context.Context, arg1 *datastore.UpdateBundleRequest) (*datastore.UpdateBundleResponse, error) {
 	ret := m.ctrl.Call(m, "UpdateBundle", arg0, arg1)
@@ -655,3 +798,16 @@ func (m *MockPlugin) UpdateRegistrationEntry(arg0 context.Context, arg1 *datasto
 func (mr *MockPluginMockRecorder) UpdateRegistrationEntry(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRegistrationEntry", reflect.TypeOf((*MockPlugin)(nil).UpdateRegistrationEntry), arg0, arg1)
 }
+
+// UseJoinToken mocks base method
+func (m *MockPlugin) UseJoinToken(arg0 context.Context, arg1 *datastore.UseJoinTokenRequest) (*datastore.UseJoinTokenResponse, error) {
+	ret := m.ctrl.Call(m, "UseJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.UseJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseJoinToken indicates an expected call of UseJoinToken
+func (mr *MockPluginMockRecorder) UseJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseJoinToken", reflect.TypeOf((*MockPlugin)(nil).UseJoinToken), arg0, arg1)
+}
@@ -35,6 +35,32 @@ func (m *MockUpstreamCA) EXPECT() *MockUpstreamCAMockRecorder {
 	return m.recorder
 }
 
+// PublishJWTKey mocks base method
+func (m *MockUpstreamCA) PublishJWTKey(arg0 context.Context, arg1 *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	ret := m.ctrl.Call(m, "PublishJWTKey", arg0, arg1)
+	ret0, _ := ret[0].(*upstreamca.PublishJWTKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishJWTKey indicates an expected call of PublishJWTKey
+func (mr *MockUpstreamCAMockRecorder) PublishJWTKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishJWTKey", reflect.TypeOf((*MockUpstreamCA)(nil).PublishJWTKey), arg0, arg1)
+}
+
+// StreamBundleUpdates mocks base method
+func (m *MockUpstreamCA) StreamBundleUpdates(arg0 context.Context, arg1 *upstreamca.StreamBundleUpdatesRequest) (upstreamca.StreamBundleUpdates_Stream, error) {
+	ret := m.ctrl.Call(m, "StreamBundleUpdates", arg0, arg1)
+	ret0, _ := ret[0].(upstreamca.StreamBundleUpdates_Stream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamBundleUpdates indicates an expected call of StreamBundleUpdates
+func (mr *MockUpstreamCAMockRecorder) StreamBundleUpdates(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamBundleUpdates", reflect.TypeOf((*MockUpstreamCA)(nil).StreamBundleUpdates), arg0, arg1)
+}
+
 // SubmitCSR mocks base method
 func (m *MockUpstreamCA) SubmitCSR(arg0 context.Context, arg1 *upstreamca.SubmitCSRRequest) (*upstreamca.SubmitCSRResponse, error) {
 	ret := m.ctrl.Call(m, "SubmitCSR", arg0, arg1)
@@ -97,6 +123,31 @@ func (mr *MockPluginMockRecorder) GetPluginInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginInfo", reflect.TypeOf((*MockPlugin)(nil).GetPluginInfo), arg0, arg1)
 }
 
+// PublishJWTKey mocks base method
+func (m *MockPlugin) PublishJWTKey(arg0 context.Context, arg1 *upstreamca.PublishJWTKeyRequest) (*upstreamca.PublishJWTKeyResponse, error) {
+	ret := m.ctrl.Call(m, "PublishJWTKey", arg0, arg1)
+	ret0, _ := ret[0].(*upstreamca.PublishJWTKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishJWTKey indicates an expected call of PublishJWTKey
+func (mr *MockPluginMockRecorder) PublishJWTKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishJWTKey", reflect.TypeOf((*MockPlugin)(nil).PublishJWTKey), arg0, arg1)
+}
+
+// StreamBundleUpdates mocks base method
+func (m *MockPlugin) StreamBundleUpdates(arg0 *upstreamca.StreamBundleUpdatesRequest, arg1 upstreamca.StreamBundleUpdates_PluginStream) error {
+	ret := m.ctrl.Call(m, "StreamBundleUpdates", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamBundleUpdates indicates an expected call of StreamBundleUpdates
+func (mr *MockPluginMockRecorder) StreamBundleUpdates(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamBundleUpdates", reflect.TypeOf((*MockPlugin)(nil).StreamBundleUpdates), arg0, arg1)
+}
+
 // SubmitCSR mocks base method
 func (m *MockPlugin) SubmitCSR(arg0 context.Context, arg1 *upstreamca.SubmitCSRRequest) (*upstreamca.SubmitCSRResponse, error) {
 	ret := m.ctrl.Call(m, "SubmitCSR", arg0, arg1)
@@ -36,6 +36,19 @@ func (m *MockServerCA) EXPECT() *MockServerCAMockRecorder {
 	return m.recorder
 }
 
+// SignAgentX509SVID mocks base method
+func (m *MockServerCA) SignAgentX509SVID(arg0 context.Context, arg1 []byte, arg2 string) ([]*x509.Certificate, error) {
+	ret := m.ctrl.Call(m, "SignAgentX509SVID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*x509.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignAgentX509SVID indicates an expected call of SignAgentX509SVID
+func (mr *MockServerCAMockRecorder) SignAgentX509SVID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAgentX509SVID", reflect.TypeOf((*MockServerCA)(nil).SignAgentX509SVID), arg0, arg1, arg2)
+}
+
 // SignJWTSVID mocks base method
 func (m *MockServerCA) SignJWTSVID(arg0 context.Context, arg1 *node.JSR) (string, error) {
 	ret := m.ctrl.Call(m, "SignJWTSVID", arg0, arg1)
@@ -74,3 +87,15 @@ func (m *MockServerCA) SignX509SVID(arg0 context.Context, arg1 []byte, arg2 time
 func (mr *MockServerCAMockRecorder) SignX509SVID(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignX509SVID", reflect.TypeOf((*MockServerCA)(nil).SignX509SVID), arg0, arg1, arg2)
 }
+
+// X509CA mocks base method
+func (m *MockServerCA) X509CA() *x509.Certificate {
+	ret := m.ctrl.Call(m, "X509CA")
+	ret0, _ := ret[0].(*x509.Certificate)
+	return ret0
+}
+
+// X509CA indicates an expected call of X509CA
+func (mr *MockServerCAMockRecorder) X509CA() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "X509CA", reflect.TypeOf((*MockServerCA)(nil).X509CA))
+}
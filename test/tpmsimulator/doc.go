@@ -0,0 +1,10 @@
+// Package tpmsimulator runs the TPM 2.0 reference implementation simulator,
+// provisioned like a TPM from a manufacturer with an endorsement key
+// certificate at the default NV index.
+//
+// The simulator is a process-wide resource, so only one Simulator can be
+// open at a time.
+//
+// The simulator is built with cgo against OpenSSL 1.x, so the package and the
+// tests that use it are only built with the tpmsimulator build tag.
+package tpmsimulator
@@ -0,0 +1,182 @@
+//go:build tpmsimulator
+// +build tpmsimulator
+
+package tpmsimulator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/go-tpm-tools/simulator"
+	"github.com/google/go-tpm-tools/tpm2tools"
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpmutil"
+)
+
+const (
+	// ekCertificateIndex is the NV index of the RSA 2048 endorsement key
+	// certificate.
+	ekCertificateIndex = tpmutil.Handle(0x01C00002)
+
+	// nvWriteBlockSize is the amount of NV data written per command.
+	nvWriteBlockSize = 512
+
+	// maxHandles is the maximum number of handles listed per handle type.
+	maxHandles = 64
+)
+
+// Simulator is a simulated TPM. It must be closed to free the simulator for
+// other callers.
+type Simulator struct {
+	sim *simulator.Simulator
+
+	manufacturerCA *x509.Certificate
+	ekCertificate  []byte
+	ekPublicKey    crypto.PublicKey
+}
+
+// New returns a simulator with an endorsement key certificate issued by a
+// freshly generated manufacturer CA and provisioned at the default NV index.
+func New() (*Simulator, error) {
+	sim, err := simulator.Get()
+	if err != nil {
+		return nil, err
+	}
+	s := &Simulator{sim: sim}
+	if err := s.provision(); err != nil {
+		sim.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// ManufacturerCA returns the CA that issued the endorsement key certificate.
+func (s *Simulator) ManufacturerCA() *x509.Certificate {
+	return s.manufacturerCA
+}
+
+// EKCertificate returns the DER encoded endorsement key certificate.
+func (s *Simulator) EKCertificate() []byte {
+	return s.ekCertificate
+}
+
+// EKPublicKey returns the public key of the endorsement key.
+func (s *Simulator) EKPublicKey() crypto.PublicKey {
+	return s.ekPublicKey
+}
+
+// RemoveEKCertificate removes the endorsement key certificate from NV.
+func (s *Simulator) RemoveEKCertificate() error {
+	return tpm2.NVUndefineSpace(s.sim, "", tpm2.HandleOwner, ekCertificateIndex)
+}
+
+// ExtendPCR extends the PCR of the SHA256 bank with the digest of the
+// measurement.
+func (s *Simulator) ExtendPCR(pcr int, measurement []byte) error {
+	digest := sha256.Sum256(measurement)
+	return tpm2.PCRExtend(s.sim, tpmutil.Handle(pcr), tpm2.AlgSHA256, digest[:], "")
+}
+
+// PCRValue returns the value of the PCR of the SHA256 bank.
+func (s *Simulator) PCRValue(pcr int) ([]byte, error) {
+	return tpm2.ReadPCR(s.sim, pcr, tpm2.AlgSHA256)
+}
+
+// LoadedHandles returns the number of loaded objects and sessions.
+func (s *Simulator) LoadedHandles() (int, error) {
+	count := 0
+	for _, first := range []tpm2.HandleType{tpm2.HandleTypeTransient, tpm2.HandleTypeLoadedSession} {
+		handles, _, err := tpm2.GetCapability(s.sim, tpm2.CapabilityHandles, maxHandles, uint32(first)<<24)
+		if err != nil {
+			return 0, err
+		}
+		count += len(handles)
+	}
+	return count, nil
+}
+
+// Write sends a command to the simulator.
+func (s *Simulator) Write(command []byte) (int, error) {
+	return s.sim.Write(command)
+}
+
+// Read reads the response to the last command.
+func (s *Simulator) Read(response []byte) (int, error) {
+	return s.sim.Read(response)
+}
+
+// Close stops the simulator.
+func (s *Simulator) Close() error {
+	return s.sim.Close()
+}
+
+func (s *Simulator) provision() error {
+	handle, ekPublicKey, err := tpm2.CreatePrimary(s.sim, tpm2.HandleEndorsement, tpm2.PCRSelection{}, "", "", tpm2tools.DefaultEKTemplateRSA())
+	if err != nil {
+		return fmt.Errorf("unable to create endorsement key: %v", err)
+	}
+	if err := tpm2.FlushContext(s.sim, handle); err != nil {
+		return err
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "TPM Manufacturer CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+	ekTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+	ekCertificate, err := x509.CreateCertificate(rand.Reader, ekTemplate, ca, ekPublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	if err := tpm2.NVDefineSpace(s.sim, tpm2.HandleOwner, ekCertificateIndex, "", "", nil,
+		tpm2.AttrOwnerWrite|tpm2.AttrOwnerRead|tpm2.AttrAuthRead|tpm2.AttrPPRead|tpm2.AttrNoDA,
+		uint16(len(ekCertificate))); err != nil {
+		return fmt.Errorf("unable to define endorsement key certificate index: %v", err)
+	}
+	for offset := 0; offset < len(ekCertificate); offset += nvWriteBlockSize {
+		end := offset + nvWriteBlockSize
+		if end > len(ekCertificate) {
+			end = len(ekCertificate)
+		}
+		if err := tpm2.NVWrite(s.sim, tpm2.HandleOwner, ekCertificateIndex, "", ekCertificate[offset:end], uint16(offset)); err != nil {
+			return fmt.Errorf("unable to write endorsement key certificate: %v", err)
+		}
+	}
+
+	s.manufacturerCA = ca
+	s.ekCertificate = ekCertificate
+	s.ekPublicKey = ekPublicKey
+	return nil
+}